@@ -462,6 +462,38 @@ func (c *client) UpdateOption(option opt.DynamicOption, value any) error {
 			return errors.New("[pd] invalid value type for EnableRouterClient option, it should be bool")
 		}
 		c.inner.option.SetEnableRouterClient(enable)
+	case opt.MaxTSOStaleness:
+		staleness, ok := value.(time.Duration)
+		if !ok {
+			return errors.New("[pd] invalid value type for MaxTSOStaleness option, it should be time.Duration")
+		}
+		if err := c.inner.option.SetMaxTSOStaleness(staleness); err != nil {
+			return err
+		}
+	case opt.StaleTSOPrefetchCount:
+		count, ok := value.(int)
+		if !ok {
+			return errors.New("[pd] invalid value type for StaleTSOPrefetchCount option, it should be int")
+		}
+		if err := c.inner.option.SetStaleTSOPrefetchCount(count); err != nil {
+			return err
+		}
+	case opt.RegionHedgeDelay:
+		delay, ok := value.(time.Duration)
+		if !ok {
+			return errors.New("[pd] invalid value type for RegionHedgeDelay option, it should be time.Duration")
+		}
+		if err := c.inner.option.SetRegionHedgeDelay(delay); err != nil {
+			return err
+		}
+	case opt.RegionHedgeBudgetRatio:
+		ratio, ok := value.(float64)
+		if !ok {
+			return errors.New("[pd] invalid value type for RegionHedgeBudgetRatio option, it should be float64")
+		}
+		if err := c.inner.option.SetRegionHedgeBudgetRatio(ratio); err != nil {
+			return err
+		}
 	default:
 		return errors.New("[pd] unsupported client option")
 	}
@@ -522,6 +554,20 @@ func (c *client) GetTS(ctx context.Context) (physical int64, logical int64, err
 	return resp.Wait()
 }
 
+// GetStaleTS implements the TSOClient interface.
+func (c *client) GetStaleTS(ctx context.Context) (physical int64, logical int64, err error) {
+	defer trace.StartRegion(ctx, "pdclient.GetStaleTS").End()
+	maxStaleness := c.inner.option.GetMaxTSOStaleness()
+	if maxStaleness <= 0 {
+		metrics.StaleTSOCacheBypass.Inc()
+		return c.GetTS(ctx)
+	}
+	return c.inner.staleTSCache.GetStaleTS(ctx, maxStaleness, int64(c.inner.option.GetStaleTSOPrefetchCount()),
+		func(ctx context.Context, count int64) (int64, int64, error) {
+			return c.inner.dispatchTSORequestWithCountWithRetry(ctx, count).Wait()
+		})
+}
+
 // GetLocalTS implements the TSOClient interface.
 // Deprecated: the Local TSO feature has been deprecated. Regardless of the
 // parameters passed, the behavior of this interface will be equivalent to
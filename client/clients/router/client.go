@@ -183,6 +183,8 @@ type Cli struct {
 	reqPool         *sync.Pool
 	requestCh       chan *Request
 	batchController *batch.Controller[*Request]
+	// hedgeBudget limits the hedged requests sent to the followers.
+	hedgeBudget hedgeBudget
 }
 
 // NewClient returns a new router client.
@@ -16,12 +16,16 @@ package router
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/client/opt"
 )
 
 func newMockRegionResponse(id uint64) *pdpb.RegionResponse {
@@ -98,3 +102,68 @@ func TestRequestFinisherNoDataRace(t *testing.T) {
 		re.Equal([]byte{byte(idx + 1)}, req.region.Meta.StartKey)
 	}
 }
+
+func TestHedgeBudget(t *testing.T) {
+	re := require.New(t)
+	b := &hedgeBudget{}
+	re.False(b.tryAcquire())
+	// Each request earns a quarter of a hedged request.
+	for range 3 {
+		b.onRequest(0.25)
+	}
+	re.False(b.tryAcquire())
+	b.onRequest(0.25)
+	re.True(b.tryAcquire())
+	re.False(b.tryAcquire())
+	// The burst is limited.
+	for range 100 {
+		b.onRequest(1)
+	}
+	for range int(maxHedgeBudgetBurst) {
+		re.True(b.tryAcquire())
+	}
+	re.False(b.tryAcquire())
+}
+
+func TestGetHedgeDelay(t *testing.T) {
+	re := require.New(t)
+	c := &Cli{option: opt.NewOption()}
+	newOp := func(opts ...opt.GetRegionOption) *opt.GetRegionOp {
+		op := &opt.GetRegionOp{}
+		for _, o := range opts {
+			o(op)
+		}
+		return op
+	}
+	re.Zero(c.getHedgeDelay(newOp()))
+	re.Equal(c.option.GetRegionHedgeDelay(), c.getHedgeDelay(newOp(opt.WithHedge())))
+	re.Equal(time.Millisecond, c.getHedgeDelay(newOp(opt.WithHedgeDelay(time.Millisecond))))
+	// The client-level options disable the hedging.
+	re.NoError(c.option.SetRegionHedgeDelay(0))
+	re.Zero(c.getHedgeDelay(newOp(opt.WithHedgeDelay(time.Millisecond))))
+	re.NoError(c.option.SetRegionHedgeDelay(time.Millisecond))
+	re.NoError(c.option.SetRegionHedgeBudgetRatio(0))
+	re.Zero(c.getHedgeDelay(newOp(opt.WithHedge())))
+}
+
+func TestWaitWithHedgeLeaderFirst(t *testing.T) {
+	re := require.New(t)
+	c := &Cli{option: opt.NewOption()}
+	re.NoError(c.option.SetRegionHedgeBudgetRatio(1))
+	req := &Request{
+		requestCtx: context.Background(),
+		clientCtx:  context.Background(),
+		key:        []byte("key"),
+		options:    &opt.GetRegionOp{Hedge: true, HedgeDelay: time.Minute},
+		done:       make(chan error, 1),
+		start:      time.Now(),
+		pool:       &sync.Pool{},
+	}
+	req.region = ConvertToRegion(newMockRegionResponse(1))
+	req.tryDone(nil)
+	region, err := c.waitWithHedge(req)
+	re.NoError(err)
+	re.Equal(uint64(1), region.Meta.GetId())
+	// The budget is not consumed since no hedged request is sent.
+	re.True(c.hedgeBudget.tryAcquire())
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/metrics"
+	"github.com/tikv/pd/client/opt"
+)
+
+// maxHedgeBudgetBurst is the max count of the hedged requests which can be sent in a burst.
+const maxHedgeBudgetBurst = 10.0
+
+// hedgeBudget limits the hedged requests to a ratio of all the requests, so that the hedging
+// does not amplify the load when the whole cluster is slow. Each request earns `ratio` tokens
+// and each hedged request costs one token.
+type hedgeBudget struct {
+	sync.Mutex
+	tokens float64
+}
+
+func (b *hedgeBudget) onRequest(ratio float64) {
+	b.Lock()
+	defer b.Unlock()
+	b.tokens = min(b.tokens+ratio, maxHedgeBudgetBurst)
+}
+
+func (b *hedgeBudget) tryAcquire() bool {
+	b.Lock()
+	defer b.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type hedgeResult struct {
+	region *Region
+	err    error
+}
+
+// getHedgeDelay returns the delay to send the hedged request, 0 means no hedging.
+func (c *Cli) getHedgeDelay(options *opt.GetRegionOp) time.Duration {
+	if !options.Hedge {
+		return 0
+	}
+	ratio := c.option.GetRegionHedgeBudgetRatio()
+	delay := c.option.GetRegionHedgeDelay()
+	// The client-level option is the switch of the hedging.
+	if ratio <= 0 || delay <= 0 {
+		return 0
+	}
+	c.hedgeBudget.onRequest(ratio)
+	if options.HedgeDelay > 0 {
+		return options.HedgeDelay
+	}
+	return delay
+}
+
+// waitWithHedge waits for the request, and sends the same request to a follower if the leader
+// does not respond within the hedge delay. The first valid answer is taken.
+func (c *Cli) waitWithHedge(req *Request) (*Region, error) {
+	delay := c.getHedgeDelay(req.options)
+	if delay <= 0 {
+		return req.wait()
+	}
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case err := <-req.done:
+		return req.finish(start, err)
+	case <-timer.C:
+	case <-req.requestCtx.Done():
+		return nil, errors.WithStack(req.requestCtx.Err())
+	case <-req.clientCtx.Done():
+		return nil, errors.WithStack(req.clientCtx.Err())
+	}
+
+	if !c.hedgeBudget.tryAcquire() {
+		metrics.QueryRegionHedgeThrottled.Inc()
+		return req.wait()
+	}
+	cc, url := c.pickFollowerClientConn()
+	if cc == nil {
+		return req.wait()
+	}
+	metrics.QueryRegionHedgeSent.Inc()
+	// Build the query in advance, the request may be reused once the leader answers.
+	queryReq, id := c.buildHedgeQuery(req)
+	hedgeCh := make(chan hedgeResult, 1)
+	ctx, cancel := context.WithCancel(req.requestCtx)
+	defer cancel()
+	go func() {
+		region, err := c.queryRegionFromFollower(ctx, cc, queryReq, id)
+		hedgeCh <- hedgeResult{region, err}
+	}()
+	select {
+	case err := <-req.done:
+		metrics.QueryRegionHedgeLose.Inc()
+		return req.finish(start, err)
+	case res := <-hedgeCh:
+		if res.err != nil || res.region == nil {
+			log.Debug("[router] the hedged request got no valid answer",
+				zap.String("url", url), errs.ZapError(res.err))
+			metrics.QueryRegionHedgeLose.Inc()
+			return req.wait()
+		}
+		metrics.QueryRegionHedgeWin.Inc()
+		// The request is still in flight, so it must not be put back into the pool.
+		return res.region, nil
+	case <-req.requestCtx.Done():
+		return nil, errors.WithStack(req.requestCtx.Err())
+	case <-req.clientCtx.Done():
+		return nil, errors.WithStack(req.clientCtx.Err())
+	}
+}
+
+// pickFollowerClientConn randomly picks a follower gRPC client connection.
+func (c *Cli) pickFollowerClientConn() (*grpc.ClientConn, string) {
+	leaderURL := c.getLeaderURL()
+	conns := c.getAllClientConns()
+	urls := make([]string, 0, len(conns))
+	for url := range conns {
+		if url != leaderURL {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, ""
+	}
+	url := urls[rand.Intn(len(urls))]
+	return conns[url], url
+}
+
+func (c *Cli) buildHedgeQuery(req *Request) (*pdpb.QueryRegionRequest, uint64) {
+	queryReq := &pdpb.QueryRegionRequest{
+		Header: &pdpb.RequestHeader{
+			ClusterId: c.svcDiscovery.GetClusterID(),
+		},
+		NeedBuckets: req.options.NeedBuckets,
+	}
+	switch {
+	case req.key != nil:
+		queryReq.Keys = [][]byte{req.key}
+	case req.prevKey != nil:
+		queryReq.PrevKeys = [][]byte{req.prevKey}
+	default:
+		queryReq.Ids = []uint64{req.id}
+	}
+	return queryReq, req.id
+}
+
+// queryRegionFromFollower sends the single query through a dedicated stream, since the
+// router streams are exclusively used by the dispatcher.
+func (c *Cli) queryRegionFromFollower(
+	ctx context.Context,
+	cc *grpc.ClientConn,
+	queryReq *pdpb.QueryRegionRequest,
+	id uint64,
+) (*Region, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.option.Timeout)
+	defer cancel()
+	stream, err := pdpb.NewPDClient(cc).QueryRegion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+	if err := stream.Send(queryReq); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if headerErr := resp.GetHeader().GetError(); headerErr != nil {
+		return nil, errors.New(headerErr.String())
+	}
+	switch {
+	case len(resp.KeyIdMap) > 0:
+		id = resp.KeyIdMap[0]
+	case len(resp.PrevKeyIdMap) > 0:
+		id = resp.PrevKeyIdMap[0]
+	}
+	regionResp, ok := resp.RegionsById[id]
+	if !ok {
+		return nil, nil
+	}
+	return ConvertToRegion(regionResp), nil
+}
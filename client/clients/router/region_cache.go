@@ -0,0 +1,452 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/errorpb"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/opt"
+)
+
+const (
+	defaultRegionCacheTTL              = 10 * time.Minute
+	defaultRegionCacheMaxSize          = 1 << 20
+	defaultRegionCacheRefreshInterval  = 10 * time.Second
+	defaultRegionCacheRefreshBatchSize = 128
+	// regionCacheEvictRatio is the ratio of the regions evicted once the cache is full, so that
+	// the eviction is not triggered by every insertion.
+	regionCacheEvictRatio = 16
+)
+
+// RegionCacheOption configures the RegionCache.
+type RegionCacheOption func(c *RegionCache)
+
+// WithRegionCacheTTL sets the TTL of the cached regions, the expired regions are reloaded on access.
+func WithRegionCacheTTL(ttl time.Duration) RegionCacheOption {
+	return func(c *RegionCache) { c.ttl = ttl }
+}
+
+// WithRegionCacheMaxSize sets the max count of the cached regions, the least recently accessed
+// regions are evicted once it's exceeded.
+func WithRegionCacheMaxSize(size int) RegionCacheOption {
+	return func(c *RegionCache) { c.maxSize = size }
+}
+
+// WithRegionCacheRefresh sets the interval and the batch size to refresh the cached regions in the
+// background, the refreshing is disabled if the interval is not positive.
+func WithRegionCacheRefresh(interval time.Duration, batchSize int) RegionCacheOption {
+	return func(c *RegionCache) {
+		c.refreshInterval = interval
+		c.refreshBatchSize = batchSize
+	}
+}
+
+// WithRegionCacheGetRegionOptions sets the options used to load the regions from PD.
+func WithRegionCacheGetRegionOptions(opts ...opt.GetRegionOption) RegionCacheOption {
+	return func(c *RegionCache) { c.getRegionOpts = opts }
+}
+
+type cachedRegion struct {
+	region   *Region
+	loadTime time.Time
+	// lastAccess is the unix nano time of the last access.
+	lastAccess atomic.Int64
+}
+
+func newCachedRegion(region *Region, now time.Time) *cachedRegion {
+	r := &cachedRegion{region: region, loadTime: now}
+	r.lastAccess.Store(now.UnixNano())
+	return r
+}
+
+func (r *cachedRegion) startKey() []byte {
+	return r.region.Meta.GetStartKey()
+}
+
+func (r *cachedRegion) contains(key []byte) bool {
+	endKey := r.region.Meta.GetEndKey()
+	return bytes.Compare(r.startKey(), key) <= 0 &&
+		(len(endKey) == 0 || bytes.Compare(key, endKey) < 0)
+}
+
+// RegionCache is a concurrency-safe cache of the regions built on the router client. The regions
+// are indexed by the key range, the stale ones are replaced once a region with a newer epoch is
+// loaded, and the callers could feed the region errors back to invalidate them.
+type RegionCache struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	client Client
+
+	ttl              time.Duration
+	maxSize          int
+	refreshInterval  time.Duration
+	refreshBatchSize int
+	getRegionOpts    []opt.GetRegionOption
+
+	mu sync.RWMutex
+	// regions is sorted by the start key, and the key ranges do not overlap.
+	regions []*cachedRegion
+	// byID is the index of the regions by the region ID.
+	byID map[uint64]*cachedRegion
+}
+
+// NewRegionCache creates a region cache which loads the regions through the given client.
+func NewRegionCache(ctx context.Context, client Client, opts ...RegionCacheOption) *RegionCache {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &RegionCache{
+		ctx:              ctx,
+		cancel:           cancel,
+		client:           client,
+		ttl:              defaultRegionCacheTTL,
+		maxSize:          defaultRegionCacheMaxSize,
+		refreshInterval:  defaultRegionCacheRefreshInterval,
+		refreshBatchSize: defaultRegionCacheRefreshBatchSize,
+		byID:             make(map[uint64]*cachedRegion),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.refreshInterval > 0 && c.refreshBatchSize > 0 {
+		c.wg.Add(1)
+		go c.refreshLoop()
+	}
+	return c
+}
+
+// Close stops the background refreshing of the region cache.
+func (c *RegionCache) Close() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Len returns the count of the cached regions.
+func (c *RegionCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.regions)
+}
+
+// LocateKey returns the region which contains the key, it's loaded from PD if it's not cached or expired.
+// It returns nil if PD finds no region for the key temporarily.
+func (c *RegionCache) LocateKey(ctx context.Context, key []byte) (*Region, error) {
+	if region := c.GetCachedRegionByKey(key); region != nil {
+		return region, nil
+	}
+	region, err := c.client.GetRegion(ctx, key, c.getRegionOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.Insert(region)
+	return region, nil
+}
+
+// LocateRegionByID returns the region with the ID, it's loaded from PD if it's not cached or expired.
+// It returns nil if PD finds no region with the ID temporarily.
+func (c *RegionCache) LocateRegionByID(ctx context.Context, regionID uint64) (*Region, error) {
+	if region := c.GetCachedRegionByID(regionID); region != nil {
+		return region, nil
+	}
+	region, err := c.client.GetRegionByID(ctx, regionID, c.getRegionOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.Insert(region)
+	return region, nil
+}
+
+// GetCachedRegionByKey returns the unexpired cached region which contains the key, or nil if not found.
+func (c *RegionCache) GetCachedRegionByKey(key []byte) *Region {
+	now := time.Now()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	idx := c.search(key)
+	if idx < 0 || !c.regions[idx].contains(key) {
+		return nil
+	}
+	return c.access(c.regions[idx], now)
+}
+
+// GetCachedRegionByID returns the unexpired cached region with the ID, or nil if not found.
+func (c *RegionCache) GetCachedRegionByID(regionID uint64) *Region {
+	now := time.Now()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r, ok := c.byID[regionID]
+	if !ok {
+		return nil
+	}
+	return c.access(r, now)
+}
+
+func (c *RegionCache) access(r *cachedRegion, now time.Time) *Region {
+	if c.ttl > 0 && now.Sub(r.loadTime) > c.ttl {
+		return nil
+	}
+	r.lastAccess.Store(now.UnixNano())
+	return r.region
+}
+
+// search returns the index of the last region whose start key is not greater than the key, or -1.
+func (c *RegionCache) search(key []byte) int {
+	return sort.Search(len(c.regions), func(i int) bool {
+		return bytes.Compare(c.regions[i].startKey(), key) > 0
+	}) - 1
+}
+
+// Insert puts the region into the cache and removes the cached regions overlapped with it. The
+// region is ignored if any overlapped region has a newer epoch.
+func (c *RegionCache) Insert(region *Region) {
+	if region == nil || region.Meta == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insertLocked(region, time.Now())
+}
+
+func (c *RegionCache) insertLocked(region *Region, now time.Time) {
+	startKey, endKey := region.Meta.GetStartKey(), region.Meta.GetEndKey()
+	// Find the overlapped regions in [first, last).
+	first := c.search(startKey)
+	if first < 0 || !c.regions[first].contains(startKey) {
+		first++
+	}
+	last := first
+	for last < len(c.regions) &&
+		(len(endKey) == 0 || bytes.Compare(c.regions[last].startKey(), endKey) < 0) {
+		last++
+	}
+	overlapped := c.regions[first:last]
+	old, cached := c.byID[region.Meta.GetId()]
+	if cached {
+		overlapped = append(overlapped[:len(overlapped):len(overlapped)], old)
+	}
+	for _, r := range overlapped {
+		if isStaleEpoch(region.Meta.GetRegionEpoch(), r.region.Meta.GetRegionEpoch()) {
+			return
+		}
+	}
+	// The region may be cached with a disjoint key range before, e.g. it's merged with its neighbor.
+	if cached && !slices.Contains(c.regions[first:last], old) {
+		c.removeLocked(old)
+		c.insertLocked(region, now)
+		return
+	}
+	for _, old := range c.regions[first:last] {
+		delete(c.byID, old.region.Meta.GetId())
+	}
+	r := newCachedRegion(region, now)
+	c.regions = append(c.regions[:first], append([]*cachedRegion{r}, c.regions[last:]...)...)
+	c.byID[region.Meta.GetId()] = r
+	if c.maxSize > 0 && len(c.regions) > c.maxSize {
+		c.evictLocked()
+	}
+}
+
+// isStaleEpoch returns whether the epoch is older than the other one.
+func isStaleEpoch(epoch, other *metapb.RegionEpoch) bool {
+	return epoch.GetVersion() < other.GetVersion() ||
+		(epoch.GetVersion() == other.GetVersion() && epoch.GetConfVer() < other.GetConfVer())
+}
+
+func (c *RegionCache) removeLocked(r *cachedRegion) {
+	idx := c.search(r.startKey())
+	if idx < 0 || c.regions[idx] != r {
+		return
+	}
+	c.regions = append(c.regions[:idx], c.regions[idx+1:]...)
+	delete(c.byID, r.region.Meta.GetId())
+}
+
+// evictLocked evicts the least recently accessed regions.
+func (c *RegionCache) evictLocked() {
+	sorted := append(c.regions[:0:0], c.regions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].lastAccess.Load() < sorted[j].lastAccess.Load()
+	})
+	evictCount := len(c.regions) - c.maxSize + c.maxSize/regionCacheEvictRatio
+	evicted := make(map[*cachedRegion]struct{}, evictCount)
+	for _, r := range sorted[:min(evictCount, len(sorted))] {
+		evicted[r] = struct{}{}
+		delete(c.byID, r.region.Meta.GetId())
+	}
+	regions := c.regions[:0]
+	for _, r := range c.regions {
+		if _, ok := evicted[r]; !ok {
+			regions = append(regions, r)
+		}
+	}
+	clear(c.regions[len(regions):])
+	c.regions = regions
+}
+
+// InvalidateRegion removes the region from the cache.
+func (c *RegionCache) InvalidateRegion(regionID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.byID[regionID]; ok {
+		c.removeLocked(r)
+	}
+}
+
+// OnNotLeader updates the leader of the cached region according to the NotLeader error. The region
+// is invalidated if the new leader is unknown or not a peer of the cached region.
+func (c *RegionCache) OnNotLeader(regionID uint64, leader *metapb.Peer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.byID[regionID]
+	if !ok {
+		return
+	}
+	if leader != nil {
+		for _, peer := range r.region.Meta.GetPeers() {
+			if peer.GetId() != leader.GetId() {
+				continue
+			}
+			// Copy the region since it may be being used by the callers.
+			region := *r.region
+			region.Leader = peer
+			updated := newCachedRegion(&region, r.loadTime)
+			updated.lastAccess.Store(r.lastAccess.Load())
+			c.regions[c.search(r.startKey())] = updated
+			c.byID[regionID] = updated
+			return
+		}
+	}
+	c.removeLocked(r)
+}
+
+// OnEpochNotMatch invalidates the cached region according to the EpochNotMatch error, and puts the
+// current regions carried by the error into the cache if any.
+func (c *RegionCache) OnEpochNotMatch(regionID uint64, currentRegions []*metapb.Region) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.byID[regionID]; ok {
+		c.removeLocked(r)
+	}
+	now := time.Now()
+	for _, meta := range currentRegions {
+		if meta == nil {
+			continue
+		}
+		// The leader is unknown, it will be fixed by the NotLeader error.
+		c.insertLocked(&Region{Meta: meta}, now)
+	}
+}
+
+// OnRegionError updates the cache according to the region error returned by TiKV. It returns
+// whether the region error is handled.
+func (c *RegionCache) OnRegionError(regionID uint64, regionErr *errorpb.Error) bool {
+	switch {
+	case regionErr == nil:
+		return false
+	case regionErr.GetNotLeader() != nil:
+		c.OnNotLeader(regionID, regionErr.GetNotLeader().GetLeader())
+	case regionErr.GetEpochNotMatch() != nil:
+		c.OnEpochNotMatch(regionID, regionErr.GetEpochNotMatch().GetCurrentRegions())
+	case regionErr.GetRegionNotFound() != nil:
+		c.InvalidateRegion(regionID)
+	default:
+		return false
+	}
+	return true
+}
+
+// refreshLoop reloads the regions which are about to expire and have been accessed since loaded
+// in the background, so that the hot regions are rarely loaded on the access path.
+func (c *RegionCache) refreshLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := c.refresh(); err != nil {
+			log.Warn("[router] failed to refresh the region cache", errs.ZapError(err))
+		}
+	}
+}
+
+func (c *RegionCache) refresh() error {
+	keyRanges := c.collectRefreshRanges(time.Now())
+	if len(keyRanges) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, c.refreshInterval)
+	defer cancel()
+	regions, err := c.client.BatchScanRegions(ctx, keyRanges, 0, c.getRegionOpts...)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, region := range regions {
+		if region == nil || region.Meta == nil {
+			continue
+		}
+		c.insertLocked(region, now)
+	}
+	log.Debug("[router] refreshed the region cache",
+		zap.Int("range-count", len(keyRanges)), zap.Int("region-count", len(regions)))
+	return nil
+}
+
+// collectRefreshRanges returns the key ranges of the regions to refresh, the oldest first.
+func (c *RegionCache) collectRefreshRanges(now time.Time) []KeyRange {
+	c.mu.RLock()
+	candidates := make([]*cachedRegion, 0)
+	for _, r := range c.regions {
+		// Refresh the region if it has lived for half of the TTL.
+		if c.ttl > 0 && now.Sub(r.loadTime) < c.ttl/2 {
+			continue
+		}
+		if r.lastAccess.Load() <= r.loadTime.UnixNano() {
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+	c.mu.RUnlock()
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].loadTime.Before(candidates[j].loadTime)
+	})
+	candidates = candidates[:min(len(candidates), c.refreshBatchSize)]
+	// BatchScanRegions requires the key ranges to be sorted.
+	sort.Slice(candidates, func(i, j int) bool {
+		return bytes.Compare(candidates[i].startKey(), candidates[j].startKey()) < 0
+	})
+	keyRanges := make([]KeyRange, 0, len(candidates))
+	for _, r := range candidates {
+		keyRanges = append(keyRanges, KeyRange{StartKey: r.startKey(), EndKey: r.region.Meta.GetEndKey()})
+	}
+	return keyRanges
+}
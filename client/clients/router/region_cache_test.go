@@ -0,0 +1,259 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/errorpb"
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/client/opt"
+	"github.com/tikv/pd/client/pkg/utils/testutil"
+)
+
+type mockRouterClient struct {
+	Client
+	sync.Mutex
+	regions   []*Region
+	loadCount int
+	scanCount int
+}
+
+func (m *mockRouterClient) setRegions(regions ...*Region) {
+	m.Lock()
+	defer m.Unlock()
+	m.regions = regions
+}
+
+func (m *mockRouterClient) GetRegion(_ context.Context, key []byte, _ ...opt.GetRegionOption) (*Region, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.loadCount++
+	for _, region := range m.regions {
+		if (&cachedRegion{region: region}).contains(key) {
+			return region, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRouterClient) GetRegionByID(_ context.Context, regionID uint64, _ ...opt.GetRegionOption) (*Region, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.loadCount++
+	for _, region := range m.regions {
+		if region.Meta.GetId() == regionID {
+			return region, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRouterClient) BatchScanRegions(_ context.Context, keyRanges []KeyRange, _ int, _ ...opt.GetRegionOption) ([]*Region, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.scanCount++
+	var regions []*Region
+	for _, region := range m.regions {
+		for _, keyRange := range keyRanges {
+			if (len(keyRange.EndKey) == 0 || bytes.Compare(region.Meta.GetStartKey(), keyRange.EndKey) < 0) &&
+				(len(region.Meta.GetEndKey()) == 0 || bytes.Compare(keyRange.StartKey, region.Meta.GetEndKey()) < 0) {
+				regions = append(regions, region)
+				break
+			}
+		}
+	}
+	return regions, nil
+}
+
+func (m *mockRouterClient) getCounts() (loadCount, scanCount int) {
+	m.Lock()
+	defer m.Unlock()
+	return m.loadCount, m.scanCount
+}
+
+func newTestRegion(id uint64, startKey, endKey string, version uint64) *Region {
+	peers := []*metapb.Peer{{Id: id*10 + 1, StoreId: 1}, {Id: id*10 + 2, StoreId: 2}}
+	return &Region{
+		Meta: &metapb.Region{
+			Id:          id,
+			StartKey:    []byte(startKey),
+			EndKey:      []byte(endKey),
+			RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1},
+			Peers:       peers,
+		},
+		Leader: peers[0],
+	}
+}
+
+func TestRegionCacheLocate(t *testing.T) {
+	re := require.New(t)
+	client := &mockRouterClient{}
+	client.setRegions(newTestRegion(1, "", "b", 1), newTestRegion(2, "b", "d", 1), newTestRegion(3, "d", "", 1))
+	cache := NewRegionCache(context.Background(), client, WithRegionCacheRefresh(0, 0))
+	defer cache.Close()
+
+	for _, testCase := range []struct {
+		key string
+		id  uint64
+	}{{"", 1}, {"a", 1}, {"b", 2}, {"c", 2}, {"d", 3}, {"zzz", 3}} {
+		region, err := cache.LocateKey(context.Background(), []byte(testCase.key))
+		re.NoError(err)
+		re.Equal(testCase.id, region.Meta.GetId(), testCase.key)
+	}
+	loadCount, _ := client.getCounts()
+	re.Equal(3, loadCount)
+	re.Equal(3, cache.Len())
+
+	region, err := cache.LocateRegionByID(context.Background(), 2)
+	re.NoError(err)
+	re.Equal([]byte("b"), region.Meta.GetStartKey())
+	loadCount, _ = client.getCounts()
+	re.Equal(3, loadCount)
+
+	// The region is split, the new regions replace the old one.
+	cache.Insert(newTestRegion(4, "b", "c", 2))
+	re.Nil(cache.GetCachedRegionByID(2))
+	re.Nil(cache.GetCachedRegionByKey([]byte("c")))
+	re.Equal(uint64(4), cache.GetCachedRegionByKey([]byte("b")).Meta.GetId())
+	cache.Insert(newTestRegion(2, "c", "d", 2))
+	re.Equal(uint64(2), cache.GetCachedRegionByKey([]byte("c")).Meta.GetId())
+	re.Equal(4, cache.Len())
+
+	// The stale region is ignored.
+	cache.Insert(newTestRegion(2, "b", "d", 1))
+	re.Equal(uint64(4), cache.GetCachedRegionByKey([]byte("b")).Meta.GetId())
+	re.Equal([]byte("c"), cache.GetCachedRegionByID(2).Meta.GetStartKey())
+
+	// The regions are merged.
+	cache.Insert(newTestRegion(2, "b", "", 3))
+	re.Equal(2, cache.Len())
+	re.Nil(cache.GetCachedRegionByID(3))
+	re.Nil(cache.GetCachedRegionByID(4))
+	re.Equal(uint64(2), cache.GetCachedRegionByKey([]byte("zzz")).Meta.GetId())
+
+	// The region is not found.
+	client.setRegions()
+	cache.InvalidateRegion(2)
+	region, err = cache.LocateKey(context.Background(), []byte("c"))
+	re.NoError(err)
+	re.Nil(region)
+	re.Equal(1, cache.Len())
+}
+
+func TestRegionCacheRegionError(t *testing.T) {
+	re := require.New(t)
+	cache := NewRegionCache(context.Background(), &mockRouterClient{}, WithRegionCacheRefresh(0, 0))
+	defer cache.Close()
+	region := newTestRegion(1, "a", "c", 1)
+	cache.Insert(region)
+
+	// The leader is updated without modifying the region held by the callers.
+	re.True(cache.OnRegionError(1, &errorpb.Error{NotLeader: &errorpb.NotLeader{
+		RegionId: 1,
+		Leader:   &metapb.Peer{Id: 12, StoreId: 2},
+	}}))
+	re.Equal(uint64(12), cache.GetCachedRegionByID(1).Leader.GetId())
+	re.Equal(uint64(11), region.Leader.GetId())
+	re.Equal(uint64(12), cache.GetCachedRegionByKey([]byte("b")).Leader.GetId())
+
+	// The unknown leader invalidates the region.
+	cache.OnNotLeader(1, &metapb.Peer{Id: 13, StoreId: 3})
+	re.Nil(cache.GetCachedRegionByID(1))
+	re.Zero(cache.Len())
+
+	// The current regions replace the stale one.
+	cache.Insert(region)
+	re.True(cache.OnRegionError(1, &errorpb.Error{EpochNotMatch: &errorpb.EpochNotMatch{
+		CurrentRegions: []*metapb.Region{
+			newTestRegion(1, "a", "b", 2).Meta,
+			newTestRegion(2, "b", "c", 2).Meta,
+		},
+	}}))
+	re.Equal(2, cache.Len())
+	re.Equal(uint64(2), cache.GetCachedRegionByKey([]byte("b")).Meta.GetId())
+	re.Nil(cache.GetCachedRegionByKey([]byte("b")).Leader)
+
+	re.True(cache.OnRegionError(2, &errorpb.Error{RegionNotFound: &errorpb.RegionNotFound{RegionId: 2}}))
+	re.Equal(1, cache.Len())
+	re.False(cache.OnRegionError(1, &errorpb.Error{ServerIsBusy: &errorpb.ServerIsBusy{}}))
+	re.False(cache.OnRegionError(1, nil))
+	re.Equal(1, cache.Len())
+}
+
+func TestRegionCacheLimits(t *testing.T) {
+	re := require.New(t)
+	client := &mockRouterClient{}
+	client.setRegions(newTestRegion(1, "", "b", 1))
+	cache := NewRegionCache(context.Background(), client,
+		WithRegionCacheTTL(50*time.Millisecond),
+		WithRegionCacheMaxSize(32),
+		WithRegionCacheRefresh(0, 0))
+	defer cache.Close()
+
+	// The expired region is reloaded.
+	_, err := cache.LocateKey(context.Background(), []byte("a"))
+	re.NoError(err)
+	re.NotNil(cache.GetCachedRegionByKey([]byte("a")))
+	time.Sleep(100 * time.Millisecond)
+	re.Nil(cache.GetCachedRegionByKey([]byte("a")))
+	_, err = cache.LocateKey(context.Background(), []byte("a"))
+	re.NoError(err)
+	loadCount, _ := client.getCounts()
+	re.Equal(2, loadCount)
+
+	// The least recently accessed regions are evicted.
+	for i := range 40 {
+		key := []byte{'c', byte(i)}
+		cache.Insert(newTestRegion(uint64(i+2), string(key), string(append(key, 0)), 1))
+		re.NotNil(cache.GetCachedRegionByKey([]byte("a")))
+	}
+	re.LessOrEqual(cache.Len(), 32)
+	re.NotNil(cache.GetCachedRegionByKey([]byte("a")))
+	re.Nil(cache.GetCachedRegionByID(2))
+	re.NotNil(cache.GetCachedRegionByID(41))
+}
+
+func TestRegionCacheRefresh(t *testing.T) {
+	re := require.New(t)
+	client := &mockRouterClient{}
+	client.setRegions(newTestRegion(1, "", "b", 1), newTestRegion(2, "b", "", 1))
+	cache := NewRegionCache(context.Background(), client,
+		WithRegionCacheTTL(200*time.Millisecond),
+		WithRegionCacheRefresh(20*time.Millisecond, 16))
+	defer cache.Close()
+
+	_, err := cache.LocateKey(context.Background(), []byte("a"))
+	re.NoError(err)
+	_, err = cache.LocateKey(context.Background(), []byte("c"))
+	re.NoError(err)
+	// Only the accessed region is refreshed.
+	client.setRegions(newTestRegion(1, "", "b", 2), newTestRegion(2, "b", "", 2))
+	re.NotNil(cache.GetCachedRegionByKey([]byte("a")))
+	testutil.Eventually(re, func() bool {
+		region := cache.GetCachedRegionByID(1)
+		return region != nil && region.Meta.GetRegionEpoch().GetVersion() == 2
+	})
+	re.Equal(uint64(1), cache.GetCachedRegionByID(2).Meta.GetRegionEpoch().GetVersion())
+	_, scanCount := client.getCounts()
+	re.Positive(scanCount)
+}
@@ -61,17 +61,7 @@ func (req *Request) wait() (*Region, error) {
 	metrics.CmdDurationQueryRegionAsyncWait.Observe(start.Sub(req.start).Seconds())
 	select {
 	case err := <-req.done:
-		defer req.pool.Put(req)
-		defer trace.StartRegion(req.requestCtx, "pdclient.regionReqDone").End()
-		now := time.Now()
-		if err != nil {
-			metrics.CmdFailedDurationQueryRegionWait.Observe(now.Sub(start).Seconds())
-			metrics.CmdFailedDurationQueryRegion.Observe(now.Sub(req.start).Seconds())
-			return nil, errors.WithStack(err)
-		}
-		metrics.CmdDurationQueryRegionWait.Observe(now.Sub(start).Seconds())
-		metrics.CmdDurationQueryRegion.Observe(now.Sub(req.start).Seconds())
-		return req.region, nil
+		return req.finish(start, err)
 	case <-req.requestCtx.Done():
 		return nil, errors.WithStack(req.requestCtx.Err())
 	case <-req.clientCtx.Done():
@@ -79,13 +69,28 @@ func (req *Request) wait() (*Region, error) {
 	}
 }
 
+// finish returns the result of the done request and puts it back into the pool.
+func (req *Request) finish(start time.Time, err error) (*Region, error) {
+	defer req.pool.Put(req)
+	defer trace.StartRegion(req.requestCtx, "pdclient.regionReqDone").End()
+	now := time.Now()
+	if err != nil {
+		metrics.CmdFailedDurationQueryRegionWait.Observe(now.Sub(start).Seconds())
+		metrics.CmdFailedDurationQueryRegion.Observe(now.Sub(req.start).Seconds())
+		return nil, errors.WithStack(err)
+	}
+	metrics.CmdDurationQueryRegionWait.Observe(now.Sub(start).Seconds())
+	metrics.CmdDurationQueryRegion.Observe(now.Sub(req.start).Seconds())
+	return req.region, nil
+}
+
 // GetRegion implements the Client interface.
 func (c *Cli) GetRegion(ctx context.Context, key []byte, opts ...opt.GetRegionOption) (*Region, error) {
 	req := c.newRequest(ctx, opts...)
 	req.key = key
 
 	c.requestCh <- req
-	return req.wait()
+	return c.waitWithHedge(req)
 }
 
 // GetPrevRegion implements the Client interface.
@@ -94,7 +99,7 @@ func (c *Cli) GetPrevRegion(ctx context.Context, key []byte, opts ...opt.GetRegi
 	req.prevKey = key
 
 	c.requestCh <- req
-	return req.wait()
+	return c.waitWithHedge(req)
 }
 
 // GetRegionByID implements the Client interface.
@@ -103,5 +108,5 @@ func (c *Cli) GetRegionByID(ctx context.Context, regionID uint64, opts ...opt.Ge
 	req.id = regionID
 
 	c.requestCh <- req
-	return req.wait()
+	return c.waitWithHedge(req)
 }
@@ -58,6 +58,11 @@ type Client interface {
 	// GetMinTS gets a timestamp from PD or the minimal timestamp across all keyspace groups from
 	// the TSO microservice.
 	GetMinTS(ctx context.Context) (int64, int64, error)
+	// GetStaleTS gets a timestamp which may be served locally from a prefetched TSO range,
+	// it's only suitable for the read-only requests which can tolerate a bounded staleness.
+	// The staleness is bounded by the `MaxTSOStaleness` option, and it's equivalent to
+	// `GetTS` if the option is 0.
+	GetStaleTS(ctx context.Context) (int64, int64, error)
 
 	// Deprecated: the Local TSO feature has been deprecated. Regardless of the
 	// parameters passed, the behavior of this interface will be equivalent to
@@ -172,6 +177,11 @@ func (c *Cli) scheduleUpdateTSOConnectionCtxs() {
 
 // GetTSORequest gets a TSO request from the pool.
 func (c *Cli) GetTSORequest(ctx context.Context) *Request {
+	return c.GetTSORequestWithCount(ctx, 1)
+}
+
+// GetTSORequestWithCount gets a TSO request which requests a range of `count` TSO from the pool.
+func (c *Cli) GetTSORequestWithCount(ctx context.Context, count int64) *Request {
 	req := c.tsoReqPool.Get().(*Request)
 	// Set needed fields in the request before using it.
 	req.start = time.Now()
@@ -180,6 +190,7 @@ func (c *Cli) GetTSORequest(ctx context.Context) *Request {
 	req.clientCtx = c.ctx
 	req.physical = 0
 	req.logical = 0
+	req.count = count
 	req.streamID = ""
 	return req
 }
@@ -422,8 +422,11 @@ func (td *tsoDispatcher) processRequests(
 		}
 	}()
 
+	var count int64
+	for _, req := range requests {
+		count += req.count
+	}
 	var (
-		count              = int64(len(requests))
 		svcDiscovery       = td.provider.getServiceDiscovery()
 		clusterID          = svcDiscovery.GetClusterID()
 		keyspaceID         = svcDiscovery.GetKeyspaceID()
@@ -473,10 +476,14 @@ func (td *tsoDispatcher) processRequests(
 }
 
 func tsoRequestFinisher(physical, firstLogical int64, streamID string) batch.FinisherFunc[*Request] {
-	return func(idx int, tsoReq *Request, err error) {
+	// The requests are finished in order, each of them takes a range of `count` TSO and
+	// gets the largest one of its range.
+	var offset int64
+	return func(_ int, tsoReq *Request, err error) {
 		// Retrieve the request context before the request is done to trace without race.
 		requestCtx := tsoReq.requestCtx
-		tsoReq.physical, tsoReq.logical = physical, firstLogical+int64(idx)
+		offset += tsoReq.count
+		tsoReq.physical, tsoReq.logical = physical, firstLogical+offset-1
 		tsoReq.streamID = streamID
 		tsoReq.TryDone(err)
 		trace.StartRegion(requestCtx, "pdclient.tsoReqDequeue").End()
@@ -150,6 +150,7 @@ func (s *testTSODispatcherSuite) getReq(ctx context.Context) *Request {
 	req.requestCtx = ctx
 	req.physical = 0
 	req.logical = 0
+	req.count = 1
 	req.start = time.Now()
 	req.pool = s.reqPool
 	return req
@@ -190,6 +191,26 @@ func (s *testTSODispatcherSuite) TestBasic() {
 	s.reqMustNotReady(req)
 }
 
+func (s *testTSODispatcherSuite) TestRequestWithCount() {
+	ctx := context.Background()
+	req := s.sendReq(ctx)
+	s.streamInner.generateNext()
+	_, lastLogical := s.reqMustReady(req)
+
+	// The request with count gets the largest TSO of its range.
+	req = s.getReq(ctx)
+	req.count = 10
+	s.dispatcher.push(req)
+	s.streamInner.generateNext()
+	_, logical := s.reqMustReady(req)
+	s.re.Equal(lastLogical+10, logical)
+
+	req = s.sendReq(ctx)
+	s.streamInner.generateNext()
+	_, lastLogical = s.reqMustReady(req)
+	s.re.Equal(logical+1, lastLogical)
+}
+
 func (s *testTSODispatcherSuite) checkIdleTokenCount(expectedTotal int) {
 	// When the tsoDispatcher is idle, the dispatcher loop will acquire a token and wait for requests. Therefore
 	// there should be N-1 free tokens remaining.
@@ -347,6 +368,7 @@ func BenchmarkTSODispatcherHandleRequests(b *testing.B) {
 		req.requestCtx = ctx
 		req.physical = 0
 		req.logical = 0
+		req.count = 1
 		req.start = time.Now()
 		req.pool = reqPool
 		return req
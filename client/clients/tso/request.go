@@ -43,6 +43,9 @@ type Request struct {
 	done       chan error
 	physical   int64
 	logical    int64
+	// The count of the TSO requested, the result is the largest one of the range
+	// [logical-count+1, logical]. It's 1 for the normal requests.
+	count int64
 
 	// The identifier of the RPC stream in which the request is processed.
 	streamID string
@@ -0,0 +1,91 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tikv/pd/client/metrics"
+)
+
+// PrefetchFunc fetches a range of `count` TSO and returns the largest one of the range.
+type PrefetchFunc func(ctx context.Context, count int64) (physical int64, logical int64, err error)
+
+// StaleTSCache serves the TSO locally from a range prefetched in bulk. Every TSO in the range
+// is allocated by the server after the prefetch starts, so it's not older than any TSO got before
+// the prefetch starts. As a result, the TSO served is stale for no more than the time elapsed since
+// the prefetch starts, which is bounded by the max staleness. The zero value is ready to use.
+type StaleTSCache struct {
+	// fetchMu makes sure only one prefetch is in flight, the other requests wait for it
+	// and are served from the newly prefetched range.
+	fetchMu sync.Mutex
+
+	mu struct {
+		sync.Mutex
+		physical int64
+		// The range [nextLogical, maxLogical] is not served yet.
+		nextLogical int64
+		maxLogical  int64
+		// The time when the prefetch of the current range starts.
+		fetchStart time.Time
+	}
+}
+
+// GetStaleTS gets a TSO which is stale for no more than `maxStaleness`. If the prefetched range is
+// used up or too stale, a new range of `prefetchCount` TSO is prefetched with the given function.
+func (c *StaleTSCache) GetStaleTS(
+	ctx context.Context, maxStaleness time.Duration, prefetchCount int64, prefetch PrefetchFunc,
+) (int64, int64, error) {
+	if physical, logical, ok := c.tryGet(maxStaleness); ok {
+		metrics.StaleTSOCacheHit.Inc()
+		return physical, logical, nil
+	}
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	// The range may be prefetched by others while waiting for the lock.
+	if physical, logical, ok := c.tryGet(maxStaleness); ok {
+		metrics.StaleTSOCacheHit.Inc()
+		return physical, logical, nil
+	}
+	metrics.StaleTSOCacheMiss.Inc()
+	metrics.StaleTSOPrefetchSize.Observe(float64(prefetchCount))
+	fetchStart := time.Now()
+	physical, logical, err := prefetch(ctx, prefetchCount)
+	if err != nil {
+		return 0, 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Take the first one of the range and keep the rest.
+	c.mu.physical = physical
+	c.mu.nextLogical = logical - prefetchCount + 2
+	c.mu.maxLogical = logical
+	c.mu.fetchStart = fetchStart
+	return physical, logical - prefetchCount + 1, nil
+}
+
+func (c *StaleTSCache) tryGet(maxStaleness time.Duration) (physical int64, logical int64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.fetchStart.IsZero() || c.mu.nextLogical > c.mu.maxLogical ||
+		time.Since(c.mu.fetchStart) >= maxStaleness {
+		return 0, 0, false
+	}
+	logical = c.mu.nextLogical
+	c.mu.nextLogical++
+	return c.mu.physical, logical, true
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleTSCache(t *testing.T) {
+	re := require.New(t)
+	ctx := context.Background()
+
+	var (
+		cache      StaleTSCache
+		fetchCount atomic.Int64
+		physical   atomic.Int64
+		logical    int64
+		fetchErr   error
+	)
+	prefetch := func(_ context.Context, count int64) (int64, int64, error) {
+		fetchCount.Add(1)
+		if fetchErr != nil {
+			return 0, 0, fetchErr
+		}
+		logical += count
+		return physical.Load(), logical, nil
+	}
+
+	physical.Store(100)
+	// The range [1, 4] is prefetched.
+	for i := range 4 {
+		p, l, err := cache.GetStaleTS(ctx, time.Hour, 4, prefetch)
+		re.NoError(err)
+		re.Equal(int64(100), p)
+		re.Equal(int64(i+1), l)
+	}
+	re.Equal(int64(1), fetchCount.Load())
+	// The range is used up.
+	p, l, err := cache.GetStaleTS(ctx, time.Hour, 4, prefetch)
+	re.NoError(err)
+	re.Equal(int64(100), p)
+	re.Equal(int64(5), l)
+	re.Equal(int64(2), fetchCount.Load())
+
+	// The range is too stale.
+	physical.Store(200)
+	time.Sleep(10 * time.Millisecond)
+	p, l, err = cache.GetStaleTS(ctx, 5*time.Millisecond, 4, prefetch)
+	re.NoError(err)
+	re.Equal(int64(200), p)
+	re.Equal(int64(9), l)
+	re.Equal(int64(3), fetchCount.Load())
+
+	// The error is returned and the range is kept.
+	fetchErr = errors.New("mock error")
+	time.Sleep(10 * time.Millisecond)
+	_, _, err = cache.GetStaleTS(ctx, 5*time.Millisecond, 4, prefetch)
+	re.Error(err)
+	fetchErr = nil
+	p, l, err = cache.GetStaleTS(ctx, time.Hour, 4, prefetch)
+	re.NoError(err)
+	re.Equal(int64(200), p)
+	re.Equal(int64(10), l)
+	re.Equal(int64(4), fetchCount.Load())
+
+	// The concurrent requests share the prefetched range and get unique TSO.
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[int64]struct{})
+	)
+	for range 64 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, l, err := cache.GetStaleTS(ctx, time.Hour, 16, prefetch)
+			re.NoError(err)
+			mu.Lock()
+			defer mu.Unlock()
+			seen[l] = struct{}{}
+		}()
+	}
+	wg.Wait()
+	re.Len(seen, 64)
+	re.Equal(int64(4+4), fetchCount.Load())
+}
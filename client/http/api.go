@@ -39,6 +39,7 @@ const (
 	store                     = "/pd/api/v1/store"
 	Stores                    = "/pd/api/v1/stores"
 	StatsRegion               = "/pd/api/v1/stats/region"
+	StoresLimit               = "/pd/api/v1/stores/limit"
 	membersPrefix             = "/pd/api/v1/members"
 	leaderPrefix              = "/pd/api/v1/leader"
 	transferLeader            = "/pd/api/v1/leader/transfer"
@@ -63,10 +64,17 @@ const (
 	Schedulers            = "/pd/api/v1/schedulers"
 	SchedulerConfig       = "/pd/api/v1/scheduler-config"
 	scatterRangeScheduler = "/pd/api/v1/schedulers/scatter-range-scheduler-"
+	schedulerDiagnostic   = "/pd/api/v1/schedulers/diagnostic"
+	// Checker
+	checker = "/pd/api/v1/checker"
+	// Operator
+	Operators = "/pd/api/v1/operators"
 	// Admin
 	ResetTS                = "/pd/api/v1/admin/reset-ts"
 	BaseAllocID            = "/pd/api/v1/admin/base-alloc-id"
 	SnapshotRecoveringMark = "/pd/api/v1/admin/cluster/markers/snapshot-recovering"
+	RemoveFailedStores     = "/pd/api/v1/admin/unsafe/remove-failed-stores"
+	RemoveFailedStoresShow = "/pd/api/v1/admin/unsafe/remove-failed-stores/show"
 	// Debug
 	PProfProfile   = "/pd/api/v1/debug/pprof/profile"
 	PProfHeap      = "/pd/api/v1/debug/pprof/heap"
@@ -80,8 +88,8 @@ const (
 	ClusterStatus       = "/pd/api/v1/cluster/status"
 	Status              = "/pd/api/v1/status"
 	Version             = "/pd/api/v1/version"
-	operators           = "/pd/api/v1/operators"
 	safepoint           = "/pd/api/v1/gc/safepoint"
+	ReplicationMode     = "/pd/api/v1/replication_mode/status"
 	// Microservice
 	microservicePrefix = "/pd/api/v2/ms"
 	// Keyspace
@@ -151,6 +159,11 @@ func LabelByStoreID(storeID int64) string {
 	return fmt.Sprintf("%s/%d/label", store, storeID)
 }
 
+// StoreLimitByID returns the store limit API with store ID parameter.
+func StoreLimitByID(id uint64) string {
+	return fmt.Sprintf("%s/%d/limit", store, id)
+}
+
 // TransferLeaderByID returns the path of PD HTTP API to transfer leader by ID.
 func TransferLeaderByID(leaderID string) string {
 	return fmt.Sprintf("%s/%s", transferLeader, leaderID)
@@ -201,6 +214,30 @@ func SchedulerByName(name string) string {
 	return fmt.Sprintf("%s/%s", Schedulers, name)
 }
 
+// SchedulerDiagnosticByName returns the path of PD HTTP API to get the diagnostic result of the given scheduler.
+func SchedulerDiagnosticByName(name string) string {
+	return fmt.Sprintf("%s/%s", schedulerDiagnostic, name)
+}
+
+// CheckerByName returns the checker API with the given checker name.
+func CheckerByName(name string) string {
+	return fmt.Sprintf("%s/%s", checker, name)
+}
+
+// OperatorsWithKinds returns the operators API with the kind parameters, the operators are returned as JSON objects.
+func OperatorsWithKinds(kinds ...string) string {
+	query := url.Values{"object": []string{""}}
+	if len(kinds) > 0 {
+		query["kind"] = kinds
+	}
+	return fmt.Sprintf("%s?%s", Operators, query.Encode())
+}
+
+// OperatorByRegionID returns the operator API with the given region ID.
+func OperatorByRegionID(regionID uint64) string {
+	return fmt.Sprintf("%s/%d", Operators, regionID)
+}
+
 // ScatterRangeSchedulerWithName returns the scatter range scheduler API with name parameter.
 // It is used in https://github.com/pingcap/tidb/blob/2a3352c45dd0f8dd5102adb92879bbfa964e7f5f/pkg/server/handler/tikvhandler/tikv_handler.go#L1252.
 func ScatterRangeSchedulerWithName(name string) string {
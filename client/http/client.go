@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/pingcap/log"
 
 	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/pkg/circuitbreaker"
 	"github.com/tikv/pd/client/pkg/retry"
 	sd "github.com/tikv/pd/client/servicediscovery"
 )
@@ -70,6 +72,8 @@ type clientInner struct {
 	executionDuration *prometheus.HistogramVec
 	// defaultSD indicates whether the client is created with the default service discovery.
 	defaultSD bool
+	// circuitBreakers is used to fail the requests fast when the API is overloaded.
+	circuitBreakers *circuitbreaker.Registry
 }
 
 func newClientInner(ctx context.Context, cancel context.CancelFunc, source string) *clientInner {
@@ -169,17 +173,46 @@ func (ci *clientInner) requestWithRetry(
 		}
 		return err
 	}
-	if reqInfo.bo == nil {
-		return execFunc()
+	execWithRetry := func() error {
+		if reqInfo.bo == nil {
+			return execFunc()
+		}
+		// Copy a new backoffer for each request.
+		bo := *reqInfo.bo
+		// Set the retryable checker for the backoffer if it's not set.
+		bo.SetRetryableChecker(func(err error) bool {
+			// Backoffer also needs to check the status code to determine whether to retry.
+			return err != nil && !noNeedRetry(statusCode)
+		}, false)
+		return bo.Exec(ctx, execFunc)
+	}
+	if ci.circuitBreakers == nil {
+		return execWithRetry()
+	}
+	// The whole request including the retries is protected by the circuit breaker of the API,
+	// so that the fast-failed request will not be retried.
+	return ci.circuitBreakers.Get(reqInfo.name).Execute(func() (circuitbreaker.Overloading, error) {
+		err := execWithRetry()
+		return isOverloaded(statusCode, err), err
+	})
+}
+
+// isOverloaded checks whether the request failure indicates that PD is overloaded.
+func isOverloaded(statusCode int, err error) circuitbreaker.Overloading {
+	if err == nil {
+		return circuitbreaker.No
+	}
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		return circuitbreaker.Yes
 	}
-	// Copy a new backoffer for each request.
-	bo := *reqInfo.bo
-	// Set the retryable checker for the backoffer if it's not set.
-	bo.SetRetryableChecker(func(err error) bool {
-		// Backoffer also needs to check the status code to determine whether to retry.
-		return err != nil && !noNeedRetry(statusCode)
-	}, false)
-	return bo.Exec(ctx, execFunc)
+	cause := errors.Cause(err)
+	if cause == context.DeadlineExceeded {
+		return circuitbreaker.Yes
+	}
+	if netErr, ok := cause.(net.Error); ok && netErr.Timeout() {
+		return circuitbreaker.Yes
+	}
+	return circuitbreaker.No
 }
 
 func noNeedRetry(statusCode int) bool {
@@ -304,6 +337,14 @@ func WithMetrics(
 	}
 }
 
+// WithCircuitBreakers configures the client with the circuit breakers registry,
+// each API is protected by its own circuit breaker.
+func WithCircuitBreakers(registry *circuitbreaker.Registry) ClientOption {
+	return func(c *client) {
+		c.inner.circuitBreakers = registry
+	}
+}
+
 // NewClientWithServiceDiscovery creates a PD HTTP client with the given service discovery.
 func NewClientWithServiceDiscovery(
 	source string,
@@ -15,7 +15,10 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 	"sync/atomic"
@@ -25,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/pkg/circuitbreaker"
 	"github.com/tikv/pd/client/pkg/retry"
 )
 
@@ -112,3 +116,105 @@ func TestWithTargetURL(t *testing.T) {
 	_, err = c.WithTargetURL("http://127.0.0.2").GetStatus(ctx)
 	re.ErrorContains(err, "connect: connection refused")
 }
+
+func TestWithCircuitBreakers(t *testing.T) {
+	re := require.New(t)
+	var requested atomic.Int32
+	httpClient := NewHTTPClientWithRequestChecker(func(*http.Request) error {
+		requested.Add(1)
+		return context.DeadlineExceeded
+	})
+	registry := circuitbreaker.NewRegistry("test-http", circuitbreaker.Settings{
+		ErrorRateThresholdPct: 50,
+		MinQPSForOpen:         1,
+		ErrorRateWindow:       time.Second,
+		CoolDownInterval:      time.Minute,
+		HalfOpenSuccessCount:  1,
+	})
+	c := newClientWithMockServiceDiscovery("test-circuit-breakers", []string{"http://127.0.0.1"},
+		WithHTTPClient(httpClient), WithCircuitBreakers(registry))
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err := c.GetPDVersion(ctx)
+	re.Error(err)
+	re.NotErrorIs(err, errs.ErrCircuitBreakerOpen)
+	re.Equal(int32(1), requested.Load())
+	// The timeout is regarded as overloading, so the circuit breaker is tripped in the next window.
+	time.Sleep(1100 * time.Millisecond)
+	_, err = c.GetPDVersion(ctx)
+	re.ErrorIs(err, errs.ErrCircuitBreakerOpen)
+	re.Equal(int32(1), requested.Load())
+	// The other APIs are not affected.
+	_, err = c.GetStores(ctx)
+	re.NotErrorIs(err, errs.ErrCircuitBreakerOpen)
+	re.Equal(int32(2), requested.Load())
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the `http.RoundTripper` interface.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIterHistoryHotRegions(t *testing.T) {
+	re := require.New(t)
+	var requests []HistoryHotRegionsRequest
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var hotRegionsReq HistoryHotRegionsRequest
+		if err := json.NewDecoder(req.Body).Decode(&hotRegionsReq); err != nil {
+			return nil, err
+		}
+		requests = append(requests, hotRegionsReq)
+		// Return a hot region at the start and the end of the time range.
+		resp := HistoryHotRegions{HistoryHotRegion: []*HistoryHotRegion{
+			{UpdateTime: hotRegionsReq.StartTime, RegionID: 1},
+			{UpdateTime: hotRegionsReq.EndTime, RegionID: 2},
+		}}
+		body, err := json.Marshal(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	})}
+	c := newClientWithMockServiceDiscovery("test-iter-history-hot-regions", []string{"http://127.0.0.1"}, WithHTTPClient(httpClient))
+	defer c.Close()
+
+	ctx := context.Background()
+	it := c.IterHistoryHotRegions(&HistoryHotRegionsRequest{
+		StartTime: 1000,
+		EndTime:   3499,
+		RegionIDs: []uint64{1, 2},
+	}, time.Second)
+	var updateTimes []int64
+	for it.Next(ctx) {
+		updateTimes = append(updateTimes, it.Region().UpdateTime)
+		// The next step is only fetched once the hot regions of the current step are consumed.
+		re.Len(requests, (len(updateTimes)+1)/2)
+	}
+	re.NoError(it.Err())
+	re.Nil(it.Region())
+	re.Equal([]int64{1000, 1999, 2000, 2999, 3000, 3499}, updateTimes)
+	re.Len(requests, 3)
+	for _, req := range requests {
+		re.Equal([]uint64{1, 2}, req.RegionIDs)
+	}
+
+	// The whole time range is fetched at once without the step.
+	requests = requests[:0]
+	it = c.IterHistoryHotRegions(&HistoryHotRegionsRequest{StartTime: 1000, EndTime: 3499}, 0)
+	updateTimes = updateTimes[:0]
+	for it.Next(ctx) {
+		updateTimes = append(updateTimes, it.Region().UpdateTime)
+	}
+	re.NoError(it.Err())
+	re.Equal([]int64{1000, 3499}, updateTimes)
+	re.Len(requests, 1)
+}
+
+func TestOperatorsWithKinds(t *testing.T) {
+	re := require.New(t)
+	re.Equal(Operators+"?object=", OperatorsWithKinds())
+	re.Equal(Operators+"?kind=admin&kind=leader&object=", OperatorsWithKinds("admin", "leader"))
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"time"
+)
+
+// HistoryHotRegionsIterator iterates the history hot regions in the time range of the request.
+// The time range is split by the step, and each step is only fetched once the hot regions of
+// the previous step are consumed.
+//
+//	it := cli.IterHistoryHotRegions(req, time.Hour)
+//	for it.Next(ctx) {
+//		region := it.Region()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type HistoryHotRegionsIterator struct {
+	cli *client
+	req HistoryHotRegionsRequest
+	// step is the time range in milliseconds fetched by each request.
+	step      int64
+	nextStart int64
+
+	buffered []*HistoryHotRegion
+	current  *HistoryHotRegion
+	err      error
+}
+
+// IterHistoryHotRegions returns an iterator of the history hot regions. The `StartTime` and `EndTime`
+// of the request are in milliseconds and both inclusive. The whole time range is fetched at once if the
+// step is not positive.
+func (c *client) IterHistoryHotRegions(req *HistoryHotRegionsRequest, step time.Duration) *HistoryHotRegionsIterator {
+	it := &HistoryHotRegionsIterator{
+		cli:       c,
+		req:       *req,
+		step:      step.Milliseconds(),
+		nextStart: req.StartTime,
+	}
+	if it.step <= 0 {
+		it.step = req.EndTime - req.StartTime + 1
+	}
+	return it
+}
+
+// Next advances the iterator to the next hot region. It returns false when the iteration
+// is finished or an error occurs, which can be checked by `Err`.
+func (it *HistoryHotRegionsIterator) Next(ctx context.Context) bool {
+	for len(it.buffered) == 0 {
+		if it.err != nil || it.step <= 0 || it.nextStart > it.req.EndTime {
+			it.current = nil
+			return false
+		}
+		req := it.req
+		req.StartTime = it.nextStart
+		req.EndTime = min(it.nextStart+it.step-1, it.req.EndTime)
+		resp, err := it.cli.GetHistoryHotRegions(ctx, &req)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		it.nextStart = req.EndTime + 1
+		it.buffered = resp.HistoryHotRegion
+	}
+	it.current = it.buffered[0]
+	// Release the reference so the consumed hot region can be garbage collected.
+	it.buffered[0] = nil
+	it.buffered = it.buffered[1:]
+	return true
+}
+
+// Region returns the current hot region.
+func (it *HistoryHotRegionsIterator) Region() *HistoryHotRegion {
+	return it.current
+}
+
+// Err returns the error that stops the iteration, if any.
+func (it *HistoryHotRegionsIterator) Err() error {
+	return it.err
+}
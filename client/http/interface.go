@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
@@ -47,6 +48,9 @@ type Client interface {
 	GetHotReadRegions(context.Context) (*StoreHotPeersInfos, error)
 	GetHotWriteRegions(context.Context) (*StoreHotPeersInfos, error)
 	GetHistoryHotRegions(context.Context, *HistoryHotRegionsRequest) (*HistoryHotRegions, error)
+	// IterHistoryHotRegions returns an iterator which fetches the history hot regions of the request
+	// time range step by step, so that a large time range does not need to be buffered in memory.
+	IterHistoryHotRegions(req *HistoryHotRegionsRequest, step time.Duration) *HistoryHotRegionsIterator
 	GetRegionStatusByKeyRange(context.Context, *KeyRange, bool) (*RegionStats, error)
 	GetRegionDistributionByKeyRange(ctx context.Context, keyRange *KeyRange, engine string) (*RegionDistributions, error)
 	GetStores(context.Context) (*StoresInfo, error)
@@ -54,6 +58,9 @@ type Client interface {
 	DeleteStore(context.Context, uint64) error
 	SetStoreLabels(context.Context, int64, map[string]string) error
 	DeleteStoreLabel(ctx context.Context, storeID int64, labelKey string) error
+	GetStoresLimit(context.Context) (map[uint64]StoreLimitConfig, error)
+	SetStoreLimit(context.Context, uint64, *StoreLimitInput) error
+	SetAllStoresLimit(context.Context, *StoreLimitInput) error
 	GetHealthStatus(context.Context) ([]Health, error)
 	/* Config-related interfaces */
 	GetConfig(context.Context) (map[string]any, error)
@@ -73,6 +80,19 @@ type Client interface {
 	DeleteScheduler(ctx context.Context, name string) error
 	SetSchedulerDelay(context.Context, string, int64) error
 	GetSchedulerConfig(ctx context.Context, name string) (any, error)
+	GetSchedulerDiagnosticResult(ctx context.Context, name string) (*SchedulerDiagnosticResult, error)
+	/* Checker-related interfaces */
+	GetCheckerStatus(ctx context.Context, name string) (*CheckerStatus, error)
+	PauseChecker(ctx context.Context, name string, delaySec int64) error
+	ResumeChecker(ctx context.Context, name string) error
+	/* Operator-related interfaces */
+	// GetOperators gets the running operators, only the operators of the given kinds are returned if any.
+	GetOperators(ctx context.Context, kinds ...string) ([]*OperatorObject, error)
+	GetOperatorByRegionID(context.Context, uint64) (string, error)
+	// CreateOperator creates an operator with the given input, the `name` field indicates the operator type,
+	// e.g. `transfer-leader`, `add-peer`, `merge-region` and `split-region`.
+	CreateOperator(ctx context.Context, input map[string]any) error
+	DeleteOperatorByRegionID(context.Context, uint64) error
 	/* Rule-related interfaces */
 	GetAllPlacementRuleBundles(context.Context) ([]*GroupBundle, error)
 	GetPlacementRuleBundleByGroup(context.Context, string) (*GroupBundle, error)
@@ -102,11 +122,14 @@ type Client interface {
 	ResetBaseAllocID(context.Context, uint64) error
 	SetSnapshotRecoveringMark(context.Context) error
 	DeleteSnapshotRecoveringMark(context.Context) error
+	RemoveFailedStores(context.Context, *RemoveFailedStoresInput) error
+	GetFailedStoresRemovalStatus(context.Context) ([]UnsafeRecoveryStageOutput, error)
 	/* Other interfaces */
 	GetMinResolvedTSByStoresIDs(context.Context, []uint64) (uint64, map[uint64]uint64, error)
 	GetPDVersion(context.Context) (string, error)
 	GetGCSafePoint(context.Context) (ListServiceGCSafepoint, error)
 	DeleteGCSafePoint(context.Context, string) (string, error)
+	GetReplicationModeStatus(context.Context) (*ReplicationModeStatus, error)
 	/* Microservice interfaces */
 	GetMicroserviceMembers(context.Context, string) ([]MicroserviceMember, error)
 	GetMicroservicePrimary(context.Context, string) (string, error)
@@ -403,6 +426,46 @@ func (c *client) DeleteStoreLabel(ctx context.Context, storeID int64, labelKey s
 		WithBody(jsonInput))
 }
 
+// GetStoresLimit gets the store limits of all stores.
+func (c *client) GetStoresLimit(ctx context.Context) (map[uint64]StoreLimitConfig, error) {
+	var limits map[uint64]StoreLimitConfig
+	err := c.request(ctx, newRequestInfo().
+		WithName(getStoresLimitName).
+		WithURI(StoresLimit).
+		WithMethod(http.MethodGet).
+		WithResp(&limits))
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// SetStoreLimit sets the limit of the given store.
+func (c *client) SetStoreLimit(ctx context.Context, storeID uint64, input *StoreLimitInput) error {
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request(ctx, newRequestInfo().
+		WithName(setStoreLimitName).
+		WithURI(StoreLimitByID(storeID)).
+		WithMethod(http.MethodPost).
+		WithBody(inputJSON))
+}
+
+// SetAllStoresLimit sets the limit of all stores, or the stores matching the labels if any.
+func (c *client) SetAllStoresLimit(ctx context.Context, input *StoreLimitInput) error {
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request(ctx, newRequestInfo().
+		WithName(setAllStoresLimitName).
+		WithURI(StoresLimit).
+		WithMethod(http.MethodPost).
+		WithBody(inputJSON))
+}
+
 // GetHealthStatus gets the health status of the cluster.
 func (c *client) GetHealthStatus(ctx context.Context) ([]Health, error) {
 	var healths []Health
@@ -824,6 +887,108 @@ func (c *client) GetSchedulerConfig(ctx context.Context, name string) (any, erro
 	return config, nil
 }
 
+// GetSchedulerDiagnosticResult gets the diagnostic result of the given scheduler.
+func (c *client) GetSchedulerDiagnosticResult(ctx context.Context, name string) (*SchedulerDiagnosticResult, error) {
+	var result SchedulerDiagnosticResult
+	err := c.request(ctx, newRequestInfo().
+		WithName(getSchedulerDiagnosticResultName).
+		WithURI(SchedulerDiagnosticByName(name)).
+		WithMethod(http.MethodGet).
+		WithResp(&result))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCheckerStatus gets the status of the given checker.
+func (c *client) GetCheckerStatus(ctx context.Context, name string) (*CheckerStatus, error) {
+	var status CheckerStatus
+	err := c.request(ctx, newRequestInfo().
+		WithName(getCheckerStatusName).
+		WithURI(CheckerByName(name)).
+		WithMethod(http.MethodGet).
+		WithResp(&status))
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// PauseChecker pauses the given checker for the given seconds.
+func (c *client) PauseChecker(ctx context.Context, name string, delaySec int64) error {
+	return c.setCheckerDelay(ctx, pauseCheckerName, name, delaySec)
+}
+
+// ResumeChecker resumes the given checker.
+func (c *client) ResumeChecker(ctx context.Context, name string) error {
+	return c.setCheckerDelay(ctx, resumeCheckerName, name, 0)
+}
+
+func (c *client) setCheckerDelay(ctx context.Context, reqName, checker string, delaySec int64) error {
+	m := map[string]int64{
+		"delay": delaySec,
+	}
+	inputJSON, err := json.Marshal(m)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request(ctx, newRequestInfo().
+		WithName(reqName).
+		WithURI(CheckerByName(checker)).
+		WithMethod(http.MethodPost).
+		WithBody(inputJSON))
+}
+
+// GetOperators gets the running operators, only the operators of the given kinds are returned if any.
+func (c *client) GetOperators(ctx context.Context, kinds ...string) ([]*OperatorObject, error) {
+	var ops []*OperatorObject
+	err := c.request(ctx, newRequestInfo().
+		WithName(getOperatorsName).
+		WithURI(OperatorsWithKinds(kinds...)).
+		WithMethod(http.MethodGet).
+		WithResp(&ops))
+	if err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// GetOperatorByRegionID gets the description of the operator of the given region.
+func (c *client) GetOperatorByRegionID(ctx context.Context, regionID uint64) (string, error) {
+	var op string
+	err := c.request(ctx, newRequestInfo().
+		WithName(getOperatorByRegionIDName).
+		WithURI(OperatorByRegionID(regionID)).
+		WithMethod(http.MethodGet).
+		WithResp(&op))
+	if err != nil {
+		return "", err
+	}
+	return op, nil
+}
+
+// CreateOperator creates an operator with the given input.
+func (c *client) CreateOperator(ctx context.Context, input map[string]any) error {
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request(ctx, newRequestInfo().
+		WithName(createOperatorName).
+		WithURI(Operators).
+		WithMethod(http.MethodPost).
+		WithBody(inputJSON))
+}
+
+// DeleteOperatorByRegionID cancels the pending operator of the given region.
+func (c *client) DeleteOperatorByRegionID(ctx context.Context, regionID uint64) error {
+	return c.request(ctx, newRequestInfo().
+		WithName(deleteOperatorByRegionIDName).
+		WithURI(OperatorByRegionID(regionID)).
+		WithMethod(http.MethodDelete))
+}
+
 // CancelSchedulerJob cancels the specified scheduler job.
 func (c *client) CancelSchedulerJob(ctx context.Context, name string, jobID uint64) error {
 	return c.request(ctx, newRequestInfo().
@@ -962,6 +1127,33 @@ func (c *client) DeleteSnapshotRecoveringMark(ctx context.Context) error {
 		WithMethod(http.MethodDelete))
 }
 
+// RemoveFailedStores removes the failed stores by the unsafe recovery.
+func (c *client) RemoveFailedStores(ctx context.Context, input *RemoveFailedStoresInput) error {
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request(ctx, newRequestInfo().
+		WithName(removeFailedStoresName).
+		WithURI(RemoveFailedStores).
+		WithMethod(http.MethodPost).
+		WithBody(inputJSON))
+}
+
+// GetFailedStoresRemovalStatus gets the progress of the unsafe recovery.
+func (c *client) GetFailedStoresRemovalStatus(ctx context.Context) ([]UnsafeRecoveryStageOutput, error) {
+	var outputs []UnsafeRecoveryStageOutput
+	err := c.request(ctx, newRequestInfo().
+		WithName(getFailedStoresRemovalStatusName).
+		WithURI(RemoveFailedStoresShow).
+		WithMethod(http.MethodGet).
+		WithResp(&outputs))
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
 // SetSchedulerDelay sets the delay of given scheduler.
 func (c *client) SetSchedulerDelay(ctx context.Context, scheduler string, delaySec int64) error {
 	m := map[string]int64{
@@ -1057,7 +1249,7 @@ func (c *client) GetPDVersion(ctx context.Context) (string, error) {
 func (c *client) DeleteOperators(ctx context.Context) error {
 	return c.request(ctx, newRequestInfo().
 		WithName(deleteOperators).
-		WithURI(operators).
+		WithURI(Operators).
 		WithMethod(http.MethodDelete))
 }
 
@@ -1133,3 +1325,17 @@ func (c *client) DeleteGCSafePoint(ctx context.Context, serviceID string) (strin
 	}
 	return msg, nil
 }
+
+// GetReplicationModeStatus gets the replication mode status of the cluster.
+func (c *client) GetReplicationModeStatus(ctx context.Context) (*ReplicationModeStatus, error) {
+	var status ReplicationModeStatus
+	err := c.request(ctx, newRequestInfo().
+		WithName(getReplicationModeStatusName).
+		WithURI(ReplicationMode).
+		WithMethod(http.MethodGet).
+		WithResp(&status))
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
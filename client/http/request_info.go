@@ -88,6 +88,20 @@ const (
 	setSnapshotRecoveringMarkName           = "SetSnapshotRecoveringMark"
 	deleteSnapshotRecoveringMarkName        = "DeleteSnapshotRecoveringMark"
 	deleteOperators                         = "DeleteOperators"
+	getOperatorsName                        = "GetOperators"
+	getOperatorByRegionIDName               = "GetOperatorByRegionID"
+	createOperatorName                      = "CreateOperator"
+	deleteOperatorByRegionIDName            = "DeleteOperatorByRegionID"
+	getCheckerStatusName                    = "GetCheckerStatus"
+	pauseCheckerName                        = "PauseChecker"
+	resumeCheckerName                       = "ResumeChecker"
+	getSchedulerDiagnosticResultName        = "GetSchedulerDiagnosticResult"
+	getStoresLimitName                      = "GetStoresLimit"
+	setStoreLimitName                       = "SetStoreLimit"
+	setAllStoresLimitName                   = "SetAllStoresLimit"
+	removeFailedStoresName                  = "RemoveFailedStores"
+	getFailedStoresRemovalStatusName        = "GetFailedStoresRemovalStatus"
+	getReplicationModeStatusName            = "GetReplicationModeStatus"
 	UpdateKeyspaceGCManagementTypeName      = "UpdateKeyspaceGCManagementType"
 	GetKeyspaceMetaByNameName               = "GetKeyspaceMetaByName"
 	GetGCSafePointName                      = "GetGCSafePoint"
@@ -187,6 +187,11 @@ type HotPeerStatShow struct {
 	QueryRate      float64   `json:"flow_query"`
 	AntiCount      int       `json:"anti_count"`
 	LastUpdateTime time.Time `json:"last_update_time,omitempty"`
+	// PredictedByteRate, PredictedKeyRate and PredictedQueryRate are the predicted rates of the next interval.
+	PredictedByteRate    float64 `json:"predicted_flow_bytes"`
+	PredictedKeyRate     float64 `json:"predicted_flow_keys"`
+	PredictedQueryRate   float64 `json:"predicted_flow_query"`
+	PredictionConfidence float64 `json:"prediction_confidence"`
 }
 
 // HistoryHotRegionsRequest wrap the request conditions.
@@ -700,3 +705,90 @@ type Health struct {
 	ClientUrls []string `json:"client_urls"`
 	Health     bool     `json:"health"`
 }
+
+// OperatorObject represents the information of one operator.
+// NOTE: This type is in sync with pd/pkg/schedule/operator/operator.go `OpObject`.
+type OperatorObject struct {
+	Desc        string       `json:"desc"`
+	Brief       string       `json:"brief"`
+	RegionID    uint64       `json:"region_id"`
+	RegionEpoch *RegionEpoch `json:"region_epoch"`
+	Kind        uint32       `json:"kind"`
+	Timeout     string       `json:"timeout"`
+	Status      uint32       `json:"status"`
+}
+
+// CheckerStatus represents the status of a checker.
+type CheckerStatus struct {
+	Paused bool `json:"paused"`
+}
+
+// SchedulerDiagnosticResult represents the diagnostic result of a scheduler.
+// NOTE: This type is in sync with pd/pkg/schedule/schedulers/diagnostic_recorder.go `DiagnosticResult`.
+type SchedulerDiagnosticResult struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Summary   string `json:"summary"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// StoreLimitType is the type of the store limit.
+type StoreLimitType string
+
+const (
+	// StoreLimitAll sets the limits of all types.
+	StoreLimitAll StoreLimitType = ""
+	// StoreLimitAddPeer is the limit of adding peers to the store.
+	StoreLimitAddPeer StoreLimitType = "add-peer"
+	// StoreLimitRemovePeer is the limit of removing peers from the store.
+	StoreLimitRemovePeer StoreLimitType = "remove-peer"
+)
+
+// StoreLimitConfig represents the store limit rate per minute.
+type StoreLimitConfig struct {
+	AddPeer    float64 `json:"add-peer"`
+	RemovePeer float64 `json:"remove-peer"`
+}
+
+// StoreLimitInput represents the input to set the store limit.
+type StoreLimitInput struct {
+	// Rate is the rate per minute of the store limit, it should be larger than 0.
+	Rate float64 `json:"rate"`
+	// Type is the type of the store limit, all types are set if it's empty.
+	Type StoreLimitType `json:"type,omitempty"`
+	// Labels is used to select the stores to set the limit, only works for all stores.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// RemoveFailedStoresInput represents the input to remove the failed stores by the unsafe recovery.
+type RemoveFailedStoresInput struct {
+	Stores []uint64 `json:"stores,omitempty"`
+	// Timeout is the timeout in seconds of the unsafe recovery, 600 is used if it's not set.
+	Timeout uint64 `json:"timeout,omitempty"`
+	// AutoDetect indicates whether to detect the failed stores automatically, `Stores` is ignored if it's true.
+	AutoDetect bool `json:"auto-detect,omitempty"`
+}
+
+// UnsafeRecoveryStageOutput represents the output of one stage of the unsafe recovery.
+// NOTE: This type is in sync with pd/pkg/unsaferecovery/unsafe_recovery_controller.go `StageOutput`.
+type UnsafeRecoveryStageOutput struct {
+	Info    string              `json:"info,omitempty"`
+	Time    string              `json:"time,omitempty"`
+	Actions map[string][]string `json:"actions,omitempty"`
+	Details []string            `json:"details,omitempty"`
+}
+
+// ReplicationModeStatus represents the replication mode status of the cluster.
+// NOTE: This type is in sync with pd/pkg/replication/replication_mode.go `HTTPReplicationStatus`.
+type ReplicationModeStatus struct {
+	Mode       string `json:"mode"`
+	DrAutoSync struct {
+		LabelKey        string  `json:"label_key"`
+		State           string  `json:"state"`
+		StateID         uint64  `json:"state_id,omitempty"`
+		ACIDConsistent  bool    `json:"acid_consistent"`
+		TotalRegions    int     `json:"total_regions,omitempty"`
+		SyncedRegions   int     `json:"synced_regions,omitempty"`
+		RecoverProgress float32 `json:"recover_progress,omitempty"`
+	} `json:"dr-auto-sync,omitempty"`
+}
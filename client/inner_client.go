@@ -57,6 +57,9 @@ type innerClient struct {
 	wg     sync.WaitGroup
 	tlsCfg *tls.Config
 	option *opt.Option
+
+	// For serving the TSO with bounded staleness.
+	staleTSCache tso.StaleTSCache
 }
 
 func (c *innerClient) init(updateKeyspaceIDCb sd.UpdateKeyspaceIDFunc) error {
@@ -304,6 +307,11 @@ func (c *innerClient) getOrCreateGRPCConn() (*grpc.ClientConn, error) {
 }
 
 func (c *innerClient) dispatchTSORequestWithRetry(ctx context.Context) tso.TSFuture {
+	return c.dispatchTSORequestWithCountWithRetry(ctx, 1)
+}
+
+// dispatchTSORequestWithCountWithRetry requests a range of `count` TSO, the future returns the largest one of the range.
+func (c *innerClient) dispatchTSORequestWithCountWithRetry(ctx context.Context, count int64) tso.TSFuture {
 	var (
 		retryable bool
 		err       error
@@ -322,7 +330,7 @@ func (c *innerClient) dispatchTSORequestWithRetry(ctx context.Context) tso.TSFut
 		}
 		// Get a new request from the pool if it's not from the current pool.
 		if !req.IsFrom(tsoClient.GetRequestPool()) {
-			req = tsoClient.GetTSORequest(ctx)
+			req = tsoClient.GetTSORequestWithCount(ctx, count)
 		}
 		retryable, err = tsoClient.DispatchRequest(req)
 		if !retryable {
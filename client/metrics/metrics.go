@@ -90,6 +90,12 @@ var (
 	QueryRegionBatchSize *prometheus.HistogramVec
 	// QueryRegionBatchSendLatency is the histogram of the latency of sending query region requests.
 	QueryRegionBatchSendLatency prometheus.Histogram
+	// StaleTSOCacheCounter is the counter of the stale TSO requests served by the cache or not.
+	StaleTSOCacheCounter *prometheus.CounterVec
+	// StaleTSOPrefetchSize is the histogram of the count of the TSO prefetched for the stale TSO requests.
+	StaleTSOPrefetchSize prometheus.Histogram
+	// QueryRegionHedgeCounter is the counter of the hedged query region requests by the result.
+	QueryRegionHedgeCounter *prometheus.CounterVec
 )
 
 func initMetrics(constLabels prometheus.Labels) {
@@ -237,6 +243,34 @@ func initMetrics(constLabels prometheus.Labels) {
 			Buckets:     prometheus.ExponentialBuckets(0.0005, 2, 13),
 			Help:        "query region batch send latency",
 		})
+
+	StaleTSOCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "stale_tso_cache_count",
+			Help:        "Counter of the stale TSO requests served by the local cache or not",
+			ConstLabels: constLabels,
+		}, []string{"type"})
+
+	StaleTSOPrefetchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "stale_tso_prefetch_size",
+			Help:        "Bucketed histogram of the count of the TSO prefetched for the stale TSO requests.",
+			ConstLabels: constLabels,
+			Buckets:     prometheus.ExponentialBuckets(1, 2, 16),
+		})
+
+	QueryRegionHedgeCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "query_region_hedge_count",
+			Help:        "Counter of the hedged query region requests by the result",
+			ConstLabels: constLabels,
+		}, []string{"type"})
 }
 
 // CmdDurationXXX and CmdFailedDurationXXX are the durations of the client commands.
@@ -311,6 +345,22 @@ var (
 	QueryRegionBatchSizeByKeys     prometheus.Observer
 	QueryRegionBatchSizeByPrevKeys prometheus.Observer
 	QueryRegionBatchSizeByIDs      prometheus.Observer
+
+	// StaleTSOCacheHit counts the stale TSO requests served by the local cache.
+	StaleTSOCacheHit prometheus.Counter
+	// StaleTSOCacheMiss counts the stale TSO requests which prefetch the TSO from the server.
+	StaleTSOCacheMiss prometheus.Counter
+	// StaleTSOCacheBypass counts the stale TSO requests sent to the server since the cache is disabled.
+	StaleTSOCacheBypass prometheus.Counter
+
+	// QueryRegionHedgeSent counts the hedged query region requests sent to the followers.
+	QueryRegionHedgeSent prometheus.Counter
+	// QueryRegionHedgeWin counts the hedged query region requests answered before the leader.
+	QueryRegionHedgeWin prometheus.Counter
+	// QueryRegionHedgeLose counts the hedged query region requests answered after the leader or failed.
+	QueryRegionHedgeLose prometheus.Counter
+	// QueryRegionHedgeThrottled counts the query region requests not hedged due to the exhausted budget.
+	QueryRegionHedgeThrottled prometheus.Counter
 )
 
 func initLabelValues() {
@@ -381,6 +431,15 @@ func initLabelValues() {
 	QueryRegionBatchSizeByKeys = QueryRegionBatchSize.WithLabelValues("by_keys")
 	QueryRegionBatchSizeByPrevKeys = QueryRegionBatchSize.WithLabelValues("by_prev_keys")
 	QueryRegionBatchSizeByIDs = QueryRegionBatchSize.WithLabelValues("by_ids")
+
+	StaleTSOCacheHit = StaleTSOCacheCounter.WithLabelValues("hit")
+	StaleTSOCacheMiss = StaleTSOCacheCounter.WithLabelValues("miss")
+	StaleTSOCacheBypass = StaleTSOCacheCounter.WithLabelValues("bypass")
+
+	QueryRegionHedgeSent = QueryRegionHedgeCounter.WithLabelValues("sent")
+	QueryRegionHedgeWin = QueryRegionHedgeCounter.WithLabelValues("win")
+	QueryRegionHedgeLose = QueryRegionHedgeCounter.WithLabelValues("lose")
+	QueryRegionHedgeThrottled = QueryRegionHedgeCounter.WithLabelValues("throttled")
 }
 
 func registerMetrics() {
@@ -398,4 +457,7 @@ func registerMetrics() {
 	prometheus.MustRegister(QueryRegionBestBatchSize)
 	prometheus.MustRegister(QueryRegionBatchSize)
 	prometheus.MustRegister(QueryRegionBatchSendLatency)
+	prometheus.MustRegister(StaleTSOCacheCounter)
+	prometheus.MustRegister(StaleTSOPrefetchSize)
+	prometheus.MustRegister(QueryRegionHedgeCounter)
 }
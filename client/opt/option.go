@@ -19,9 +19,11 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
 
 	"github.com/tikv/pd/client/pkg/retry"
 )
@@ -34,6 +36,13 @@ const (
 	defaultEnableFollowerHandle                  = false
 	defaultTSOClientRPCConcurrency               = 1
 	defaultEnableRouterClient                    = false
+	defaultMaxTSOStaleness         time.Duration = 0
+	defaultStaleTSOPrefetchCount                 = 1024
+	maxMaxTSOStaleness                           = time.Minute
+	maxStaleTSOPrefetchCount                     = 1 << 16
+	defaultRegionHedgeDelay                      = 20 * time.Millisecond
+	maxRegionHedgeDelay                          = time.Second
+	defaultRegionHedgeBudgetRatio                = 0.1
 )
 
 // DynamicOption is used to distinguish the dynamic option type.
@@ -53,6 +62,18 @@ const (
 	// EnableRouterClient is the router client option.
 	// It is stored as bool.
 	EnableRouterClient
+	// MaxTSOStaleness is the max staleness of the TSO served locally by `GetStaleTS`.
+	// It is stored as time.Duration and should be between 0 and 1min, 0 means disabled.
+	MaxTSOStaleness
+	// StaleTSOPrefetchCount is the count of the TSO prefetched in bulk for `GetStaleTS`.
+	// It is stored as int and should be between 1 and 65536.
+	StaleTSOPrefetchCount
+	// RegionHedgeDelay is the delay after which a hedged query region request is sent to a follower.
+	// It is stored as time.Duration and should be between 0 and 1s, 0 means the hedging is disabled.
+	RegionHedgeDelay
+	// RegionHedgeBudgetRatio is the max ratio of the hedged query region requests to all of them.
+	// It is stored as float64 and should be between 0 and 1, 0 means the hedging is disabled.
+	RegionHedgeBudgetRatio
 
 	dynamicOptionCount
 )
@@ -94,6 +115,10 @@ func NewOption() *Option {
 	co.dynamicOptions[EnableFollowerHandle].Store(defaultEnableFollowerHandle)
 	co.dynamicOptions[TSOClientRPCConcurrency].Store(defaultTSOClientRPCConcurrency)
 	co.dynamicOptions[EnableRouterClient].Store(defaultEnableRouterClient)
+	co.dynamicOptions[MaxTSOStaleness].Store(defaultMaxTSOStaleness)
+	co.dynamicOptions[StaleTSOPrefetchCount].Store(defaultStaleTSOPrefetchCount)
+	co.dynamicOptions[RegionHedgeDelay].Store(defaultRegionHedgeDelay)
+	co.dynamicOptions[RegionHedgeBudgetRatio].Store(defaultRegionHedgeBudgetRatio)
 	return co
 }
 
@@ -167,6 +192,66 @@ func (o *Option) GetEnableRouterClient() bool {
 	return o.dynamicOptions[EnableRouterClient].Load().(bool)
 }
 
+// SetMaxTSOStaleness sets the max staleness of the TSO served locally by `GetStaleTS`.
+// It only accepts the value between 0 and 1min, 0 means the TSO is always got from the server.
+func (o *Option) SetMaxTSOStaleness(staleness time.Duration) error {
+	if staleness < 0 || staleness > maxMaxTSOStaleness {
+		return errors.New("[pd] invalid max TSO staleness, should be between 0 and 1min")
+	}
+	o.dynamicOptions[MaxTSOStaleness].Store(staleness)
+	return nil
+}
+
+// GetMaxTSOStaleness gets the max staleness of the TSO served locally by `GetStaleTS`.
+func (o *Option) GetMaxTSOStaleness() time.Duration {
+	return o.dynamicOptions[MaxTSOStaleness].Load().(time.Duration)
+}
+
+// SetStaleTSOPrefetchCount sets the count of the TSO prefetched in bulk for `GetStaleTS`.
+// It only accepts the value between 1 and 65536.
+func (o *Option) SetStaleTSOPrefetchCount(count int) error {
+	if count < 1 || count > maxStaleTSOPrefetchCount {
+		return errors.New("[pd] invalid stale TSO prefetch count, should be between 1 and 65536")
+	}
+	o.dynamicOptions[StaleTSOPrefetchCount].Store(count)
+	return nil
+}
+
+// GetStaleTSOPrefetchCount gets the count of the TSO prefetched in bulk for `GetStaleTS`.
+func (o *Option) GetStaleTSOPrefetchCount() int {
+	return o.dynamicOptions[StaleTSOPrefetchCount].Load().(int)
+}
+
+// SetRegionHedgeDelay sets the delay after which a hedged query region request is sent to a follower.
+// It only accepts the value between 0 and 1s, 0 means the hedging is disabled.
+func (o *Option) SetRegionHedgeDelay(delay time.Duration) error {
+	if delay < 0 || delay > maxRegionHedgeDelay {
+		return errors.New("[pd] invalid region hedge delay, should be between 0 and 1s")
+	}
+	o.dynamicOptions[RegionHedgeDelay].Store(delay)
+	return nil
+}
+
+// GetRegionHedgeDelay gets the delay after which a hedged query region request is sent to a follower.
+func (o *Option) GetRegionHedgeDelay() time.Duration {
+	return o.dynamicOptions[RegionHedgeDelay].Load().(time.Duration)
+}
+
+// SetRegionHedgeBudgetRatio sets the max ratio of the hedged query region requests to all of them.
+// It only accepts the value between 0 and 1, 0 means the hedging is disabled.
+func (o *Option) SetRegionHedgeBudgetRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return errors.New("[pd] invalid region hedge budget ratio, should be between 0 and 1")
+	}
+	o.dynamicOptions[RegionHedgeBudgetRatio].Store(ratio)
+	return nil
+}
+
+// GetRegionHedgeBudgetRatio gets the max ratio of the hedged query region requests to all of them.
+func (o *Option) GetRegionHedgeBudgetRatio() float64 {
+	return o.dynamicOptions[RegionHedgeBudgetRatio].Load().(float64)
+}
+
 // ClientOption configures client.
 type ClientOption func(*Option)
 
@@ -242,6 +327,38 @@ func WithEnableFollowerHandle(enable bool) ClientOption {
 	}
 }
 
+// WithStaleTSOOption configures the client to serve `GetStaleTS` locally with the TSO
+// prefetched in bulk, as long as the TSO is not staler than the given staleness. The invalid
+// values are ignored with a warning, and the default ones are kept.
+func WithStaleTSOOption(maxStaleness time.Duration, prefetchCount int) ClientOption {
+	return func(op *Option) {
+		if err := op.SetMaxTSOStaleness(maxStaleness); err != nil {
+			log.Warn("[pd] ignore the invalid max TSO staleness",
+				zap.Duration("max-staleness", maxStaleness), zap.Error(err))
+		}
+		if err := op.SetStaleTSOPrefetchCount(prefetchCount); err != nil {
+			log.Warn("[pd] ignore the invalid stale TSO prefetch count",
+				zap.Int("prefetch-count", prefetchCount), zap.Error(err))
+		}
+	}
+}
+
+// WithRegionHedgeOption configures the delay after which a hedged query region request is sent
+// to a follower, and the max ratio of the hedged requests to all of them. The invalid values are
+// ignored with a warning, and the default ones are kept.
+func WithRegionHedgeOption(delay time.Duration, budgetRatio float64) ClientOption {
+	return func(op *Option) {
+		if err := op.SetRegionHedgeDelay(delay); err != nil {
+			log.Warn("[pd] ignore the invalid region hedge delay",
+				zap.Duration("delay", delay), zap.Error(err))
+		}
+		if err := op.SetRegionHedgeBudgetRatio(budgetRatio); err != nil {
+			log.Warn("[pd] ignore the invalid region hedge budget ratio",
+				zap.Float64("budget-ratio", budgetRatio), zap.Error(err))
+		}
+	}
+}
+
 // GetStoreOp represents available options when getting stores.
 type GetStoreOp struct {
 	ExcludeTombstone bool
@@ -285,6 +402,10 @@ type GetRegionOp struct {
 	NeedBuckets                  bool
 	AllowFollowerHandle          bool
 	OutputMustContainAllKeyRange bool
+	// Hedge means a hedged request may be sent to a follower if the leader is slow.
+	Hedge bool
+	// HedgeDelay overrides the client-level hedge delay if it's positive.
+	HedgeDelay time.Duration
 }
 
 // GetRegionOption configures GetRegionOp.
@@ -300,6 +421,20 @@ func WithAllowFollowerHandle() GetRegionOption {
 	return func(op *GetRegionOp) { op.AllowFollowerHandle = true }
 }
 
+// WithHedge means that if the leader does not respond within the hedge delay, the same request
+// is also sent to a follower and the first valid answer is taken. It only works with the router client.
+func WithHedge() GetRegionOption {
+	return func(op *GetRegionOp) { op.Hedge = true }
+}
+
+// WithHedgeDelay is the same as WithHedge, but uses the given delay instead of the client-level one.
+func WithHedgeDelay(delay time.Duration) GetRegionOption {
+	return func(op *GetRegionOp) {
+		op.Hedge = true
+		op.HedgeDelay = delay
+	}
+}
+
 // WithOutputMustContainAllKeyRange means the output must contain all key ranges.
 func WithOutputMustContainAllKeyRange() GetRegionOption {
 	return func(op *GetRegionOp) { op.OutputMustContainAllKeyRange = true }
@@ -33,6 +33,8 @@ func TestDynamicOptionChange(t *testing.T) {
 	re.Equal(defaultEnableFollowerHandle, o.GetEnableFollowerHandle(), "default enable follower handle")
 	re.Equal(defaultTSOClientRPCConcurrency, o.GetTSOClientRPCConcurrency(), "default TSO client RPC concurrency")
 	re.Equal(defaultEnableRouterClient, o.GetEnableRouterClient(), "default enable router client")
+	re.Equal(defaultRegionHedgeDelay, o.GetRegionHedgeDelay(), "default region hedge delay")
+	re.Equal(defaultRegionHedgeBudgetRatio, o.GetRegionHedgeBudgetRatio(), "default region hedge budget ratio")
 
 	// Test invalid setting.
 	err := o.SetMaxTSOBatchWaitInterval(time.Second)
@@ -105,6 +107,40 @@ func TestDynamicOptionChange(t *testing.T) {
 	// Testing that setting the same value should not trigger a notification.
 	o.SetEnableRouterClient(expectBool)
 	ensureNoNotification(t, o.EnableRouterClientCh)
+
+	re.Error(o.SetRegionHedgeDelay(-time.Millisecond))
+	re.Error(o.SetRegionHedgeDelay(2 * time.Second))
+	re.Equal(defaultRegionHedgeDelay, o.GetRegionHedgeDelay(), "region hedge delay should not change to an invalid value")
+	re.NoError(o.SetRegionHedgeDelay(time.Millisecond))
+	re.Equal(time.Millisecond, o.GetRegionHedgeDelay(), "region hedge delay should update accordingly")
+	re.Error(o.SetRegionHedgeBudgetRatio(1.5))
+	re.Equal(defaultRegionHedgeBudgetRatio, o.GetRegionHedgeBudgetRatio(), "region hedge budget ratio should not change to an invalid value")
+	re.NoError(o.SetRegionHedgeBudgetRatio(0.5))
+	re.Equal(0.5, o.GetRegionHedgeBudgetRatio(), "region hedge budget ratio should update accordingly")
+}
+
+func TestStaleTSOOption(t *testing.T) {
+	re := require.New(t)
+	o := NewOption()
+	WithStaleTSOOption(time.Second, 16)(o)
+	re.Equal(time.Second, o.GetMaxTSOStaleness())
+	re.Equal(16, o.GetStaleTSOPrefetchCount())
+	// The invalid values are ignored.
+	WithStaleTSOOption(time.Hour, 0)(o)
+	re.Equal(time.Second, o.GetMaxTSOStaleness())
+	re.Equal(16, o.GetStaleTSOPrefetchCount())
+}
+
+func TestRegionHedgeOption(t *testing.T) {
+	re := require.New(t)
+	o := NewOption()
+	WithRegionHedgeOption(10*time.Millisecond, 0.2)(o)
+	re.Equal(10*time.Millisecond, o.GetRegionHedgeDelay())
+	re.Equal(0.2, o.GetRegionHedgeBudgetRatio())
+	// The invalid values are ignored.
+	WithRegionHedgeOption(time.Minute, -1)(o)
+	re.Equal(10*time.Millisecond, o.GetRegionHedgeDelay())
+	re.Equal(0.2, o.GetRegionHedgeBudgetRatio())
 }
 
 // clearChannel drains any pending events from the channel.
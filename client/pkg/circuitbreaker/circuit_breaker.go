@@ -52,6 +52,35 @@ type Settings struct {
 	CoolDownInterval time.Duration
 	// Defines how many subsequent requests to test after cooldown period before fully close the circuit.
 	HalfOpenSuccessCount uint32
+	// Defines the latency threshold to trip the circuit breaker, the `LatencyPercentile` of the latencies
+	// over the sliding `ErrorRateWindow` is evaluated against it. 0 means the latency is not evaluated.
+	LatencyThreshold time.Duration
+	// Defines the percentile of the latencies to evaluate, 99 is used if it's not set.
+	LatencyPercentile float64
+	// Defines how long to ramp up the allowed requests gradually from 0 to 100% in half-open state.
+	// 0 means only `HalfOpenSuccessCount` probe requests are allowed in half-open state.
+	HalfOpenRampUpDuration time.Duration
+}
+
+func (st *Settings) isEnabled() bool {
+	return st.ErrorRateThresholdPct > 0 || st.LatencyThreshold > 0
+}
+
+func (st *Settings) getLatencyPercentile() float64 {
+	if st.LatencyPercentile <= 0 || st.LatencyPercentile > 100 {
+		return defaultLatencyPercentile
+	}
+	return st.LatencyPercentile
+}
+
+func (st *Settings) minCountForOpen() uint32 {
+	return uint32(st.ErrorRateWindow.Seconds()) * st.MinQPSForOpen
+}
+
+// minLatencyCountForOpen returns the min count of the latency samples to trip the circuit breaker,
+// which is capped by the size of the latency window, otherwise it could never trip on latency.
+func (st *Settings) minLatencyCountForOpen() uint32 {
+	return min(st.minCountForOpen(), maxLatencySamples)
 }
 
 // AlwaysClosedSettings is a configuration that never trips the circuit breaker.
@@ -70,11 +99,15 @@ type CircuitBreaker struct {
 
 	sync.RWMutex
 	state *State
+	// latencies is the sliding window of the request latencies, it's only recorded when
+	// the latency tripping is enabled.
+	latencies latencyWindow
 
 	successCounter  prometheus.Counter
 	errorCounter    prometheus.Counter
 	overloadCounter prometheus.Counter
 	fastFailCounter prometheus.Counter
+	slowCounter     prometheus.Counter
 }
 
 // StateType is a type that represents a state of CircuitBreaker.
@@ -122,13 +155,14 @@ func registerMetrics(cb *CircuitBreaker) {
 	cb.errorCounter = m.CircuitBreakerCounters.WithLabelValues(metricName, "error")
 	cb.overloadCounter = m.CircuitBreakerCounters.WithLabelValues(metricName, "overload")
 	cb.fastFailCounter = m.CircuitBreakerCounters.WithLabelValues(metricName, "fast_fail")
+	cb.slowCounter = m.CircuitBreakerCounters.WithLabelValues(metricName, "slow")
 }
 
 // IsEnabled returns true if the circuit breaker is enabled.
 func (cb *CircuitBreaker) IsEnabled() bool {
 	cb.RLock()
 	defer cb.RUnlock()
-	return cb.config.ErrorRateThresholdPct > 0
+	return cb.config.isEnabled()
 }
 
 // ChangeSettings changes the CircuitBreaker settings.
@@ -151,18 +185,19 @@ func (cb *CircuitBreaker) Execute(call func() (Overloading, error)) error {
 		return err
 	}
 
+	start := time.Now()
 	defer func() {
 		e := recover()
 		if e != nil {
 			cb.emitMetric(Yes, err)
-			cb.onResult(state, Yes)
+			cb.onResult(state, Yes, time.Since(start))
 			panic(e)
 		}
 	}()
 
 	overloaded, err := call()
 	cb.emitMetric(overloaded, err)
-	cb.onResult(state, overloaded)
+	cb.onResult(state, overloaded, time.Since(start))
 	return err
 }
 
@@ -175,10 +210,22 @@ func (cb *CircuitBreaker) onRequest() (*State, error) {
 	return state, err
 }
 
-func (cb *CircuitBreaker) onResult(state *State, overloaded Overloading) {
+func (cb *CircuitBreaker) onResult(state *State, overloaded Overloading, latency time.Duration) {
 	cb.Lock()
 	defer cb.Unlock()
 
+	slow := false
+	if cb.config.LatencyThreshold > 0 {
+		cb.latencies.record(time.Now(), latency)
+		slow = latency >= cb.config.LatencyThreshold
+		if slow {
+			cb.slowCounter.Inc()
+		}
+	}
+	// the slow requests are regarded as failures in half-open state, since the service is not recovered yet
+	if slow && state.stateType == StateHalfOpen {
+		overloaded = Yes
+	}
 	// even if the circuit breaker already moved to a new state while the request was in progress,
 	// it is still ok to update the old state, but it is not relevant anymore
 	state.onResult(overloaded)
@@ -202,8 +249,11 @@ func (cb *CircuitBreaker) emitMetric(overloaded Overloading, err error) {
 type State struct {
 	stateType StateType
 	cb        *CircuitBreaker
+	start     time.Time
 	end       time.Time
 
+	// requestCount is the count of the requests evaluated in half-open state with ramp-up.
+	requestCount uint32
 	pendingCount uint32
 	successCount uint32
 	failureCount uint32
@@ -222,13 +272,16 @@ func (cb *CircuitBreaker) newState(now time.Time, stateType StateType) *State {
 		// we transition to HalfOpen state on the first request after the cooldown period,
 		// so we start with 1 pending request
 		pendingCount = 1
+		end = now.Add(cb.config.HalfOpenRampUpDuration)
 	default:
 		panic("unknown state")
 	}
 	return &State{
 		cb:           cb,
 		stateType:    stateType,
+		requestCount: pendingCount,
 		pendingCount: pendingCount,
+		start:        now,
 		end:          end,
 	}
 }
@@ -239,8 +292,11 @@ func (cb *CircuitBreaker) newState(now time.Time, stateType StateType) *State {
 // Circuit breaker start with a closed state, allows all requests to pass through and always lasts for a fixed duration of `Settings.ErrorRateWindow`.
 // If `Settings.ErrorRateThresholdPct` is breached at the end of the window, then it moves to Open state, otherwise it moves to a new Closed state with a new window.
 // Open state fails all request, it has a fixed duration of `Settings.CoolDownInterval` and always moves to HalfOpen state at the end of the interval.
+// If `Settings.LatencyThreshold` is set, the latency percentile over the sliding window is evaluated at the end of the window as well.
 // HalfOpen state does not have a fixed duration and lasts till `Settings.HalfOpenSuccessCount` are evaluated.
 // If any of `Settings.HalfOpenSuccessCount` fails then it moves back to Open state, otherwise it moves to Closed state.
+// If `Settings.HalfOpenRampUpDuration` is set, HalfOpen state allows a growing ratio of requests instead, and it
+// moves to Closed state once the ramp-up is over and at least `Settings.HalfOpenSuccessCount` requests succeed.
 func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 	var now = time.Now()
 	switch s.stateType {
@@ -261,6 +317,18 @@ func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 					}
 				}
 			}
+			if s.cb.config.LatencyThreshold > 0 {
+				observedLatency, count := cb.latencies.percentile(now, cb.config.ErrorRateWindow, cb.config.getLatencyPercentile())
+				if count > 0 && uint32(count) >= cb.config.minLatencyCountForOpen() && observedLatency >= cb.config.LatencyThreshold {
+					// the latency threshold is breached, let's move to open state and start failing all requests
+					log.Error("circuit breaker tripped by the latency and starting to fail all requests",
+						zap.String("name", cb.name),
+						zap.Duration("observed-latency", observedLatency),
+						zap.Any("config", cb.config))
+					cb.latencies.reset()
+					return cb.newState(now, StateOpen), errs.ErrCircuitBreakerOpen
+				}
+			}
 			// the error threshold is not breached or there were not enough requests to evaluate it,
 			// continue in the closed state and allow all requests
 			return cb.newState(now, StateClosed), nil
@@ -268,7 +336,7 @@ func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 		// continue in closed state till ErrorRateWindow is over
 		return s, nil
 	case StateOpen:
-		if s.cb.config.ErrorRateThresholdPct == 0 {
+		if !s.cb.config.isEnabled() {
 			return cb.newState(now, StateClosed), nil
 		}
 
@@ -282,7 +350,7 @@ func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 		// continue in the open state till CoolDownInterval is over
 		return s, errs.ErrCircuitBreakerOpen
 	case StateHalfOpen:
-		if s.cb.config.ErrorRateThresholdPct == 0 {
+		if !s.cb.config.isEnabled() {
 			return cb.newState(now, StateClosed), nil
 		}
 
@@ -293,6 +361,8 @@ func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 				zap.String("name", cb.name),
 				zap.Any("config", cb.config))
 			return cb.newState(now, StateOpen), errs.ErrCircuitBreakerOpen
+		} else if s.cb.config.HalfOpenRampUpDuration > 0 {
+			return s.rampUp(now)
 		} else if s.successCount == s.cb.config.HalfOpenSuccessCount {
 			// all probe requests are succeeded, we can move to closed state and allow all requests
 			log.Info("circuit breaker is closed and start allowing all requests",
@@ -311,6 +381,26 @@ func (s *State) onRequest(cb *CircuitBreaker) (*State, error) {
 	}
 }
 
+// rampUp allows a growing ratio of the requests in half-open state, the ratio grows linearly from 0 to 100%
+// during `Settings.HalfOpenRampUpDuration`.
+func (s *State) rampUp(now time.Time) (*State, error) {
+	cb := s.cb
+	if !now.Before(s.end) && s.successCount >= cb.config.HalfOpenSuccessCount {
+		// the ramp-up is over and no failure occurs, we can move to closed state and allow all requests
+		log.Info("circuit breaker is closed after ramping up and start allowing all requests",
+			zap.String("name", cb.name),
+			zap.Any("config", cb.config))
+		return cb.newState(now, StateClosed), nil
+	}
+	s.requestCount++
+	ratio := min(float64(now.Sub(s.start))/float64(cb.config.HalfOpenRampUpDuration), 1)
+	if float64(s.pendingCount) < max(1, float64(s.requestCount)*ratio) {
+		s.pendingCount++
+		return s, nil
+	}
+	return s, errs.ErrCircuitBreakerOpen
+}
+
 func (s *State) onResult(overloaded Overloading) {
 	switch overloaded {
 	case No:
@@ -276,3 +276,167 @@ func assertSucceeds(cb *CircuitBreaker, re *require.Assertions) {
 	})
 	re.NoError(err)
 }
+
+func TestCircuitBreakerLatencyTrip(t *testing.T) {
+	re := require.New(t)
+	latencySettings := Settings{
+		MinQPSForOpen:        10,
+		ErrorRateWindow:      30 * time.Second,
+		CoolDownInterval:     10 * time.Second,
+		HalfOpenSuccessCount: 2,
+		LatencyThreshold:     100 * time.Millisecond,
+	}
+	cb := NewCircuitBreaker("test_cb", latencySettings)
+	re.True(cb.IsEnabled())
+	driveQPS(cb, minCountToOpen, No, re)
+	// the p99 latency is still under the threshold
+	cb.latencies.record(time.Now(), time.Second)
+	cb.advance(latencySettings.ErrorRateWindow)
+	assertSucceeds(cb, re)
+	re.Equal(StateClosed, cb.state.stateType)
+
+	driveQPS(cb, minCountToOpen, No, re)
+	for range 10 {
+		cb.latencies.record(time.Now(), time.Second)
+	}
+	cb.advance(latencySettings.ErrorRateWindow)
+	assertFastFail(cb, re)
+	re.Equal(StateOpen, cb.state.stateType)
+	re.Empty(cb.latencies.samples)
+
+	// the slow probe moves the circuit breaker back to open state
+	cb.advance(latencySettings.CoolDownInterval)
+	err := cb.Execute(func() (Overloading, error) {
+		time.Sleep(latencySettings.LatencyThreshold)
+		return No, nil
+	})
+	re.NoError(err)
+	re.Equal(StateHalfOpen, cb.state.stateType)
+	assertFastFail(cb, re)
+	re.Equal(StateOpen, cb.state.stateType)
+}
+
+func TestCircuitBreakerLatencyTripWithFullWindow(t *testing.T) {
+	re := require.New(t)
+	// more samples are required than the latency window holds
+	latencySettings := Settings{
+		MinQPSForOpen:        500,
+		ErrorRateWindow:      10 * time.Second,
+		CoolDownInterval:     10 * time.Second,
+		HalfOpenSuccessCount: 2,
+		LatencyThreshold:     100 * time.Millisecond,
+	}
+	re.Greater(latencySettings.minCountForOpen(), uint32(maxLatencySamples))
+	cb := NewCircuitBreaker("test_cb", latencySettings)
+	for range maxLatencySamples - 1 {
+		cb.latencies.record(time.Now(), time.Second)
+	}
+	cb.advance(latencySettings.ErrorRateWindow)
+	assertSucceeds(cb, re)
+	re.Equal(StateClosed, cb.state.stateType)
+
+	for range 2 * maxLatencySamples {
+		cb.latencies.record(time.Now(), time.Second)
+	}
+	cb.advance(latencySettings.ErrorRateWindow)
+	assertFastFail(cb, re)
+	re.Equal(StateOpen, cb.state.stateType)
+}
+
+func TestLatencyWindowPercentile(t *testing.T) {
+	re := require.New(t)
+	var w latencyWindow
+	now := time.Now()
+	latency, count := w.percentile(now, time.Second, 99)
+	re.Zero(latency)
+	re.Zero(count)
+	for i := range 100 {
+		w.record(now, time.Duration(i+1)*time.Millisecond)
+	}
+	latency, count = w.percentile(now, time.Second, 99)
+	re.Equal(99*time.Millisecond, latency)
+	re.Equal(100, count)
+	latency, _ = w.percentile(now, time.Second, 50)
+	re.Equal(50*time.Millisecond, latency)
+	// the samples out of the window are ignored
+	_, count = w.percentile(now.Add(2*time.Second), time.Second, 99)
+	re.Zero(count)
+	// the oldest samples are overwritten
+	for range maxLatencySamples {
+		w.record(now, time.Millisecond)
+	}
+	re.Len(w.samples, maxLatencySamples)
+	latency, _ = w.percentile(now, time.Second, 100)
+	re.Equal(time.Millisecond, latency)
+}
+
+func TestCircuitBreakerHalfOpenRampUp(t *testing.T) {
+	re := require.New(t)
+	rampUpSettings := settings
+	rampUpSettings.HalfOpenRampUpDuration = 10 * time.Second
+	cb := NewCircuitBreaker("test_cb", rampUpSettings)
+	driveQPS(cb, minCountToOpen, Yes, re)
+	cb.advance(settings.ErrorRateWindow)
+	assertFastFail(cb, re)
+	cb.advance(settings.CoolDownInterval)
+	assertSucceeds(cb, re)
+	re.Equal(StateHalfOpen, cb.state.stateType)
+	// only the probe request is allowed at the beginning of the ramp-up
+	assertFastFail(cb, re)
+
+	// almost half of the requests are allowed in the middle of the ramp-up
+	elapsed := rampUpSettings.HalfOpenRampUpDuration * 45 / 100
+	cb.state.start = cb.state.start.Add(-elapsed)
+	cb.state.end = cb.state.end.Add(-elapsed)
+	assertSucceeds(cb, re)
+	assertFastFail(cb, re)
+	assertSucceeds(cb, re)
+	re.Equal(StateHalfOpen, cb.state.stateType)
+
+	// all requests are allowed after the ramp-up
+	cb.state.start = cb.state.start.Add(-rampUpSettings.HalfOpenRampUpDuration)
+	cb.state.end = cb.state.end.Add(-rampUpSettings.HalfOpenRampUpDuration)
+	assertSucceeds(cb, re)
+	re.Equal(StateClosed, cb.state.stateType)
+}
+
+func TestCircuitBreakerHalfOpenRampUpToOpen(t *testing.T) {
+	re := require.New(t)
+	rampUpSettings := settings
+	rampUpSettings.HalfOpenRampUpDuration = 10 * time.Second
+	cb := NewCircuitBreaker("test_cb", rampUpSettings)
+	driveQPS(cb, minCountToOpen, Yes, re)
+	cb.advance(settings.ErrorRateWindow)
+	assertFastFail(cb, re)
+	cb.advance(settings.CoolDownInterval)
+	driveQPS(cb, 1, Yes, re)
+	re.Equal(StateHalfOpen, cb.state.stateType)
+	assertFastFail(cb, re)
+	re.Equal(StateOpen, cb.state.stateType)
+}
+
+func TestCircuitBreakerRegistry(t *testing.T) {
+	re := require.New(t)
+	registry := NewRegistry("test_registry", settings)
+	cb := registry.Get("method1")
+	re.Same(cb, registry.Get("method1"))
+	re.Equal("test_registry-method1", cb.name)
+
+	// the breakers of the methods are independent
+	driveQPS(cb, minCountToOpen, Yes, re)
+	cb.advance(settings.ErrorRateWindow)
+	assertFastFail(cb, re)
+	assertSucceeds(registry.Get("method2"), re)
+
+	registry.SetMethodSettings("method2", AlwaysClosedSettings)
+	re.False(registry.Get("method2").IsEnabled())
+	registry.SetMethodSettings("method3", AlwaysClosedSettings)
+	re.False(registry.Get("method3").IsEnabled())
+
+	registry.ChangeSettings(func(config *Settings) {
+		config.ErrorRateThresholdPct = 20
+	})
+	re.Equal(uint32(20), registry.Get("method1").config.ErrorRateThresholdPct)
+	re.Equal(uint32(20), registry.Get("method4").config.ErrorRateThresholdPct)
+	re.False(registry.Get("method2").IsEnabled())
+}
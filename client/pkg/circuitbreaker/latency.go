@@ -0,0 +1,74 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	defaultLatencyPercentile = 99.0
+	// maxLatencySamples is the max count of the latency samples kept in the sliding window,
+	// the oldest samples are overwritten once it's full.
+	maxLatencySamples = 4096
+)
+
+type latencySample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// latencyWindow is a ring buffer of the latency samples. It's not thread-safe and
+// is protected by the lock of the circuit breaker.
+type latencyWindow struct {
+	samples []latencySample
+	next    int
+}
+
+func (w *latencyWindow) record(now time.Time, latency time.Duration) {
+	sample := latencySample{at: now, latency: latency}
+	if len(w.samples) < maxLatencySamples {
+		w.samples = append(w.samples, sample)
+		return
+	}
+	w.samples[w.next] = sample
+	w.next = (w.next + 1) % maxLatencySamples
+}
+
+// percentile returns the given percentile of the latencies recorded within the window,
+// and the count of the samples it's calculated from.
+func (w *latencyWindow) percentile(now time.Time, window time.Duration, pct float64) (time.Duration, int) {
+	since := now.Add(-window)
+	latencies := make([]time.Duration, 0, len(w.samples))
+	for _, sample := range w.samples {
+		if sample.at.After(since) {
+			latencies = append(latencies, sample.latency)
+		}
+	}
+	if len(latencies) == 0 {
+		return 0, 0
+	}
+	slices.Sort(latencies)
+	idx := int(math.Ceil(pct/100*float64(len(latencies)))) - 1
+	idx = max(0, min(idx, len(latencies)-1))
+	return latencies[idx], len(latencies)
+}
+
+func (w *latencyWindow) reset() {
+	w.samples = w.samples[:0]
+	w.next = 0
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"sync"
+)
+
+// Registry holds a CircuitBreaker for each method, so that an unhealthy method does not
+// fail the requests of the other methods. The breakers are created lazily.
+type Registry struct {
+	name string
+
+	sync.RWMutex
+	settings       Settings
+	methodSettings map[string]Settings
+	breakers       map[string]*CircuitBreaker
+}
+
+// NewRegistry returns a new Registry, the breakers are named with the given name as the prefix
+// and configured with the given Settings unless the method has its own settings.
+func NewRegistry(name string, st Settings) *Registry {
+	return &Registry{
+		name:           name,
+		settings:       st,
+		methodSettings: make(map[string]Settings),
+		breakers:       make(map[string]*CircuitBreaker),
+	}
+}
+
+// Get returns the CircuitBreaker of the given method.
+func (r *Registry) Get(method string) *CircuitBreaker {
+	r.RLock()
+	cb, ok := r.breakers[method]
+	r.RUnlock()
+	if ok {
+		return cb
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if cb, ok := r.breakers[method]; ok {
+		return cb
+	}
+	st, ok := r.methodSettings[method]
+	if !ok {
+		st = r.settings
+	}
+	cb = NewCircuitBreaker(r.name+"-"+method, st)
+	r.breakers[method] = cb
+	return cb
+}
+
+// SetMethodSettings sets the Settings of the given method, which overrides the default settings.
+func (r *Registry) SetMethodSettings(method string, st Settings) {
+	r.Lock()
+	defer r.Unlock()
+	r.methodSettings[method] = st
+	if cb, ok := r.breakers[method]; ok {
+		cb.ChangeSettings(func(config *Settings) {
+			*config = st
+		})
+	}
+}
+
+// ChangeSettings changes the default Settings and the settings of the breakers of
+// the methods without their own settings.
+func (r *Registry) ChangeSettings(apply func(config *Settings)) {
+	r.Lock()
+	defer r.Unlock()
+	apply(&r.settings)
+	for method, cb := range r.breakers {
+		if _, ok := r.methodSettings[method]; ok {
+			continue
+		}
+		cb.ChangeSettings(apply)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -45,6 +46,8 @@ const (
 	ForwardMetadataKey = "pd-forwarded-host"
 	// FollowerHandleMetadataKey is used to mark the permit of follower handle.
 	FollowerHandleMetadataKey = "pd-allow-follower-handle"
+	// KeyspaceIDMetadataKey is used to record the keyspace ID of the client.
+	KeyspaceIDMetadataKey = "pd-keyspace-id"
 )
 
 // UnaryBackofferInterceptor is a gRPC interceptor that adds a backoffer to the call.
@@ -159,6 +162,12 @@ func BuildForwardContext(ctx context.Context, url string) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
+// BuildKeyspaceContext appends the keyspace ID of the client to the metadata.
+// It is used in client side.
+func BuildKeyspaceContext(ctx context.Context, keyspaceID uint32) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, KeyspaceIDMetadataKey, strconv.FormatUint(uint64(keyspaceID), 10))
+}
+
 // GetForwardedHost returns the forwarded host in metadata.
 // Only used for test.
 func GetForwardedHost(ctx context.Context, f func(context.Context) (metadata.MD, bool)) string {
@@ -549,6 +549,7 @@ func (c *ResourceGroupsController) cleanUpResourceGroup() {
 			if gc.inactive || gc.tombstone.Load() {
 				c.groupsController.Delete(resourceGroupName)
 				resourceGroupStatusGauge.DeleteLabelValues(resourceGroupName, resourceGroupName)
+				grantedRUPerSecGauge.DeleteLabelValues(resourceGroupName)
 				return true
 			}
 			gc.inactive = true
@@ -789,6 +790,7 @@ type groupMetricsCollection struct {
 	tokenRequestCounter               prometheus.Counter
 	runningKVRequestCounter           prometheus.Gauge
 	consumeTokenHistogram             prometheus.Observer
+	grantedRUPerSecGauge              prometheus.Gauge
 }
 
 func initMetrics(oldName, name string) *groupMetricsCollection {
@@ -805,6 +807,7 @@ func initMetrics(oldName, name string) *groupMetricsCollection {
 		tokenRequestCounter:               resourceGroupTokenRequestCounter.WithLabelValues(oldName, name),
 		runningKVRequestCounter:           groupRunningKVRequestCounter.WithLabelValues(name),
 		consumeTokenHistogram:             tokenConsumedHistogram.WithLabelValues(name),
+		grantedRUPerSecGauge:              grantedRUPerSecGauge.WithLabelValues(name),
 	}
 }
 
@@ -1138,6 +1141,13 @@ func (gc *groupCostController) handleRUTokenResponse(resp *rmpb.TokenBucketRespo
 			continue
 		}
 		gc.modifyTokenCounter(counter, grantedTB.GetGrantedTokens(), grantedTB.GetTrickleTimeMs())
+		// While throttled, the granted tokens are delivered at the share of the group's fill rate,
+		// which includes the rate borrowed from the sibling groups under the same parent group.
+		if grantedTB.GetTrickleTimeMs() > 0 {
+			gc.metrics.grantedRUPerSecGauge.Set(counter.lastRate)
+		} else {
+			gc.metrics.grantedRUPerSecGauge.Set(0)
+		}
 	}
 }
 
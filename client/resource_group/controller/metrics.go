@@ -103,6 +103,13 @@ var (
 			Name:      "low_token_notified",
 			Help:      "Counter of low token request.",
 		}, []string{newResourceGroupNameLabel})
+	grantedRUPerSecGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: tokenRequestSubsystem,
+			Name:      "granted_ru_per_sec",
+			Help:      "Gauge of the RU per second granted to the throttled resource group, including the RU borrowed from the sibling groups.",
+		}, []string{newResourceGroupNameLabel})
 	tokenConsumedHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: namespace,
@@ -130,4 +137,5 @@ func init() {
 	prometheus.MustRegister(resourceGroupTokenRequestCounter)
 	prometheus.MustRegister(lowTokenRequestNotifyCounter)
 	prometheus.MustRegister(tokenConsumedHistogram)
+	prometheus.MustRegister(grantedRUPerSecGauge)
 }
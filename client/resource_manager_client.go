@@ -29,6 +29,7 @@ import (
 	"github.com/tikv/pd/client/constants"
 	"github.com/tikv/pd/client/errs"
 	"github.com/tikv/pd/client/opt"
+	"github.com/tikv/pd/client/pkg/utils/grpcutil"
 )
 
 type actionType int
@@ -385,6 +386,8 @@ func (c *innerClient) tryResourceManagerConnect(ctx context.Context, connection
 			continue
 		}
 		cctx, cancel := context.WithCancel(ctx)
+		// Carry the keyspace ID so the resource manager could record the usage to it.
+		cctx = grpcutil.BuildKeyspaceContext(cctx, c.keyspaceID)
 		stream, err = cc.AcquireTokenBuckets(cctx)
 		if err == nil && stream != nil {
 			connection.cancel = cancel
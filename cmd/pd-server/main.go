@@ -114,6 +114,7 @@ func NewSchedulingServiceCommand() *cobra.Command {
 		Run:   scheduling.CreateServerWrapper,
 	}
 	cmd.Flags().StringP("name", "", "", "human-readable name for this scheduling member")
+	cmd.Flags().StringP("data-dir", "", "", "path to the data directory, the finished operators are not persisted if it's empty")
 	cmd.Flags().BoolP("version", "V", false, "print version information and exit")
 	cmd.Flags().StringP("config", "", "", "config file")
 	cmd.Flags().StringP("backend-endpoints", "", "", "url for etcd client")
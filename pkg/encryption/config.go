@@ -15,6 +15,7 @@
 package encryption
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
@@ -70,7 +71,14 @@ func (c *Config) Adjust() error {
 	}
 	if len(c.MasterKey.Type) == 0 {
 		c.MasterKey.Type = masterKeyTypePlaintext
-	} else if _, err := c.GetMasterKeyMeta(); err != nil {
+	} else if c.MasterKey.Type == masterKeyTypeKMS {
+		if len(c.MasterKey.KmsVendor) == 0 {
+			c.MasterKey.KmsVendor = kmsVendorAWS
+		} else {
+			c.MasterKey.KmsVendor = strings.ToUpper(c.MasterKey.KmsVendor)
+		}
+	}
+	if _, err := c.GetMasterKeyMeta(); err != nil {
 		return err
 	}
 	return nil
@@ -103,14 +111,22 @@ func (c *Config) GetMasterKeyMeta() (*encryptionpb.MasterKey, error) {
 			},
 		}, nil
 	case masterKeyTypeKMS:
+		vendor := strings.ToUpper(c.MasterKey.KmsVendor)
+		if len(vendor) == 0 {
+			vendor = kmsVendorAWS
+		}
+		kms := &encryptionpb.MasterKeyKms{
+			Vendor:   vendor,
+			KeyId:    c.MasterKey.KmsKeyID,
+			Region:   c.MasterKey.KmsRegion,
+			Endpoint: c.MasterKey.KmsEndpoint,
+		}
+		if err := validateKMSConfig(kms); err != nil {
+			return nil, err
+		}
 		return &encryptionpb.MasterKey{
 			Backend: &encryptionpb.MasterKey_Kms{
-				Kms: &encryptionpb.MasterKeyKms{
-					Vendor:   kmsVendorAWS,
-					KeyId:    c.MasterKey.KmsKeyID,
-					Region:   c.MasterKey.KmsRegion,
-					Endpoint: c.MasterKey.KmsEndpoint,
-				},
+				Kms: kms,
 			},
 		}, nil
 	case masterKeyTypeFile:
@@ -138,6 +154,8 @@ type MasterKeyConfig struct {
 
 // MasterKeyKMSConfig defines a KMS master key config structure.
 type MasterKeyKMSConfig struct {
+	// KMS vendor, one of "AWS", "VAULT", "GCP", "AZURE", "KMIP_HTTP" or "LOCAL". Default is "AWS".
+	KmsVendor string `toml:"vendor" json:"vendor"`
 	// KMS CMK key id.
 	KmsKeyID string `toml:"key-id" json:"key-id"`
 	// KMS region of the CMK.
@@ -15,6 +15,7 @@
 package encryption
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -51,3 +52,38 @@ func TestAdjustInvalidMasterKeyType(t *testing.T) {
 	config := &Config{MasterKey: MasterKeyConfig{Type: "unknown"}}
 	re.Error(config.Adjust())
 }
+
+func TestAdjustKMSMasterKey(t *testing.T) {
+	re := require.New(t)
+	// The vendor is AWS by default.
+	config := &Config{MasterKey: MasterKeyConfig{
+		Type:               "kms",
+		MasterKeyKMSConfig: MasterKeyKMSConfig{KmsKeyID: "key", KmsRegion: "us-west-2"},
+	}}
+	re.NoError(config.Adjust())
+	re.Equal(kmsVendorAWS, config.MasterKey.KmsVendor)
+	meta, err := config.GetMasterKeyMeta()
+	re.NoError(err)
+	re.Equal(kmsVendorAWS, meta.GetKms().Vendor)
+	re.Equal("key", meta.GetKms().KeyId)
+
+	// The vendor is case-insensitive.
+	config.MasterKey.KmsVendor = "local"
+	config.MasterKey.KmsKeyID = filepath.Join(t.TempDir(), "kms.json")
+	re.NoError(config.Adjust())
+	re.Equal(kmsVendorLocal, config.MasterKey.KmsVendor)
+
+	// Unknown vendor.
+	config.MasterKey.KmsVendor = "unknown"
+	re.Error(config.Adjust())
+	// Missing key id.
+	config.MasterKey.KmsVendor = kmsVendorAWS
+	config.MasterKey.KmsKeyID = ""
+	re.Error(config.Adjust())
+	// Invalid vendor specific config.
+	config.MasterKey.KmsVendor = kmsVendorAzure
+	config.MasterKey.KmsKeyID = "key"
+	re.Error(config.Adjust())
+	config.MasterKey.KmsEndpoint = "https://my-vault.vault.azure.net"
+	re.NoError(config.Adjust())
+}
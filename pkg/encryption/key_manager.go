@@ -74,6 +74,10 @@ type Manager struct {
 		leadership *election.Leadership
 		// Revision of keys loaded from etcd. Guarded by mu.
 		keysRevision int64
+		// Metadata of the master key used to encrypt the loaded keys. Guarded by mu.
+		loadedMasterKeyMeta *encryptionpb.MasterKey
+		// Name of the current PD member, used to report the loaded master key. Guarded by mu.
+		memberName string
 	}
 	// List of all encryption keys and current encryption key id,
 	// with type *encryptionpb.KeyDictionary. The content is read-only.
@@ -87,10 +91,37 @@ func saveKeys(
 	keys *encryptionpb.KeyDictionary,
 	helper keyManagerHelper,
 ) (err error) {
+	value, err := encryptKeys(masterKeyMeta, keys, helper)
+	if err != nil {
+		return err
+	}
+	// Avoid write conflict with PD peer by checking if we are leader.
+	resp, err := leadership.LeaderTxn().
+		Then(clientv3.OpPut(EncryptionKeysPath, value)).
+		Commit()
+	if err != nil {
+		log.Warn("fail to save encryption keys", errs.ZapError(err))
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStack("fail to save encryption keys")
+	}
+	if !resp.Succeeded {
+		log.Warn("fail to save encryption keys and leader expired")
+		return errs.ErrEncryptionSaveDataKeys.GenWithStack("leader expired")
+	}
+	// Leave for the watcher to load the updated keys.
+	log.Info("saved encryption keys")
+	return nil
+}
+
+// encryptKeys encrypts encryption keys with the master key, and returns the value to store in etcd.
+func encryptKeys(
+	masterKeyMeta *encryptionpb.MasterKey,
+	keys *encryptionpb.KeyDictionary,
+	helper keyManagerHelper,
+) (string, error) {
 	// Get master key.
 	masterKey, err := helper.newMasterKey(masterKeyMeta, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	// Set was_exposed flag if master key is plaintext (no-op).
 	if masterKey.IsPlaintext() {
@@ -101,11 +132,11 @@ func saveKeys(
 	// Encode and encrypt data keys.
 	plaintextContent, err := proto.Marshal(keys)
 	if err != nil {
-		return errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal encrypion keys")
+		return "", errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal encrypion keys")
 	}
 	ciphertextContent, iv, err := masterKey.Encrypt(plaintextContent)
 	if err != nil {
-		return err
+		return "", err
 	}
 	content := &encryptionpb.EncryptedContent{
 		Content:       ciphertextContent,
@@ -115,23 +146,9 @@ func saveKeys(
 	}
 	value, err := proto.Marshal(content)
 	if err != nil {
-		return errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal encrypted encryption keys")
+		return "", errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal encrypted encryption keys")
 	}
-	// Avoid write conflict with PD peer by checking if we are leader.
-	resp, err := leadership.LeaderTxn().
-		Then(clientv3.OpPut(EncryptionKeysPath, string(value))).
-		Commit()
-	if err != nil {
-		log.Warn("fail to save encryption keys", errs.ZapError(err))
-		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStack("fail to save encryption keys")
-	}
-	if !resp.Succeeded {
-		log.Warn("fail to save encryption keys and leader expired")
-		return errs.ErrEncryptionSaveDataKeys.GenWithStack("leader expired")
-	}
-	// Leave for the watcher to load the updated keys.
-	log.Info("saved encryption keys")
-	return nil
+	return string(value), nil
 }
 
 // extractKeysFromKV unpack encrypted keys from etcd KV.
@@ -139,32 +156,42 @@ func extractKeysFromKV(
 	kv *mvccpb.KeyValue,
 	helper keyManagerHelper,
 ) (*encryptionpb.KeyDictionary, error) {
+	keys, _, err := extractKeysAndMasterKeyFromKV(kv, helper)
+	return keys, err
+}
+
+// extractKeysAndMasterKeyFromKV unpack encrypted keys and the metadata of the master key
+// used to encrypt them from etcd KV.
+func extractKeysAndMasterKeyFromKV(
+	kv *mvccpb.KeyValue,
+	helper keyManagerHelper,
+) (*encryptionpb.KeyDictionary, *encryptionpb.MasterKey, error) {
 	content := &encryptionpb.EncryptedContent{}
 	err := content.Unmarshal(kv.Value)
 	if err != nil {
-		return nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack(
+		return nil, nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack(
 			"fail to unmarshal encrypted encryption keys")
 	}
 	masterKeyConfig := content.MasterKey
 	if masterKeyConfig == nil {
-		return nil, errs.ErrEncryptionLoadKeys.GenWithStack(
+		return nil, nil, errs.ErrEncryptionLoadKeys.GenWithStack(
 			"no master key config found with encryption keys")
 	}
 	masterKey, err := helper.newMasterKey(masterKeyConfig, content.CiphertextKey)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	plaintextContent, err := masterKey.Decrypt(content.Content, content.Iv)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	keys := &encryptionpb.KeyDictionary{}
 	err = keys.Unmarshal(plaintextContent)
 	if err != nil {
-		return nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack(
+		return nil, nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack(
 			"fail to unmarshal encryption keys")
 	}
-	return keys, nil
+	return keys, masterKeyConfig, nil
 }
 
 // NewManager creates a new key manager.
@@ -303,13 +330,18 @@ func (m *Manager) loadKeysFromKVImpl(
 	if kv.ModRevision <= m.mu.keysRevision {
 		return m.getKeys(), nil
 	}
-	keys, err := extractKeysFromKV(kv, m.helper)
+	keys, masterKeyMeta, err := extractKeysAndMasterKeyFromKV(kv, m.helper)
 	if err != nil {
 		return nil, err
 	}
 	m.mu.keysRevision = kv.ModRevision
 	m.keys.Store(keys)
 	log.Info("reloaded encryption keys", zap.Int64("revision", kv.ModRevision))
+	if MasterKeyVersion(masterKeyMeta) != MasterKeyVersion(m.mu.loadedMasterKeyMeta) {
+		m.mu.loadedMasterKeyMeta = masterKeyMeta
+		log.Info("loaded master key", zap.String("version", MasterKeyVersion(masterKeyMeta)))
+		m.reportLoadedMasterKeyImpl()
+	}
 	return keys, nil
 }
 
@@ -429,7 +461,11 @@ func (m *Manager) rotateKeyIfNeeded(forceUpdate bool) error {
 		return nil
 	}
 	// Store updated keys in etcd.
-	err = saveKeys(m.mu.leadership, m.masterKeyMeta, keys, m.helper)
+	masterKeyMeta, err := m.getMasterKeyMetaImpl()
+	if err != nil {
+		return err
+	}
+	err = saveKeys(m.mu.leadership, masterKeyMeta, keys, m.helper)
 	if err != nil {
 		m.helper.eventSaveKeysFailure()
 		log.Error("failed to save keys", errs.ZapError(err))
@@ -16,6 +16,7 @@ package encryption
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"os"
 	"path/filepath"
@@ -1088,6 +1089,95 @@ func TestKeyRotationConflict(t *testing.T) {
 	re.True(proto.Equal(storedKeys, keys))
 }
 
+func TestKeyRotationWithLocalKMS(t *testing.T) {
+	re := require.New(t)
+	// Initialize.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestEtcd(t)
+	leadership := newTestLeader(re, client)
+	// Setup helper
+	helper := defaultKeyManagerHelper()
+	// Mock time
+	mockNow := int64(1601679533)
+	helper.now = func() time.Time { return time.Unix(atomic.LoadInt64(&mockNow), 0) }
+	mockTick := make(chan time.Time)
+	helper.tick = func(_ *time.Ticker) <-chan time.Time { return mockTick }
+	// Listen on watcher event
+	reloadEvent := make(chan struct{}, 10)
+	helper.eventAfterReloadByWatcher = func() {
+		var e struct{}
+		reloadEvent <- e
+	}
+	// Listen on ticker event
+	tickerEvent := make(chan struct{}, 10)
+	helper.eventAfterTicker = func() {
+		var e struct{}
+		tickerEvent <- e
+	}
+	// Config with the local KMS and 100s rotation period.
+	rotationPeriod, err := time.ParseDuration("100s")
+	re.NoError(err)
+	config := &Config{
+		DataEncryptionMethod:  "aes128-ctr",
+		DataKeyRotationPeriod: typeutil.NewDuration(rotationPeriod),
+		MasterKey: MasterKeyConfig{
+			Type: "kms",
+			MasterKeyKMSConfig: MasterKeyKMSConfig{
+				KmsVendor: "local",
+				KmsKeyID:  filepath.Join(t.TempDir(), "kms.json"),
+			},
+		},
+	}
+	err = config.Adjust()
+	re.NoError(err)
+	// Create the key manager.
+	m, err := newKeyManagerImpl(client, config, helper)
+	re.NoError(err)
+	checkStoredKeys := func(kmsKeyVersion uint32) *encryptionpb.KeyDictionary {
+		resp, err := etcdutil.EtcdKVGet(client, EncryptionKeysPath)
+		re.NoError(err)
+		content := &encryptionpb.EncryptedContent{}
+		re.NoError(content.Unmarshal(resp.Kvs[0].Value))
+		// The master key is encrypted by the given version of the KMS key.
+		re.Equal(kmsKeyVersion, binary.BigEndian.Uint32(content.CiphertextKey))
+		storedKeys, err := extractKeysFromKV(resp.Kvs[0], defaultKeyManagerHelper())
+		re.NoError(err)
+		re.True(proto.Equal(m.keys.Load().(*encryptionpb.KeyDictionary), storedKeys))
+		return storedKeys
+	}
+	go m.StartBackgroundLoop(ctx)
+	// Set leadership, the data key is created.
+	err = m.SetLeadership(leadership)
+	re.NoError(err)
+	<-reloadEvent
+	currentKeyID, _, err := m.GetCurrentKey()
+	re.NoError(err)
+	checkStoredKeys(1)
+	// Rotate the KMS key, the keys encrypted with the old version can still be loaded.
+	meta, err := config.GetMasterKeyMeta()
+	re.NoError(err)
+	provider, err := NewKMSProvider(meta.GetKms())
+	re.NoError(err)
+	re.NoError(provider.Rotate(ctx))
+	checkStoredKeys(1)
+	// Advance time and trigger ticker to rotate the data key.
+	atomic.AddInt64(&mockNow, int64(101))
+	mockTick <- time.Unix(atomic.LoadInt64(&mockNow), 0)
+	<-tickerEvent
+	<-reloadEvent
+	newKeyID, _, err := m.GetCurrentKey()
+	re.NoError(err)
+	re.NotEqual(currentKeyID, newKeyID)
+	// The keys are saved with the master key encrypted by the new version of the KMS key.
+	storedKeys := checkStoredKeys(2)
+	re.Len(storedKeys.Keys, 2)
+	// The keys can be recovered by a new key manager.
+	m2, err := newKeyManagerImpl(client, config, defaultKeyManagerHelper())
+	re.NoError(err)
+	re.True(proto.Equal(storedKeys, m2.keys.Load().(*encryptionpb.KeyDictionary)))
+}
+
 func newTestMasterKey(keyFile string) *encryptionpb.MasterKey {
 	return &encryptionpb.MasterKey{
 		Backend: &encryptionpb.MasterKey_File{
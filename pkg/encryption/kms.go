@@ -16,7 +16,11 @@ package encryption
 
 import (
 	"context"
+	"crypto/rand"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	sdkconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
@@ -26,10 +30,10 @@ import (
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
 
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
 const (
-	// We only support AWS KMS right now.
 	kmsVendorAWS = "AWS"
 
 	// K8S IAM related environment variables.
@@ -37,19 +41,197 @@ const (
 	// #nosec
 	envAwsWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
 	envAwsRoleSessionName      = "AWS_ROLE_SESSION_NAME"
+
+	// kmsRequestTimeout is the timeout of each request to the KMS.
+	kmsRequestTimeout = 30 * time.Second
 )
 
+// KMSProvider is a key management service used to protect the master key.
+// The master key is generated by PD and stored in etcd in the form encrypted by the KMS.
+type KMSProvider interface {
+	// Encrypt encrypts the plaintext with the current version of the KMS key.
+	Encrypt(ctx context.Context, plaintext []byte) (ciphertext []byte, err error)
+	// Decrypt decrypts the ciphertext returned by Encrypt, even if it is encrypted with
+	// an old version of the KMS key.
+	Decrypt(ctx context.Context, ciphertext []byte) (plaintext []byte, err error)
+	// Rotate creates a new version of the KMS key, which is used by the following Encrypt.
+	// It returns an error if the vendor can't rotate the key on demand.
+	Rotate(ctx context.Context) error
+}
+
+// dataKeyGenerator is an optional interface of KMSProvider. If it's implemented, the master key
+// is generated by the KMS instead of PD.
+type dataKeyGenerator interface {
+	// GenerateDataKey generates a data key of the given length, and returns both the plaintext
+	// and the ciphertext encrypted with the KMS key.
+	GenerateDataKey(ctx context.Context, length int) (plaintext, ciphertext []byte, err error)
+}
+
+// KMSProviderBuilder creates a KMS provider from the config.
+type KMSProviderBuilder func(config *encryptionpb.MasterKeyKms) (KMSProvider, error)
+
+// KMSConfigValidator checks whether the config is valid for a KMS vendor.
+type KMSConfigValidator func(config *encryptionpb.MasterKeyKms) error
+
+type kmsProviderRegistration struct {
+	validate KMSConfigValidator
+	build    KMSProviderBuilder
+}
+
+var kmsProviders = struct {
+	syncutil.RWMutex
+	m map[string]kmsProviderRegistration
+}{m: make(map[string]kmsProviderRegistration)}
+
+func init() {
+	// The region of AWS KMS can also be set by the environment variables, so there is nothing to validate.
+	RegisterKMSProvider(kmsVendorAWS, nil, newAWSKMSProvider)
+	RegisterKMSProvider(kmsVendorVault, validateVaultKMSConfig, newVaultKMSProvider)
+	RegisterKMSProvider(kmsVendorGCP, validateGCPKMSConfig, newGCPKMSProvider)
+	RegisterKMSProvider(kmsVendorAzure, validateAzureKMSConfig, newAzureKMSProvider)
+	RegisterKMSProvider(kmsVendorKMIPHTTP, validateKMIPHTTPKMSConfig, newKMIPHTTPKMSProvider)
+	RegisterKMSProvider(kmsVendorLocal, validateLocalKMSConfig, newLocalKMSProvider)
+}
+
+// RegisterKMSProvider registers a KMS vendor. The vendor name is case-insensitive,
+// and the registered one with the same name is replaced.
+func RegisterKMSProvider(vendor string, validate KMSConfigValidator, build KMSProviderBuilder) {
+	kmsProviders.Lock()
+	defer kmsProviders.Unlock()
+	kmsProviders.m[strings.ToUpper(vendor)] = kmsProviderRegistration{
+		validate: validate,
+		build:    build,
+	}
+}
+
+// registeredKMSVendors returns the sorted names of the registered KMS vendors.
+func registeredKMSVendors() []string {
+	kmsProviders.RLock()
+	defer kmsProviders.RUnlock()
+	vendors := make([]string, 0, len(kmsProviders.m))
+	for vendor := range kmsProviders.m {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+func getKMSProviderRegistration(vendor string) (kmsProviderRegistration, bool) {
+	kmsProviders.RLock()
+	defer kmsProviders.RUnlock()
+	r, ok := kmsProviders.m[strings.ToUpper(vendor)]
+	return r, ok
+}
+
+// validateKMSConfig checks whether the vendor is registered and the config is valid for it.
+func validateKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	if config == nil {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack("missing KMS config")
+	}
+	r, ok := getKMSProviderRegistration(config.Vendor)
+	if !ok {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"unsupported KMS vendor %s, should be one of %s",
+			config.Vendor, strings.Join(registeredKMSVendors(), ", "))
+	}
+	if len(config.KeyId) == 0 {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack("missing KMS key id")
+	}
+	if r.validate == nil {
+		return nil
+	}
+	return r.validate(config)
+}
+
+// NewKMSProvider creates the KMS provider of the vendor specified by the config.
+func NewKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	if config == nil {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("missing KMS config")
+	}
+	r, ok := getKMSProviderRegistration(config.Vendor)
+	if !ok {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("unsupported KMS vendor: %s", config.Vendor)
+	}
+	if r.validate != nil {
+		if err := r.validate(config); err != nil {
+			return nil, err
+		}
+	}
+	return r.build(config)
+}
+
+// newMasterKeyFromKMS generates a new master key and encrypts it with the KMS if the ciphertext
+// key is empty, otherwise it decrypts the ciphertext key with the KMS to recover the master key.
 func newMasterKeyFromKMS(
 	config *encryptionpb.MasterKeyKms,
 	ciphertextKey []byte,
-) (masterKey *MasterKey, err error) {
+) (*MasterKey, error) {
 	if config == nil {
-		return nil, errs.ErrEncryptionNewMasterKey.GenWithStack("missing master key file config")
+		return nil, errs.ErrEncryptionNewMasterKey.GenWithStack("missing master key kms config")
 	}
-	if config.Vendor != kmsVendorAWS {
-		return nil, errs.ErrEncryptionKMS.GenWithStack("unsupported KMS vendor: %s", config.Vendor)
+	provider, err := NewKMSProvider(config)
+	if err != nil {
+		return nil, err
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), kmsRequestTimeout)
+	defer cancel()
+	if generator, ok := provider.(dataKeyGenerator); ok && len(ciphertextKey) == 0 {
+		// Create a new master key by the KMS.
+		key, ciphertextKey, err := generator.GenerateDataKey(ctx, masterKeyLength)
+		if err != nil {
+			return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
+				"fail to generate data key from %s KMS", config.Vendor)
+		}
+		if len(key) != masterKeyLength {
+			return nil, errs.ErrEncryptionKMS.GenWithStack(
+				"unexpected data key length generated from %s KMS, expected %d vs actual %d",
+				config.Vendor, masterKeyLength, len(key))
+		}
+		return &MasterKey{
+			key:           key,
+			ciphertextKey: ciphertextKey,
+		}, nil
+	}
+	if len(ciphertextKey) == 0 {
+		// Create a new master key.
+		key := make([]byte, masterKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, errs.ErrEncryptionNewMasterKey.Wrap(err).GenWithStack("fail to generate master key")
+		}
+		ciphertextKey, err = provider.Encrypt(ctx, key)
+		if err != nil {
+			return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
+				"fail to encrypt master key with %s KMS", config.Vendor)
+		}
+		return &MasterKey{
+			key:           key,
+			ciphertextKey: ciphertextKey,
+		}, nil
+	}
+	// Decrypt existing master key.
+	key, err := provider.Decrypt(ctx, ciphertextKey)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
+			"fail to decrypt master key with %s KMS", config.Vendor)
+	}
+	if len(key) != masterKeyLength {
+		return nil, errs.ErrEncryptionKMS.GenWithStack(
+			"unexpected master key length decrypted from %s KMS, expected %d vs actual %d",
+			config.Vendor, masterKeyLength, len(key))
+	}
+	return &MasterKey{
+		key:           key,
+		ciphertextKey: ciphertextKey,
+	}, nil
+}
 
+// awsKMSProvider uses the AWS KMS.
+type awsKMSProvider struct {
+	keyID  string
+	client *kms.Client
+}
+
+func newAWSKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
 	cfg, err := sdkconfig.LoadDefaultConfig(context.TODO(),
 		sdkconfig.WithRegion(config.Region),
 	)
@@ -62,7 +244,7 @@ func newMasterKeyFromKMS(
 	roleArn := os.Getenv(envAwsRoleArn)
 	tokenFile := os.Getenv(envAwsWebIdentityTokenFile)
 	sessionName := os.Getenv(envAwsRoleSessionName)
-	optFn := func(*kms.Options) {}
+	optFns := []func(*kms.Options){}
 	// Session name is optional.
 	if roleArn != "" && tokenFile != "" {
 		client := sts.NewFromConfig(cfg)
@@ -74,50 +256,62 @@ func newMasterKeyFromKMS(
 				o.RoleSessionName = sessionName
 			},
 		)
-		optFn = func(options *kms.Options) {
+		optFns = append(optFns, func(options *kms.Options) {
 			options.Credentials = webIdentityRoleProvider
-		}
-	}
-	client := kms.NewFromConfig(cfg, optFn)
-	if len(ciphertextKey) == 0 {
-		numberOfBytes := int32(masterKeyLength)
-		// Create a new data key.
-		output, err := client.GenerateDataKey(context.Background(), &kms.GenerateDataKeyInput{
-			KeyId:         &config.KeyId,
-			NumberOfBytes: &numberOfBytes,
 		})
-		if err != nil {
-			return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
-				"fail to generate data key from AWS KMS")
-		}
-		if len(output.Plaintext) != masterKeyLength {
-			return nil, errs.ErrEncryptionKMS.GenWithStack(
-				"unexpected data key length generated from AWS KMS, expected %d vs actual %d",
-				masterKeyLength, len(output.Plaintext))
-		}
-		masterKey = &MasterKey{
-			key:           output.Plaintext,
-			ciphertextKey: output.CiphertextBlob,
-		}
-	} else {
-		// Decrypt existing data key.
-		output, err := client.Decrypt(context.Background(), &kms.DecryptInput{
-			KeyId:          &config.KeyId,
-			CiphertextBlob: ciphertextKey,
+	}
+	if config.Endpoint != "" {
+		endpoint := config.Endpoint
+		optFns = append(optFns, func(options *kms.Options) {
+			options.BaseEndpoint = &endpoint
 		})
-		if err != nil {
-			return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
-				"fail to decrypt data key from AWS KMS")
-		}
-		if len(output.Plaintext) != masterKeyLength {
-			return nil, errs.ErrEncryptionKMS.GenWithStack(
-				"unexpected data key length decrypted from AWS KMS, expected %d vs actual %d",
-				masterKeyLength, len(output.Plaintext))
-		}
-		masterKey = &MasterKey{
-			key:           output.Plaintext,
-			ciphertextKey: ciphertextKey,
-		}
 	}
-	return
+	return &awsKMSProvider{
+		keyID:  config.KeyId,
+		client: kms.NewFromConfig(cfg, optFns...),
+	}, nil
+}
+
+// Encrypt implements KMSProvider.
+func (p *awsKMSProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	output, err := p.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     &p.keyID,
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.CiphertextBlob, nil
+}
+
+// Decrypt implements KMSProvider.
+func (p *awsKMSProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	output, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          &p.keyID,
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
+
+// GenerateDataKey implements dataKeyGenerator.
+func (p *awsKMSProvider) GenerateDataKey(ctx context.Context, length int) ([]byte, []byte, error) {
+	numberOfBytes := int32(length)
+	output, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:         &p.keyID,
+		NumberOfBytes: &numberOfBytes,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return output.Plaintext, output.CiphertextBlob, nil
+}
+
+// Rotate implements KMSProvider. AWS KMS only rotates the key material automatically once the
+// rotation is enabled in AWS, so the key can't be rotated on demand.
+func (*awsKMSProvider) Rotate(context.Context) error {
+	return errs.ErrEncryptionKMS.GenWithStack(
+		"AWS KMS key can't be rotated on demand, enable the automatic key rotation in AWS instead")
 }
@@ -0,0 +1,173 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+const (
+	kmsVendorAzure = "AZURE"
+
+	// Azure related environment variables, which are the same as the ones used by the Azure SDK.
+	envAzureTenantID = "AZURE_TENANT_ID"
+	envAzureClientID = "AZURE_CLIENT_ID"
+	// #nosec
+	envAzureClientSecret = "AZURE_CLIENT_SECRET"
+	envAzureAuthority    = "AZURE_AUTHORITY_HOST"
+
+	defaultAzureAuthority = "https://login.microsoftonline.com"
+	azureKeyVaultScope    = "https://vault.azure.net/.default"
+	azureKeyVaultAPI      = "7.4"
+	azureWrapAlgorithm    = "RSA-OAEP-256"
+)
+
+// azureKMSProvider uses the Azure Key Vault. The endpoint is the URL of the vault, like
+// "https://my-vault.vault.azure.net", and the key id is the name of an RSA key in the vault.
+type azureKMSProvider struct {
+	client   kmsHTTPClient
+	endpoint string
+	name     string
+}
+
+// azureCiphertext is the ciphertext stored by PD. The id of the key version is kept because
+// Azure Key Vault needs it to unwrap the key.
+type azureCiphertext struct {
+	KeyID string `json:"kid"`
+	Value string `json:"value"`
+}
+
+func validateAzureKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	u, err := url.Parse(config.Endpoint)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"invalid Azure Key Vault endpoint %q, should be the URL of the vault", config.Endpoint)
+	}
+	if strings.Contains(config.KeyId, "/") {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"invalid Azure Key Vault key id %s, should be the name of the key", config.KeyId)
+	}
+	return nil
+}
+
+func newAzureKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	tenantID := os.Getenv(envAzureTenantID)
+	clientID := os.Getenv(envAzureClientID)
+	clientSecret := os.Getenv(envAzureClientSecret)
+	if len(tenantID) == 0 || len(clientID) == 0 || len(clientSecret) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("missing Azure credentials, set the %s, %s and %s environment variables",
+			envAzureTenantID, envAzureClientID, envAzureClientSecret)
+	}
+	authority := os.Getenv(envAzureAuthority)
+	if len(authority) == 0 {
+		authority = defaultAzureAuthority
+	}
+	client, err := newKMSHTTPClient("", "", "")
+	if err != nil {
+		return nil, err
+	}
+	cfg := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     strings.TrimRight(authority, "/") + "/" + tenantID + "/oauth2/v2.0/token",
+		Scopes:       []string{azureKeyVaultScope},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	ts := cfg.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, client))
+	return &azureKMSProvider{
+		client: kmsHTTPClient{
+			client:  client,
+			setAuth: bearerTokenAuth(ts),
+		},
+		endpoint: strings.TrimRight(config.Endpoint, "/"),
+		name:     config.KeyId,
+	}, nil
+}
+
+func withAzureAPIVersion(u string) string {
+	return u + "?api-version=" + azureKeyVaultAPI
+}
+
+// Encrypt implements KMSProvider. It wraps the plaintext with the latest version of the key.
+func (p *azureKMSProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var key struct {
+		Key struct {
+			KeyID string `json:"kid"`
+		} `json:"key"`
+	}
+	if err := p.client.do(ctx, http.MethodGet, withAzureAPIVersion(p.endpoint+"/keys/"+p.name), nil, &key); err != nil {
+		return nil, err
+	}
+	if len(key.Key.KeyID) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("empty key id returned by Azure Key Vault")
+	}
+	var resp azureCiphertext
+	req := map[string]string{
+		"alg":   azureWrapAlgorithm,
+		"value": base64.RawURLEncoding.EncodeToString(plaintext),
+	}
+	if err := p.client.do(ctx, http.MethodPost, withAzureAPIVersion(key.Key.KeyID+"/wrapkey"), req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.KeyID) == 0 {
+		resp.KeyID = key.Key.KeyID
+	}
+	return json.Marshal(resp)
+}
+
+// Decrypt implements KMSProvider.
+func (p *azureKMSProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var c azureCiphertext
+	if err := json.Unmarshal(ciphertext, &c); err != nil || len(c.KeyID) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("invalid Azure Key Vault ciphertext")
+	}
+	// Make sure the key belongs to the configured vault, so the token is not sent elsewhere.
+	if !strings.HasPrefix(c.KeyID, p.endpoint+"/keys/"+p.name+"/") {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("the key %s doesn't belong to %s/keys/%s", c.KeyID, p.endpoint, p.name)
+	}
+	var resp struct {
+		Value string `json:"value"`
+	}
+	req := map[string]string{
+		"alg":   azureWrapAlgorithm,
+		"value": c.Value,
+	}
+	if err := p.client.do(ctx, http.MethodPost, withAzureAPIVersion(c.KeyID+"/unwrapkey"), req, &resp); err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(resp.Value, "="))
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid plaintext returned by Azure Key Vault")
+	}
+	return plaintext, nil
+}
+
+// Rotate implements KMSProvider.
+func (p *azureKMSProvider) Rotate(ctx context.Context) error {
+	return p.client.do(ctx, http.MethodPost, withAzureAPIVersion(p.endpoint+"/keys/"+p.name+"/rotate"), nil, nil)
+}
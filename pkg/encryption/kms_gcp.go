@@ -0,0 +1,178 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+const (
+	kmsVendorGCP = "GCP"
+
+	// GCP related environment variables. The static access token takes precedence over
+	// the service account key file.
+	// #nosec
+	envGoogleAccessToken = "GOOGLE_OAUTH_ACCESS_TOKEN"
+	// #nosec
+	envGoogleCredentials = "GOOGLE_APPLICATION_CREDENTIALS"
+
+	defaultGCPKMSEndpoint = "https://cloudkms.googleapis.com"
+	defaultGoogleTokenURL = "https://oauth2.googleapis.com/token"
+	gcpKMSScope           = "https://www.googleapis.com/auth/cloudkms"
+)
+
+// gcpKMSProvider uses the Google Cloud KMS. The key id is the resource name of the crypto key,
+// e.g. "projects/p/locations/global/keyRings/r/cryptoKeys/k".
+type gcpKMSProvider struct {
+	client   kmsHTTPClient
+	endpoint string
+	name     string
+}
+
+func validateGCPKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	parts := strings.Split(config.KeyId, "/")
+	if len(parts) != 8 || parts[0] != "projects" || parts[2] != "locations" ||
+		parts[4] != "keyRings" || parts[6] != "cryptoKeys" {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"invalid GCP KMS key id %s, should be like projects/*/locations/*/keyRings/*/cryptoKeys/*", config.KeyId)
+	}
+	return nil
+}
+
+func newGCPKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	client, err := newKMSHTTPClient("", "", "")
+	if err != nil {
+		return nil, err
+	}
+	ts, err := newGoogleTokenSource(client)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := config.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = defaultGCPKMSEndpoint
+	}
+	return &gcpKMSProvider{
+		client: kmsHTTPClient{
+			client:  client,
+			setAuth: bearerTokenAuth(ts),
+		},
+		endpoint: strings.TrimRight(endpoint, "/"),
+		name:     config.KeyId,
+	}, nil
+}
+
+func newGoogleTokenSource(client *http.Client) (oauth2.TokenSource, error) {
+	if token := os.Getenv(envGoogleAccessToken); len(token) > 0 {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), nil
+	}
+	path := os.Getenv(envGoogleCredentials)
+	if len(path) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack(
+			"missing GCP credentials, set the %s or %s environment variable", envGoogleAccessToken, envGoogleCredentials)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to read the GCP credentials %s", path)
+	}
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to parse the GCP credentials %s", path)
+	}
+	if key.Type != "service_account" {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("unsupported GCP credentials type %s, only service_account is supported", key.Type)
+	}
+	if len(key.TokenURI) == 0 {
+		key.TokenURI = defaultGoogleTokenURL
+	}
+	cfg := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{gcpKMSScope},
+		TokenURL:     key.TokenURI,
+	}
+	return cfg.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, client)), nil
+}
+
+func (p *gcpKMSProvider) url(suffix string) string {
+	return p.endpoint + "/v1/" + p.name + suffix
+}
+
+// Encrypt implements KMSProvider. The ciphertext returned by GCP KMS carries the version of the key.
+func (p *gcpKMSProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url(":encrypt"), req, &resp); err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(resp.Ciphertext)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid ciphertext returned by GCP KMS")
+	}
+	return ciphertext, nil
+}
+
+// Decrypt implements KMSProvider.
+func (p *gcpKMSProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	req := map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url(":decrypt"), req, &resp); err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid plaintext returned by GCP KMS")
+	}
+	return plaintext, nil
+}
+
+// Rotate implements KMSProvider. It creates a new version of the crypto key and makes it primary.
+func (p *gcpKMSProvider) Rotate(ctx context.Context) error {
+	var version struct {
+		Name string `json:"name"`
+	}
+	if err := p.client.do(ctx, http.MethodPost, p.url("/cryptoKeyVersions"), struct{}{}, &version); err != nil {
+		return err
+	}
+	id := version.Name[strings.LastIndex(version.Name, "/")+1:]
+	if len(id) == 0 {
+		return errs.ErrEncryptionKMS.GenWithStack("invalid crypto key version %s returned by GCP KMS", version.Name)
+	}
+	req := map[string]string{"cryptoKeyVersionId": id}
+	return p.client.do(ctx, http.MethodPost, p.url(":updatePrimaryVersion"), req, nil)
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// maxKMSErrorBodySize limits the size of the error response kept in the error message.
+const maxKMSErrorBodySize = 4096
+
+// kmsHTTPClient sends JSON requests to the REST API of a KMS.
+type kmsHTTPClient struct {
+	client *http.Client
+	// setAuth sets the authentication headers of the request, nil means no authentication.
+	setAuth func(*http.Request) error
+}
+
+// newKMSHTTPClient creates an HTTP client with the optional CA and client certificate. The
+// certificates are specified by the environment variables of each vendor rather than the config,
+// because the config is persisted along with the encrypted keys.
+func newKMSHTTPClient(caPath, certPath, keyPath string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caPath) > 0 || len(certPath) > 0 || len(keyPath) > 0 {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(caPath) > 0 {
+			ca, err := os.ReadFile(caPath)
+			if err != nil {
+				return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to read the KMS CA %s", caPath)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return nil, errs.ErrEncryptionKMS.GenWithStack("fail to parse the KMS CA %s", caPath)
+			}
+			cfg.RootCAs = pool
+		}
+		if len(certPath) > 0 || len(keyPath) > 0 {
+			cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+			if err != nil {
+				return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack(
+					"fail to load the KMS client certificate %s and key %s", certPath, keyPath)
+			}
+			cfg.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = cfg
+	}
+	return &http.Client{Transport: transport, Timeout: kmsRequestTimeout}, nil
+}
+
+// bearerTokenAuth returns a function setting the bearer token got from the token source.
+func bearerTokenAuth(ts oauth2.TokenSource) func(*http.Request) error {
+	return func(req *http.Request) error {
+		token, err := ts.Token()
+		if err != nil {
+			return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to get the access token of the KMS")
+		}
+		token.SetAuthHeader(req)
+		return nil
+	}
+}
+
+// do sends the request body in JSON, and decodes the JSON response into resp if it is not nil.
+func (c *kmsHTTPClient) do(ctx context.Context, method, url string, body, resp any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to marshal the KMS request")
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to create the KMS request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.setAuth != nil {
+		if err := c.setAuth(req); err != nil {
+			return err
+		}
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to send the KMS request %s %s", method, url)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, maxKMSErrorBodySize))
+		return errs.ErrEncryptionKMS.GenWithStack("the KMS request %s %s failed with status %d: %s",
+			method, url, res.StatusCode, bytes.TrimSpace(msg))
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to decode the KMS response of %s %s", method, url)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+const (
+	kmsVendorKMIPHTTP = "KMIP_HTTP"
+
+	// KMIP HTTP gateway related environment variables.
+	// #nosec
+	envKMIPHTTPToken      = "KMIP_HTTP_TOKEN"
+	envKMIPHTTPCACert     = "KMIP_HTTP_CACERT"
+	envKMIPHTTPClientCert = "KMIP_HTTP_CLIENT_CERT"
+	envKMIPHTTPClientKey  = "KMIP_HTTP_CLIENT_KEY"
+)
+
+// kmipHTTPKMSProvider talks to an HTTP/JSON gateway in front of a KMIP server. It does not speak
+// the KMIP binary TTLV protocol, and the HTTP API below is not a part of the KMIP standard, so the
+// gateway must be deployed to serve it. The key id is the unique identifier of the symmetric key
+// managed by the server, and the gateway is expected to serve the following APIs:
+//
+//	POST {endpoint}/v1/keys/{key-id}/encrypt {"plaintext": base64} -> {"ciphertext": base64}
+//	POST {endpoint}/v1/keys/{key-id}/decrypt {"ciphertext": base64} -> {"plaintext": base64}
+//	POST {endpoint}/v1/keys/{key-id}/rotate
+//
+// The ciphertext should identify the version of the key, so it can be decrypted after rotation.
+// The connection is usually protected by mutual TLS, and an optional bearer token is supported.
+type kmipHTTPKMSProvider struct {
+	client   kmsHTTPClient
+	endpoint string
+	keyID    string
+}
+
+func validateKMIPHTTPKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	u, err := url.Parse(config.Endpoint)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"invalid KMIP_HTTP endpoint %q, should be the URL of the KMIP HTTP gateway", config.Endpoint)
+	}
+	return nil
+}
+
+func newKMIPHTTPKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	client, err := newKMSHTTPClient(os.Getenv(envKMIPHTTPCACert), os.Getenv(envKMIPHTTPClientCert), os.Getenv(envKMIPHTTPClientKey))
+	if err != nil {
+		return nil, err
+	}
+	p := &kmipHTTPKMSProvider{
+		client:   kmsHTTPClient{client: client},
+		endpoint: strings.TrimRight(config.Endpoint, "/"),
+		keyID:    config.KeyId,
+	}
+	if token := os.Getenv(envKMIPHTTPToken); len(token) > 0 {
+		p.client.setAuth = bearerTokenAuth(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	}
+	return p, nil
+}
+
+func (p *kmipHTTPKMSProvider) url(op string) string {
+	return p.endpoint + "/v1/keys/" + url.PathEscape(p.keyID) + "/" + op
+}
+
+// Encrypt implements KMSProvider.
+func (p *kmipHTTPKMSProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url("encrypt"), req, &resp); err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(resp.Ciphertext)
+	if err != nil || len(ciphertext) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("invalid ciphertext returned by the KMIP HTTP gateway")
+	}
+	return ciphertext, nil
+}
+
+// Decrypt implements KMSProvider.
+func (p *kmipHTTPKMSProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	req := map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url("decrypt"), req, &resp); err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid plaintext returned by the KMIP HTTP gateway")
+	}
+	return plaintext, nil
+}
+
+// Rotate implements KMSProvider.
+func (p *kmipHTTPKMSProvider) Rotate(ctx context.Context) error {
+	return p.client.do(ctx, http.MethodPost, p.url("rotate"), nil, nil)
+}
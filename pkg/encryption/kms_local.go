@@ -0,0 +1,187 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// kmsVendorLocal is a fake KMS backed by a local file. It is NOT secure since the keys are
+	// stored in plaintext, and is only used to test the KMS related logic offline.
+	kmsVendorLocal = "LOCAL"
+
+	localKMSVersionLength = 4 // in bytes
+)
+
+// localKMSMu serializes the access to the key files of the local KMS.
+var localKMSMu syncutil.Mutex
+
+// localKMSKeys is the content of the key file of the local KMS.
+type localKMSKeys struct {
+	// Primary is the version of the key used to encrypt.
+	Primary uint32 `json:"primary"`
+	// Keys is the mapping from the version to the hex encoded key.
+	Keys map[string]string `json:"keys"`
+}
+
+// localKMSProvider is a file backed fake KMS. The key id is the path of the key file, which is
+// created with a random key on the first use. The ciphertext is the version of the key followed
+// by the IV and the AES-GCM ciphertext.
+type localKMSProvider struct {
+	path string
+}
+
+// IsLocalKMSMasterKey returns whether the master key is backed by the local KMS, whose key file
+// may only exist on the current member.
+func IsLocalKMSMasterKey(meta *encryptionpb.MasterKey) bool {
+	return meta.GetKms().GetVendor() == kmsVendorLocal
+}
+
+func validateLocalKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	if !filepath.IsAbs(config.KeyId) {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"invalid local KMS key id %s, should be an absolute file path", config.KeyId)
+	}
+	return nil
+}
+
+func newLocalKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	return &localKMSProvider{path: config.KeyId}, nil
+}
+
+// load reads the key file, and creates it if it doesn't exist and create is true.
+// The caller should hold localKMSMu.
+func (p *localKMSProvider) load(create bool) (*localKMSKeys, error) {
+	data, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) && create {
+		keys := &localKMSKeys{Keys: make(map[string]string)}
+		if err := p.addVersion(keys); err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to read the local KMS key file %s", p.path)
+	}
+	keys := &localKMSKeys{}
+	if err := json.Unmarshal(data, keys); err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to parse the local KMS key file %s", p.path)
+	}
+	if keys.Keys == nil {
+		keys.Keys = make(map[string]string)
+	}
+	return keys, nil
+}
+
+// addVersion adds a new random key as the primary one, and saves the key file.
+// The caller should hold localKMSMu.
+func (p *localKMSProvider) addVersion(keys *localKMSKeys) error {
+	key := make([]byte, masterKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to generate the local KMS key")
+	}
+	keys.Primary++
+	keys.Keys[strconv.FormatUint(uint64(keys.Primary), 10)] = hex.EncodeToString(key)
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to marshal the local KMS keys")
+	}
+	// Write to a temporary file first so the key file is never partially written.
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to write the local KMS key file %s", tmp)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return errs.ErrEncryptionKMS.Wrap(err).GenWithStack("fail to write the local KMS key file %s", p.path)
+	}
+	return nil
+}
+
+func (keys *localKMSKeys) get(version uint32) ([]byte, error) {
+	v, ok := keys.Keys[strconv.FormatUint(uint64(version), 10)]
+	if !ok {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("local KMS key version %d not found", version)
+	}
+	key, err := hex.DecodeString(v)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid local KMS key version %d", version)
+	}
+	return key, nil
+}
+
+// Encrypt implements KMSProvider.
+func (p *localKMSProvider) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	localKMSMu.Lock()
+	defer localKMSMu.Unlock()
+	keys, err := p.load(true)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.get(keys.Primary)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, iv, err := AesGcmEncrypt(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, localKMSVersionLength, localKMSVersionLength+len(iv)+len(ciphertext))
+	binary.BigEndian.PutUint32(res, keys.Primary)
+	res = append(res, iv...)
+	return append(res, ciphertext...), nil
+}
+
+// Decrypt implements KMSProvider.
+func (p *localKMSProvider) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < localKMSVersionLength+ivLengthGCM {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("invalid local KMS ciphertext length %d", len(ciphertext))
+	}
+	localKMSMu.Lock()
+	defer localKMSMu.Unlock()
+	keys, err := p.load(false)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.get(binary.BigEndian.Uint32(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+	iv := ciphertext[localKMSVersionLength : localKMSVersionLength+ivLengthGCM]
+	return AesGcmDecrypt(key, ciphertext[localKMSVersionLength+ivLengthGCM:], iv)
+}
+
+// Rotate implements KMSProvider.
+func (p *localKMSProvider) Rotate(context.Context) error {
+	localKMSMu.Lock()
+	defer localKMSMu.Unlock()
+	keys, err := p.load(true)
+	if err != nil {
+		return err
+	}
+	return p.addVersion(keys)
+}
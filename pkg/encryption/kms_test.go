@@ -0,0 +1,418 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+)
+
+// newTestLocalKMS creates a local KMS used by the fake KMS servers to do the real encryption.
+func newTestLocalKMS(t *testing.T) *localKMSProvider {
+	return &localKMSProvider{path: filepath.Join(t.TempDir(), "kms.json")}
+}
+
+func readTestKMSRequest(re *require.Assertions, r *http.Request) map[string]string {
+	req := make(map[string]string)
+	re.NoError(json.NewDecoder(r.Body).Decode(&req))
+	return req
+}
+
+func writeTestKMSResponse(w http.ResponseWriter, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// checkKMSProviderRotation checks the ciphertexts encrypted before the rotation can still be decrypted.
+func checkKMSProviderRotation(re *require.Assertions, provider KMSProvider) {
+	ctx := context.Background()
+	plaintext := []byte("this-is-a-master-key-of-32-bytes")
+	ciphertext1, err := provider.Encrypt(ctx, plaintext)
+	re.NoError(err)
+	re.NotEqual(plaintext, ciphertext1)
+	re.NoError(provider.Rotate(ctx))
+	ciphertext2, err := provider.Encrypt(ctx, plaintext)
+	re.NoError(err)
+	re.NotEqual(ciphertext1, ciphertext2)
+	for _, ciphertext := range [][]byte{ciphertext1, ciphertext2} {
+		decrypted, err := provider.Decrypt(ctx, ciphertext)
+		re.NoError(err)
+		re.Equal(plaintext, decrypted)
+	}
+	_, err = provider.Decrypt(ctx, []byte("invalid"))
+	re.Error(err)
+}
+
+func TestLocalKMSProvider(t *testing.T) {
+	re := require.New(t)
+	ctx := context.Background()
+	local := newTestLocalKMS(t)
+	// Decrypt before the key file is created.
+	_, err := local.Decrypt(ctx, make([]byte, 64))
+	re.Error(err)
+	checkKMSProviderRotation(re, local)
+	ciphertext, err := local.Encrypt(ctx, []byte("plaintext"))
+	re.NoError(err)
+	re.Equal(uint32(2), binary.BigEndian.Uint32(ciphertext))
+
+	// Another provider of the same file shares the keys.
+	provider, err := NewKMSProvider(&encryptionpb.MasterKeyKms{Vendor: "local", KeyId: local.path})
+	re.NoError(err)
+	plaintext, err := provider.Decrypt(ctx, ciphertext)
+	re.NoError(err)
+	re.Equal("plaintext", string(plaintext))
+}
+
+func TestNewMasterKeyFromKMS(t *testing.T) {
+	re := require.New(t)
+	config := &encryptionpb.MasterKeyKms{
+		Vendor: kmsVendorLocal,
+		KeyId:  filepath.Join(t.TempDir(), "kms.json"),
+	}
+	// Generate a new master key.
+	masterKey, err := newMasterKeyFromKMS(config, nil)
+	re.NoError(err)
+	re.Len(masterKey.key, masterKeyLength)
+	re.NotEmpty(masterKey.CiphertextKey())
+	// Recover the master key from the ciphertext after the KMS key is rotated.
+	provider, err := NewKMSProvider(config)
+	re.NoError(err)
+	re.NoError(provider.Rotate(context.Background()))
+	masterKey2, err := newMasterKeyFromKMS(config, masterKey.CiphertextKey())
+	re.NoError(err)
+	re.Equal(masterKey.key, masterKey2.key)
+	// The decrypted key with unexpected length is rejected.
+	ciphertext, err := provider.Encrypt(context.Background(), []byte("short"))
+	re.NoError(err)
+	_, err = newMasterKeyFromKMS(config, ciphertext)
+	re.Error(err)
+	// Unknown vendor.
+	_, err = newMasterKeyFromKMS(&encryptionpb.MasterKeyKms{Vendor: "unknown", KeyId: "k"}, nil)
+	re.Error(err)
+}
+
+func TestRegisterKMSProvider(t *testing.T) {
+	re := require.New(t)
+	path := filepath.Join(t.TempDir(), "kms.json")
+	built := 0
+	RegisterKMSProvider("test", func(config *encryptionpb.MasterKeyKms) error {
+		if config.Region != "test" {
+			return fmt.Errorf("invalid region %s", config.Region)
+		}
+		return nil
+	}, func(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+		built++
+		return &localKMSProvider{path: config.KeyId}, nil
+	})
+	re.Contains(registeredKMSVendors(), "TEST")
+	config := &encryptionpb.MasterKeyKms{Vendor: "Test", KeyId: path}
+	re.Error(validateKMSConfig(config))
+	config.Region = "test"
+	re.NoError(validateKMSConfig(config))
+	masterKey, err := newMasterKeyFromKMS(config, nil)
+	re.NoError(err)
+	re.Len(masterKey.key, masterKeyLength)
+	re.Equal(1, built)
+}
+
+// testDataKeyKMSProvider is a KMS provider that generates the data keys by itself.
+type testDataKeyKMSProvider struct {
+	*localKMSProvider
+	generated int
+	length    int
+}
+
+func (p *testDataKeyKMSProvider) GenerateDataKey(ctx context.Context, length int) ([]byte, []byte, error) {
+	p.generated++
+	key := make([]byte, p.length)
+	ciphertext, err := p.Encrypt(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, ciphertext, nil
+}
+
+func TestNewMasterKeyFromDataKeyGenerator(t *testing.T) {
+	re := require.New(t)
+	provider := &testDataKeyKMSProvider{
+		localKMSProvider: newTestLocalKMS(t),
+		length:           masterKeyLength,
+	}
+	RegisterKMSProvider("data-key", func(*encryptionpb.MasterKeyKms) error {
+		return nil
+	}, func(*encryptionpb.MasterKeyKms) (KMSProvider, error) {
+		return provider, nil
+	})
+	config := &encryptionpb.MasterKeyKms{Vendor: "data-key", KeyId: "k"}
+	masterKey, err := newMasterKeyFromKMS(config, nil)
+	re.NoError(err)
+	re.Equal(1, provider.generated)
+	re.Equal(make([]byte, masterKeyLength), masterKey.key)
+	// The master key is decrypted instead of generated when the ciphertext is given.
+	masterKey2, err := newMasterKeyFromKMS(config, masterKey.CiphertextKey())
+	re.NoError(err)
+	re.Equal(1, provider.generated)
+	re.Equal(masterKey.key, masterKey2.key)
+	// The data key with unexpected length is rejected.
+	provider.length = masterKeyLength / 2
+	_, err = newMasterKeyFromKMS(config, nil)
+	re.Error(err)
+}
+
+func TestVaultKMSProvider(t *testing.T) {
+	re := require.New(t)
+	local := newTestLocalKMS(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.Header.Get("X-Vault-Namespace") != "ns" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		ctx := r.Context()
+		switch r.URL.Path {
+		case "/v1/team/transit/encrypt/pd":
+			plaintext, err := base64.StdEncoding.DecodeString(readTestKMSRequest(re, r)["plaintext"])
+			re.NoError(err)
+			ciphertext, err := local.Encrypt(ctx, plaintext)
+			re.NoError(err)
+			writeTestKMSResponse(w, map[string]any{"data": map[string]string{
+				"ciphertext": "vault:v1:" + base64.StdEncoding.EncodeToString(ciphertext),
+			}})
+		case "/v1/team/transit/decrypt/pd":
+			ciphertext, err := base64.StdEncoding.DecodeString(
+				strings.TrimPrefix(readTestKMSRequest(re, r)["ciphertext"], "vault:v1:"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			plaintext, err := local.Decrypt(ctx, ciphertext)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			writeTestKMSResponse(w, map[string]any{"data": map[string]string{
+				"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+			}})
+		case "/v1/team/transit/keys/pd/rotate":
+			re.NoError(local.Rotate(ctx))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(envVaultAddr, "")
+	t.Setenv(envVaultToken, "")
+	config := &encryptionpb.MasterKeyKms{Vendor: kmsVendorVault, KeyId: "team/transit/pd"}
+	re.Error(validateKMSConfig(config))
+	t.Setenv(envVaultAddr, server.URL)
+	re.NoError(validateKMSConfig(config))
+	_, err := NewKMSProvider(config)
+	re.Error(err)
+	t.Setenv(envVaultToken, "token")
+	t.Setenv(envVaultNamespace, "ns")
+	provider, err := NewKMSProvider(config)
+	re.NoError(err)
+	checkKMSProviderRotation(re, provider)
+
+	mount, key := parseVaultKeyID("pd")
+	re.Equal(defaultVaultTransitMount, mount)
+	re.Equal("pd", key)
+}
+
+func TestGCPKMSProvider(t *testing.T) {
+	re := require.New(t)
+	local := newTestLocalKMS(t)
+	name := "projects/p/locations/global/keyRings/r/cryptoKeys/k"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ctx := r.Context()
+		switch r.URL.Path {
+		case "/v1/" + name + ":encrypt":
+			plaintext, err := base64.StdEncoding.DecodeString(readTestKMSRequest(re, r)["plaintext"])
+			re.NoError(err)
+			ciphertext, err := local.Encrypt(ctx, plaintext)
+			re.NoError(err)
+			writeTestKMSResponse(w, map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)})
+		case "/v1/" + name + ":decrypt":
+			ciphertext, err := base64.StdEncoding.DecodeString(readTestKMSRequest(re, r)["ciphertext"])
+			re.NoError(err)
+			plaintext, err := local.Decrypt(ctx, ciphertext)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			writeTestKMSResponse(w, map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)})
+		case "/v1/" + name + "/cryptoKeyVersions":
+			writeTestKMSResponse(w, map[string]string{"name": name + "/cryptoKeyVersions/2"})
+		case "/v1/" + name + ":updatePrimaryVersion":
+			re.Equal("2", readTestKMSRequest(re, r)["cryptoKeyVersionId"])
+			re.NoError(local.Rotate(ctx))
+			writeTestKMSResponse(w, map[string]string{"name": name})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	re.Error(validateKMSConfig(&encryptionpb.MasterKeyKms{Vendor: kmsVendorGCP, KeyId: "k"}))
+	config := &encryptionpb.MasterKeyKms{Vendor: kmsVendorGCP, KeyId: name, Endpoint: server.URL}
+	re.NoError(validateKMSConfig(config))
+	t.Setenv(envGoogleAccessToken, "")
+	t.Setenv(envGoogleCredentials, "")
+	_, err := NewKMSProvider(config)
+	re.Error(err)
+	t.Setenv(envGoogleAccessToken, "token")
+	provider, err := NewKMSProvider(config)
+	re.NoError(err)
+	checkKMSProviderRotation(re, provider)
+}
+
+func TestAzureKMSProvider(t *testing.T) {
+	re := require.New(t)
+	local := newTestLocalKMS(t)
+	var version atomic.Int32
+	version.Store(1)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tenant/oauth2/v2.0/token" {
+			re.NoError(r.ParseForm())
+			re.Equal("client", r.Form.Get("client_id"))
+			re.Equal("secret", r.Form.Get("client_secret"))
+			re.Equal(azureKeyVaultScope, r.Form.Get("scope"))
+			writeTestKMSResponse(w, map[string]any{"access_token": "token", "token_type": "Bearer", "expires_in": 3600})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		re.Equal(azureKeyVaultAPI, r.URL.Query().Get("api-version"))
+		ctx := r.Context()
+		kid := fmt.Sprintf("%s/keys/pd/v%d", server.URL, version.Load())
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/keys/pd":
+			writeTestKMSResponse(w, map[string]any{"key": map[string]string{"kid": kid}})
+		case r.URL.Path == fmt.Sprintf("/keys/pd/v%d/wrapkey", version.Load()):
+			req := readTestKMSRequest(re, r)
+			re.Equal(azureWrapAlgorithm, req["alg"])
+			plaintext, err := base64.RawURLEncoding.DecodeString(req["value"])
+			re.NoError(err)
+			ciphertext, err := local.Encrypt(ctx, plaintext)
+			re.NoError(err)
+			writeTestKMSResponse(w, map[string]string{"kid": kid, "value": base64.RawURLEncoding.EncodeToString(ciphertext)})
+		case strings.HasPrefix(r.URL.Path, "/keys/pd/v") && strings.HasSuffix(r.URL.Path, "/unwrapkey"):
+			ciphertext, err := base64.RawURLEncoding.DecodeString(readTestKMSRequest(re, r)["value"])
+			re.NoError(err)
+			plaintext, err := local.Decrypt(ctx, ciphertext)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			writeTestKMSResponse(w, map[string]string{"value": base64.RawURLEncoding.EncodeToString(plaintext)})
+		case r.URL.Path == "/keys/pd/rotate":
+			re.NoError(local.Rotate(ctx))
+			version.Add(1)
+			writeTestKMSResponse(w, map[string]any{"key": map[string]string{"kid": kid}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	re.Error(validateKMSConfig(&encryptionpb.MasterKeyKms{Vendor: kmsVendorAzure, KeyId: "pd"}))
+	re.Error(validateKMSConfig(&encryptionpb.MasterKeyKms{Vendor: kmsVendorAzure, KeyId: "keys/pd", Endpoint: server.URL}))
+	config := &encryptionpb.MasterKeyKms{Vendor: kmsVendorAzure, KeyId: "pd", Endpoint: server.URL}
+	re.NoError(validateKMSConfig(config))
+	t.Setenv(envAzureTenantID, "tenant")
+	t.Setenv(envAzureClientID, "client")
+	t.Setenv(envAzureClientSecret, "")
+	_, err := NewKMSProvider(config)
+	re.Error(err)
+	t.Setenv(envAzureClientSecret, "secret")
+	t.Setenv(envAzureAuthority, server.URL)
+	provider, err := NewKMSProvider(config)
+	re.NoError(err)
+	checkKMSProviderRotation(re, provider)
+
+	// The key outside the vault is rejected.
+	ciphertext, err := json.Marshal(azureCiphertext{KeyID: "https://other/keys/pd/v1", Value: "v"})
+	re.NoError(err)
+	_, err = provider.Decrypt(context.Background(), ciphertext)
+	re.Error(err)
+}
+
+func TestKMIPHTTPKMSProvider(t *testing.T) {
+	re := require.New(t)
+	local := newTestLocalKMS(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ctx := r.Context()
+		switch r.URL.Path {
+		case "/v1/keys/key-1/encrypt":
+			plaintext, err := base64.StdEncoding.DecodeString(readTestKMSRequest(re, r)["plaintext"])
+			re.NoError(err)
+			ciphertext, err := local.Encrypt(ctx, plaintext)
+			re.NoError(err)
+			writeTestKMSResponse(w, map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)})
+		case "/v1/keys/key-1/decrypt":
+			ciphertext, err := base64.StdEncoding.DecodeString(readTestKMSRequest(re, r)["ciphertext"])
+			re.NoError(err)
+			plaintext, err := local.Decrypt(ctx, ciphertext)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			writeTestKMSResponse(w, map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)})
+		case "/v1/keys/key-1/rotate":
+			re.NoError(local.Rotate(ctx))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	re.Error(validateKMSConfig(&encryptionpb.MasterKeyKms{Vendor: kmsVendorKMIPHTTP, KeyId: "key-1"}))
+	config := &encryptionpb.MasterKeyKms{Vendor: kmsVendorKMIPHTTP, KeyId: "key-1", Endpoint: server.URL}
+	re.NoError(validateKMSConfig(config))
+	t.Setenv(envKMIPHTTPToken, "token")
+	provider, err := NewKMSProvider(config)
+	re.NoError(err)
+	checkKMSProviderRotation(re, provider)
+
+	// The client certificate must be valid.
+	t.Setenv(envKMIPHTTPClientCert, filepath.Join(t.TempDir(), "cert.pem"))
+	_, err = NewKMSProvider(config)
+	re.Error(err)
+}
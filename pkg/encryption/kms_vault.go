@@ -0,0 +1,151 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+const (
+	kmsVendorVault = "VAULT"
+
+	// Vault related environment variables, which are the same as the ones used by the Vault CLI.
+	envVaultAddr = "VAULT_ADDR"
+	// #nosec
+	envVaultToken      = "VAULT_TOKEN"
+	envVaultNamespace  = "VAULT_NAMESPACE"
+	envVaultCACert     = "VAULT_CACERT"
+	envVaultClientCert = "VAULT_CLIENT_CERT"
+	envVaultClientKey  = "VAULT_CLIENT_KEY"
+
+	defaultVaultTransitMount = "transit"
+)
+
+// vaultKMSProvider uses the transit secrets engine of HashiCorp Vault. The key id is the name
+// of the transit key, optionally prefixed by the mount path of the engine, e.g. "transit/pd".
+type vaultKMSProvider struct {
+	client    kmsHTTPClient
+	addr      string
+	mount     string
+	key       string
+	namespace string
+}
+
+func getVaultAddr(config *encryptionpb.MasterKeyKms) string {
+	if len(config.Endpoint) > 0 {
+		return config.Endpoint
+	}
+	return os.Getenv(envVaultAddr)
+}
+
+func parseVaultKeyID(keyID string) (mount, key string) {
+	keyID = strings.Trim(keyID, "/")
+	if i := strings.LastIndex(keyID, "/"); i >= 0 {
+		return keyID[:i], keyID[i+1:]
+	}
+	return defaultVaultTransitMount, keyID
+}
+
+func validateVaultKMSConfig(config *encryptionpb.MasterKeyKms) error {
+	if len(getVaultAddr(config)) == 0 {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack(
+			"missing Vault address, set the KMS endpoint or the %s environment variable", envVaultAddr)
+	}
+	if _, key := parseVaultKeyID(config.KeyId); len(key) == 0 {
+		return errs.ErrEncryptionInvalidConfig.GenWithStack("invalid Vault transit key id %s", config.KeyId)
+	}
+	return nil
+}
+
+func newVaultKMSProvider(config *encryptionpb.MasterKeyKms) (KMSProvider, error) {
+	token := os.Getenv(envVaultToken)
+	if len(token) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("missing Vault token, set the %s environment variable", envVaultToken)
+	}
+	client, err := newKMSHTTPClient(os.Getenv(envVaultCACert), os.Getenv(envVaultClientCert), os.Getenv(envVaultClientKey))
+	if err != nil {
+		return nil, err
+	}
+	p := &vaultKMSProvider{
+		addr:      strings.TrimRight(getVaultAddr(config), "/"),
+		namespace: os.Getenv(envVaultNamespace),
+	}
+	p.mount, p.key = parseVaultKeyID(config.KeyId)
+	p.client = kmsHTTPClient{
+		client: client,
+		setAuth: func(req *http.Request) error {
+			req.Header.Set("X-Vault-Token", token)
+			if len(p.namespace) > 0 {
+				req.Header.Set("X-Vault-Namespace", p.namespace)
+			}
+			return nil
+		},
+	}
+	return p, nil
+}
+
+func (p *vaultKMSProvider) url(format string, args ...any) string {
+	return p.addr + "/v1/" + p.mount + fmt.Sprintf(format, args...)
+}
+
+// Encrypt implements KMSProvider. The ciphertext is the one returned by Vault, like "vault:v1:...",
+// which carries the version of the key.
+func (p *vaultKMSProvider) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url("/encrypt/%s", p.key), req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data.Ciphertext) == 0 {
+		return nil, errs.ErrEncryptionKMS.GenWithStack("empty ciphertext returned by Vault")
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// Decrypt implements KMSProvider.
+func (p *vaultKMSProvider) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	req := map[string]string{"ciphertext": string(ciphertext)}
+	if err := p.client.do(ctx, http.MethodPost, p.url("/decrypt/%s", p.key), req, &resp); err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, errs.ErrEncryptionKMS.Wrap(err).GenWithStack("invalid plaintext returned by Vault")
+	}
+	return plaintext, nil
+}
+
+// Rotate implements KMSProvider.
+func (p *vaultKMSProvider) Rotate(ctx context.Context) error {
+	return p.client.do(ctx, http.MethodPost, p.url("/keys/%s/rotate", p.key), nil, nil)
+}
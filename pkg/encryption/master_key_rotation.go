@@ -0,0 +1,350 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+)
+
+const (
+	// EncryptionMasterKeyRotationPath is the path to store the state of the online master key rotation in etcd.
+	EncryptionMasterKeyRotationPath = "encryption_master_key_rotation"
+	// EncryptionMasterKeyMembersPath is the path prefix to store the master key loaded by each PD member in etcd.
+	EncryptionMasterKeyMembersPath = "encryption_master_key_members"
+
+	// MasterKeyRotated means the encryption keys are re-encrypted with a new master key.
+	MasterKeyRotated = "rotated"
+	// MasterKeyRolledBack means the encryption keys are re-encrypted with the master key before the last rotation.
+	MasterKeyRolledBack = "rolled-back"
+
+	// masterKeyVersionLength is the length of the master key version in bytes.
+	masterKeyVersionLength = 8
+)
+
+// masterKeyRotationRecord is the state of the online master key rotation persisted in etcd.
+type masterKeyRotationRecord struct {
+	MasterKeyRotation
+	// MasterKey is the marshaled metadata of the master key after the rotation.
+	MasterKey []byte `json:"master_key"`
+	// PreviousMasterKey is the marshaled metadata of the master key before the rotation.
+	PreviousMasterKey []byte `json:"previous_master_key"`
+}
+
+// MasterKeyRotation is the state of the last online master key rotation.
+type MasterKeyRotation struct {
+	// ID increases by one for each rotation or rollback.
+	ID    uint64 `json:"id"`
+	State string `json:"state"`
+	// Version is the version of the master key after the rotation.
+	Version string `json:"version"`
+	// PreviousVersion is the version of the master key before the rotation, which is used to roll back.
+	PreviousVersion string `json:"previous_version"`
+	// ConfiguredVersion is the version of the master key in the config of the PD leader doing the rotation.
+	// The rotated master key takes precedence over the configured one until the config is changed.
+	ConfiguredVersion string    `json:"configured_version"`
+	UpdateTime        time.Time `json:"update_time"`
+}
+
+// MasterKeyMemberStatus is the version of the master key loaded by a PD member.
+type MasterKeyMemberStatus struct {
+	Name string `json:"name"`
+	// Version is empty if the member has not reported yet.
+	Version    string    `json:"version"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// MasterKeyRotationStatus is the progress of the online master key rotation.
+type MasterKeyRotationStatus struct {
+	// Version is the version of the master key protecting the encryption keys stored in etcd.
+	Version string `json:"version"`
+	// Rotation is the last rotation, nil if the master key has never been rotated online.
+	Rotation *MasterKeyRotation       `json:"rotation,omitempty"`
+	Members  []*MasterKeyMemberStatus `json:"members"`
+	// Loaded is the number of the members which have loaded the current master key.
+	Loaded int `json:"loaded"`
+	// Completed is true if all the members have loaded the current master key.
+	Completed bool `json:"completed"`
+}
+
+// MasterKeyVersion returns the version of the master key, which is the digest of its metadata.
+// The version is empty if the metadata is nil.
+func MasterKeyVersion(meta *encryptionpb.MasterKey) string {
+	if meta == nil {
+		return ""
+	}
+	data, err := proto.Marshal(meta)
+	if err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:masterKeyVersionLength])
+}
+
+func masterKeyMemberPath(name string) string {
+	return path.Join(EncryptionMasterKeyMembersPath, name)
+}
+
+// loadMasterKeyRotation loads the state of the online master key rotation, nil if there is no rotation.
+func (m *Manager) loadMasterKeyRotation() (*masterKeyRotationRecord, error) {
+	resp, err := etcdutil.EtcdKVGet(m.etcdClient, EncryptionMasterKeyRotationPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	record := &masterKeyRotationRecord{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, record); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return record, nil
+}
+
+// getMasterKeyMetaImpl returns the master key used to save the encryption keys. The master key
+// rotated online takes precedence over the configured one, unless the config has been changed
+// since the rotation.
+// Require mu lock to be held.
+func (m *Manager) getMasterKeyMetaImpl() (*encryptionpb.MasterKey, error) {
+	record, err := m.loadMasterKeyRotation()
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.ConfiguredVersion != MasterKeyVersion(m.masterKeyMeta) {
+		return m.masterKeyMeta, nil
+	}
+	meta := &encryptionpb.MasterKey{}
+	if err := meta.Unmarshal(record.MasterKey); err != nil {
+		return nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack("fail to unmarshal the rotated master key")
+	}
+	return meta, nil
+}
+
+// RotateMasterKey re-encrypts the encryption keys with the new master key online. The encryption
+// keys and the rotation state are updated in a single etcd transaction, and the other PD members
+// load the re-encrypted keys by the watcher. Only the PD leader can rotate the master key.
+func (m *Manager) RotateMasterKey(meta *encryptionpb.MasterKey) error {
+	if meta == nil || meta.GetPlaintext() != nil {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("the new master key should not be plaintext")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rewrapKeysImpl(meta, MasterKeyRotated)
+}
+
+// RollbackMasterKey re-encrypts the encryption keys with the master key before the last rotation.
+func (m *Manager) RollbackMasterKey() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record, err := m.loadMasterKeyRotation()
+	if err != nil {
+		return err
+	}
+	if record == nil || len(record.PreviousMasterKey) == 0 {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("no master key rotation to roll back")
+	}
+	if _, err := m.loadKeysImpl(); err != nil {
+		return err
+	}
+	if version := MasterKeyVersion(m.mu.loadedMasterKeyMeta); version != record.Version {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack(
+			"the master key %s is not the one %s of the last rotation", version, record.Version)
+	}
+	meta := &encryptionpb.MasterKey{}
+	if err := meta.Unmarshal(record.PreviousMasterKey); err != nil {
+		return errs.ErrProtoUnmarshal.Wrap(err).GenWithStack("fail to unmarshal the previous master key")
+	}
+	return m.rewrapKeysImpl(meta, MasterKeyRolledBack)
+}
+
+// rewrapKeysImpl re-encrypts the encryption keys with the given master key.
+// Require mu lock to be held.
+func (m *Manager) rewrapKeysImpl(meta *encryptionpb.MasterKey, state string) error {
+	leadership := m.mu.leadership
+	if leadership == nil || !leadership.Check() {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("not leader")
+	}
+	keys, err := m.loadKeysImpl()
+	if err != nil {
+		return err
+	}
+	if keys == nil {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("encryption keys not found, encryption is not enabled")
+	}
+	previous := m.mu.loadedMasterKeyMeta
+	version, previousVersion := MasterKeyVersion(meta), MasterKeyVersion(previous)
+	if version == previousVersion {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("the master key %s is in use already", version)
+	}
+	record, err := m.loadMasterKeyRotation()
+	if err != nil {
+		return err
+	}
+	newRecord := &masterKeyRotationRecord{
+		MasterKeyRotation: MasterKeyRotation{
+			ID:                1,
+			State:             state,
+			Version:           version,
+			PreviousVersion:   previousVersion,
+			ConfiguredVersion: MasterKeyVersion(m.masterKeyMeta),
+			UpdateTime:        m.helper.now(),
+		},
+	}
+	if record != nil {
+		newRecord.ID = record.ID + 1
+	}
+	if newRecord.MasterKey, err = proto.Marshal(meta); err != nil {
+		return errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal the master key")
+	}
+	if newRecord.PreviousMasterKey, err = proto.Marshal(previous); err != nil {
+		return errs.ErrProtoMarshal.Wrap(err).GenWithStack("fail to marshal the previous master key")
+	}
+	recordValue, err := json.Marshal(newRecord)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	// Decrypting the keys with the new master key makes sure the master key works before saving.
+	keysValue, err := encryptKeys(meta, proto.Clone(keys).(*encryptionpb.KeyDictionary), m.helper)
+	if err != nil {
+		return err
+	}
+	if _, err := extractKeysFromKV(&mvccpb.KeyValue{Value: []byte(keysValue)}, m.helper); err != nil {
+		return err
+	}
+	// The keys must not be changed since they are loaded, otherwise the change is lost.
+	resp, err := leadership.LeaderTxn(
+		clientv3.Compare(clientv3.ModRevision(EncryptionKeysPath), "=", m.mu.keysRevision),
+	).Then(
+		clientv3.OpPut(EncryptionKeysPath, keysValue),
+		clientv3.OpPut(EncryptionMasterKeyRotationPath, string(recordValue)),
+	).Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStack("fail to save re-encrypted encryption keys")
+	}
+	if !resp.Succeeded {
+		return errs.ErrEncryptionRotateMasterKey.GenWithStack("leader expired or encryption keys changed concurrently")
+	}
+	log.Info("re-encrypted encryption keys with the new master key",
+		zap.String("state", state), zap.Uint64("id", newRecord.ID),
+		zap.String("version", version), zap.String("previous-version", previousVersion))
+	// Reload keys.
+	_, err = m.loadKeysImpl()
+	return err
+}
+
+// SetMemberName sets the name of the current PD member, which is used to report the version
+// of the loaded master key.
+func (m *Manager) SetMemberName(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mu.memberName = name
+	m.reportLoadedMasterKeyImpl()
+}
+
+// reportLoadedMasterKeyImpl saves the version of the master key loaded by the current member.
+// Require mu lock to be held.
+func (m *Manager) reportLoadedMasterKeyImpl() {
+	if len(m.mu.memberName) == 0 || m.mu.loadedMasterKeyMeta == nil {
+		return
+	}
+	status := &MasterKeyMemberStatus{
+		Name:       m.mu.memberName,
+		Version:    MasterKeyVersion(m.mu.loadedMasterKeyMeta),
+		UpdateTime: m.helper.now(),
+	}
+	value, err := json.Marshal(status)
+	if err != nil {
+		log.Warn("fail to marshal the loaded master key", errs.ZapError(errs.ErrJSONMarshal, err))
+		return
+	}
+	if _, err := kv.NewSlowLogTxn(m.etcdClient).
+		Then(clientv3.OpPut(masterKeyMemberPath(status.Name), string(value))).
+		Commit(); err != nil {
+		log.Warn("fail to report the loaded master key", zap.String("version", status.Version), errs.ZapError(err))
+	}
+}
+
+// GetMasterKeyRotationStatus returns the progress of the online master key rotation, including
+// the version of the master key loaded by each member. Only the given members are reported if
+// members is not empty, so the removed members are ignored.
+func (m *Manager) GetMasterKeyRotationStatus(members []string) (*MasterKeyRotationStatus, error) {
+	status := &MasterKeyRotationStatus{}
+	resp, err := etcdutil.EtcdKVGet(m.etcdClient, EncryptionKeysPath)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil && len(resp.Kvs) > 0 {
+		content := &encryptionpb.EncryptedContent{}
+		if err := content.Unmarshal(resp.Kvs[0].Value); err != nil {
+			return nil, errs.ErrProtoUnmarshal.Wrap(err).GenWithStack("fail to unmarshal encrypted encryption keys")
+		}
+		status.Version = MasterKeyVersion(content.MasterKey)
+	}
+	record, err := m.loadMasterKeyRotation()
+	if err != nil {
+		return nil, err
+	}
+	if record != nil {
+		status.Rotation = &record.MasterKeyRotation
+	}
+
+	resp, err = etcdutil.EtcdKVGet(m.etcdClient, EncryptionMasterKeyMembersPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	reported := make(map[string]*MasterKeyMemberStatus)
+	for _, item := range resp.Kvs {
+		member := &MasterKeyMemberStatus{}
+		if err := json.Unmarshal(item.Value, member); err != nil {
+			log.Warn("fail to unmarshal the loaded master key", zap.String("key", string(item.Key)), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			continue
+		}
+		reported[member.Name] = member
+	}
+	if len(members) == 0 {
+		for name := range reported {
+			members = append(members, name)
+		}
+	}
+	sort.Strings(members)
+	for _, name := range members {
+		member, ok := reported[name]
+		if !ok {
+			member = &MasterKeyMemberStatus{Name: name}
+		}
+		if len(member.Version) > 0 && member.Version == status.Version {
+			status.Loaded++
+		}
+		status.Members = append(status.Members, member)
+	}
+	status.Completed = status.Loaded == len(status.Members)
+	return status, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/testutil"
+)
+
+func TestRotateMasterKey(t *testing.T) {
+	re := require.New(t)
+	// Initialize.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestEtcd(t)
+	keyFile := newTestKeyFile(t, re)
+	keyFile2 := newTestKeyFile(t, re, testMasterKey2)
+	leadership := newTestLeader(re, client)
+	masterKeyMeta := newTestMasterKey(keyFile)
+	masterKeyMeta2 := newTestMasterKey(keyFile2)
+	keys := &encryptionpb.KeyDictionary{
+		CurrentKeyId: 123,
+		Keys: map[uint64]*encryptionpb.DataKey{
+			123: {
+				Key:          getTestDataKey(),
+				Method:       encryptionpb.EncryptionMethod_AES128_CTR,
+				CreationTime: uint64(1601679533),
+				WasExposed:   false,
+			},
+		},
+	}
+	err := saveKeys(leadership, masterKeyMeta, keys, defaultKeyManagerHelper())
+	re.NoError(err)
+	config := &Config{
+		DataEncryptionMethod: "aes128-ctr",
+		MasterKey: MasterKeyConfig{
+			Type: "file",
+			MasterKeyFileConfig: MasterKeyFileConfig{
+				FilePath: keyFile,
+			},
+		},
+	}
+	err = config.Adjust()
+	re.NoError(err)
+	helper := defaultKeyManagerHelper()
+	helper.now = func() time.Time { return time.Unix(int64(1601679533), 0) }
+	// Create the key manager of the leader.
+	m, err := newKeyManagerImpl(client, config, helper)
+	re.NoError(err)
+	m.SetMemberName("pd-1")
+	err = m.SetLeadership(leadership)
+	re.NoError(err)
+	// Create the key manager of the follower, which loads the keys by the watcher.
+	follower, err := newKeyManagerImpl(client, config, defaultKeyManagerHelper())
+	re.NoError(err)
+	follower.SetMemberName("pd-2")
+	go follower.StartBackgroundLoop(ctx)
+	version, version2 := MasterKeyVersion(masterKeyMeta), MasterKeyVersion(masterKeyMeta2)
+	re.NotEqual(version, version2)
+	// The follower reports the loaded master key asynchronously.
+	checkStatus := func(version string) *MasterKeyRotationStatus {
+		var status *MasterKeyRotationStatus
+		testutil.Eventually(re, func() bool {
+			status, err = m.GetMasterKeyRotationStatus([]string{"pd-1", "pd-2", "pd-3"})
+			re.NoError(err)
+			return status.Loaded == 2
+		})
+		re.Equal(version, status.Version)
+		re.Len(status.Members, 3)
+		re.False(status.Completed)
+		return status
+	}
+	checkStoredKeys := func(meta *encryptionpb.MasterKey) {
+		resp, err := etcdutil.EtcdKVGet(client, EncryptionKeysPath)
+		re.NoError(err)
+		checkMasterKeyMeta(re, resp.Kvs[0].Value, meta, nil)
+		storedKeys, err := extractKeysFromKV(resp.Kvs[0], defaultKeyManagerHelper())
+		re.NoError(err)
+		re.True(proto.Equal(keys, storedKeys))
+	}
+	status := checkStatus(version)
+	re.Nil(status.Rotation)
+	re.Equal("pd-3", status.Members[2].Name)
+	re.Empty(status.Members[2].Version)
+	status, err = m.GetMasterKeyRotationStatus(nil)
+	re.NoError(err)
+	re.Len(status.Members, 2)
+	re.True(status.Completed)
+
+	// Only the leader can rotate the master key, and the new master key can't be plaintext.
+	re.Error(follower.RotateMasterKey(masterKeyMeta2))
+	re.Error(m.RotateMasterKey(&encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Plaintext{Plaintext: &encryptionpb.MasterKeyPlaintext{}},
+	}))
+	re.Error(m.RotateMasterKey(masterKeyMeta))
+	re.Error(m.RollbackMasterKey())
+
+	// Rotate the master key.
+	re.NoError(m.RotateMasterKey(masterKeyMeta2))
+	checkStoredKeys(masterKeyMeta2)
+	status = checkStatus(version2)
+	re.Equal(&MasterKeyRotation{
+		ID:                1,
+		State:             MasterKeyRotated,
+		Version:           version2,
+		PreviousVersion:   version,
+		ConfiguredVersion: version,
+		UpdateTime:        time.Unix(int64(1601679533), 0),
+	}, status.Rotation)
+	re.Equal(version2, status.Members[0].Version)
+	re.Equal(version2, status.Members[1].Version)
+	re.True(proto.Equal(keys, follower.getKeys()))
+
+	// The rotated master key takes precedence over the configured one after the leader changes.
+	err = m.SetLeadership(leadership)
+	re.NoError(err)
+	checkStoredKeys(masterKeyMeta2)
+
+	// Roll back the rotation.
+	re.NoError(m.RollbackMasterKey())
+	checkStoredKeys(masterKeyMeta)
+	status = checkStatus(version)
+	re.Equal(uint64(2), status.Rotation.ID)
+	re.Equal(MasterKeyRolledBack, status.Rotation.State)
+	re.Equal(version, status.Rotation.Version)
+	re.Equal(version2, status.Rotation.PreviousVersion)
+}
@@ -113,6 +113,7 @@ var (
 	ErrKeyspaceNotAssigned              = errors.Normalize("the keyspace %d isn't assigned to any keyspace group", errors.RFCCodeText("PD:tso:ErrKeyspaceNotAssigned"))
 	ErrGetMinTS                         = errors.Normalize("get min ts failed, %s", errors.RFCCodeText("PD:tso:ErrGetMinTS"))
 	ErrKeyspaceGroupIsMerging           = errors.Normalize("the keyspace group %d is merging", errors.RFCCodeText("PD:tso:ErrKeyspaceGroupIsMerging"))
+	ErrTSOAudit                         = errors.Normalize("tso audit failed, %s", errors.RFCCodeText("PD:tso:ErrTSOAudit"))
 )
 
 // member errors
@@ -153,6 +154,12 @@ var (
 	ErrOperatorNotFound = errors.Normalize("operator not found", errors.RFCCodeText("PD:operator:ErrOperatorNotFound"))
 	// ErrAddOperator is error info for already have an operator when adding operator.
 	ErrAddOperator = errors.Normalize("failed to add operator, maybe already have one", errors.RFCCodeText("PD:operator:ErrAddOperator"))
+	// ErrOperatorGroupInvalid is error info for invalid operator group.
+	ErrOperatorGroupInvalid = errors.Normalize("invalid operator group, %s", errors.RFCCodeText("PD:operator:ErrOperatorGroupInvalid"))
+	// ErrOperatorGroupNotFound is error info for operator group not found.
+	ErrOperatorGroupNotFound = errors.Normalize("operator group %s not found", errors.RFCCodeText("PD:operator:ErrOperatorGroupNotFound"))
+	// ErrOperatorGroupExists is error info for adding an operator group which is still running.
+	ErrOperatorGroupExists = errors.Normalize("operator group %s already exists", errors.RFCCodeText("PD:operator:ErrOperatorGroupExists"))
 )
 
 // region errors
@@ -175,10 +182,14 @@ var (
 
 // schedule errors
 var (
-	ErrUnexpectedOperatorStatus = errors.Normalize("operator with unexpected status", errors.RFCCodeText("PD:schedule:ErrUnexpectedOperatorStatus"))
-	ErrUnknownOperatorStep      = errors.Normalize("unknown operator step found", errors.RFCCodeText("PD:schedule:ErrUnknownOperatorStep"))
-	ErrMergeOperator            = errors.Normalize("merge operator error, %s", errors.RFCCodeText("PD:schedule:ErrMergeOperator"))
-	ErrCreateOperator           = errors.Normalize("unable to create operator, %s", errors.RFCCodeText("PD:schedule:ErrCreateOperator"))
+	ErrUnexpectedOperatorStatus  = errors.Normalize("operator with unexpected status", errors.RFCCodeText("PD:schedule:ErrUnexpectedOperatorStatus"))
+	ErrUnknownOperatorStep       = errors.Normalize("unknown operator step found", errors.RFCCodeText("PD:schedule:ErrUnknownOperatorStep"))
+	ErrMergeOperator             = errors.Normalize("merge operator error, %s", errors.RFCCodeText("PD:schedule:ErrMergeOperator"))
+	ErrCreateOperator            = errors.Normalize("unable to create operator, %s", errors.RFCCodeText("PD:schedule:ErrCreateOperator"))
+	ErrMaintenanceWindowConfig   = errors.Normalize("invalid maintenance window, %s", errors.RFCCodeText("PD:schedule:ErrMaintenanceWindowConfig"))
+	ErrMaintenanceWindowNotFound = errors.Normalize("maintenance window %s not found", errors.RFCCodeText("PD:schedule:ErrMaintenanceWindowNotFound"))
+	ErrStoreDrainJobNotFound     = errors.Normalize("drain job of store %v not found", errors.RFCCodeText("PD:schedule:ErrStoreDrainJobNotFound"))
+	ErrStoreDrainJobState        = errors.Normalize("drain job of store %v is %v", errors.RFCCodeText("PD:schedule:ErrStoreDrainJobState"))
 )
 
 // scheduler errors
@@ -258,6 +269,18 @@ var (
 	ErrKeyspaceGroupInMerging = errors.Normalize("keyspace group %v is in merging state", errors.RFCCodeText("PD:keyspace:ErrKeyspaceGroupInMerging"))
 	// ErrKeyspaceGroupNotInMerging is used to indicate target keyspace group is not in merging state.
 	ErrKeyspaceGroupNotInMerging = errors.Normalize("keyspace group %v is not in merging state", errors.RFCCodeText("PD:keyspace:ErrKeyspaceGroupNotInMerging"))
+	// ErrKeyspaceGroupRebalance is used to indicate the keyspace groups failed to rebalance.
+	ErrKeyspaceGroupRebalance = errors.Normalize("failed to rebalance keyspace groups, %s", errors.RFCCodeText("PD:keyspace:ErrKeyspaceGroupRebalance"))
+	// ErrKeyspaceDestroyRangeNotFound is used to indicate there is no pending destroy range task of the keyspace.
+	ErrKeyspaceDestroyRangeNotFound = errors.Normalize("destroy range task of keyspace %d does not exist", errors.RFCCodeText("PD:keyspace:ErrKeyspaceDestroyRangeNotFound"))
+	// ErrInvalidKeyspaceQuota is used to indicate the keyspace quota is invalid.
+	ErrInvalidKeyspaceQuota = errors.Normalize("invalid keyspace quota, %s", errors.RFCCodeText("PD:keyspace:ErrInvalidKeyspaceQuota"))
+	// ErrKeyspaceQuotaExceeded is used to indicate the keyspace reaches the hard limit of its quota.
+	ErrKeyspaceQuotaExceeded = errors.Normalize("keyspace %d reaches the hard limit of %s", errors.RFCCodeText("PD:keyspace:ErrKeyspaceQuotaExceeded"))
+	// ErrInvalidKeyspaceTemplate is used to indicate the keyspace template is invalid.
+	ErrInvalidKeyspaceTemplate = errors.Normalize("invalid keyspace template, %s", errors.RFCCodeText("PD:keyspace:ErrInvalidKeyspaceTemplate"))
+	// ErrKeyspaceTemplateNotFound is used to indicate the keyspace template does not exist.
+	ErrKeyspaceTemplateNotFound = errors.Normalize("keyspace template %s does not exist", errors.RFCCodeText("PD:keyspace:ErrKeyspaceTemplateNotFound"))
 	// errKeyspaceGroupNotInMerging is used to indicate target keyspace group is not in merging state.
 )
 
@@ -496,6 +519,7 @@ var (
 	ErrEncryptionKeysWatcher        = errors.Normalize("data key watcher error", errors.RFCCodeText("PD:encryption:ErrEncryptionKeysWatcher"))
 	ErrEncryptionLoadKeys           = errors.Normalize("load data keys error", errors.RFCCodeText("PD:encryption:ErrEncryptionLoadKeys"))
 	ErrEncryptionRotateDataKey      = errors.Normalize("failed to rotate data key", errors.RFCCodeText("PD:encryption:ErrEncryptionRotateDataKey"))
+	ErrEncryptionRotateMasterKey    = errors.Normalize("failed to rotate master key", errors.RFCCodeText("PD:encryption:ErrEncryptionRotateMasterKey"))
 	ErrEncryptionSaveDataKeys       = errors.Normalize("failed to save data keys", errors.RFCCodeText("PD:encryption:ErrEncryptionSaveDataKeys"))
 	ErrEncryptionKMS                = errors.Normalize("KMS error", errors.RFCCodeText("PD:ErrEncryptionKMS"))
 )
@@ -528,6 +552,8 @@ var (
 	ErrResourceGroupNotExists = errors.Normalize("the %s resource group does not exist", errors.RFCCodeText("PD:resourcemanager:ErrGroupNotExists"))
 	ErrDeleteReservedGroup    = errors.Normalize("cannot delete reserved group", errors.RFCCodeText("PD:resourcemanager:ErrDeleteReservedGroup"))
 	ErrInvalidGroup           = errors.Normalize("invalid group settings, please check the group name, priority and the number of resources", errors.RFCCodeText("PD:resourcemanager:ErrInvalidGroup"))
+	ErrParentGroupNotExists   = errors.Normalize("the %s parent resource group does not exist", errors.RFCCodeText("PD:resourcemanager:ErrParentGroupNotExists"))
+	ErrInvalidParentGroup     = errors.Normalize("invalid parent group settings, %s", errors.RFCCodeText("PD:resourcemanager:ErrInvalidParentGroup"))
 )
 
 // Microservice errors
@@ -557,6 +557,49 @@ func (m *GCStateManager) deleteGCBarrierImpl(keyspaceID uint32, barrierID string
 	return deletedBarrier, nil
 }
 
+// DeleteKeyspaceGCStates deletes the GC safe point, the txn safe point and all the GC barriers of the keyspace. It's
+// used to clean up the keyspaces that are archived, and does nothing for keyspaces without keyspace-level GC enabled,
+// since their GC states are managed by the unified GC.
+func (m *GCStateManager) DeleteKeyspaceGCStates(keyspaceID uint32) error {
+	keyspaceID, err := m.redirectKeyspace(keyspaceID, true)
+	if err != nil {
+		return err
+	}
+	if keyspaceID == constant.NullKeyspaceID {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var barrierCount int
+	err = m.gcMetaStorage.RunInGCStateTransaction(func(wb *endpoint.GCStateWriteBatch) error {
+		barriers, err1 := m.gcMetaStorage.LoadAllGCBarriers(keyspaceID)
+		if err1 != nil {
+			return err1
+		}
+		barrierCount = len(barriers)
+		for _, barrier := range barriers {
+			if err1 = wb.DeleteGCBarrier(keyspaceID, barrier.BarrierID); err1 != nil {
+				return err1
+			}
+		}
+		if err1 = wb.DeleteTxnSafePoint(keyspaceID); err1 != nil {
+			return err1
+		}
+		return wb.DeleteGCSafePoint(keyspaceID)
+	})
+	if err != nil {
+		log.Error("failed to delete keyspace GC states",
+			zap.Uint32("keyspace-id", keyspaceID), zap.Error(err))
+		return err
+	}
+
+	log.Info("keyspace GC states deleted",
+		zap.Uint32("keyspace-id", keyspaceID), zap.Int("gc-barrier-count", barrierCount))
+	return nil
+}
+
 // getGCStateInTransaction gets all properties in GC states within a context of gcMetaStorage.RunInGCStateTransaction.
 // This read only and won't write anything to the GCStateWriteBatch. It still receives a write batch to ensure
 // it's running in a in-transaction context.
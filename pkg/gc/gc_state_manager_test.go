@@ -1311,6 +1311,47 @@ func (s *gcStateManagerTestSuite) TestGetGCState() {
 	checkAllKeyspaceGCStates()
 }
 
+func (s *gcStateManagerTestSuite) TestDeleteKeyspaceGCStates() {
+	re := s.Require()
+
+	now := time.Now().Truncate(time.Second)
+	_, err := s.manager.AdvanceTxnSafePoint(constant.NullKeyspaceID, 20, now)
+	re.NoError(err)
+	_, err = s.manager.SetGCBarrier(constant.NullKeyspaceID, "b1", 25, time.Hour, now)
+	re.NoError(err)
+	_, err = s.manager.AdvanceTxnSafePoint(2, 50, now)
+	re.NoError(err)
+	_, _, err = s.manager.AdvanceGCSafePoint(2, 45)
+	re.NoError(err)
+	_, err = s.manager.SetGCBarrier(2, "b1", 55, time.Hour, now)
+	re.NoError(err)
+	_, err = s.manager.SetGCBarrier(2, "b2", 60, time.Hour, now)
+	re.NoError(err)
+
+	// The GC states of the keyspaces without keyspace-level GC are managed by the unified GC, and are kept.
+	for _, keyspaceID := range slices.Concat(s.keyspacePresets.unmanageable, s.keyspacePresets.nullSynonyms) {
+		re.NoError(s.manager.DeleteKeyspaceGCStates(keyspaceID))
+	}
+	state, err := s.manager.GetGCState(constant.NullKeyspaceID)
+	re.NoError(err)
+	re.Equal(uint64(20), state.TxnSafePoint)
+	re.Len(state.GCBarriers, 1)
+
+	re.NoError(s.manager.DeleteKeyspaceGCStates(2))
+	state, err = s.manager.GetGCState(2)
+	re.NoError(err)
+	re.True(state.IsKeyspaceLevel)
+	re.Equal(uint64(0), state.TxnSafePoint)
+	re.Equal(uint64(0), state.GCSafePoint)
+	re.Empty(state.GCBarriers)
+	// Deleting again is a no-op.
+	re.NoError(s.manager.DeleteKeyspaceGCStates(2))
+
+	for _, keyspaceID := range s.keyspacePresets.notExisting {
+		re.ErrorIs(s.manager.DeleteKeyspaceGCStates(keyspaceID), errs.ErrKeyspaceNotFound)
+	}
+}
+
 func (s *gcStateManagerTestSuite) TestWeakenedConstraints() {
 	re := s.Require()
 
@@ -66,6 +66,9 @@ const (
 	// UnifiedGC is a type of gc_management_type used to indicate that the GC states of this keyspace is managed
 	// in a unified way (managed by the NullKeyspace).
 	UnifiedGC = "unified"
+	// ResourceGroupsKey is the key for the resource groups used by the keyspace in keyspace config,
+	// which are specified as comma separated resource group names.
+	ResourceGroupsKey = "resource_groups"
 )
 
 // Config is the interface for keyspace config.
@@ -74,6 +77,12 @@ type Config interface {
 	ToWaitRegionSplit() bool
 	GetWaitRegionSplitTimeout() time.Duration
 	GetCheckRegionSplitInterval() time.Duration
+	// IsLifecycleEnabled returns whether to archive and tombstone the keyspaces automatically.
+	IsLifecycleEnabled() bool
+	// GetArchiveAfter returns how long a keyspace stays disabled before it's archived, 0 means never.
+	GetArchiveAfter() time.Duration
+	// GetTombstoneAfter returns how long a keyspace stays archived before it's tombstoned, 0 means never.
+	GetTombstoneAfter() time.Duration
 }
 
 // Manager manages keyspace related data.
@@ -95,6 +104,18 @@ type Manager struct {
 	kgm *GroupManager
 	// nextPatrolStartID is the next start id of keyspace assignment patrol.
 	nextPatrolStartID uint32
+	// deleteGCStates deletes the GC states of the archived keyspace.
+	deleteGCStates func(keyspaceID uint32) error
+	// quotaStatuses caches the quota statuses of the keyspaces with quota, which are
+	// collected periodically by the quota loop.
+	quotaStatuses struct {
+		syncutil.RWMutex
+		// statuses is the mapping from keyspace id to its quota status.
+		statuses map[uint32]*QuotaStatus
+		// splitDenied is the region bounds of the keyspaces which reach the hard limit
+		// of their region count quotas.
+		splitDenied []*RegionBound
+	}
 }
 
 // CreateKeyspaceRequest represents necessary arguments to create a keyspace.
@@ -105,6 +126,9 @@ type CreateKeyspaceRequest struct {
 	Config map[string]string
 	// CreateTime is the timestamp used to record creation time.
 	CreateTime int64
+	// Template is applied to the keyspace after its region is split if it's not nil.
+	// Its config should have been merged into Config.
+	Template *Template
 }
 
 // CreateKeyspaceByIDRequest represents necessary arguments to create a keyspace.
@@ -250,7 +274,26 @@ func (manager *Manager) CreateKeyspace(request *CreateKeyspaceRequest) (*keyspac
 	}
 	// Split keyspace region.
 	err = manager.splitKeyspaceRegion(newID, manager.config.ToWaitRegionSplit())
+	if err == nil && request.Template != nil {
+		// Apply the template before the keyspace is enabled, so the keyspace is not created
+		// with only part of the settings.
+		err = manager.applyTemplate(newID, request.Template)
+	}
+	if err == nil {
+		// enable the keyspace metadata after split.
+		_, err = manager.UpdateKeyspaceStateByID(newID, keyspacepb.KeyspaceState_ENABLED, request.CreateTime)
+		if err != nil {
+			log.Warn("[keyspace] failed to create keyspace",
+				zap.Uint32("keyspace-id", keyspace.GetId()),
+				zap.String("name", keyspace.GetName()),
+				zap.Error(err),
+			)
+		}
+	}
 	if err != nil {
+		if request.Template != nil {
+			manager.revertTemplate(newID)
+		}
 		err2 := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
 			idPath := keypath.KeyspaceIDPath(request.Name)
 			metaPath := keypath.KeyspaceMetaPath(newID)
@@ -269,17 +312,7 @@ func (manager *Manager) CreateKeyspace(request *CreateKeyspaceRequest) (*keyspac
 		}
 		return nil, err
 	}
-	// enable the keyspace metadata after split.
 	keyspace.State = keyspacepb.KeyspaceState_ENABLED
-	_, err = manager.UpdateKeyspaceStateByID(newID, keyspacepb.KeyspaceState_ENABLED, request.CreateTime)
-	if err != nil {
-		log.Warn("[keyspace] failed to create keyspace",
-			zap.Uint32("keyspace-id", keyspace.GetId()),
-			zap.String("name", keyspace.GetName()),
-			zap.Error(err),
-		)
-		return nil, err
-	}
 	if err := manager.kgm.UpdateKeyspaceForGroup(userKind, config[TSOKeyspaceGroupIDKey], keyspace.GetId(), opAdd); err != nil {
 		return nil, err
 	}
@@ -598,6 +631,9 @@ func (manager *Manager) UpdateKeyspaceConfig(name string, mutations []*Mutation)
 			}
 		}
 		newConfig := meta.GetConfig()
+		if err := manager.checkQuotaForConfigUpdate(txn, meta.GetId(), oldConfig, newConfig, mutations); err != nil {
+			return err
+		}
 		oldUserKind := endpoint.StringUserKind(oldConfig[UserKindKey])
 		newUserKind := endpoint.StringUserKind(newConfig[UserKindKey])
 		oldID := oldConfig[TSOKeyspaceGroupIDKey]
@@ -59,6 +59,9 @@ type mockConfig struct {
 	WaitRegionSplit          bool
 	WaitRegionSplitTimeout   typeutil.Duration
 	CheckRegionSplitInterval typeutil.Duration
+	EnableLifecycle          bool
+	ArchiveAfter             typeutil.Duration
+	TombstoneAfter           typeutil.Duration
 }
 
 func (m *mockConfig) GetPreAlloc() []string {
@@ -77,6 +80,18 @@ func (m *mockConfig) GetCheckRegionSplitInterval() time.Duration {
 	return m.CheckRegionSplitInterval.Duration
 }
 
+func (m *mockConfig) IsLifecycleEnabled() bool {
+	return m.EnableLifecycle
+}
+
+func (m *mockConfig) GetArchiveAfter() time.Duration {
+	return m.ArchiveAfter.Duration
+}
+
+func (m *mockConfig) GetTombstoneAfter() time.Duration {
+	return m.TombstoneAfter.Duration
+}
+
 func (suite *keyspaceTestSuite) SetupTest() {
 	re := suite.Require()
 	suite.ctx, suite.cancel = context.WithCancel(context.Background())
@@ -0,0 +1,408 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/keyspacepb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+// lifecycleCheckInterval is the interval to apply the keyspace lifecycle policies.
+const lifecycleCheckInterval = time.Minute
+
+// The steps applied to a keyspace by the lifecycle controller. A disabled keyspace is archived first,
+// then the resources of the archived keyspace are cleaned up, and the keyspace is tombstoned at last.
+// The keyspaces archived by hand are left untouched.
+//
+// PD does not destroy the data by itself. The GC worker, or the operator, should list the pending tasks
+// by `GET /pd/api/v2/keyspace-destroy-ranges`, destroy the ranges with UnsafeDestroyRange of TiKV, and
+// finish the task by `DELETE /pd/api/v2/keyspace-destroy-ranges/{id}`. The archived keyspace is not
+// tombstoned until its task is finished.
+const (
+	// LifecycleStepArchive archives the keyspace which stays disabled long enough.
+	LifecycleStepArchive = "archive"
+	// LifecycleStepScheduleDestroyRange schedules a task for the GC worker to destroy the data of the keyspace.
+	LifecycleStepScheduleDestroyRange = "schedule-destroy-range"
+	// LifecycleStepRemoveGroupMembership removes the keyspace from its keyspace group.
+	LifecycleStepRemoveGroupMembership = "remove-keyspace-group-membership"
+	// LifecycleStepRemoveGCStates removes the GC safe points and GC barriers of the keyspace.
+	LifecycleStepRemoveGCStates = "remove-gc-states"
+	// LifecycleStepFinishDestroyRange is recorded when the GC worker finishes destroying the data of the keyspace.
+	LifecycleStepFinishDestroyRange = "finish-destroy-range"
+	// LifecycleStepRemoveLabelRule removes the region label rule of the keyspace.
+	LifecycleStepRemoveLabelRule = "remove-label-rule"
+	// LifecycleStepRemoveTemplateRules removes the placement rules and region label rules applied
+	// to the keyspace from a template.
+	LifecycleStepRemoveTemplateRules = "remove-template-rules"
+	// LifecycleStepTombstone tombstones the keyspace which stays archived long enough.
+	LifecycleStepTombstone = "tombstone"
+)
+
+// SetGCStatesCleaner sets the function to delete the GC states of an archived keyspace. The GC states
+// are left untouched if it's not set.
+func (manager *Manager) SetGCStatesCleaner(cleaner func(keyspaceID uint32) error) {
+	manager.deleteGCStates = cleaner
+}
+
+// RunLifecycleLoop applies the keyspace lifecycle policies periodically until the context is done.
+// It should only be run on the leader.
+func (manager *Manager) RunLifecycleLoop(ctx context.Context) {
+	defer logutil.LogPanic()
+	ticker := time.NewTicker(lifecycleCheckInterval)
+	failpoint.Inject("acceleratedKeyspaceLifecycle", func() {
+		ticker.Reset(time.Millisecond * 100)
+	})
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("[keyspace] stop to apply keyspace lifecycle policies")
+			return
+		case <-ticker.C:
+		}
+		if !manager.config.IsLifecycleEnabled() {
+			continue
+		}
+		manager.applyLifecyclePolicies(time.Now())
+	}
+}
+
+// applyLifecyclePolicies archives the keyspaces which stay disabled long enough, cleans up the archived
+// keyspaces, and tombstones the archived keyspaces which stay archived long enough and are cleaned up.
+func (manager *Manager) applyLifecyclePolicies(now time.Time) {
+	for startID := constant.DefaultKeyspaceID; ; {
+		keyspaces, err := manager.LoadRangeKeyspace(startID, etcdutil.MaxEtcdTxnOps)
+		if err != nil {
+			log.Warn("[keyspace] failed to load keyspaces to apply lifecycle policies",
+				zap.Uint32("start-keyspace-id", startID),
+				zap.Error(err),
+			)
+			return
+		}
+		for _, meta := range keyspaces {
+			if meta.GetId() == constant.DefaultKeyspaceID {
+				continue
+			}
+			if err := manager.applyLifecyclePolicy(meta, now); err != nil {
+				log.Warn("[keyspace] failed to apply lifecycle policy",
+					zap.Uint32("keyspace-id", meta.GetId()),
+					zap.String("name", meta.GetName()),
+					zap.String("state", meta.GetState().String()),
+					zap.Error(err),
+				)
+			}
+		}
+		if len(keyspaces) < etcdutil.MaxEtcdTxnOps {
+			return
+		}
+		startID = keyspaces[len(keyspaces)-1].GetId() + 1
+	}
+}
+
+func (manager *Manager) applyLifecyclePolicy(meta *keyspacepb.KeyspaceMeta, now time.Time) error {
+	stateDuration := now.Sub(time.Unix(meta.GetStateChangedAt(), 0))
+	switch meta.GetState() {
+	case keyspacepb.KeyspaceState_DISABLED:
+		archiveAfter := manager.config.GetArchiveAfter()
+		if archiveAfter <= 0 || stateDuration < archiveAfter {
+			return nil
+		}
+		var err error
+		meta, err = manager.UpdateKeyspaceStateByID(meta.GetId(), keyspacepb.KeyspaceState_ARCHIVED, now.Unix())
+		if err != nil {
+			return err
+		}
+		if err := manager.recordLifecycleStep(meta.GetId(), LifecycleStepArchive, now,
+			fmt.Sprintf("disabled for %s", stateDuration.Truncate(time.Second))); err != nil {
+			return err
+		}
+		return manager.cleanupArchivedKeyspace(meta, now)
+	case keyspacepb.KeyspaceState_ARCHIVED:
+		applied, err := manager.loadAppliedLifecycleSteps(meta.GetId())
+		if err != nil {
+			return err
+		}
+		// Only clean up the keyspaces archived by the controller, the data of the keyspaces archived
+		// by hand may be still wanted.
+		if !applied[LifecycleStepArchive] {
+			return nil
+		}
+		if err := manager.cleanupArchivedKeyspace(meta, now); err != nil {
+			return err
+		}
+		tombstoneAfter := manager.config.GetTombstoneAfter()
+		if tombstoneAfter <= 0 || stateDuration < tombstoneAfter {
+			return nil
+		}
+		return manager.tombstoneArchivedKeyspace(meta, now)
+	default:
+		return nil
+	}
+}
+
+// cleanupArchivedKeyspace schedules the task to destroy the data of the archived keyspace, and removes
+// its keyspace group membership and GC states. The steps already applied are skipped.
+func (manager *Manager) cleanupArchivedKeyspace(meta *keyspacepb.KeyspaceMeta, now time.Time) error {
+	id := meta.GetId()
+	applied, err := manager.loadAppliedLifecycleSteps(id)
+	if err != nil {
+		return err
+	}
+	if !applied[LifecycleStepScheduleDestroyRange] {
+		ranges, err := makeDestroyRanges(id)
+		if err != nil {
+			return err
+		}
+		task := &endpoint.KeyspaceDestroyRange{
+			KeyspaceID: id,
+			Ranges:     ranges,
+			CreateTime: now.Unix(),
+		}
+		err = manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+			if err := manager.store.SaveKeyspaceDestroyRange(txn, task); err != nil {
+				return err
+			}
+			return manager.appendLifecycleRecord(txn, id, &endpoint.KeyspaceLifecycleRecord{
+				Step: LifecycleStepScheduleDestroyRange,
+				Time: now.Unix(),
+			})
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("[keyspace] scheduled to destroy the data of the archived keyspace", zap.Uint32("keyspace-id", id))
+	}
+	if !applied[LifecycleStepRemoveGroupMembership] {
+		var detail string
+		groupID, err := manager.kgm.RemoveKeyspace(id)
+		switch {
+		case errs.ErrKeyspaceNotInAnyKeyspaceGroup.Equal(err):
+			detail = "not in any keyspace group"
+		case err != nil:
+			return err
+		default:
+			detail = fmt.Sprintf("removed from keyspace group %d", groupID)
+		}
+		if err := manager.recordLifecycleStep(id, LifecycleStepRemoveGroupMembership, now, detail); err != nil {
+			return err
+		}
+	}
+	if !applied[LifecycleStepRemoveGCStates] {
+		var detail string
+		if meta.GetConfig()[GCManagementType] != KeyspaceLevelGC {
+			detail = "managed by the unified GC"
+		} else if manager.deleteGCStates == nil {
+			detail = "skipped since the GC states cleaner is not set"
+		} else if err := manager.deleteGCStates(id); err != nil {
+			return err
+		}
+		if err := manager.recordLifecycleStep(id, LifecycleStepRemoveGCStates, now, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tombstoneArchivedKeyspace removes the region label rule and the template rules of the archived keyspace
+// and tombstones it after its data is destroyed.
+func (manager *Manager) tombstoneArchivedKeyspace(meta *keyspacepb.KeyspaceMeta, now time.Time) error {
+	id := meta.GetId()
+	applied, err := manager.loadAppliedLifecycleSteps(id)
+	if err != nil {
+		return err
+	}
+	// Keep the region boundaries until the data is destroyed, so the data of the other keyspaces is not
+	// in the same regions.
+	if !applied[LifecycleStepFinishDestroyRange] {
+		log.Debug("[keyspace] wait for the data of the archived keyspace to be destroyed", zap.Uint32("keyspace-id", id))
+		return nil
+	}
+	if !applied[LifecycleStepRemoveLabelRule] {
+		if err := manager.removeKeyspaceLabelRule(id); err != nil {
+			return err
+		}
+		if err := manager.recordLifecycleStep(id, LifecycleStepRemoveLabelRule, now, ""); err != nil {
+			return err
+		}
+	}
+	if !applied[LifecycleStepRemoveTemplateRules] {
+		if err := manager.removeTemplateRules(id); err != nil {
+			return err
+		}
+		if err := manager.recordLifecycleStep(id, LifecycleStepRemoveTemplateRules, now, ""); err != nil {
+			return err
+		}
+	}
+	if _, err := manager.UpdateKeyspaceStateByID(id, keyspacepb.KeyspaceState_TOMBSTONE, now.Unix()); err != nil {
+		return err
+	}
+	return manager.recordLifecycleStep(id, LifecycleStepTombstone, now,
+		fmt.Sprintf("archived for %s", now.Sub(time.Unix(meta.GetStateChangedAt(), 0)).Truncate(time.Second)))
+}
+
+func (manager *Manager) removeKeyspaceLabelRule(id uint32) error {
+	cl, ok := manager.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler })
+	if !ok {
+		return errors.New("cluster does not support region label")
+	}
+	err := cl.GetRegionLabeler().DeleteLabelRule(getRegionLabelID(id))
+	if err != nil && !errs.ErrRegionRuleNotFound.Equal(err) {
+		return err
+	}
+	log.Info("[keyspace] removed region label for keyspace", zap.Uint32("keyspace-id", id))
+	return nil
+}
+
+// GetLifecycleRecords returns the lifecycle steps applied to the keyspace in order.
+func (manager *Manager) GetLifecycleRecords(name string) ([]*endpoint.KeyspaceLifecycleRecord, error) {
+	var records []*endpoint.KeyspaceLifecycleRecord
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		loaded, id, err := manager.store.LoadKeyspaceID(txn, name)
+		if err != nil {
+			return err
+		}
+		if !loaded {
+			return errs.ErrKeyspaceNotFound
+		}
+		records, err = manager.store.LoadKeyspaceLifecycleRecords(txn, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []*endpoint.KeyspaceLifecycleRecord{}
+	}
+	return records, nil
+}
+
+// GetDestroyRanges returns the pending tasks to destroy the data of the archived keyspaces.
+func (manager *Manager) GetDestroyRanges() ([]*endpoint.KeyspaceDestroyRange, error) {
+	return manager.store.LoadKeyspaceDestroyRanges()
+}
+
+// FinishDestroyRange is called after the data of the archived keyspace is destroyed, which is not done
+// by PD itself. It removes the pending task, so the keyspace can be tombstoned.
+func (manager *Manager) FinishDestroyRange(id uint32, now time.Time) error {
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		task, err := manager.store.LoadKeyspaceDestroyRange(txn, id)
+		if err != nil {
+			return err
+		}
+		if task == nil {
+			return errs.ErrKeyspaceDestroyRangeNotFound.FastGenByArgs(id)
+		}
+		if err := manager.store.DeleteKeyspaceDestroyRange(txn, id); err != nil {
+			return err
+		}
+		return manager.appendLifecycleRecord(txn, id, &endpoint.KeyspaceLifecycleRecord{
+			Step: LifecycleStepFinishDestroyRange,
+			Time: now.Unix(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("[keyspace] the data of the archived keyspace is destroyed", zap.Uint32("keyspace-id", id))
+	return nil
+}
+
+// loadAppliedLifecycleSteps returns the set of the lifecycle steps applied to the keyspace.
+func (manager *Manager) loadAppliedLifecycleSteps(id uint32) (map[string]bool, error) {
+	var records []*endpoint.KeyspaceLifecycleRecord
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		var err error
+		records, err = manager.store.LoadKeyspaceLifecycleRecords(txn, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.Step] = true
+	}
+	return applied, nil
+}
+
+func (manager *Manager) recordLifecycleStep(id uint32, step string, now time.Time, detail string) error {
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.appendLifecycleRecord(txn, id, &endpoint.KeyspaceLifecycleRecord{
+			Step:   step,
+			Time:   now.Unix(),
+			Detail: detail,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("[keyspace] applied keyspace lifecycle step",
+		zap.Uint32("keyspace-id", id),
+		zap.String("step", step),
+		zap.String("detail", detail),
+	)
+	return nil
+}
+
+func (manager *Manager) appendLifecycleRecord(txn kv.Txn, id uint32, record *endpoint.KeyspaceLifecycleRecord) error {
+	records, err := manager.store.LoadKeyspaceLifecycleRecords(txn, id)
+	if err != nil {
+		return err
+	}
+	return manager.store.SaveKeyspaceLifecycleRecords(txn, id, append(records, record))
+}
+
+// makeDestroyRanges returns the raw and txn key ranges of the keyspace. Different from the region
+// boundaries, the keys are decoded since UnsafeDestroyRange works on the raw keys.
+func makeDestroyRanges(id uint32) ([]*endpoint.KeyspaceKeyRange, error) {
+	bound := MakeRegionBound(id)
+	ranges := make([]*endpoint.KeyspaceKeyRange, 0, 2)
+	for _, keys := range [][2][]byte{
+		{bound.RawLeftBound, bound.RawRightBound},
+		{bound.TxnLeftBound, bound.TxnRightBound},
+	} {
+		_, startKey, err := codec.DecodeBytes(keys[0])
+		if err != nil {
+			return nil, err
+		}
+		_, endKey, err := codec.DecodeBytes(keys[1])
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, &endpoint.KeyspaceKeyRange{
+			StartKey: hex.EncodeToString(startKey),
+			EndKey:   hex.EncodeToString(endKey),
+		})
+	}
+	return ranges, nil
+}
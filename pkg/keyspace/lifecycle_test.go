@@ -0,0 +1,210 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/keyspacepb"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+type mockLabelerCluster struct {
+	core.ClusterInformer
+	labeler *labeler.RegionLabeler
+}
+
+func (c *mockLabelerCluster) GetRegionLabeler() *labeler.RegionLabeler {
+	return c.labeler
+}
+
+func (*mockLabelerCluster) GetRuleManager() *placement.RuleManager {
+	return nil
+}
+
+func TestKeyspaceLifecycle(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	regionLabeler, err := labeler.NewRegionLabeler(ctx, store, time.Hour)
+	re.NoError(err)
+	kgm := NewKeyspaceGroupManager(ctx, store, nil)
+	cfg := &mockConfig{
+		EnableLifecycle: true,
+		ArchiveAfter:    typeutil.NewDuration(time.Hour),
+		TombstoneAfter:  typeutil.NewDuration(24 * time.Hour),
+	}
+	manager := NewKeyspaceManager(ctx, store, &mockLabelerCluster{labeler: regionLabeler}, mockid.NewIDAllocator(), cfg, kgm)
+	re.NoError(kgm.Bootstrap(ctx))
+	re.NoError(manager.Bootstrap())
+	var cleaned []uint32
+	manager.SetGCStatesCleaner(func(keyspaceID uint32) error {
+		cleaned = append(cleaned, keyspaceID)
+		return nil
+	})
+
+	start := time.Now()
+	ks1, err := manager.CreateKeyspace(&CreateKeyspaceRequest{
+		Name:       "ks1",
+		Config:     map[string]string{GCManagementType: KeyspaceLevelGC},
+		CreateTime: start.Unix(),
+	})
+	re.NoError(err)
+	ks2, err := manager.CreateKeyspace(&CreateKeyspaceRequest{
+		Name:       "ks2",
+		CreateTime: start.Unix(),
+	})
+	re.NoError(err)
+	_, err = manager.UpdateKeyspaceState("ks1", keyspacepb.KeyspaceState_DISABLED, start.Unix())
+	re.NoError(err)
+	_, err = manager.UpdateKeyspaceState("ks2", keyspacepb.KeyspaceState_DISABLED, start.Add(30*time.Minute).Unix())
+	re.NoError(err)
+	// ks3 is archived by hand.
+	_, err = manager.CreateKeyspace(&CreateKeyspaceRequest{
+		Name:       "ks3",
+		CreateTime: start.Unix(),
+	})
+	re.NoError(err)
+	_, err = manager.UpdateKeyspaceState("ks3", keyspacepb.KeyspaceState_DISABLED, start.Unix())
+	re.NoError(err)
+	_, err = manager.UpdateKeyspaceState("ks3", keyspacepb.KeyspaceState_ARCHIVED, start.Unix())
+	re.NoError(err)
+	re.NotNil(regionLabeler.GetLabelRule(getRegionLabelID(ks1.GetId())))
+	_, err = kgm.GetGroupByKeyspaceID(ks1.GetId())
+	re.NoError(err)
+	// Apply a template label rule to ks1.
+	re.NoError(manager.applyTemplate(ks1.GetId(), &Template{
+		Name: "template",
+		LabelRules: []*TemplateLabelRule{{
+			ID:     "rule",
+			Labels: []labeler.RegionLabel{{Key: "k", Value: "v"}},
+		}},
+	}))
+	templateRuleID := getTemplateRuleIDPrefix(ks1.GetId()) + "rule"
+	re.NotNil(regionLabeler.GetLabelRule(templateRuleID))
+
+	checkState := func(name string, state keyspacepb.KeyspaceState) {
+		meta, err := manager.LoadKeyspace(name)
+		re.NoError(err)
+		re.Equal(state, meta.GetState())
+	}
+	checkSteps := func(name string, steps ...string) {
+		records, err := manager.GetLifecycleRecords(name)
+		re.NoError(err)
+		applied := make([]string, 0, len(records))
+		for _, record := range records {
+			applied = append(applied, record.Step)
+		}
+		re.Equal(steps, applied)
+	}
+
+	// The keyspace is not archived before it stays disabled long enough.
+	manager.applyLifecyclePolicies(start.Add(time.Hour - time.Second))
+	checkState("ks1", keyspacepb.KeyspaceState_DISABLED)
+	checkSteps("ks1")
+
+	// Archive ks1 and clean it up.
+	now := start.Add(time.Hour)
+	manager.applyLifecyclePolicies(now)
+	checkState("ks1", keyspacepb.KeyspaceState_ARCHIVED)
+	checkState("ks2", keyspacepb.KeyspaceState_DISABLED)
+	archivedSteps := []string{
+		LifecycleStepArchive,
+		LifecycleStepScheduleDestroyRange,
+		LifecycleStepRemoveGroupMembership,
+		LifecycleStepRemoveGCStates,
+	}
+	checkSteps("ks1", archivedSteps...)
+	_, err = kgm.GetGroupByKeyspaceID(ks1.GetId())
+	re.ErrorIs(err, errs.ErrKeyspaceNotInAnyKeyspaceGroup)
+	re.Equal([]uint32{ks1.GetId()}, cleaned)
+	tasks, err := manager.GetDestroyRanges()
+	re.NoError(err)
+	re.Len(tasks, 1)
+	re.Equal(ks1.GetId(), tasks[0].KeyspaceID)
+	ranges, err := makeDestroyRanges(ks1.GetId())
+	re.NoError(err)
+	re.Equal(ranges, tasks[0].Ranges)
+	// The region label rule is kept until the data is destroyed.
+	re.NotNil(regionLabeler.GetLabelRule(getRegionLabelID(ks1.GetId())))
+
+	// The applied steps are skipped.
+	manager.applyLifecyclePolicies(now)
+	checkSteps("ks1", archivedSteps...)
+	re.Len(cleaned, 1)
+
+	// The archived keyspace is not tombstoned until its data is destroyed.
+	now = now.Add(24 * time.Hour)
+	manager.applyLifecyclePolicies(now)
+	checkState("ks1", keyspacepb.KeyspaceState_ARCHIVED)
+	checkState("ks2", keyspacepb.KeyspaceState_ARCHIVED)
+	checkSteps("ks1", archivedSteps...)
+	checkSteps("ks2", archivedSteps...)
+	records, err := manager.GetLifecycleRecords("ks2")
+	re.NoError(err)
+	re.Equal("managed by the unified GC", records[3].Detail)
+	re.Len(cleaned, 1)
+
+	err = manager.FinishDestroyRange(ks1.GetId()+100, now)
+	re.True(errs.ErrKeyspaceDestroyRangeNotFound.Equal(err))
+	re.NoError(manager.FinishDestroyRange(ks1.GetId(), now))
+	tasks, err = manager.GetDestroyRanges()
+	re.NoError(err)
+	re.Len(tasks, 1)
+	re.Equal(ks2.GetId(), tasks[0].KeyspaceID)
+
+	// Tombstone ks1, ks2 is just archived.
+	manager.applyLifecyclePolicies(now)
+	checkState("ks1", keyspacepb.KeyspaceState_TOMBSTONE)
+	checkState("ks2", keyspacepb.KeyspaceState_ARCHIVED)
+	checkSteps("ks1", append(archivedSteps,
+		LifecycleStepFinishDestroyRange,
+		LifecycleStepRemoveLabelRule,
+		LifecycleStepRemoveTemplateRules,
+		LifecycleStepTombstone,
+	)...)
+	re.Nil(regionLabeler.GetLabelRule(getRegionLabelID(ks1.GetId())))
+	re.Nil(regionLabeler.GetLabelRule(templateRuleID))
+	re.NotNil(regionLabeler.GetLabelRule(getRegionLabelID(ks2.GetId())))
+	// The keyspace archived by hand is left untouched.
+	checkState("ks3", keyspacepb.KeyspaceState_ARCHIVED)
+	checkSteps("ks3")
+
+	_, err = manager.GetLifecycleRecords("not-exist")
+	re.ErrorIs(err, errs.ErrKeyspaceNotFound)
+}
+
+func TestMakeDestroyRanges(t *testing.T) {
+	re := require.New(t)
+	ranges, err := makeDestroyRanges(1)
+	re.NoError(err)
+	re.Equal([]*endpoint.KeyspaceKeyRange{
+		{StartKey: "72000001", EndKey: "72000002"},
+		{StartKey: "78000001", EndKey: "78000002"},
+	}, ranges)
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	quotaUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "keyspace",
+			Name:      "quota_usage",
+			Help:      "The resource usage of the keyspaces with quota.",
+		}, []string{"keyspace", "resource"})
+
+	quotaLimitExceededGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "keyspace",
+			Name:      "quota_limit_exceeded",
+			Help:      "Whether the keyspace exceeds the limit of its quota, 1 means exceeded.",
+		}, []string{"keyspace", "resource", "limit"})
+)
+
+func init() {
+	prometheus.MustRegister(quotaUsageGauge)
+	prometheus.MustRegister(quotaLimitExceededGauge)
+}
@@ -0,0 +1,495 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/keyspacepb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/slice"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// quotaCheckInterval is the interval to collect the resource usages of the keyspaces with quota.
+const quotaCheckInterval = 30 * time.Second
+
+// The resources limited by the keyspace quota.
+const (
+	// QuotaResourceRegionCount is the number of regions of the keyspace. Once the hard limit
+	// is reached, the split checker stops splitting the regions of the keyspace, and the splits
+	// asked by TiKV are rejected.
+	QuotaResourceRegionCount = "region-count"
+	// QuotaResourceStorageSize is the approximate size of the keyspace in MiB.
+	QuotaResourceStorageSize = "storage-size"
+	// QuotaResourceResourceGroupCount is the number of resource groups listed in the keyspace config.
+	QuotaResourceResourceGroupCount = "resource-group-count"
+)
+
+const (
+	quotaLimitSoft = "soft"
+	quotaLimitHard = "hard"
+)
+
+// QuotaUsage is the resource usage of a keyspace.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type QuotaUsage struct {
+	// RegionCount is the number of regions overlapping with the key ranges of the keyspace.
+	RegionCount uint64 `json:"region-count"`
+	// StorageSize is the total approximate size of the regions in MiB.
+	StorageSize        uint64 `json:"storage-size"`
+	ResourceGroupCount uint64 `json:"resource-group-count"`
+}
+
+// QuotaStatus is the quota of a keyspace along with its resource usage.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type QuotaStatus struct {
+	Quota *endpoint.KeyspaceQuota `json:"quota"`
+	Usage *QuotaUsage             `json:"usage"`
+	// SoftLimitReached is the resources whose usages reach the soft limits.
+	SoftLimitReached []string `json:"soft-limit-reached,omitempty"`
+	// HardLimitReached is the resources whose usages reach the hard limits.
+	HardLimitReached []string `json:"hard-limit-reached,omitempty"`
+	// UpdateTime is the unix timestamp in seconds when the usage is collected.
+	UpdateTime int64 `json:"update-time"`
+}
+
+func newQuotaStatus(quota *endpoint.KeyspaceQuota, usage *QuotaUsage, now time.Time) *QuotaStatus {
+	status := &QuotaStatus{
+		Quota:      quota,
+		Usage:      usage,
+		UpdateTime: now.Unix(),
+	}
+	for _, item := range []struct {
+		resource string
+		limit    endpoint.KeyspaceQuotaLimit
+		used     uint64
+	}{
+		{QuotaResourceRegionCount, quota.RegionCount, usage.RegionCount},
+		{QuotaResourceStorageSize, quota.StorageSize, usage.StorageSize},
+		{QuotaResourceResourceGroupCount, quota.ResourceGroupCount, usage.ResourceGroupCount},
+	} {
+		if item.limit.Soft > 0 && item.used >= item.limit.Soft {
+			status.SoftLimitReached = append(status.SoftLimitReached, item.resource)
+		}
+		if item.limit.Hard > 0 && item.used >= item.limit.Hard {
+			status.HardLimitReached = append(status.HardLimitReached, item.resource)
+		}
+	}
+	return status
+}
+
+// parseResourceGroups returns the distinct resource group names in the given config value.
+func parseResourceGroups(value string) []string {
+	groups := make([]string, 0)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slice.Contains(groups, name) {
+			continue
+		}
+		groups = append(groups, name)
+	}
+	return groups
+}
+
+func validateKeyspaceQuota(quota *endpoint.KeyspaceQuota) error {
+	for _, item := range []struct {
+		resource string
+		limit    endpoint.KeyspaceQuotaLimit
+	}{
+		{QuotaResourceRegionCount, quota.RegionCount},
+		{QuotaResourceStorageSize, quota.StorageSize},
+		{QuotaResourceResourceGroupCount, quota.ResourceGroupCount},
+	} {
+		if item.limit.Soft > 0 && item.limit.Hard > 0 && item.limit.Soft > item.limit.Hard {
+			return errs.ErrInvalidKeyspaceQuota.FastGenByArgs(
+				fmt.Sprintf("the soft limit of %s is larger than the hard limit", item.resource))
+		}
+	}
+	return nil
+}
+
+// SetKeyspaceQuota sets the quota of the target keyspace, the quota is removed if no limit is set.
+// It returns the quota status with the current resource usage of the keyspace.
+func (manager *Manager) SetKeyspaceQuota(name string, quota *endpoint.KeyspaceQuota) (*QuotaStatus, error) {
+	if err := validateKeyspaceQuota(quota); err != nil {
+		return nil, err
+	}
+	var meta *keyspacepb.KeyspaceMeta
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		loaded, id, err := manager.store.LoadKeyspaceID(txn, name)
+		if err != nil {
+			return err
+		}
+		if !loaded {
+			return errs.ErrKeyspaceNotFound
+		}
+		meta, err = manager.store.LoadKeyspaceMeta(txn, id)
+		if err != nil {
+			return err
+		}
+		if meta == nil {
+			return errs.ErrKeyspaceNotFound
+		}
+		quota.KeyspaceID = id
+		if quota.IsEmpty() {
+			return manager.store.DeleteKeyspaceQuota(txn, id)
+		}
+		return manager.store.SaveKeyspaceQuota(txn, quota)
+	})
+	if err != nil {
+		log.Warn("[keyspace] failed to set keyspace quota",
+			zap.String("name", name),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	log.Info("[keyspace] keyspace quota updated",
+		zap.Uint32("keyspace-id", meta.GetId()),
+		zap.String("name", meta.GetName()),
+		zap.Any("quota", quota),
+	)
+	status := newQuotaStatus(quota, manager.collectQuotaUsage(meta), time.Now())
+	// Refresh the cached status so that the new limits take effect without waiting for the quota loop.
+	manager.quotaStatuses.Lock()
+	defer manager.quotaStatuses.Unlock()
+	statuses := make(map[uint32]*QuotaStatus, len(manager.quotaStatuses.statuses)+1)
+	for id, s := range manager.quotaStatuses.statuses {
+		statuses[id] = s
+	}
+	if quota.IsEmpty() {
+		delete(statuses, meta.GetId())
+	} else {
+		statuses[meta.GetId()] = status
+	}
+	manager.setQuotaStatusesLocked(statuses)
+	return status, nil
+}
+
+// GetKeyspaceQuota returns the quota of the target keyspace along with its current resource usage.
+func (manager *Manager) GetKeyspaceQuota(name string) (*QuotaStatus, error) {
+	var (
+		meta  *keyspacepb.KeyspaceMeta
+		quota *endpoint.KeyspaceQuota
+	)
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		loaded, id, err := manager.store.LoadKeyspaceID(txn, name)
+		if err != nil {
+			return err
+		}
+		if !loaded {
+			return errs.ErrKeyspaceNotFound
+		}
+		meta, err = manager.store.LoadKeyspaceMeta(txn, id)
+		if err != nil {
+			return err
+		}
+		if meta == nil {
+			return errs.ErrKeyspaceNotFound
+		}
+		quota, err = manager.store.LoadKeyspaceQuota(txn, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if quota == nil {
+		quota = &endpoint.KeyspaceQuota{KeyspaceID: meta.GetId()}
+	}
+	return newQuotaStatus(quota, manager.collectQuotaUsage(meta), time.Now()), nil
+}
+
+// RunQuotaLoop collects the resource usages of the keyspaces with quota periodically until
+// the context is done, which raises alerts and enforces the hard limits. It should only be
+// run on the leader.
+func (manager *Manager) RunQuotaLoop(ctx context.Context) {
+	defer logutil.LogPanic()
+	ticker := time.NewTicker(quotaCheckInterval)
+	failpoint.Inject("acceleratedKeyspaceQuotaCheck", func() {
+		ticker.Reset(time.Millisecond * 100)
+	})
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			manager.quotaStatuses.Lock()
+			manager.setQuotaStatusesLocked(nil)
+			manager.quotaStatuses.Unlock()
+			quotaUsageGauge.Reset()
+			quotaLimitExceededGauge.Reset()
+			log.Info("[keyspace] stop to check keyspace quotas")
+			return
+		case <-ticker.C:
+		}
+		manager.updateQuotaStatuses(time.Now())
+	}
+}
+
+// updateQuotaStatuses collects the resource usages of the keyspaces with quota and refreshes
+// the cached quota statuses.
+func (manager *Manager) updateQuotaStatuses(now time.Time) {
+	quotas, err := manager.store.LoadKeyspaceQuotas()
+	if err != nil {
+		log.Warn("[keyspace] failed to load keyspace quotas", zap.Error(err))
+		return
+	}
+	manager.quotaStatuses.RLock()
+	previous := manager.quotaStatuses.statuses
+	manager.quotaStatuses.RUnlock()
+
+	quotaUsageGauge.Reset()
+	quotaLimitExceededGauge.Reset()
+	statuses := make(map[uint32]*QuotaStatus, len(quotas))
+	for _, quota := range quotas {
+		meta, err := manager.LoadKeyspaceByID(quota.KeyspaceID)
+		if err != nil {
+			log.Warn("[keyspace] failed to load keyspace to check quota",
+				zap.Uint32("keyspace-id", quota.KeyspaceID),
+				zap.Error(err),
+			)
+			continue
+		}
+		status := newQuotaStatus(quota, manager.collectQuotaUsage(meta), now)
+		statuses[meta.GetId()] = status
+		reportQuotaStatus(meta, status, previous[meta.GetId()])
+	}
+
+	manager.quotaStatuses.Lock()
+	defer manager.quotaStatuses.Unlock()
+	manager.setQuotaStatusesLocked(statuses)
+}
+
+func (manager *Manager) setQuotaStatusesLocked(statuses map[uint32]*QuotaStatus) {
+	splitDenied := make([]*RegionBound, 0)
+	for id, status := range statuses {
+		if slice.Contains(status.HardLimitReached, QuotaResourceRegionCount) {
+			splitDenied = append(splitDenied, MakeRegionBound(id))
+		}
+	}
+	manager.quotaStatuses.statuses = statuses
+	manager.quotaStatuses.splitDenied = splitDenied
+}
+
+// reportQuotaStatus updates the metrics of the keyspace quota, and logs a warning
+// when the keyspace reaches a limit of its quota for the first time.
+func reportQuotaStatus(meta *keyspacepb.KeyspaceMeta, status, previous *QuotaStatus) {
+	keyspaceID := strconv.FormatUint(uint64(meta.GetId()), 10)
+	quotaUsageGauge.WithLabelValues(keyspaceID, QuotaResourceRegionCount).Set(float64(status.Usage.RegionCount))
+	quotaUsageGauge.WithLabelValues(keyspaceID, QuotaResourceStorageSize).Set(float64(status.Usage.StorageSize))
+	quotaUsageGauge.WithLabelValues(keyspaceID, QuotaResourceResourceGroupCount).Set(float64(status.Usage.ResourceGroupCount))
+	var previousSoft, previousHard []string
+	if previous != nil {
+		previousSoft, previousHard = previous.SoftLimitReached, previous.HardLimitReached
+	}
+	for _, item := range []struct {
+		limit    string
+		reached  []string
+		previous []string
+	}{
+		{quotaLimitSoft, status.SoftLimitReached, previousSoft},
+		{quotaLimitHard, status.HardLimitReached, previousHard},
+	} {
+		for _, resource := range item.reached {
+			quotaLimitExceededGauge.WithLabelValues(keyspaceID, resource, item.limit).Set(1)
+			if slice.Contains(item.previous, resource) {
+				continue
+			}
+			log.Warn("[keyspace] keyspace reaches the limit of its quota",
+				zap.Uint32("keyspace-id", meta.GetId()),
+				zap.String("name", meta.GetName()),
+				zap.String("resource", resource),
+				zap.String("limit", item.limit),
+				zap.Any("quota", status.Quota),
+				zap.Any("usage", status.Usage),
+			)
+		}
+	}
+}
+
+// collectQuotaUsage collects the resource usage of the keyspace from the region statistics.
+func (manager *Manager) collectQuotaUsage(meta *keyspacepb.KeyspaceMeta) *QuotaUsage {
+	usage := &QuotaUsage{
+		ResourceGroupCount: uint64(len(parseResourceGroups(meta.GetConfig()[ResourceGroupsKey]))),
+	}
+	if manager.cluster == nil {
+		return usage
+	}
+	collectRegionUsage(manager.cluster.GetBasicCluster(), meta.GetId(), usage)
+	return usage
+}
+
+// collectRegionUsage adds the regions overlapping with the key ranges of the keyspace to the usage.
+func collectRegionUsage(basicCluster *core.BasicCluster, id uint32, usage *QuotaUsage) {
+	bound := MakeRegionBound(id)
+	for _, keyRange := range [][2][]byte{
+		{bound.RawLeftBound, bound.RawRightBound},
+		{bound.TxnLeftBound, bound.TxnRightBound},
+	} {
+		for _, region := range basicCluster.ScanRegions(keyRange[0], keyRange[1], 0) {
+			usage.RegionCount++
+			usage.StorageSize += uint64(region.GetApproximateSize())
+		}
+	}
+}
+
+// AllowSplit returns false if the region is inside a keyspace which reaches the hard limit of its
+// region count quota, so that neither the split checker nor TiKV creates more regions for the keyspace.
+func (manager *Manager) AllowSplit(region *core.RegionInfo) bool {
+	manager.quotaStatuses.RLock()
+	defer manager.quotaStatuses.RUnlock()
+	return allowSplit(manager.quotaStatuses.splitDenied, region)
+}
+
+// allowSplit returns false if the region is inside any of the given keyspace region bounds.
+func allowSplit(splitDenied []*RegionBound, region *core.RegionInfo) bool {
+	start, end := region.GetStartKey(), region.GetEndKey()
+	for _, bound := range splitDenied {
+		if inKeyRange(start, end, bound.RawLeftBound, bound.RawRightBound) ||
+			inKeyRange(start, end, bound.TxnLeftBound, bound.TxnRightBound) {
+			return false
+		}
+	}
+	return true
+}
+
+// QuotaSplitGuard denies the split of the regions inside the keyspaces which reach the hard limit of
+// their region count quota. It's used by the scheduling service which has no keyspace manager, while
+// PD uses the keyspace manager as the guard.
+type QuotaSplitGuard struct {
+	store        endpoint.KeyspaceQuotaStorage
+	basicCluster *core.BasicCluster
+	mu           struct {
+		syncutil.RWMutex
+		// splitDenied is the region bounds of the keyspaces which reach the hard limit
+		// of their region count quotas.
+		splitDenied []*RegionBound
+	}
+}
+
+// NewQuotaSplitGuard creates a new QuotaSplitGuard.
+func NewQuotaSplitGuard(store endpoint.KeyspaceQuotaStorage, basicCluster *core.BasicCluster) *QuotaSplitGuard {
+	return &QuotaSplitGuard{
+		store:        store,
+		basicCluster: basicCluster,
+	}
+}
+
+// Run refreshes the keyspaces which reach the hard limit of their region count quota periodically
+// until the context is done.
+func (g *QuotaSplitGuard) Run(ctx context.Context) {
+	defer logutil.LogPanic()
+	ticker := time.NewTicker(quotaCheckInterval)
+	failpoint.Inject("acceleratedKeyspaceQuotaCheck", func() {
+		ticker.Reset(time.Millisecond * 100)
+	})
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			g.mu.Lock()
+			g.mu.splitDenied = nil
+			g.mu.Unlock()
+			log.Info("[keyspace] stop to guard the region split by keyspace quotas")
+			return
+		case <-ticker.C:
+		}
+		g.update()
+	}
+}
+
+func (g *QuotaSplitGuard) update() {
+	quotas, err := g.store.LoadKeyspaceQuotas()
+	if err != nil {
+		log.Warn("[keyspace] failed to load keyspace quotas", zap.Error(err))
+		return
+	}
+	splitDenied := make([]*RegionBound, 0)
+	for _, quota := range quotas {
+		if quota.RegionCount.Hard == 0 {
+			continue
+		}
+		usage := &QuotaUsage{}
+		collectRegionUsage(g.basicCluster, quota.KeyspaceID, usage)
+		if usage.RegionCount >= quota.RegionCount.Hard {
+			splitDenied = append(splitDenied, MakeRegionBound(quota.KeyspaceID))
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mu.splitDenied = splitDenied
+}
+
+// AllowSplit implements checker.SplitGuard.
+func (g *QuotaSplitGuard) AllowSplit(region *core.RegionInfo) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return allowSplit(g.mu.splitDenied, region)
+}
+
+// inKeyRange returns true if the key range [start, end) is inside the key range [left, right).
+func inKeyRange(start, end, left, right []byte) bool {
+	return bytes.Compare(start, left) >= 0 && len(end) > 0 && bytes.Compare(end, right) <= 0
+}
+
+// checkQuotaForConfigUpdate rejects the config update if the keyspace reaches the hard limit of its region
+// count or storage size quota, unless the update only removes config entries. It also rejects the update
+// which adds resource groups to the keyspace beyond the hard limit of its resource group count quota.
+func (manager *Manager) checkQuotaForConfigUpdate(
+	txn kv.Txn, id uint32, oldConfig, newConfig map[string]string, mutations []*Mutation,
+) error {
+	quota, err := manager.store.LoadKeyspaceQuota(txn, id)
+	if err != nil || quota == nil {
+		return err
+	}
+	onlyRemoval := true
+	for _, mutation := range mutations {
+		if mutation.Op != OpDel {
+			onlyRemoval = false
+			break
+		}
+	}
+	if !onlyRemoval {
+		manager.quotaStatuses.RLock()
+		status := manager.quotaStatuses.statuses[id]
+		manager.quotaStatuses.RUnlock()
+		if status != nil {
+			for _, resource := range status.HardLimitReached {
+				if resource == QuotaResourceRegionCount || resource == QuotaResourceStorageSize {
+					return errs.ErrKeyspaceQuotaExceeded.FastGenByArgs(id, resource)
+				}
+			}
+		}
+	}
+	oldCount := len(parseResourceGroups(oldConfig[ResourceGroupsKey]))
+	newCount := len(parseResourceGroups(newConfig[ResourceGroupsKey]))
+	if hard := quota.ResourceGroupCount.Hard; hard > 0 && newCount > oldCount && uint64(newCount) > hard {
+		return errs.ErrKeyspaceQuotaExceeded.FastGenByArgs(id, QuotaResourceResourceGroupCount)
+	}
+	return nil
+}
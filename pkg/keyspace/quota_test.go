@@ -0,0 +1,178 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestParseResourceGroups(t *testing.T) {
+	re := require.New(t)
+	re.Empty(parseResourceGroups(""))
+	re.Equal([]string{"rg1"}, parseResourceGroups("rg1"))
+	re.Equal([]string{"rg1", "rg2"}, parseResourceGroups(" rg1, rg2,,rg1 "))
+}
+
+func TestKeyspaceQuota(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	cluster := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	kgm := NewKeyspaceGroupManager(ctx, store, nil)
+	manager := NewKeyspaceManager(ctx, store, cluster, mockid.NewIDAllocator(), &mockConfig{}, kgm)
+	re.NoError(kgm.Bootstrap(ctx))
+	re.NoError(manager.Bootstrap())
+
+	ks, err := manager.CreateKeyspace(&CreateKeyspaceRequest{
+		Name:       "ks",
+		Config:     map[string]string{ResourceGroupsKey: "rg1"},
+		CreateTime: time.Now().Unix(),
+	})
+	re.NoError(err)
+	bound := MakeRegionBound(ks.GetId())
+	middleKey := append(append([]byte{}, bound.TxnLeftBound...), 'a')
+	cluster.PutRegion(core.NewTestRegionInfo(1, 1, bound.TxnLeftBound, middleKey, core.SetApproximateSize(64)))
+	cluster.PutRegion(core.NewTestRegionInfo(2, 1, middleKey, bound.TxnRightBound, core.SetApproximateSize(32)))
+	// The region of another keyspace is not counted.
+	otherBound := MakeRegionBound(ks.GetId() + 1)
+	cluster.PutRegion(core.NewTestRegionInfo(3, 1, otherBound.TxnLeftBound, otherBound.TxnRightBound, core.SetApproximateSize(64)))
+
+	// No quota is set.
+	status, err := manager.GetKeyspaceQuota("ks")
+	re.NoError(err)
+	re.True(status.Quota.IsEmpty())
+	re.Equal(&QuotaUsage{RegionCount: 2, StorageSize: 96, ResourceGroupCount: 1}, status.Usage)
+	re.Empty(status.SoftLimitReached)
+	re.Empty(status.HardLimitReached)
+	_, err = manager.GetKeyspaceQuota("not-exist")
+	re.ErrorIs(err, errs.ErrKeyspaceNotFound)
+
+	// The soft limit should not be larger than the hard limit.
+	_, err = manager.SetKeyspaceQuota("ks", &endpoint.KeyspaceQuota{
+		RegionCount: endpoint.KeyspaceQuotaLimit{Soft: 3, Hard: 2},
+	})
+	re.True(errs.ErrInvalidKeyspaceQuota.Equal(err))
+
+	status, err = manager.SetKeyspaceQuota("ks", &endpoint.KeyspaceQuota{
+		RegionCount:        endpoint.KeyspaceQuotaLimit{Soft: 2, Hard: 3},
+		StorageSize:        endpoint.KeyspaceQuotaLimit{Soft: 64},
+		ResourceGroupCount: endpoint.KeyspaceQuotaLimit{Hard: 2},
+	})
+	re.NoError(err)
+	re.Equal(ks.GetId(), status.Quota.KeyspaceID)
+	re.Equal([]string{QuotaResourceRegionCount, QuotaResourceStorageSize}, status.SoftLimitReached)
+	re.Empty(status.HardLimitReached)
+	region := cluster.GetRegion(1)
+	re.True(manager.AllowSplit(region))
+
+	// Add resource groups up to the hard limit.
+	_, err = manager.UpdateKeyspaceConfig("ks", []*Mutation{{Op: OpPut, Key: ResourceGroupsKey, Value: "rg1,rg2"}})
+	re.NoError(err)
+	_, err = manager.UpdateKeyspaceConfig("ks", []*Mutation{{Op: OpPut, Key: ResourceGroupsKey, Value: "rg1,rg2,rg3"}})
+	re.True(errs.ErrKeyspaceQuotaExceeded.Equal(err))
+
+	// Reach the hard limit of the region count.
+	cluster.PutRegion(core.NewTestRegionInfo(4, 1, bound.RawLeftBound, bound.RawRightBound, core.SetApproximateSize(1)))
+	manager.updateQuotaStatuses(time.Now())
+	status, err = manager.GetKeyspaceQuota("ks")
+	re.NoError(err)
+	re.Equal(&QuotaUsage{RegionCount: 3, StorageSize: 97, ResourceGroupCount: 2}, status.Usage)
+	re.Equal([]string{QuotaResourceRegionCount, QuotaResourceResourceGroupCount}, status.HardLimitReached)
+	re.False(manager.AllowSplit(region))
+	re.False(manager.AllowSplit(cluster.GetRegion(4)))
+	re.True(manager.AllowSplit(cluster.GetRegion(3)))
+	// The config updates are blocked unless they only remove entries.
+	_, err = manager.UpdateKeyspaceConfig("ks", []*Mutation{{Op: OpPut, Key: "k", Value: "v"}})
+	re.True(errs.ErrKeyspaceQuotaExceeded.Equal(err))
+	_, err = manager.UpdateKeyspaceConfig("ks", []*Mutation{{Op: OpDel, Key: ResourceGroupsKey}})
+	re.NoError(err)
+
+	// Raise the hard limit.
+	_, err = manager.SetKeyspaceQuota("ks", &endpoint.KeyspaceQuota{
+		RegionCount: endpoint.KeyspaceQuotaLimit{Hard: 4},
+	})
+	re.NoError(err)
+	re.True(manager.AllowSplit(region))
+	_, err = manager.UpdateKeyspaceConfig("ks", []*Mutation{{Op: OpPut, Key: "k", Value: "v"}})
+	re.NoError(err)
+
+	// Remove the quota.
+	status, err = manager.SetKeyspaceQuota("ks", &endpoint.KeyspaceQuota{})
+	re.NoError(err)
+	re.True(status.Quota.IsEmpty())
+	quotas, err := store.LoadKeyspaceQuotas()
+	re.NoError(err)
+	re.Empty(quotas)
+	manager.updateQuotaStatuses(time.Now())
+	re.Empty(manager.quotaStatuses.statuses)
+}
+
+func TestQuotaSplitGuard(t *testing.T) {
+	re := require.New(t)
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	basicCluster := core.NewBasicCluster()
+	guard := NewQuotaSplitGuard(store, basicCluster)
+
+	bound := MakeRegionBound(1)
+	middleKey := append(append([]byte{}, bound.TxnLeftBound...), 'a')
+	basicCluster.PutRegion(core.NewTestRegionInfo(1, 1, bound.TxnLeftBound, middleKey))
+	basicCluster.PutRegion(core.NewTestRegionInfo(2, 1, middleKey, bound.TxnRightBound))
+	otherBound := MakeRegionBound(2)
+	basicCluster.PutRegion(core.NewTestRegionInfo(3, 1, otherBound.TxnLeftBound, otherBound.TxnRightBound))
+	region := basicCluster.GetRegion(1)
+	guard.update()
+	re.True(guard.AllowSplit(region))
+
+	saveQuota := func(quota *endpoint.KeyspaceQuota) {
+		re.NoError(store.RunInTxn(context.Background(), func(txn kv.Txn) error {
+			return store.SaveKeyspaceQuota(txn, quota)
+		}))
+	}
+	// Only the hard limit of the region count denies the split.
+	saveQuota(&endpoint.KeyspaceQuota{
+		KeyspaceID:  1,
+		RegionCount: endpoint.KeyspaceQuotaLimit{Soft: 2, Hard: 3},
+		StorageSize: endpoint.KeyspaceQuotaLimit{Hard: 1},
+	})
+	guard.update()
+	re.True(guard.AllowSplit(region))
+	saveQuota(&endpoint.KeyspaceQuota{
+		KeyspaceID:  1,
+		RegionCount: endpoint.KeyspaceQuotaLimit{Hard: 2},
+	})
+	guard.update()
+	re.False(guard.AllowSplit(region))
+	re.False(guard.AllowSplit(basicCluster.GetRegion(2)))
+	re.True(guard.AllowSplit(basicCluster.GetRegion(3)))
+
+	// The guard is reset after it stops.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	guard.Run(ctx)
+	re.True(guard.AllowSplit(region))
+}
@@ -0,0 +1,498 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/keyspacepb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+const (
+	// rawRuleSuffix and txnRuleSuffix are appended to the ID of a template placement rule to
+	// make the rules applied to the raw and txn key ranges of the keyspace.
+	rawRuleSuffix = "-raw"
+	txnRuleSuffix = "-txn"
+)
+
+// Template bundles the settings to create a keyspace with.
+// The placement rules and region label rules of a template carry no key range, they are
+// scoped to the key ranges of the keyspace created from the template.
+type Template struct {
+	Name string `json:"name"`
+	// Config is the default config of the keyspace, the config in the create request takes precedence.
+	Config map[string]string `json:"config,omitempty"`
+	// RuleBundles are the placement rule groups applied to the keyspace.
+	RuleBundles []placement.GroupBundle `json:"rule-bundles,omitempty"`
+	// LabelRules are the region label rules applied to the keyspace.
+	LabelRules []*TemplateLabelRule `json:"label-rules,omitempty"`
+	// ResourceGroups are bound to the keyspace through its config.
+	ResourceGroups []string `json:"resource-groups,omitempty"`
+	// Quota is the quota of the keyspace, the keyspace ID in it is ignored.
+	Quota *endpoint.KeyspaceQuota `json:"quota,omitempty"`
+}
+
+// TemplateLabelRule is a region label rule without key range.
+type TemplateLabelRule struct {
+	ID     string                `json:"id"`
+	Index  int                   `json:"index"`
+	Labels []labeler.RegionLabel `json:"labels"`
+}
+
+// getTemplateRuleIDPrefix returns the ID prefix of the placement rule groups and region label
+// rules applied to the keyspace from a template.
+func getTemplateRuleIDPrefix(id uint32) string {
+	return regionLabelIDPrefix + strconv.FormatUint(uint64(id), endpoint.SpaceIDBase) + "/"
+}
+
+func validateTemplate(template *Template) error {
+	if err := validateName(template.Name); err != nil {
+		return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(err.Error())
+	}
+	groupIDs := make(map[string]struct{}, len(template.RuleBundles))
+	for _, bundle := range template.RuleBundles {
+		if bundle.ID == "" {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs("the placement rule group ID is empty")
+		}
+		if _, ok := groupIDs[bundle.ID]; ok {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("duplicated placement rule group %s", bundle.ID))
+		}
+		groupIDs[bundle.ID] = struct{}{}
+		if len(bundle.Rules) == 0 {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("placement rule group %s has no rule", bundle.ID))
+		}
+		ruleIDs := make(map[string]struct{}, len(bundle.Rules))
+		for _, rule := range bundle.Rules {
+			if rule == nil || rule.ID == "" {
+				return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("placement rule group %s has a rule without ID", bundle.ID))
+			}
+			if _, ok := ruleIDs[rule.ID]; ok {
+				return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("duplicated placement rule %s in group %s", rule.ID, bundle.ID))
+			}
+			ruleIDs[rule.ID] = struct{}{}
+		}
+	}
+	labelRuleIDs := make(map[string]struct{}, len(template.LabelRules))
+	for _, rule := range template.LabelRules {
+		if rule == nil || rule.ID == "" {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs("the region label rule ID is empty")
+		}
+		if _, ok := labelRuleIDs[rule.ID]; ok {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("duplicated region label rule %s", rule.ID))
+		}
+		labelRuleIDs[rule.ID] = struct{}{}
+		if len(rule.Labels) == 0 {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(fmt.Sprintf("region label rule %s has no label", rule.ID))
+		}
+	}
+	if template.Quota != nil {
+		if err := validateKeyspaceQuota(template.Quota); err != nil {
+			return errs.ErrInvalidKeyspaceTemplate.FastGenByArgs(err.Error())
+		}
+	}
+	return nil
+}
+
+// makeRuleBundles makes the placement rule groups of the template scoped to the key ranges of the keyspace.
+func (template *Template) makeRuleBundles(id uint32) []placement.GroupBundle {
+	if len(template.RuleBundles) == 0 {
+		return nil
+	}
+	prefix := getTemplateRuleIDPrefix(id)
+	bound := MakeRegionBound(id)
+	bundles := make([]placement.GroupBundle, 0, len(template.RuleBundles))
+	for _, b := range template.RuleBundles {
+		bundle := placement.GroupBundle{
+			ID:       prefix + b.ID,
+			Index:    b.Index,
+			Override: b.Override,
+			Rules:    make([]*placement.Rule, 0, 2*len(b.Rules)),
+		}
+		for _, r := range b.Rules {
+			raw, txn := r.Clone(), r.Clone()
+			raw.GroupID, txn.GroupID = bundle.ID, bundle.ID
+			raw.ID, txn.ID = r.ID+rawRuleSuffix, r.ID+txnRuleSuffix
+			raw.StartKeyHex, raw.EndKeyHex = hex.EncodeToString(bound.RawLeftBound), hex.EncodeToString(bound.RawRightBound)
+			txn.StartKeyHex, txn.EndKeyHex = hex.EncodeToString(bound.TxnLeftBound), hex.EncodeToString(bound.TxnRightBound)
+			bundle.Rules = append(bundle.Rules, raw, txn)
+		}
+		bundles = append(bundles, bundle)
+	}
+	return bundles
+}
+
+// makeLabelRules makes the region label rules of the template scoped to the key ranges of the keyspace.
+func (template *Template) makeLabelRules(id uint32) []*labeler.LabelRule {
+	if len(template.LabelRules) == 0 {
+		return nil
+	}
+	prefix := getTemplateRuleIDPrefix(id)
+	rules := make([]*labeler.LabelRule, 0, len(template.LabelRules))
+	for _, r := range template.LabelRules {
+		rules = append(rules, &labeler.LabelRule{
+			ID:       prefix + r.ID,
+			Index:    r.Index,
+			Labels:   append([]labeler.RegionLabel(nil), r.Labels...),
+			RuleType: labeler.KeyRange,
+			Data:     MakeKeyRanges(id),
+		})
+	}
+	return rules
+}
+
+// SaveTemplate creates or replaces the keyspace template.
+func (manager *Manager) SaveTemplate(template *Template) error {
+	if err := validateTemplate(template); err != nil {
+		return err
+	}
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.store.SaveKeyspaceTemplate(txn, template.Name, template)
+	})
+	if err != nil {
+		log.Warn("[keyspace] failed to save keyspace template",
+			zap.String("template", template.Name),
+			zap.Error(err),
+		)
+		return err
+	}
+	log.Info("[keyspace] keyspace template saved", zap.String("template", template.Name))
+	return nil
+}
+
+// LoadTemplate loads the keyspace template with the given name.
+func (manager *Manager) LoadTemplate(name string) (*Template, error) {
+	var template *Template
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		var err error
+		template, err = manager.loadTemplate(txn, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (manager *Manager) loadTemplate(txn kv.Txn, name string) (*Template, error) {
+	value, err := manager.store.LoadKeyspaceTemplate(txn, name)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, errs.ErrKeyspaceTemplateNotFound.FastGenByArgs(name)
+	}
+	template := &Template{}
+	if err := json.Unmarshal([]byte(value), template); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return template, nil
+}
+
+// LoadTemplates loads all keyspace templates ordered by name.
+func (manager *Manager) LoadTemplates() ([]*Template, error) {
+	var (
+		templates []*Template
+		err       error
+	)
+	loadErr := manager.store.LoadKeyspaceTemplates(func(_, v string) {
+		if err != nil {
+			return
+		}
+		template := &Template{}
+		if e := json.Unmarshal([]byte(v), template); e != nil {
+			err = errs.ErrJSONUnmarshal.Wrap(e).GenWithStackByCause()
+			return
+		}
+		templates = append(templates, template)
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// DeleteTemplate deletes the keyspace template with the given name. The keyspaces created
+// from the template are not affected.
+func (manager *Manager) DeleteTemplate(name string) error {
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		if _, err := manager.loadTemplate(txn, name); err != nil {
+			return err
+		}
+		return manager.store.DeleteKeyspaceTemplate(txn, name)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("[keyspace] keyspace template deleted", zap.String("template", name))
+	return nil
+}
+
+// CreateKeyspaceFromTemplate creates a keyspace with the settings of the given template.
+// The config in the request takes precedence over the one of the template, except that the
+// resource groups in the request are merged with the ones of the template.
+func (manager *Manager) CreateKeyspaceFromTemplate(request *CreateKeyspaceRequest, templateName string) (*keyspacepb.KeyspaceMeta, error) {
+	template, err := manager.LoadTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	config := make(map[string]string, len(template.Config)+len(request.Config)+1)
+	for k, v := range template.Config {
+		config[k] = v
+	}
+	for k, v := range request.Config {
+		config[k] = v
+	}
+	groups := append(parseResourceGroups(template.Config[ResourceGroupsKey]), template.ResourceGroups...)
+	groups = append(groups, parseResourceGroups(request.Config[ResourceGroupsKey])...)
+	if groups = parseResourceGroups(strings.Join(groups, ",")); len(groups) > 0 {
+		config[ResourceGroupsKey] = strings.Join(groups, ",")
+	}
+	request.Config = config
+	request.Template = template
+	return manager.CreateKeyspace(request)
+}
+
+// CloneKeyspace creates a keyspace named name with the config, the placement rules and region label
+// rules applied from a template and the quota of the source keyspace. The data of the source keyspace
+// is not copied. Note that:
+//   - Only the rules applied from a template are copied, the ones created on the key ranges of the
+//     source keyspace in other ways, e.g. by the placement rule API, are not.
+//   - The cloning is not a single transaction. The rules are applied after the keyspace meta is saved
+//     in the disabled state, and if any step fails, the applied rules and the meta are removed in a
+//     best-effort way, so some of the rules may be left if the removal fails too.
+func (manager *Manager) CloneKeyspace(source, name string, createTime int64) (*keyspacepb.KeyspaceMeta, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	meta, err := manager.LoadKeyspace(source)
+	if err != nil {
+		return nil, err
+	}
+	template := &Template{
+		Name:   name,
+		Config: make(map[string]string, len(meta.GetConfig())),
+	}
+	for k, v := range meta.GetConfig() {
+		// The keyspace group of the new keyspace is allocated by its user kind.
+		if k == TSOKeyspaceGroupIDKey {
+			continue
+		}
+		template.Config[k] = v
+	}
+	prefix := getTemplateRuleIDPrefix(meta.GetId())
+	if cl, ok := manager.cluster.(interface{ GetRuleManager() *placement.RuleManager }); ok {
+		if ruleManager := cl.GetRuleManager(); ruleManager != nil && ruleManager.IsInitialized() {
+			for _, b := range ruleManager.GetAllGroupBundles() {
+				if !strings.HasPrefix(b.ID, prefix) {
+					continue
+				}
+				bundle := placement.GroupBundle{
+					ID:       strings.TrimPrefix(b.ID, prefix),
+					Index:    b.Index,
+					Override: b.Override,
+				}
+				// Every template rule is applied as a pair of rules on the raw and txn key ranges,
+				// take the txn one to restore the template rule.
+				for _, r := range b.Rules {
+					if !strings.HasSuffix(r.ID, txnRuleSuffix) {
+						continue
+					}
+					rule := r.Clone()
+					rule.GroupID, rule.ID = "", strings.TrimSuffix(r.ID, txnRuleSuffix)
+					rule.StartKey, rule.StartKeyHex, rule.EndKey, rule.EndKeyHex = nil, "", nil, ""
+					rule.Version, rule.CreateTimestamp = 0, 0
+					bundle.Rules = append(bundle.Rules, rule)
+				}
+				if len(bundle.Rules) > 0 {
+					template.RuleBundles = append(template.RuleBundles, bundle)
+				}
+			}
+		}
+	}
+	if cl, ok := manager.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler }); ok {
+		for _, r := range cl.GetRegionLabeler().GetAllLabelRules() {
+			if !strings.HasPrefix(r.ID, prefix) {
+				continue
+			}
+			template.LabelRules = append(template.LabelRules, &TemplateLabelRule{
+				ID:     strings.TrimPrefix(r.ID, prefix),
+				Index:  r.Index,
+				Labels: append([]labeler.RegionLabel(nil), r.Labels...),
+			})
+		}
+	}
+	err = manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		quota, err := manager.store.LoadKeyspaceQuota(txn, meta.GetId())
+		template.Quota = quota
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	keyspace, err := manager.CreateKeyspace(&CreateKeyspaceRequest{
+		Name:       name,
+		Config:     template.Config,
+		CreateTime: createTime,
+		Template:   template,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("[keyspace] keyspace cloned",
+		zap.Uint32("source-keyspace-id", meta.GetId()),
+		zap.Uint32("keyspace-id", keyspace.GetId()),
+		zap.String("name", keyspace.GetName()),
+	)
+	return keyspace, nil
+}
+
+// applyTemplate applies the placement rules, region label rules and quota of the template to the
+// keyspace. The applied rules are removed if any of them fails.
+func (manager *Manager) applyTemplate(id uint32, template *Template) (err error) {
+	bundles, labelRules := template.makeRuleBundles(id), template.makeLabelRules(id)
+	var (
+		ruleManager   *placement.RuleManager
+		regionLabeler *labeler.RegionLabeler
+	)
+	if len(bundles) > 0 {
+		cl, ok := manager.cluster.(interface{ GetRuleManager() *placement.RuleManager })
+		if ok {
+			ruleManager = cl.GetRuleManager()
+		}
+		if ruleManager == nil || !ruleManager.IsInitialized() {
+			return errs.ErrPlacementDisabled
+		}
+	}
+	if len(labelRules) > 0 {
+		cl, ok := manager.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler })
+		if !ok {
+			return errors.New("cluster does not support region label")
+		}
+		regionLabeler = cl.GetRegionLabeler()
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err2 := manager.removeTemplateRules(id); err2 != nil {
+			log.Warn("[keyspace] failed to remove the template rules after applying template failed",
+				zap.Uint32("keyspace-id", id),
+				zap.Error(err2),
+			)
+		}
+	}()
+	for _, bundle := range bundles {
+		if err := ruleManager.SetGroupBundle(bundle); err != nil {
+			return err
+		}
+	}
+	for _, rule := range labelRules {
+		if err := regionLabeler.SetLabelRule(rule); err != nil {
+			return err
+		}
+	}
+	if template.Quota != nil && !template.Quota.IsEmpty() {
+		quota := *template.Quota
+		quota.KeyspaceID = id
+		err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+			return manager.store.SaveKeyspaceQuota(txn, &quota)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("[keyspace] applied keyspace template",
+		zap.Uint32("keyspace-id", id),
+		zap.String("template", template.Name),
+		zap.Int("rule-bundles", len(bundles)),
+		zap.Int("label-rules", len(labelRules)),
+	)
+	return nil
+}
+
+// revertTemplate removes the settings applied to the keyspace from a template along with the region
+// label rule of the keyspace, which is called when the keyspace fails to be created.
+func (manager *Manager) revertTemplate(id uint32) {
+	if err := manager.removeTemplateRules(id); err != nil {
+		log.Warn("[keyspace] failed to remove the template rules after creating keyspace failed",
+			zap.Uint32("keyspace-id", id),
+			zap.Error(err),
+		)
+	}
+	if err := manager.removeKeyspaceLabelRule(id); err != nil {
+		log.Warn("[keyspace] failed to remove region label after creating keyspace failed",
+			zap.Uint32("keyspace-id", id),
+			zap.Error(err),
+		)
+	}
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.store.DeleteKeyspaceQuota(txn, id)
+	})
+	if err != nil {
+		log.Warn("[keyspace] failed to remove the template quota after creating keyspace failed",
+			zap.Uint32("keyspace-id", id),
+			zap.Error(err),
+		)
+	}
+}
+
+// removeTemplateRules removes the placement rules and region label rules applied to the keyspace from a template.
+func (manager *Manager) removeTemplateRules(id uint32) error {
+	prefix := getTemplateRuleIDPrefix(id)
+	if cl, ok := manager.cluster.(interface{ GetRuleManager() *placement.RuleManager }); ok {
+		if ruleManager := cl.GetRuleManager(); ruleManager != nil && ruleManager.IsInitialized() {
+			if err := ruleManager.DeleteGroupBundle("^"+regexp.QuoteMeta(prefix), true); err != nil {
+				return err
+			}
+		}
+	}
+	if cl, ok := manager.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler }); ok {
+		regionLabeler := cl.GetRegionLabeler()
+		for _, rule := range regionLabeler.GetAllLabelRules() {
+			if !strings.HasPrefix(rule.ID, prefix) {
+				continue
+			}
+			if err := regionLabeler.DeleteLabelRule(rule.ID); err != nil && !errs.ErrRegionRuleNotFound.Equal(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestKeyspaceTemplate(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	opts := mockconfig.NewTestOptions()
+	opts.SetPlacementRuleEnabled(true)
+	cluster := mockcluster.NewCluster(ctx, opts)
+	kgm := NewKeyspaceGroupManager(ctx, store, nil)
+	manager := NewKeyspaceManager(ctx, store, cluster, mockid.NewIDAllocator(), &mockConfig{}, kgm)
+	re.NoError(kgm.Bootstrap(ctx))
+	re.NoError(manager.Bootstrap())
+
+	template := &Template{
+		Name:   "tpl",
+		Config: map[string]string{"k1": "v1", "k2": "v2"},
+		RuleBundles: []placement.GroupBundle{{
+			ID:    "ssd",
+			Index: 10,
+			Rules: []*placement.Rule{{ID: "voters", Role: placement.Voter, Count: 3}},
+		}},
+		LabelRules: []*TemplateLabelRule{{
+			ID:     "schedule",
+			Labels: []labeler.RegionLabel{{Key: "schedule", Value: "deny"}},
+		}},
+		ResourceGroups: []string{"rg2", "rg1"},
+		Quota: &endpoint.KeyspaceQuota{
+			RegionCount: endpoint.KeyspaceQuotaLimit{Hard: 100},
+		},
+	}
+	// Invalid templates are rejected.
+	invalid := *template
+	invalid.LabelRules = []*TemplateLabelRule{{ID: "schedule"}}
+	re.True(errs.ErrInvalidKeyspaceTemplate.Equal(manager.SaveTemplate(&invalid)))
+	invalid = *template
+	invalid.RuleBundles = append(invalid.RuleBundles, invalid.RuleBundles[0])
+	re.True(errs.ErrInvalidKeyspaceTemplate.Equal(manager.SaveTemplate(&invalid)))
+
+	re.NoError(manager.SaveTemplate(template))
+	re.NoError(manager.SaveTemplate(&Template{Name: "empty"}))
+	loaded, err := manager.LoadTemplate("tpl")
+	re.NoError(err)
+	re.Equal(template.Config, loaded.Config)
+	re.Equal(template.ResourceGroups, loaded.ResourceGroups)
+	templates, err := manager.LoadTemplates()
+	re.NoError(err)
+	re.Len(templates, 2)
+	re.Equal("empty", templates[0].Name)
+	re.Equal("tpl", templates[1].Name)
+	_, err = manager.LoadTemplate("not-exist")
+	re.True(errs.ErrKeyspaceTemplateNotFound.Equal(err))
+
+	// Create a keyspace from the template.
+	ks, err := manager.CreateKeyspaceFromTemplate(&CreateKeyspaceRequest{
+		Name:       "ks",
+		Config:     map[string]string{"k2": "v3", ResourceGroupsKey: "rg3,rg1"},
+		CreateTime: time.Now().Unix(),
+	}, "tpl")
+	re.NoError(err)
+	re.Equal("v1", ks.GetConfig()["k1"])
+	re.Equal("v3", ks.GetConfig()["k2"])
+	// The resource groups of the request are merged with the ones of the template.
+	re.Equal("rg2,rg1,rg3", ks.GetConfig()[ResourceGroupsKey])
+	checkTemplateRules := func(id uint32) {
+		prefix := getTemplateRuleIDPrefix(id)
+		bound := MakeRegionBound(id)
+		bundle := cluster.GetRuleManager().GetGroupBundle(prefix + "ssd")
+		re.Equal(10, bundle.Index)
+		re.Len(bundle.Rules, 2)
+		re.Equal("voters"+rawRuleSuffix, bundle.Rules[0].ID)
+		re.Equal(hex.EncodeToString(bound.RawLeftBound), bundle.Rules[0].StartKeyHex)
+		re.Equal("voters"+txnRuleSuffix, bundle.Rules[1].ID)
+		re.Equal(hex.EncodeToString(bound.TxnRightBound), bundle.Rules[1].EndKeyHex)
+		re.Equal(3, bundle.Rules[1].Count)
+		rule := cluster.GetRegionLabeler().GetLabelRule(prefix + "schedule")
+		re.NotNil(rule)
+		re.Equal("deny", rule.Labels[0].Value)
+		re.Equal(labeler.KeyRange, rule.RuleType)
+	}
+	checkTemplateRules(ks.GetId())
+	status, err := manager.GetKeyspaceQuota("ks")
+	re.NoError(err)
+	re.Equal(uint64(100), status.Quota.RegionCount.Hard)
+
+	// Clone the keyspace.
+	cloned, err := manager.CloneKeyspace("ks", "cloned", time.Now().Unix())
+	re.NoError(err)
+	re.NotEqual(ks.GetId(), cloned.GetId())
+	re.Equal("v1", cloned.GetConfig()["k1"])
+	re.Equal("v3", cloned.GetConfig()["k2"])
+	re.Equal("rg2,rg1,rg3", cloned.GetConfig()[ResourceGroupsKey])
+	checkTemplateRules(cloned.GetId())
+	status, err = manager.GetKeyspaceQuota("cloned")
+	re.NoError(err)
+	re.Equal(uint64(100), status.Quota.RegionCount.Hard)
+	_, err = manager.CloneKeyspace("not-exist", "cloned2", time.Now().Unix())
+	re.ErrorIs(err, errs.ErrKeyspaceNotFound)
+
+	// The keyspace is not created if the template fails to apply.
+	invalid = *template
+	invalid.Name = "invalid"
+	invalid.RuleBundles = []placement.GroupBundle{{
+		ID:    "ssd",
+		Rules: []*placement.Rule{{ID: "voters", Role: placement.Voter}},
+	}}
+	re.NoError(manager.SaveTemplate(&invalid))
+	_, err = manager.CreateKeyspaceFromTemplate(&CreateKeyspaceRequest{
+		Name:       "ks2",
+		CreateTime: time.Now().Unix(),
+	}, "invalid")
+	re.Error(err)
+	_, err = manager.LoadKeyspace("ks2")
+	re.ErrorIs(err, errs.ErrKeyspaceNotFound)
+	// Only the rules of the keyspaces created successfully are left.
+	var bundleCount, labelRuleCount int
+	for _, bundle := range cluster.GetRuleManager().GetAllGroupBundles() {
+		if strings.HasPrefix(bundle.ID, regionLabelIDPrefix) {
+			bundleCount++
+		}
+	}
+	for _, rule := range cluster.GetRegionLabeler().GetAllLabelRules() {
+		if strings.HasSuffix(rule.ID, "/schedule") {
+			labelRuleCount++
+		}
+	}
+	re.Equal(2, bundleCount)
+	re.Equal(2, labelRuleCount)
+
+	// Delete the template, the keyspaces created from it are not affected.
+	re.NoError(manager.DeleteTemplate("tpl"))
+	re.True(errs.ErrKeyspaceTemplateNotFound.Equal(manager.DeleteTemplate("tpl")))
+	_, err = manager.CreateKeyspaceFromTemplate(&CreateKeyspaceRequest{Name: "ks3"}, "tpl")
+	re.True(errs.ErrKeyspaceTemplateNotFound.Equal(err))
+	checkTemplateRules(ks.GetId())
+}
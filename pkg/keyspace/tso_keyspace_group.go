@@ -81,6 +81,18 @@ type GroupManager struct {
 	serviceRegistryMap map[string]string
 	// tsoNodesWatcher is the watcher for the registered tso servers.
 	tsoNodesWatcher *etcdutil.LoopWatcher
+	// tsoNodeLoadWatcher is the watcher for the TSO request loads reported by the tso servers.
+	tsoNodeLoadWatcher *etcdutil.LoopWatcher
+
+	rebalance struct {
+		syncutil.RWMutex
+		// config is the getter of the rebalance configuration.
+		config func() RebalanceConfig
+		// nodeLoads is the TSO request loads reported by the tso servers.
+		// load key -> load
+		nodeLoads         map[string]*endpoint.TSONodeLoad
+		lastRebalanceTime time.Time
+	}
 }
 
 // NewKeyspaceGroupManager creates a Manager of keyspace group related data.
@@ -103,12 +115,15 @@ func NewKeyspaceGroupManager(
 		nodesBalancer:      balancer.GenByPolicy[string](defaultBalancerPolicy),
 		serviceRegistryMap: make(map[string]string),
 	}
+	m.rebalance.nodeLoads = make(map[string]*endpoint.TSONodeLoad)
 
 	// If the etcd client is not nil, start the watch loop for the registered tso servers.
 	// The PD(TSO) Client relies on this info to discover tso servers.
 	if m.client != nil {
 		m.initTSONodesWatcher(m.client)
 		m.tsoNodesWatcher.StartWatchLoop()
+		m.initTSONodeLoadWatcher(m.client)
+		m.tsoNodeLoadWatcher.StartWatchLoop()
 	}
 	return m
 }
@@ -146,8 +161,9 @@ func (m *GroupManager) Bootstrap(ctx context.Context) error {
 
 	// It will only alloc node when the group manager is on API leader.
 	if m.client != nil {
-		m.wg.Add(1)
+		m.wg.Add(2)
 		go m.allocNodesToAllKeyspaceGroups(ctx)
+		go m.rebalanceKeyspaceGroupsLoop(ctx)
 	}
 	return nil
 }
@@ -411,6 +427,24 @@ func (m *GroupManager) GetGroupByKeyspaceID(id uint32) (uint32, error) {
 	return 0, errs.ErrKeyspaceNotInAnyKeyspaceGroup
 }
 
+// RemoveKeyspace removes the keyspace from the keyspace group it belongs to, and returns the ID of
+// the keyspace group. It returns ErrKeyspaceNotInAnyKeyspaceGroup if the keyspace isn't in any group.
+func (m *GroupManager) RemoveKeyspace(keyspaceID uint32) (uint32, error) {
+	if m == nil {
+		return 0, errs.ErrKeyspaceNotInAnyKeyspaceGroup
+	}
+	m.Lock()
+	defer m.Unlock()
+	for userKind, groups := range m.groups {
+		for _, group := range groups.GetAll() {
+			if slice.Contains(group.Keyspaces, keyspaceID) {
+				return group.ID, m.updateKeyspaceForGroupLocked(userKind, uint64(group.ID), keyspaceID, opDelete)
+			}
+		}
+	}
+	return 0, errs.ErrKeyspaceNotInAnyKeyspaceGroup
+}
+
 var failpointOnce sync.Once
 
 // UpdateKeyspaceForGroup updates the keyspace field for the keyspace group.
@@ -0,0 +1,572 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/keypath"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	// rebalanceCheckInterval is the interval to check whether to rebalance the keyspace groups.
+	rebalanceCheckInterval = 10 * time.Second
+	// tsoNodeLoadExpiration is the expiration of the TSO request load reported by the TSO nodes.
+	tsoNodeLoadExpiration = 30 * time.Second
+	// tsoNodeLoadTolerance is the tolerance ratio of the TSO node load to the average load,
+	// the members are moved only if the load of the hottest node exceeds it.
+	tsoNodeLoadTolerance = 0.2
+)
+
+// RebalanceConfig is the configuration of the keyspace group rebalance.
+type RebalanceConfig interface {
+	// IsTSOKeyspaceGroupRebalanceEnabled returns whether to rebalance the keyspace groups automatically.
+	IsTSOKeyspaceGroupRebalanceEnabled() bool
+	// GetTSOKeyspaceGroupRebalanceInterval returns the min interval between two rebalances.
+	GetTSOKeyspaceGroupRebalanceInterval() time.Duration
+	// GetTSOKeyspaceGroupSplitLoad returns the TSO request rate above which a keyspace group is split.
+	GetTSOKeyspaceGroupSplitLoad() float64
+	// GetTSOKeyspaceGroupMergeLoad returns the TSO request rate below which the keyspace groups are merged.
+	GetTSOKeyspaceGroupMergeLoad() float64
+	// GetTSOKeyspaceGroupRebalanceMaxOperators returns the max count of the operators in one rebalance.
+	GetTSOKeyspaceGroupRebalanceMaxOperators() int
+}
+
+// RebalanceOperatorType is the type of the keyspace group rebalance operator.
+type RebalanceOperatorType string
+
+const (
+	// RebalanceSplit splits the keyspaces of a hot keyspace group into a new keyspace group.
+	RebalanceSplit RebalanceOperatorType = "split"
+	// RebalanceMerge merges the cold keyspace groups into one.
+	RebalanceMerge RebalanceOperatorType = "merge"
+	// RebalanceMoveMember moves a member of a keyspace group from a hot TSO node to a cold one.
+	RebalanceMoveMember RebalanceOperatorType = "move-member"
+)
+
+// RebalanceOperator is an operation to rebalance the keyspace groups.
+type RebalanceOperator struct {
+	Type            RebalanceOperatorType `json:"type"`
+	KeyspaceGroupID uint32                `json:"keyspace-group-id"`
+	// SplitTargetID and SplitKeyspaces are set for the split operator.
+	SplitTargetID  uint32   `json:"split-target-id,omitempty"`
+	SplitKeyspaces []uint32 `json:"split-keyspaces,omitempty"`
+	// MergeList is set for the merge operator, the keyspace groups in it are merged into
+	// the keyspace group of `KeyspaceGroupID`.
+	MergeList []uint32 `json:"merge-list,omitempty"`
+	// SourceNode and TargetNode are set for the move member operator.
+	SourceNode string `json:"source-node,omitempty"`
+	TargetNode string `json:"target-node,omitempty"`
+	// Reason describes why the operator is created.
+	Reason string `json:"reason"`
+}
+
+// RebalancePlan is the plan to rebalance the keyspace groups.
+type RebalancePlan struct {
+	// NodeLoads is the TSO request rate served by each TSO node.
+	NodeLoads map[string]float64 `json:"node-loads"`
+	// GroupLoads is the TSO request rate of each keyspace group.
+	GroupLoads map[uint32]float64   `json:"group-loads"`
+	Operators  []*RebalanceOperator `json:"operators"`
+	// SkipReason describes why no operator is created in this round, it's empty if the plan is built.
+	SkipReason string `json:"skip-reason,omitempty"`
+}
+
+// rebalanceLimits is the limits of a rebalance plan.
+type rebalanceLimits struct {
+	splitLoad    float64
+	mergeLoad    float64
+	maxOperators int
+}
+
+// SetRebalanceConfig sets the getter of the keyspace group rebalance configuration, the
+// keyspace groups are not rebalanced automatically if it's not set.
+func (m *GroupManager) SetRebalanceConfig(getter func() RebalanceConfig) {
+	m.rebalance.Lock()
+	defer m.rebalance.Unlock()
+	m.rebalance.config = getter
+}
+
+func (m *GroupManager) getRebalanceConfig() RebalanceConfig {
+	m.rebalance.RLock()
+	defer m.rebalance.RUnlock()
+	if m.rebalance.config == nil {
+		return nil
+	}
+	return m.rebalance.config()
+}
+
+func (m *GroupManager) initTSONodeLoadWatcher(client *clientv3.Client) {
+	putFn := func(kv *mvccpb.KeyValue) error {
+		load := &endpoint.TSONodeLoad{}
+		if err := json.Unmarshal(kv.Value, load); err != nil {
+			log.Warn("failed to unmarshal tso node load",
+				zap.String("event-kv-key", string(kv.Key)), zap.Error(err))
+			return err
+		}
+		m.rebalance.Lock()
+		defer m.rebalance.Unlock()
+		m.rebalance.nodeLoads[string(kv.Key)] = load
+		return nil
+	}
+	deleteFn := func(kv *mvccpb.KeyValue) error {
+		m.rebalance.Lock()
+		defer m.rebalance.Unlock()
+		delete(m.rebalance.nodeLoads, string(kv.Key))
+		return nil
+	}
+
+	m.tsoNodeLoadWatcher = etcdutil.NewLoopWatcher(
+		m.ctx,
+		&m.wg,
+		client,
+		"tso-node-load-watcher",
+		keypath.TSONodeLoadPrefix(),
+		func([]*clientv3.Event) error { return nil },
+		putFn,
+		deleteFn,
+		func([]*clientv3.Event) error { return nil },
+		true, /* withPrefix */
+	)
+}
+
+// getTSONodeLoads returns the unexpired TSO request loads reported by the TSO nodes.
+func (m *GroupManager) getTSONodeLoads() []*endpoint.TSONodeLoad {
+	m.rebalance.RLock()
+	defer m.rebalance.RUnlock()
+	loads := make([]*endpoint.TSONodeLoad, 0, len(m.rebalance.nodeLoads))
+	for _, load := range m.rebalance.nodeLoads {
+		if time.Since(load.ReportTime) > tsoNodeLoadExpiration {
+			continue
+		}
+		loads = append(loads, load)
+	}
+	return loads
+}
+
+// GetRebalancePlan returns the plan to rebalance the keyspace groups without executing it.
+func (m *GroupManager) GetRebalancePlan() (*RebalancePlan, error) {
+	cfg := m.getRebalanceConfig()
+	if cfg == nil {
+		return nil, errs.ErrKeyspaceGroupRebalance.FastGenByArgs("the rebalance config is not set")
+	}
+	groups, err := m.store.LoadKeyspaceGroups(constant.DefaultKeyspaceGroupID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return buildRebalancePlan(groups, m.nodesBalancer.GetAll(), m.getTSONodeLoads(), &rebalanceLimits{
+		splitLoad:    cfg.GetTSOKeyspaceGroupSplitLoad(),
+		mergeLoad:    cfg.GetTSOKeyspaceGroupMergeLoad(),
+		maxOperators: cfg.GetTSOKeyspaceGroupRebalanceMaxOperators(),
+	}), nil
+}
+
+// RebalanceKeyspaceGroups builds a plan to rebalance the keyspace groups and executes it.
+// It returns the executed plan, the operators after the failed one are not executed.
+func (m *GroupManager) RebalanceKeyspaceGroups() (*RebalancePlan, error) {
+	plan, err := m.GetRebalancePlan()
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range plan.Operators {
+		if err := m.executeRebalanceOperator(op); err != nil {
+			plan.Operators = plan.Operators[:i]
+			return plan, err
+		}
+		log.Info("executed keyspace group rebalance operator", zap.Reflect("operator", op))
+	}
+	m.rebalance.Lock()
+	m.rebalance.lastRebalanceTime = time.Now()
+	m.rebalance.Unlock()
+	return plan, nil
+}
+
+func (m *GroupManager) executeRebalanceOperator(op *RebalanceOperator) error {
+	switch op.Type {
+	case RebalanceSplit:
+		return m.SplitKeyspaceGroupByID(op.KeyspaceGroupID, op.SplitTargetID, op.SplitKeyspaces)
+	case RebalanceMerge:
+		return m.MergeKeyspaceGroups(op.KeyspaceGroupID, op.MergeList)
+	case RebalanceMoveMember:
+		return m.moveKeyspaceGroupMember(op.KeyspaceGroupID, op.SourceNode, op.TargetNode)
+	default:
+		return errs.ErrKeyspaceGroupRebalance.FastGenByArgs(fmt.Sprintf("unknown operator type %s", op.Type))
+	}
+}
+
+// moveKeyspaceGroupMember replaces the source node with the target node in the members of the
+// keyspace group, the priority of the member is kept.
+func (m *GroupManager) moveKeyspaceGroupMember(id uint32, sourceNode, targetNode string) error {
+	m.Lock()
+	defer m.Unlock()
+	var kg *endpoint.KeyspaceGroup
+	err := m.store.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		var err error
+		kg, err = m.store.LoadKeyspaceGroup(txn, id)
+		if err != nil {
+			return err
+		}
+		if kg == nil {
+			return errs.ErrKeyspaceGroupNotExists.FastGenByArgs(id)
+		}
+		if kg.IsSplitting() {
+			return errs.ErrKeyspaceGroupInSplit.FastGenByArgs(id)
+		}
+		if kg.IsMerging() {
+			return errs.ErrKeyspaceGroupInMerging.FastGenByArgs(id)
+		}
+		inKeyspaceGroup := false
+		members := make([]endpoint.KeyspaceGroupMember, 0, len(kg.Members))
+		for _, member := range kg.Members {
+			if member.IsAddressEquivalent(targetNode) {
+				return errs.ErrKeyspaceGroupRebalance.FastGenByArgs(
+					fmt.Sprintf("node %s is already in keyspace group %d", targetNode, id))
+			}
+			if member.IsAddressEquivalent(sourceNode) {
+				inKeyspaceGroup = true
+				member.Address = targetNode
+			}
+			members = append(members, member)
+		}
+		if !inKeyspaceGroup {
+			return errs.ErrNodeNotInKeyspaceGroup
+		}
+		kg.Members = members
+		return m.store.SaveKeyspaceGroup(txn, kg)
+	})
+	if err != nil {
+		return err
+	}
+	m.groups[endpoint.StringUserKind(kg.UserKind)].Put(kg)
+	return nil
+}
+
+// rebalanceKeyspaceGroupsLoop rebalances the keyspace groups periodically if it's enabled.
+func (m *GroupManager) rebalanceKeyspaceGroupsLoop(ctx context.Context) {
+	defer logutil.LogPanic()
+	defer m.wg.Done()
+	ticker := time.NewTicker(rebalanceCheckInterval)
+	failpoint.Inject("acceleratedRebalanceKeyspaceGroups", func() {
+		ticker.Reset(time.Millisecond * 100)
+	})
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			log.Info("server is closed, stop to rebalance keyspace groups")
+			return
+		case <-ctx.Done():
+			log.Info("the raftcluster is closed, stop to rebalance keyspace groups")
+			return
+		case <-ticker.C:
+		}
+		cfg := m.getRebalanceConfig()
+		if cfg == nil || !cfg.IsTSOKeyspaceGroupRebalanceEnabled() {
+			continue
+		}
+		m.rebalance.RLock()
+		lastRebalanceTime := m.rebalance.lastRebalanceTime
+		m.rebalance.RUnlock()
+		if time.Since(lastRebalanceTime) < cfg.GetTSOKeyspaceGroupRebalanceInterval() {
+			continue
+		}
+		plan, err := m.RebalanceKeyspaceGroups()
+		if err != nil {
+			log.Warn("failed to rebalance keyspace groups", zap.Error(err))
+			continue
+		}
+		if len(plan.SkipReason) > 0 {
+			log.Info("skip rebalancing keyspace groups", zap.String("reason", plan.SkipReason))
+		}
+		if len(plan.Operators) > 0 {
+			log.Info("rebalanced keyspace groups", zap.Int("operator-count", len(plan.Operators)))
+		}
+	}
+}
+
+// buildRebalancePlan builds the plan to rebalance the keyspace groups according to the TSO request
+// loads reported by the TSO nodes. It splits the hot keyspace groups first, then merges the cold
+// ones, and finally moves the members from the hot TSO nodes to the cold ones. The keyspace groups
+// in splitting or merging are skipped, and each keyspace group is touched at most once in a plan.
+func buildRebalancePlan(
+	groups []*endpoint.KeyspaceGroup,
+	nodes []string,
+	nodeLoads []*endpoint.TSONodeLoad,
+	limits *rebalanceLimits,
+) *RebalancePlan {
+	plan := &RebalancePlan{
+		NodeLoads:  make(map[string]float64, len(nodes)),
+		GroupLoads: make(map[uint32]float64, len(groups)),
+		Operators:  make([]*RebalanceOperator, 0),
+	}
+	keyspaceLoads := make(map[uint32]float64)
+	for _, node := range nodes {
+		plan.NodeLoads[node] = 0
+	}
+	// servedGroups is the keyspace groups served by each node, with the load of each group.
+	servedGroups := make(map[string]map[uint32]float64, len(nodeLoads))
+	groupOfKeyspace := make(map[uint32]uint32)
+	for _, group := range groups {
+		for _, keyspace := range group.Keyspaces {
+			groupOfKeyspace[keyspace] = group.ID
+		}
+	}
+	for _, load := range nodeLoads {
+		node, ok := findNode(nodes, load.Address)
+		if !ok {
+			continue
+		}
+		served := make(map[uint32]float64)
+		for keyspace, rate := range load.KeyspaceLoads {
+			keyspaceLoads[keyspace] += rate
+			plan.NodeLoads[node] += rate
+			if groupID, ok := groupOfKeyspace[keyspace]; ok {
+				served[groupID] += rate
+			}
+		}
+		servedGroups[node] = served
+	}
+	for _, group := range groups {
+		for _, keyspace := range group.Keyspaces {
+			plan.GroupLoads[group.ID] += keyspaceLoads[keyspace]
+		}
+	}
+	// The load of a node without a fresh report would be regarded as 0, which makes the keyspace
+	// groups it serves look cold, so skip the round until all the nodes report their loads.
+	for _, node := range nodes {
+		if _, ok := servedGroups[node]; !ok {
+			plan.SkipReason = fmt.Sprintf("the load of tso node %s is not reported", node)
+			return plan
+		}
+	}
+
+	touched := make(map[uint32]struct{})
+	usedIDs := make(map[uint32]struct{}, len(groups))
+	for _, group := range groups {
+		usedIDs[group.ID] = struct{}{}
+		if group.IsSplitting() || group.IsMerging() {
+			touched[group.ID] = struct{}{}
+		}
+	}
+	full := func() bool { return len(plan.Operators) >= limits.maxOperators }
+
+	// Split the hot keyspace groups, the hottest first.
+	sortedGroups := append(groups[:0:0], groups...)
+	sort.SliceStable(sortedGroups, func(i, j int) bool {
+		return plan.GroupLoads[sortedGroups[i].ID] > plan.GroupLoads[sortedGroups[j].ID]
+	})
+	for _, group := range sortedGroups {
+		if full() {
+			return plan
+		}
+		load := plan.GroupLoads[group.ID]
+		if load <= limits.splitLoad {
+			break
+		}
+		if _, ok := touched[group.ID]; ok || len(group.Members) < constant.DefaultKeyspaceGroupReplicaCount {
+			continue
+		}
+		splitKeyspaces := pickSplitKeyspaces(group.Keyspaces, keyspaceLoads)
+		if len(splitKeyspaces) == 0 {
+			continue
+		}
+		targetID, ok := nextKeyspaceGroupID(usedIDs)
+		if !ok {
+			break
+		}
+		usedIDs[targetID] = struct{}{}
+		touched[group.ID] = struct{}{}
+		plan.Operators = append(plan.Operators, &RebalanceOperator{
+			Type:            RebalanceSplit,
+			KeyspaceGroupID: group.ID,
+			SplitTargetID:   targetID,
+			SplitKeyspaces:  splitKeyspaces,
+			Reason:          fmt.Sprintf("the load %.2f exceeds the split load %.2f", load, limits.splitLoad),
+		})
+	}
+
+	// Merge the cold keyspace groups of the same user kind, the coldest first.
+	for i := len(sortedGroups) - 1; i >= 0 && !full(); i-- {
+		target := sortedGroups[i]
+		if _, ok := touched[target.ID]; ok || plan.GroupLoads[target.ID] >= limits.mergeLoad {
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			source := sortedGroups[j]
+			if _, ok := touched[source.ID]; ok || source.UserKind != target.UserKind {
+				continue
+			}
+			load := plan.GroupLoads[target.ID] + plan.GroupLoads[source.ID]
+			if load >= limits.mergeLoad {
+				break
+			}
+			// Merge into the one with the smaller ID, so the default keyspace group is never merged.
+			mergeTarget, mergeSource := target, source
+			if mergeSource.ID < mergeTarget.ID {
+				mergeTarget, mergeSource = mergeSource, mergeTarget
+			}
+			touched[target.ID], touched[source.ID] = struct{}{}, struct{}{}
+			plan.Operators = append(plan.Operators, &RebalanceOperator{
+				Type:            RebalanceMerge,
+				KeyspaceGroupID: mergeTarget.ID,
+				MergeList:       []uint32{mergeSource.ID},
+				Reason:          fmt.Sprintf("the merged load %.2f is less than the merge load %.2f", load, limits.mergeLoad),
+			})
+			break
+		}
+	}
+
+	// Move the members from the hot TSO nodes to the cold ones.
+	groupByID := make(map[uint32]*endpoint.KeyspaceGroup, len(groups))
+	for _, group := range groups {
+		groupByID[group.ID] = group
+	}
+	for len(nodes) > 1 && !full() {
+		hot, cold := nodes[0], nodes[0]
+		var total float64
+		for _, node := range nodes {
+			total += plan.NodeLoads[node]
+			if plan.NodeLoads[node] > plan.NodeLoads[hot] {
+				hot = node
+			}
+			if plan.NodeLoads[node] < plan.NodeLoads[cold] {
+				cold = node
+			}
+		}
+		average := total / float64(len(nodes))
+		if plan.NodeLoads[hot] <= average*(1+tsoNodeLoadTolerance) {
+			break
+		}
+		// Move the largest keyspace group which does not make the cold node hotter than the hot one.
+		gap := plan.NodeLoads[hot] - plan.NodeLoads[cold]
+		var (
+			moveID   uint32
+			moveLoad float64
+			found    bool
+		)
+		for groupID, load := range servedGroups[hot] {
+			group, ok := groupByID[groupID]
+			if !ok || load <= 0 || load >= gap || load <= moveLoad {
+				continue
+			}
+			if _, ok := touched[groupID]; ok || isMember(group, cold) || !isMember(group, hot) {
+				continue
+			}
+			moveID, moveLoad, found = groupID, load, true
+		}
+		if !found {
+			break
+		}
+		touched[moveID] = struct{}{}
+		plan.NodeLoads[hot] -= moveLoad
+		plan.NodeLoads[cold] += moveLoad
+		delete(servedGroups[hot], moveID)
+		plan.Operators = append(plan.Operators, &RebalanceOperator{
+			Type:            RebalanceMoveMember,
+			KeyspaceGroupID: moveID,
+			SourceNode:      hot,
+			TargetNode:      cold,
+			Reason: fmt.Sprintf("the load %.2f of node %s exceeds the average load %.2f",
+				plan.NodeLoads[hot]+moveLoad, hot, average),
+		})
+	}
+	return plan
+}
+
+// pickSplitKeyspaces picks the keyspaces to split out of the keyspace group, so that the load is
+// divided evenly. The default keyspace is always kept in the source keyspace group.
+func pickSplitKeyspaces(keyspaces []uint32, keyspaceLoads map[uint32]float64) []uint32 {
+	candidates := make([]uint32, 0, len(keyspaces))
+	var keep float64
+	for _, keyspace := range keyspaces {
+		if keyspace == constant.DefaultKeyspaceID {
+			keep += keyspaceLoads[keyspace]
+			continue
+		}
+		candidates = append(candidates, keyspace)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return keyspaceLoads[candidates[i]] > keyspaceLoads[candidates[j]]
+	})
+	var (
+		split     []uint32
+		splitLoad float64
+		kept      int
+	)
+	if len(candidates) < len(keyspaces) {
+		kept++
+	}
+	for _, keyspace := range candidates {
+		// Always keep one keyspace in the source keyspace group.
+		if kept > 0 && splitLoad < keep {
+			split = append(split, keyspace)
+			splitLoad += keyspaceLoads[keyspace]
+			continue
+		}
+		keep += keyspaceLoads[keyspace]
+		kept++
+	}
+	if kept == 0 || len(split) == 0 {
+		return nil
+	}
+	sort.Slice(split, func(i, j int) bool { return split[i] < split[j] })
+	return split
+}
+
+// nextKeyspaceGroupID returns the smallest unused keyspace group ID.
+func nextKeyspaceGroupID(usedIDs map[uint32]struct{}) (uint32, bool) {
+	for id := constant.DefaultKeyspaceGroupID + 1; id < constant.MaxKeyspaceGroupCountInUse; id++ {
+		if _, ok := usedIDs[id]; !ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func findNode(nodes []string, addr string) (string, bool) {
+	for _, node := range nodes {
+		if typeutil.EqualBaseURLs(node, addr) {
+			return node, true
+		}
+	}
+	return "", false
+}
+
+func isMember(group *endpoint.KeyspaceGroup, node string) bool {
+	for _, member := range group.Members {
+		if member.IsAddressEquivalent(node) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/storage/endpoint"
+)
+
+func newRebalanceTestGroup(id uint32, keyspaces []uint32, nodes ...string) *endpoint.KeyspaceGroup {
+	members := make([]endpoint.KeyspaceGroupMember, 0, len(nodes))
+	for _, node := range nodes {
+		members = append(members, endpoint.KeyspaceGroupMember{Address: node})
+	}
+	return &endpoint.KeyspaceGroup{
+		ID:        id,
+		UserKind:  endpoint.Basic.String(),
+		Keyspaces: keyspaces,
+		Members:   members,
+	}
+}
+
+func TestBuildRebalancePlanSplit(t *testing.T) {
+	re := require.New(t)
+	nodes := []string{"http://127.0.0.1:3379", "http://127.0.0.1:3380"}
+	groups := []*endpoint.KeyspaceGroup{
+		newRebalanceTestGroup(0, []uint32{0}, nodes...),
+		newRebalanceTestGroup(1, []uint32{1, 2, 3, 4}, nodes...),
+	}
+	loads := []*endpoint.TSONodeLoad{{
+		Address:       "https://127.0.0.1:3379",
+		KeyspaceLoads: map[uint32]float64{0: 1000, 1: 40000, 2: 30000, 3: 20000, 4: 10000},
+	}}
+	limits := &rebalanceLimits{splitLoad: 50000, mergeLoad: 500, maxOperators: 1}
+
+	// The round is skipped if any node does not report its load.
+	plan := buildRebalancePlan(groups, nodes, loads, limits)
+	re.Empty(plan.Operators)
+	re.Contains(plan.SkipReason, nodes[1])
+
+	loads = append(loads, &endpoint.TSONodeLoad{Address: nodes[1], KeyspaceLoads: map[uint32]float64{}})
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Empty(plan.SkipReason)
+	re.Equal(101000.0, plan.NodeLoads[nodes[0]])
+	re.Equal(0.0, plan.NodeLoads[nodes[1]])
+	re.Equal(1000.0, plan.GroupLoads[0])
+	re.Equal(100000.0, plan.GroupLoads[1])
+	re.Len(plan.Operators, 1)
+	op := plan.Operators[0]
+	re.Equal(RebalanceSplit, op.Type)
+	re.Equal(uint32(1), op.KeyspaceGroupID)
+	re.Equal(uint32(2), op.SplitTargetID)
+	re.Equal([]uint32{2, 3}, op.SplitKeyspaces)
+
+	// The keyspace group in splitting is skipped.
+	groups[1].SplitState = &endpoint.SplitState{SplitSource: 1}
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Empty(plan.Operators)
+
+	// The keyspace group with not enough replicas is not split, but its member is moved.
+	groups[1] = newRebalanceTestGroup(1, []uint32{1, 2, 3, 4}, nodes[0])
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Len(plan.Operators, 1)
+	op = plan.Operators[0]
+	re.Equal(RebalanceMoveMember, op.Type)
+	re.Equal(uint32(1), op.KeyspaceGroupID)
+	re.Equal(nodes[0], op.SourceNode)
+	re.Equal(nodes[1], op.TargetNode)
+}
+
+func TestBuildRebalancePlanMerge(t *testing.T) {
+	re := require.New(t)
+	nodes := []string{"http://127.0.0.1:3379", "http://127.0.0.1:3380"}
+	groups := []*endpoint.KeyspaceGroup{
+		newRebalanceTestGroup(0, []uint32{0}, nodes...),
+		newRebalanceTestGroup(1, []uint32{1}, nodes...),
+		newRebalanceTestGroup(2, []uint32{2}, nodes...),
+		newRebalanceTestGroup(3, []uint32{3}, nodes...),
+	}
+	loads := []*endpoint.TSONodeLoad{
+		{Address: nodes[0], KeyspaceLoads: map[uint32]float64{0: 1000, 1: 100, 2: 200}},
+		{Address: nodes[1], KeyspaceLoads: map[uint32]float64{3: 1000}},
+	}
+	limits := &rebalanceLimits{splitLoad: 50000, mergeLoad: 500, maxOperators: 10}
+
+	plan := buildRebalancePlan(groups, nodes, loads, limits)
+	re.Len(plan.Operators, 1)
+	op := plan.Operators[0]
+	re.Equal(RebalanceMerge, op.Type)
+	re.Equal(uint32(1), op.KeyspaceGroupID)
+	re.Equal([]uint32{2}, op.MergeList)
+
+	// The default keyspace group is never merged into others.
+	loads[0].KeyspaceLoads[0] = 0
+	loads[0].KeyspaceLoads[1] = 1000
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Len(plan.Operators, 1)
+	op = plan.Operators[0]
+	re.Equal(RebalanceMerge, op.Type)
+	re.Equal(uint32(0), op.KeyspaceGroupID)
+	re.Equal([]uint32{2}, op.MergeList)
+
+	// The keyspace groups of different user kinds are not merged.
+	groups[2].UserKind = endpoint.Standard.String()
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Empty(plan.Operators)
+}
+
+func TestBuildRebalancePlanMoveMember(t *testing.T) {
+	re := require.New(t)
+	nodes := []string{"http://127.0.0.1:3379", "http://127.0.0.1:3380", "http://127.0.0.1:3381"}
+	groups := []*endpoint.KeyspaceGroup{
+		newRebalanceTestGroup(0, []uint32{0}, nodes[0], nodes[1]),
+		newRebalanceTestGroup(1, []uint32{1}, nodes[0], nodes[1]),
+		newRebalanceTestGroup(2, []uint32{2}, nodes[1], nodes[2]),
+	}
+	loads := []*endpoint.TSONodeLoad{
+		{Address: nodes[0], KeyspaceLoads: map[uint32]float64{0: 1000, 1: 1000}},
+		{Address: nodes[1], KeyspaceLoads: map[uint32]float64{2: 200}},
+		{Address: nodes[2], KeyspaceLoads: map[uint32]float64{}},
+	}
+	limits := &rebalanceLimits{splitLoad: 50000, mergeLoad: 0, maxOperators: 10}
+
+	plan := buildRebalancePlan(groups, nodes, loads, limits)
+	re.Len(plan.Operators, 1)
+	op := plan.Operators[0]
+	re.Equal(RebalanceMoveMember, op.Type)
+	re.Equal(nodes[0], op.SourceNode)
+	re.Equal(nodes[2], op.TargetNode)
+	re.Equal(1000.0, plan.NodeLoads[nodes[0]])
+	re.Equal(200.0, plan.NodeLoads[nodes[1]])
+	re.Equal(1000.0, plan.NodeLoads[nodes[2]])
+
+	// The balanced nodes are not touched.
+	loads = []*endpoint.TSONodeLoad{
+		{Address: nodes[0], KeyspaceLoads: map[uint32]float64{1: 1000}},
+		{Address: nodes[1], KeyspaceLoads: map[uint32]float64{0: 1000}},
+		{Address: nodes[2], KeyspaceLoads: map[uint32]float64{2: 1000}},
+	}
+	plan = buildRebalancePlan(groups, nodes, loads, limits)
+	re.Empty(plan.Operators)
+}
+
+func TestPickSplitKeyspaces(t *testing.T) {
+	re := require.New(t)
+	keyspaceLoads := map[uint32]float64{0: 50, 1: 40, 2: 30, 3: 20, 4: 10}
+	re.Equal([]uint32{1, 2}, pickSplitKeyspaces([]uint32{0, 1, 2, 3, 4}, keyspaceLoads))
+	re.Equal([]uint32{2, 3}, pickSplitKeyspaces([]uint32{1, 2, 3, 4}, keyspaceLoads))
+	re.Nil(pickSplitKeyspaces([]uint32{0}, keyspaceLoads))
+	re.Nil(pickSplitKeyspaces([]uint32{1}, keyspaceLoads))
+}
@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/gzip"
@@ -96,8 +98,15 @@ func (s *Service) RegisterRouter() {
 	configEndpoint.GET("/group/:name", s.getResourceGroup)
 	configEndpoint.GET("/groups", s.getResourceGroupList)
 	configEndpoint.DELETE("/group/:name", s.deleteResourceGroup)
+	configEndpoint.POST("/parent-group", s.postParentGroup)
+	configEndpoint.GET("/parent-group/:name", s.getParentGroup)
+	configEndpoint.GET("/parent-groups", s.getParentGroupList)
+	configEndpoint.DELETE("/parent-group/:name", s.deleteParentGroup)
 	configEndpoint.GET("/controller", s.getControllerConfig)
 	configEndpoint.POST("/controller", s.setControllerConfig)
+	usageEndpoint := s.root.Group("/usage")
+	usageEndpoint.GET("", s.getUsage)
+	usageEndpoint.GET("/export", s.exportUsage)
 }
 
 func (s *Service) handler() http.Handler {
@@ -197,6 +206,70 @@ func (s *Service) deleteResourceGroup(c *gin.Context) {
 	c.String(http.StatusOK, "Success!")
 }
 
+// postParentGroup
+//
+//	@Tags		ResourceManager
+//	@Summary	Add or update a parent group, whose children can borrow the unused RU from each other.
+//	@Param		parentInfo	body		object	true	"json params, rmserver.ParentGroup"
+//	@Success	200			{string}	string	"Success!"
+//	@Failure	400			{string}	error
+//	@Router		/config/parent-group [post]
+func (s *Service) postParentGroup(c *gin.Context) {
+	var parent rmserver.ParentGroup
+	if err := c.ShouldBindJSON(&parent); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.manager.SetParentGroup(&parent); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "Success!")
+}
+
+// getParentGroup
+//
+//	@Tags		ResourceManager
+//	@Summary	Get parent group by name.
+//	@Param		name	path		string	true	"Name of the parent group"
+//	@Success	200		{string}	json	format	of	rmserver.ParentGroup
+//	@Failure	404		{string}	error
+//	@Router		/config/parent-group/{name} [get]
+func (s *Service) getParentGroup(c *gin.Context) {
+	parent := s.manager.GetParentGroup(c.Param("name"))
+	if parent == nil {
+		c.String(http.StatusNotFound, errors.New("parent group not found").Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, parent)
+}
+
+// getParentGroupList
+//
+//	@Tags		ResourceManager
+//	@Summary	Get all parent groups with a list.
+//	@Success	200	{string}	json	format	of	[]rmserver.ParentGroup
+//	@Router		/config/parent-groups [get]
+func (s *Service) getParentGroupList(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, s.manager.GetParentGroupList())
+}
+
+// deleteParentGroup
+//
+//	@Tags		ResourceManager
+//	@Summary	Delete parent group by name, its children stop borrowing from each other.
+//	@Param		name	path		string	true	"Name of the parent group to be deleted"
+//	@Success	200		{string}	string	"Success!"
+//	@Failure	404		{string}	error
+//	@Router		/config/parent-group/{name} [delete]
+func (s *Service) deleteParentGroup(c *gin.Context) {
+	if err := s.manager.DeleteParentGroup(c.Param("name")); err != nil {
+		c.String(http.StatusNotFound, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "Success!")
+}
+
 // GetControllerConfig
 //
 //	@Tags		ResourceManager
@@ -236,3 +309,110 @@ func (s *Service) setControllerConfig(c *gin.Context) {
 	}
 	c.String(http.StatusOK, "Success!")
 }
+
+const defaultUsageQueryRange = 24 * time.Hour
+
+// parseUsageTime parses the time in unix seconds or RFC3339 format.
+func parseUsageTime(s string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func parseUsageQuery(c *gin.Context) (start, end time.Time, filter *rmserver.UsageFilter, err error) {
+	end = time.Now()
+	if s := c.Query("end"); len(s) > 0 {
+		if end, err = parseUsageTime(s); err != nil {
+			return
+		}
+	}
+	start = end.Add(-defaultUsageQueryRange)
+	if s := c.Query("start"); len(s) > 0 {
+		if start, err = parseUsageTime(s); err != nil {
+			return
+		}
+	}
+	if !start.Before(end) {
+		err = errors.New("the start time should be before the end time")
+		return
+	}
+	filter = &rmserver.UsageFilter{ResourceGroup: c.Query("group")}
+	if s := c.Query("keyspace_id"); len(s) > 0 {
+		var id uint64
+		if id, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return
+		}
+		keyspaceID := uint32(id)
+		filter.KeyspaceID = &keyspaceID
+	}
+	return
+}
+
+// getUsage
+//
+//	@Tags		ResourceManager
+//	@Summary	Get the hourly resource usage of the resource groups.
+//	@Param		start		query		string	false	"Start time in unix seconds or RFC3339, 24 hours before the end by default"
+//	@Param		end			query		string	false	"End time in unix seconds or RFC3339, now by default"
+//	@Param		keyspace_id	query		integer	false	"Keyspace ID of the usage"
+//	@Param		group		query		string	false	"Resource group name of the usage"
+//	@Success	200			{string}	json	format	of	[]rmserver.UsageRecord
+//	@Failure	400			{string}	error
+//	@Failure	500			{string}	error
+//	@Router		/usage [get]
+func (s *Service) getUsage(c *gin.Context) {
+	start, end, filter, err := parseUsageQuery(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	records, err := s.manager.QueryUsage(start, end, filter)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, records)
+}
+
+// exportUsage
+//
+//	@Tags		ResourceManager
+//	@Summary	Export the hourly resource usage of the resource groups as a file.
+//	@Param		start		query		string	false	"Start time in unix seconds or RFC3339, 24 hours before the end by default"
+//	@Param		end			query		string	false	"End time in unix seconds or RFC3339, now by default"
+//	@Param		keyspace_id	query		integer	false	"Keyspace ID of the usage"
+//	@Param		group		query		string	false	"Resource group name of the usage"
+//	@Param		format		query		string	false	"csv or json, csv by default"
+//	@Success	200			{string}	string	"The usage file"
+//	@Failure	400			{string}	error
+//	@Failure	500			{string}	error
+//	@Router		/usage/export [get]
+func (s *Service) exportUsage(c *gin.Context) {
+	start, end, filter, err := parseUsageQuery(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "json" {
+		c.String(http.StatusBadRequest, fmt.Sprintf("unsupported format %s", format))
+		return
+	}
+	records, err := s.manager.QueryUsage(start, end, filter)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	filename := fmt.Sprintf("resource_group_usage_%d_%d.%s", start.Unix(), end.Unix(), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "json" {
+		c.IndentedJSON(http.StatusOK, records)
+		return
+	}
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := rmserver.WriteUsageCSV(c.Writer, records); err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+const borrowingBalanceInterval = time.Second
+
+// ParentGroup is a set of resource groups sharing a total RU budget. Each child is guaranteed
+// the fill rate of its own settings, and the unused part of the budget can be borrowed by the
+// busy children up to their borrow limits.
+type ParentGroup struct {
+	Name string `json:"name"`
+	// FillRate is the total RU per second shared by the children, which should be
+	// no less than the sum of the fill rates of the children.
+	FillRate uint64 `json:"fill_rate"`
+	// Children is the mapping from the child group name to its settings.
+	Children map[string]*ChildGroupSettings `json:"children"`
+}
+
+// ChildGroupSettings is the settings of a child in the parent group.
+type ChildGroupSettings struct {
+	// BorrowLimit is the max RU per second that can be borrowed from the siblings,
+	// 0 means the child never borrows.
+	BorrowLimit uint64 `json:"borrow_limit"`
+}
+
+// Clone returns a deep copy of the ParentGroup.
+func (p *ParentGroup) Clone() *ParentGroup {
+	children := make(map[string]*ChildGroupSettings, len(p.Children))
+	for name, child := range p.Children {
+		c := *child
+		children[name] = &c
+	}
+	return &ParentGroup{
+		Name:     p.Name,
+		FillRate: p.FillRate,
+		Children: children,
+	}
+}
+
+// childDemand is the demand of a child group in the last balance interval.
+type childDemand struct {
+	name string
+	// guaranteed is the fill rate of the child's own settings.
+	guaranteed float64
+	// demand is the RU per second required by the clients of the child.
+	demand      float64
+	borrowLimit float64
+}
+
+// calcBorrowedFillRates distributes the budget left by the children that require less than their
+// guaranteed fill rates to the children that require more. The spare budget is shared evenly, and
+// each child borrows no more than its borrow limit and its demand beyond the guaranteed fill rate.
+func calcBorrowedFillRates(budget float64, children []childDemand) map[string]float64 {
+	type need struct {
+		name   string
+		amount float64
+	}
+	borrowed := make(map[string]float64, len(children))
+	needs := make([]need, 0, len(children))
+	spare := budget
+	for _, child := range children {
+		borrowed[child.name] = 0
+		spare -= math.Min(child.demand, child.guaranteed)
+		if amount := math.Min(child.demand-child.guaranteed, child.borrowLimit); amount > 0 {
+			needs = append(needs, need{name: child.name, amount: amount})
+		}
+	}
+	// Satisfy the smaller needs first, so the leftover of them can be shared by the larger ones.
+	sort.Slice(needs, func(i, j int) bool {
+		return needs[i].amount < needs[j].amount
+	})
+	for i, n := range needs {
+		if spare <= 0 {
+			break
+		}
+		share := math.Min(n.amount, spare/float64(len(needs)-i))
+		borrowed[n.name] = share
+		spare -= share
+	}
+	return borrowed
+}
+
+func (m *Manager) loadParentGroups() error {
+	m.Lock()
+	defer m.Unlock()
+	m.parents = make(map[string]*ParentGroup)
+	handler := func(k, v string) {
+		parent := &ParentGroup{}
+		if err := json.Unmarshal([]byte(v), parent); err != nil {
+			log.Error("failed to parse the parent resource group", zap.Error(err), zap.String("k", k), zap.String("v", v))
+			return
+		}
+		m.parents[parent.Name] = parent
+	}
+	return m.storage.LoadResourceGroupParents(handler)
+}
+
+// checkParentGroupLocked checks whether the parent group is valid, the caller should hold the lock.
+func (m *Manager) checkParentGroupLocked(parent *ParentGroup) error {
+	if len(parent.Name) == 0 || len(parent.Name) > 32 {
+		return errs.ErrInvalidParentGroup.FastGenByArgs("the name should be 1 to 32 characters")
+	}
+	var guaranteed uint64
+	for name := range parent.Children {
+		group, ok := m.groups[name]
+		if !ok {
+			return errs.ErrResourceGroupNotExists.FastGenByArgs(name)
+		}
+		if group.Mode != rmpb.GroupMode_RUMode || group.RUSettings == nil || group.RUSettings.RU.Settings == nil {
+			return errs.ErrInvalidParentGroup.FastGenByArgs(fmt.Sprintf("the child %s is not in RU mode", name))
+		}
+		settings := group.RUSettings.RU.Settings
+		if getBurstableMode(settings) == unlimited || settings.GetFillRate() >= unlimitedRate {
+			return errs.ErrInvalidParentGroup.FastGenByArgs(fmt.Sprintf("the child %s is unlimited", name))
+		}
+		for _, other := range m.parents {
+			if _, ok := other.Children[name]; ok && other.Name != parent.Name {
+				return errs.ErrInvalidParentGroup.FastGenByArgs(fmt.Sprintf("the child %s belongs to the parent %s", name, other.Name))
+			}
+		}
+		guaranteed += settings.GetFillRate()
+	}
+	if guaranteed > parent.FillRate {
+		return errs.ErrInvalidParentGroup.FastGenByArgs(
+			fmt.Sprintf("the fill rate %d is less than the sum %d of the children", parent.FillRate, guaranteed))
+	}
+	return nil
+}
+
+// checkChildSettingsLocked checks whether the new settings of the group still fit in its parent group,
+// the caller should hold the lock.
+func (m *Manager) checkChildSettingsLocked(group *rmpb.ResourceGroup) error {
+	settings := group.GetRUSettings().GetRU().GetSettings()
+	if settings == nil {
+		return nil
+	}
+	for _, parent := range m.parents {
+		if _, ok := parent.Children[group.Name]; !ok {
+			continue
+		}
+		if getBurstableMode(settings) == unlimited || settings.GetFillRate() >= unlimitedRate {
+			return errs.ErrInvalidParentGroup.FastGenByArgs(fmt.Sprintf("the child %s is unlimited", group.Name))
+		}
+		guaranteed := settings.GetFillRate()
+		for name := range parent.Children {
+			if sibling, ok := m.groups[name]; ok && name != group.Name {
+				guaranteed += uint64(sibling.getFillRate())
+			}
+		}
+		if guaranteed > parent.FillRate {
+			return errs.ErrInvalidParentGroup.FastGenByArgs(
+				fmt.Sprintf("the fill rate %d is less than the sum %d of the children", parent.FillRate, guaranteed))
+		}
+	}
+	return nil
+}
+
+// SetParentGroup puts a parent group. The children removed from the parent group stop borrowing.
+func (m *Manager) SetParentGroup(parent *ParentGroup) error {
+	if parent == nil {
+		return errs.ErrInvalidParentGroup.FastGenByArgs("the parent group is empty")
+	}
+	parent = parent.Clone()
+	m.Lock()
+	defer m.Unlock()
+	if err := m.checkParentGroupLocked(parent); err != nil {
+		return err
+	}
+	if err := m.storage.SaveResourceGroupParent(parent.Name, parent); err != nil {
+		return err
+	}
+	if old, ok := m.parents[parent.Name]; ok {
+		for name := range old.Children {
+			if _, ok := parent.Children[name]; !ok {
+				m.stopBorrowingLocked(name)
+			}
+		}
+	}
+	m.parents[parent.Name] = parent
+	return nil
+}
+
+// DeleteParentGroup deletes a parent group, and its children stop borrowing.
+func (m *Manager) DeleteParentGroup(name string) error {
+	m.Lock()
+	defer m.Unlock()
+	parent, ok := m.parents[name]
+	if !ok {
+		return errs.ErrParentGroupNotExists.FastGenByArgs(name)
+	}
+	if err := m.storage.DeleteResourceGroupParent(name); err != nil {
+		return err
+	}
+	for child := range parent.Children {
+		m.stopBorrowingLocked(child)
+	}
+	delete(m.parents, name)
+	return nil
+}
+
+// GetParentGroup returns a copy of a parent group.
+func (m *Manager) GetParentGroup(name string) *ParentGroup {
+	m.RLock()
+	defer m.RUnlock()
+	if parent, ok := m.parents[name]; ok {
+		return parent.Clone()
+	}
+	return nil
+}
+
+// GetParentGroupList returns copies of the parent group list.
+func (m *Manager) GetParentGroupList() []*ParentGroup {
+	m.RLock()
+	res := make([]*ParentGroup, 0, len(m.parents))
+	for _, parent := range m.parents {
+		res = append(res, parent.Clone())
+	}
+	m.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// removeChildLocked removes the deleted group from its parent group, the caller should hold the lock.
+func (m *Manager) removeChildLocked(name string) {
+	for _, parent := range m.parents {
+		if _, ok := parent.Children[name]; !ok {
+			continue
+		}
+		newParent := parent.Clone()
+		delete(newParent.Children, name)
+		if err := m.storage.SaveResourceGroupParent(newParent.Name, newParent); err != nil {
+			log.Error("failed to remove the child from the parent resource group", zap.Error(err),
+				zap.String("parent", newParent.Name), zap.String("child", name))
+			continue
+		}
+		m.parents[newParent.Name] = newParent
+	}
+	borrowedRUGauge.DeleteLabelValues(name)
+}
+
+func (m *Manager) stopBorrowingLocked(name string) {
+	if group, ok := m.groups[name]; ok {
+		group.setBorrowedFillRate(0)
+	}
+	borrowedRUGauge.DeleteLabelValues(name)
+}
+
+func (m *Manager) borrowingBalanceLoop(ctx context.Context) {
+	defer logutil.LogPanic()
+	ticker := time.NewTicker(borrowingBalanceInterval)
+	defer ticker.Stop()
+	lastBalance := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.balanceBorrowing(now.Sub(lastBalance))
+			lastBalance = now
+		}
+	}
+}
+
+// balanceBorrowing updates the borrowed fill rates of the children by their demands in the elapsed time.
+func (m *Manager) balanceBorrowing(elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	m.RLock()
+	// The parent groups are replaced rather than modified, so it's safe to use them without the lock.
+	parents := make([]*ParentGroup, 0, len(m.parents))
+	for _, parent := range m.parents {
+		parents = append(parents, parent)
+	}
+	m.RUnlock()
+
+	seconds := elapsed.Seconds()
+	for _, parent := range parents {
+		groups := make(map[string]*ResourceGroup, len(parent.Children))
+		children := make([]childDemand, 0, len(parent.Children))
+		for name, child := range parent.Children {
+			group := m.GetMutableResourceGroup(name)
+			if group == nil {
+				continue
+			}
+			required, granted, borrowed := group.takeBorrowingStats()
+			guaranteed := group.getFillRate()
+			// Only the tokens granted beyond the guaranteed fill rate are counted as borrowed.
+			if extra := math.Min(granted-guaranteed*seconds, borrowed*seconds); extra > 0 {
+				borrowedRequestUnitCost.WithLabelValues(name).Add(extra)
+			}
+			groups[name] = group
+			children = append(children, childDemand{
+				name:        name,
+				guaranteed:  guaranteed,
+				demand:      required / seconds,
+				borrowLimit: float64(child.BorrowLimit),
+			})
+		}
+		rates := calcBorrowedFillRates(float64(parent.FillRate), children)
+		m.RLock()
+		// Skip the stale parent group, otherwise the removed children may keep borrowing.
+		if m.parents[parent.Name] == parent {
+			for name, rate := range rates {
+				groups[name].setBorrowedFillRate(rate)
+				borrowedRUGauge.WithLabelValues(name).Set(rate)
+			}
+		}
+		m.RUnlock()
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestCalcBorrowedFillRates(t *testing.T) {
+	re := require.New(t)
+	testCases := []struct {
+		budget   float64
+		children []childDemand
+		expected map[string]float64
+	}{
+		// The idle and light children lend their unused fill rate to the busy one.
+		{
+			budget: 3000,
+			children: []childDemand{
+				{name: "a", guaranteed: 1000, demand: 3000, borrowLimit: 5000},
+				{name: "b", guaranteed: 1000, demand: 0, borrowLimit: 5000},
+				{name: "c", guaranteed: 1000, demand: 500, borrowLimit: 5000},
+			},
+			expected: map[string]float64{"a": 1500, "b": 0, "c": 0},
+		},
+		// The busy children share the spare budget evenly.
+		{
+			budget: 3000,
+			children: []childDemand{
+				{name: "a", guaranteed: 1000, demand: 2500, borrowLimit: 5000},
+				{name: "b", guaranteed: 1000, demand: 2500, borrowLimit: 5000},
+				{name: "c", guaranteed: 1000, demand: 0, borrowLimit: 5000},
+			},
+			expected: map[string]float64{"a": 500, "b": 500, "c": 0},
+		},
+		// The leftover of the small needs is shared by the large ones, and the borrow limit is respected.
+		{
+			budget: 4000,
+			children: []childDemand{
+				{name: "a", guaranteed: 1000, demand: 1200, borrowLimit: 5000},
+				{name: "b", guaranteed: 1000, demand: 5000, borrowLimit: 1000},
+				{name: "c", guaranteed: 1000, demand: 5000, borrowLimit: 5000},
+				{name: "d", guaranteed: 1000, demand: 0, borrowLimit: 0},
+			},
+			expected: map[string]float64{"a": 200, "b": 400, "c": 400, "d": 0},
+		},
+		// Nothing to borrow when all the children are busy.
+		{
+			budget: 2000,
+			children: []childDemand{
+				{name: "a", guaranteed: 1000, demand: 2000, borrowLimit: 5000},
+				{name: "b", guaranteed: 1000, demand: 2000, borrowLimit: 5000},
+			},
+			expected: map[string]float64{"a": 0, "b": 0},
+		},
+		// The child with zero borrow limit never borrows.
+		{
+			budget: 2000,
+			children: []childDemand{
+				{name: "a", guaranteed: 1000, demand: 3000, borrowLimit: 0},
+				{name: "b", guaranteed: 1000, demand: 0, borrowLimit: 5000},
+			},
+			expected: map[string]float64{"a": 0, "b": 0},
+		},
+	}
+	for i, tc := range testCases {
+		borrowed := calcBorrowedFillRates(tc.budget, tc.children)
+		re.Len(borrowed, len(tc.expected), "case %d", i)
+		for name, rate := range tc.expected {
+			re.InDelta(rate, borrowed[name], 1e-7, "case %d, child %s", i, name)
+		}
+	}
+}
+
+func newTestRUGroup(name string, fillRate uint64, burstLimit int64) *rmpb.ResourceGroup {
+	return &rmpb.ResourceGroup{
+		Name: name,
+		Mode: rmpb.GroupMode_RUMode,
+		RUSettings: &rmpb.GroupRequestUnitSettings{
+			RU: &rmpb.TokenBucket{
+				Settings: &rmpb.TokenLimitSettings{
+					FillRate:   fillRate,
+					BurstLimit: burstLimit,
+				},
+			},
+		},
+	}
+}
+
+func TestParentGroup(t *testing.T) {
+	re := require.New(t)
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	m := &Manager{
+		groups:  make(map[string]*ResourceGroup),
+		parents: make(map[string]*ParentGroup),
+		storage: storage,
+	}
+	re.NoError(m.AddResourceGroup(newTestRUGroup("a", 1000, 10000)))
+	re.NoError(m.AddResourceGroup(newTestRUGroup("b", 1000, 10000)))
+	re.NoError(m.AddResourceGroup(newTestRUGroup("u", unlimitedRate, unlimitedBurstLimit)))
+
+	// Invalid parent groups.
+	re.Error(m.SetParentGroup(&ParentGroup{Name: "p", FillRate: 1500, Children: map[string]*ChildGroupSettings{
+		"a": {BorrowLimit: 2000}, "b": {},
+	}}))
+	re.Error(m.SetParentGroup(&ParentGroup{Name: "p", FillRate: 3000, Children: map[string]*ChildGroupSettings{
+		"a": {}, "u": {},
+	}}))
+	re.Error(m.SetParentGroup(&ParentGroup{Name: "p", FillRate: 3000, Children: map[string]*ChildGroupSettings{
+		"a": {}, "x": {},
+	}}))
+	re.Empty(m.GetParentGroupList())
+
+	parent := &ParentGroup{Name: "p", FillRate: 3000, Children: map[string]*ChildGroupSettings{
+		"a": {BorrowLimit: 2000}, "b": {},
+	}}
+	re.NoError(m.SetParentGroup(parent))
+	re.Equal(parent, m.GetParentGroup("p"))
+	// A child can't belong to two parents.
+	re.Error(m.SetParentGroup(&ParentGroup{Name: "q", FillRate: 3000, Children: map[string]*ChildGroupSettings{
+		"a": {},
+	}}))
+	// The children can't exceed the budget of the parent.
+	re.Error(m.ModifyResourceGroup(newTestRUGroup("a", 2500, 10000)))
+	re.NoError(m.ModifyResourceGroup(newTestRUGroup("a", 1500, 10000)))
+	re.NoError(m.ModifyResourceGroup(newTestRUGroup("a", 1000, 10000)))
+
+	// The busy child borrows the unused fill rate of the idle sibling.
+	now := time.Now()
+	m.GetMutableResourceGroup("a").RequestRU(now, 3000, 5000, 1)
+	m.balanceBorrowing(time.Second)
+	re.Equal(2000.0, m.GetResourceGroup("a", false).RUSettings.RU.BorrowedFillRate)
+	re.Equal(2000.0, m.GetMutableResourceGroup("a").GetGroupStates().RU.BorrowedFillRate)
+	re.Zero(m.GetResourceGroup("b", false).RUSettings.RU.BorrowedFillRate)
+	// Stop borrowing once the demand goes away.
+	m.balanceBorrowing(time.Second)
+	re.Zero(m.GetResourceGroup("a", false).RUSettings.RU.BorrowedFillRate)
+
+	// The parent groups are persisted.
+	m2 := &Manager{groups: m.groups, storage: storage}
+	re.NoError(m2.loadParentGroups())
+	re.Equal(parent, m2.GetParentGroup("p"))
+
+	// The deleted group is removed from the parent.
+	re.NoError(m.DeleteResourceGroup("b"))
+	re.Len(m.GetParentGroup("p").Children, 1)
+	m.GetMutableResourceGroup("a").RequestRU(now, 3000, 5000, 1)
+	m.balanceBorrowing(time.Second)
+	re.Equal(2000.0, m.GetResourceGroup("a", false).RUSettings.RU.BorrowedFillRate)
+	re.NoError(m.DeleteParentGroup("p"))
+	re.Zero(m.GetResourceGroup("a", false).RUSettings.RU.BorrowedFillRate)
+	re.Nil(m.GetParentGroup("p"))
+	re.Error(m.DeleteParentGroup("p"))
+}
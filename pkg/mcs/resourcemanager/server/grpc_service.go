@@ -31,7 +31,9 @@ import (
 	bs "github.com/tikv/pd/pkg/basicserver"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mcs/registry"
+	"github.com/tikv/pd/pkg/mcs/utils/constant"
 	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/pkg/utils/grpcutil"
 )
 
 var _ rmpb.ResourceManagerServer = (*Service)(nil)
@@ -155,6 +157,11 @@ func (s *Service) ModifyResourceGroup(_ context.Context, req *rmpb.PutResourceGr
 
 // AcquireTokenBuckets implements ResourceManagerServer.AcquireTokenBuckets.
 func (s *Service) AcquireTokenBuckets(stream rmpb.ResourceManager_AcquireTokenBucketsServer) error {
+	// The usage is recorded to the keyspace of the client.
+	keyspaceID, ok := grpcutil.GetKeyspaceID(stream.Context())
+	if !ok {
+		keyspaceID = constant.NullKeyspaceID
+	}
 	for {
 		select {
 		case <-s.ctx.Done():
@@ -193,10 +200,11 @@ func (s *Service) AcquireTokenBuckets(stream rmpb.ResourceManager_AcquireTokenBu
 			}
 			s.manager.consumptionDispatcher <- struct {
 				resourceGroupName string
+				keyspaceID        uint32
 				*rmpb.Consumption
 				isBackground bool
 				isTiFlash    bool
-			}{resourceGroupName, req.GetConsumptionSinceLastRequest(), isBackground, isTiFlash}
+			}{resourceGroupName, keyspaceID, req.GetConsumptionSinceLastRequest(), isBackground, isTiFlash}
 			if isBackground {
 				continue
 			}
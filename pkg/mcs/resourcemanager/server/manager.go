@@ -61,16 +61,22 @@ type Manager struct {
 	controllerConfig *ControllerConfig
 	groups           map[string]*ResourceGroup
 	storage          endpoint.ResourceGroupStorage
+	// parents is the mapping from the parent group name to the parent group,
+	// whose children can borrow the unused RU from each other.
+	parents map[string]*ParentGroup
 	// consumptionChan is used to send the consumption
 	// info to the background metrics flusher.
 	consumptionDispatcher chan struct {
 		resourceGroupName string
+		keyspaceID        uint32
 		*rmpb.Consumption
 		isBackground bool
 		isTiFlash    bool
 	}
 	// record update time of each resource group
 	consumptionRecord map[consumptionRecordKey]time.Time
+	// usageLedger records the resource usage of each resource group per hour for chargeback.
+	usageLedger *usageLedger
 }
 
 type consumptionRecordKey struct {
@@ -90,8 +96,10 @@ func NewManager[T ConfigProvider](srv bs.Server) *Manager {
 	m := &Manager{
 		controllerConfig: srv.(T).GetControllerConfig(),
 		groups:           make(map[string]*ResourceGroup),
+		parents:          make(map[string]*ParentGroup),
 		consumptionDispatcher: make(chan struct {
 			resourceGroupName string
+			keyspaceID        uint32
 			*rmpb.Consumption
 			isBackground bool
 			isTiFlash    bool
@@ -105,6 +113,7 @@ func NewManager[T ConfigProvider](srv bs.Server) *Manager {
 			kv.NewEtcdKVBase(srv.GetClient()),
 			nil,
 		)
+		m.usageLedger = newUsageLedger(m.storage, defaultUsageRetention)
 		m.srv = srv
 	})
 	// The second initialization after becoming serving.
@@ -162,6 +171,11 @@ func (m *Manager) Init(ctx context.Context) error {
 		return err
 	}
 
+	// Load the parent groups after the resource groups, so the children can be found.
+	if err := m.loadParentGroups(); err != nil {
+		return err
+	}
+
 	// Add default group if it's not inited.
 	if _, ok := m.groups[reservedDefaultGroupName]; !ok {
 		defaultGroup := &ResourceGroup{
@@ -188,6 +202,7 @@ func (m *Manager) Init(ctx context.Context) error {
 		defer logutil.LogPanic()
 		m.persistLoop(ctx)
 	}()
+	go m.borrowingBalanceLoop(ctx)
 	log.Info("resource group manager finishes initialization")
 	return nil
 }
@@ -265,10 +280,15 @@ func (m *Manager) ModifyResourceGroup(group *rmpb.ResourceGroup) error {
 	}
 	m.Lock()
 	curGroup, ok := m.groups[group.Name]
-	m.Unlock()
 	if !ok {
+		m.Unlock()
 		return errs.ErrResourceGroupNotExists.FastGenByArgs(group.Name)
 	}
+	if err := m.checkChildSettingsLocked(group); err != nil {
+		m.Unlock()
+		return err
+	}
+	m.Unlock()
 
 	err := curGroup.PatchSettings(group)
 	if err != nil {
@@ -287,6 +307,7 @@ func (m *Manager) DeleteResourceGroup(name string) error {
 	}
 	m.Lock()
 	delete(m.groups, name)
+	m.removeChildLocked(name)
 	m.Unlock()
 	return nil
 }
@@ -325,6 +346,11 @@ func (m *Manager) GetResourceGroupList(withStats bool) []*ResourceGroup {
 	return res
 }
 
+// QueryUsage returns the resource usage within the hours overlapping [start, end) which matches the filter.
+func (m *Manager) QueryUsage(start, end time.Time, filter *UsageFilter) ([]*UsageRecord, error) {
+	return m.usageLedger.query(start, end, filter)
+}
+
 func (m *Manager) persistLoop(ctx context.Context) {
 	ticker := time.NewTicker(time.Minute)
 	failpoint.Inject("fastPersist", func() {
@@ -334,9 +360,17 @@ func (m *Manager) persistLoop(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			// The context is canceled after losing the primary, flushing now may race with the new
+			// primary, so drop the usage not persisted yet.
+			if n := m.usageLedger.drop(); n > 0 {
+				log.Warn("drop the resource group usage not persisted before stepping down", zap.Int("count", n))
+			}
 			return
 		case <-ticker.C:
 			m.persistResourceGroupRunningState()
+			if err := m.usageLedger.flush(time.Now()); err != nil {
+				log.Error("persist resource group usage failed", zap.Error(err))
+			}
 		}
 	}
 }
@@ -405,6 +439,7 @@ func (m *Manager) backgroundMetricsFlush(ctx context.Context) {
 				maxPerSecTrackers[name] = t
 			}
 			t.CollectConsumption(consumption)
+			m.usageLedger.record(time.Now(), consumptionInfo.keyspaceID, name, consumption)
 
 			// RU info.
 			if consumption.RRU > 0 {
@@ -461,6 +496,7 @@ func (m *Manager) backgroundMetricsFlush(ctx context.Context) {
 					readRequestUnitMaxPerSecCost.DeleteLabelValues(r.name)
 					writeRequestUnitMaxPerSecCost.DeleteLabelValues(r.name)
 					resourceGroupConfigGauge.DeletePartialMatch(prometheus.Labels{newResourceGroupNameLabel: r.name})
+					borrowedRequestUnitCost.DeleteLabelValues(r.name)
 				}
 			}
 		case <-availableRUTicker.C:
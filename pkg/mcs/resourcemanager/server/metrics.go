@@ -122,6 +122,22 @@ var (
 			Help:      "Counter of the available RU for all resource groups.",
 		}, []string{resourceGroupNameLabel, newResourceGroupNameLabel})
 
+	borrowedRUGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: ruSubsystem,
+			Name:      "borrowed_ru_per_sec",
+			Help:      "Gauge of the RU per second borrowed from the sibling groups for all resource groups.",
+		}, []string{newResourceGroupNameLabel})
+
+	borrowedRequestUnitCost = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: ruSubsystem,
+			Name:      "borrowed_request_unit_sum",
+			Help:      "Counter of the request unit granted beyond the guaranteed fill rate for all resource groups.",
+		}, []string{newResourceGroupNameLabel})
+
 	resourceGroupConfigGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -144,4 +160,6 @@ func init() {
 	prometheus.MustRegister(readRequestUnitMaxPerSecCost)
 	prometheus.MustRegister(writeRequestUnitMaxPerSecCost)
 	prometheus.MustRegister(resourceGroupConfigGauge)
+	prometheus.MustRegister(borrowedRUGauge)
+	prometheus.MustRegister(borrowedRequestUnitCost)
 }
@@ -200,6 +200,30 @@ func (rg *ResourceGroup) RequestRU(
 	return &rmpb.GrantedRUTokenBucket{GrantedTokens: tb, TrickleTimeMs: trickleTimeMs}
 }
 
+// takeBorrowingStats returns the RU tokens required and granted since the last call,
+// and the RU fill rate borrowed from the sibling groups during this period.
+func (rg *ResourceGroup) takeBorrowingStats() (required, granted, borrowed float64) {
+	rg.Lock()
+	defer rg.Unlock()
+
+	if rg.RUSettings == nil || rg.RUSettings.RU == nil {
+		return 0, 0, 0
+	}
+	required, granted = rg.RUSettings.RU.takeBorrowingStats()
+	return required, granted, rg.RUSettings.RU.BorrowedFillRate
+}
+
+// setBorrowedFillRate sets the RU fill rate borrowed from the sibling groups.
+func (rg *ResourceGroup) setBorrowedFillRate(rate float64) {
+	rg.Lock()
+	defer rg.Unlock()
+
+	if rg.RUSettings == nil || rg.RUSettings.RU == nil {
+		return
+	}
+	rg.RUSettings.RU.BorrowedFillRate = rate
+}
+
 // IntoProtoResourceGroup converts a ResourceGroup to a rmpb.ResourceGroup.
 func (rg *ResourceGroup) IntoProtoResourceGroup() *rmpb.ResourceGroup {
 	rg.RLock()
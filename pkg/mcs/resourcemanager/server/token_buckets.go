@@ -127,6 +127,14 @@ type GroupTokenBucketState struct {
 	// settingChanged is used to avoid that the number of tokens returned is jitter because of changing fill rate.
 	settingChanged      bool
 	lastCheckExpireSlot time.Time
+
+	// BorrowedFillRate is the fill rate borrowed from the sibling groups under the same parent group,
+	// which is added to the fill rate of the settings when refilling the tokens.
+	BorrowedFillRate float64 `json:"borrowed_fill_rate,omitempty"`
+	// requiredTokensSum and grantedTokensSum are the tokens required by and granted to the clients
+	// since the last borrowing balance, which are used to estimate the demand of the group.
+	requiredTokensSum float64
+	grantedTokensSum  float64
 }
 
 // Clone returns the copy of GroupTokenBucketState
@@ -151,9 +159,17 @@ func (gts *GroupTokenBucketState) Clone() *GroupTokenBucketState {
 		tokenSlots:                 tokenSlots,
 		clientConsumptionTokensSum: gts.clientConsumptionTokensSum,
 		lastCheckExpireSlot:        gts.lastCheckExpireSlot,
+		BorrowedFillRate:           gts.BorrowedFillRate,
 	}
 }
 
+// takeBorrowingStats returns the tokens required and granted since the last call and resets them.
+func (gts *GroupTokenBucketState) takeBorrowingStats() (required, granted float64) {
+	required, granted = gts.requiredTokensSum, gts.grantedTokensSum
+	gts.requiredTokensSum, gts.grantedTokensSum = 0, 0
+	return
+}
+
 func (gts *GroupTokenBucketState) resetLoan() {
 	gts.settingChanged = false
 	gts.Tokens = 0
@@ -296,6 +312,23 @@ func NewGroupTokenBucket(tokenBucket *rmpb.TokenBucket) *GroupTokenBucket {
 	}
 }
 
+// getEffectiveSettings returns the settings with the borrowed fill rate added. The burst limit
+// of the limited mode is raised by the same amount so that the borrowed tokens can be accumulated.
+func (gtb *GroupTokenBucket) getEffectiveSettings() *rmpb.TokenLimitSettings {
+	if gtb.BorrowedFillRate <= 0 || gtb.Settings == nil {
+		return gtb.Settings
+	}
+	settings := &rmpb.TokenLimitSettings{
+		FillRate:   gtb.Settings.GetFillRate() + uint64(gtb.BorrowedFillRate),
+		BurstLimit: gtb.Settings.GetBurstLimit(),
+		MaxTokens:  gtb.Settings.GetMaxTokens(),
+	}
+	if getBurstableMode(gtb.Settings) == limited {
+		settings.BurstLimit += int64(gtb.BorrowedFillRate)
+	}
+	return settings
+}
+
 // GetTokenBucket returns the grpc protoc struct of GroupTokenBucket.
 func (gtb *GroupTokenBucket) GetTokenBucket() *rmpb.TokenBucket {
 	if gtb.Settings == nil {
@@ -343,11 +376,12 @@ func (gtb *GroupTokenBucket) init(now time.Time, clientID uint64) {
 // updateTokens updates the tokens and settings.
 func (gtb *GroupTokenBucket) updateTokens(now time.Time, burstLimit int64, clientUniqueID uint64, requiredToken float64) {
 	var elapseTokens float64
+	settings := gtb.getEffectiveSettings()
 	if !gtb.Initialized {
 		gtb.init(now, clientUniqueID)
 	} else if burst := float64(burstLimit); burst > 0 {
 		if delta := now.Sub(*gtb.LastUpdate); delta > 0 {
-			elapseTokens = float64(gtb.Settings.GetFillRate())*delta.Seconds() + gtb.lastBurstTokens
+			elapseTokens = float64(settings.GetFillRate())*delta.Seconds() + gtb.lastBurstTokens
 			gtb.lastBurstTokens = 0
 			gtb.Tokens += elapseTokens
 		}
@@ -363,7 +397,7 @@ func (gtb *GroupTokenBucket) updateTokens(now time.Time, burstLimit int64, clien
 		gtb.resetLoan()
 	}
 	// Balance each slots.
-	gtb.balanceSlotTokens(clientUniqueID, gtb.Settings, requiredToken, elapseTokens)
+	gtb.balanceSlotTokens(clientUniqueID, settings, requiredToken, elapseTokens)
 }
 
 // request requests tokens from the corresponding slot.
@@ -371,7 +405,7 @@ func (gtb *GroupTokenBucket) request(now time.Time,
 	requiredToken float64,
 	targetPeriodMs, clientUniqueID uint64,
 ) (*rmpb.TokenBucket, int64) {
-	burstLimit := gtb.Settings.GetBurstLimit()
+	burstLimit := gtb.getEffectiveSettings().GetBurstLimit()
 	gtb.updateTokens(now, burstLimit, clientUniqueID, requiredToken)
 	slot, ok := gtb.tokenSlots[clientUniqueID]
 	if !ok {
@@ -381,6 +415,8 @@ func (gtb *GroupTokenBucket) request(now time.Time,
 	// Update bucket to record all tokens.
 	gtb.Tokens -= slot.lastTokenCapacity - slot.tokenCapacity
 	slot.lastTokenCapacity = slot.tokenCapacity
+	gtb.requiredTokensSum += requiredToken
+	gtb.grantedTokensSum += res.GetTokens()
 
 	return res, trickleDuration
 }
@@ -271,3 +271,38 @@ func TestGroupTokenBucketRequestLoop(t *testing.T) {
 		currentTime = currentTime.Add(timeIncrement)
 	}
 }
+
+func TestGroupTokenBucketBorrowedFillRate(t *testing.T) {
+	re := require.New(t)
+	tb := NewGroupTokenBucket(&rmpb.TokenBucket{
+		Settings: &rmpb.TokenLimitSettings{
+			FillRate:   1000,
+			BurstLimit: 10000000,
+		},
+	})
+	clientUniqueID := uint64(0)
+	time1 := time.Now()
+	tb.request(time1, 0, 0, clientUniqueID)
+	re.Equal(float64(defaultInitialTokens), tb.Tokens)
+
+	// The borrowed fill rate is added when refilling the tokens.
+	tb.BorrowedFillRate = 500
+	time2 := time1.Add(time.Second)
+	tb.request(time2, 0, 0, clientUniqueID)
+	re.InDelta(float64(defaultInitialTokens+1500), tb.Tokens, 1e-7)
+	settings := tb.getEffectiveSettings()
+	re.Equal(uint64(1500), settings.GetFillRate())
+	re.Equal(int64(10000500), settings.GetBurstLimit())
+	re.Equal(uint64(1000), tb.Settings.GetFillRate())
+	re.Equal(500.0, tb.GroupTokenBucketState.Clone().BorrowedFillRate)
+
+	// The demand is recorded for the borrowing balance.
+	res, _ := tb.request(time2, 1000, 1000, clientUniqueID)
+	re.Equal(1000.0, res.GetTokens())
+	required, granted := tb.takeBorrowingStats()
+	re.Equal(1000.0, required)
+	re.Equal(1000.0, granted)
+	required, granted = tb.takeBorrowingStats()
+	re.Zero(required)
+	re.Zero(granted)
+}
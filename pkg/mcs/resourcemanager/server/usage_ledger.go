@@ -0,0 +1,304 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/errors"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	usageRecordInterval   = time.Hour
+	defaultUsageRetention = 90 * 24 * time.Hour
+	usagePruneInterval    = time.Hour
+	usageMergeMaxRetry    = 3
+)
+
+// UsageRecord is the resource usage of a resource group in a keyspace within an hour.
+type UsageRecord struct {
+	// Hour is the start of the hour in unix seconds.
+	Hour              int64   `json:"hour"`
+	KeyspaceID        uint32  `json:"keyspace_id"`
+	ResourceGroup     string  `json:"resource_group"`
+	RRU               float64 `json:"rru"`
+	WRU               float64 `json:"wru"`
+	ReadBytes         float64 `json:"read_bytes"`
+	WriteBytes        float64 `json:"write_bytes"`
+	TotalCPUTimeMs    float64 `json:"total_cpu_time_ms"`
+	SQLLayerCPUTimeMs float64 `json:"sql_layer_cpu_time_ms"`
+}
+
+func (r *UsageRecord) add(other *UsageRecord) {
+	r.RRU += other.RRU
+	r.WRU += other.WRU
+	r.ReadBytes += other.ReadBytes
+	r.WriteBytes += other.WriteBytes
+	r.TotalCPUTimeMs += other.TotalCPUTimeMs
+	r.SQLLayerCPUTimeMs += other.SQLLayerCPUTimeMs
+}
+
+func (r *UsageRecord) addConsumption(c *rmpb.Consumption) {
+	r.RRU += c.RRU
+	r.WRU += c.WRU
+	r.ReadBytes += c.ReadBytes
+	r.WriteBytes += c.WriteBytes
+	r.TotalCPUTimeMs += c.TotalCpuTimeMs
+	r.SQLLayerCPUTimeMs += c.SqlLayerCpuTimeMs
+}
+
+// UsageFilter is used to filter the usage records.
+type UsageFilter struct {
+	// KeyspaceID is the keyspace of the records, nil means all keyspaces.
+	KeyspaceID *uint32
+	// ResourceGroup is the resource group of the records, empty means all resource groups.
+	ResourceGroup string
+}
+
+func (f *UsageFilter) match(r *UsageRecord) bool {
+	if f == nil {
+		return true
+	}
+	if f.KeyspaceID != nil && *f.KeyspaceID != r.KeyspaceID {
+		return false
+	}
+	return len(f.ResourceGroup) == 0 || f.ResourceGroup == r.ResourceGroup
+}
+
+type usageKey struct {
+	hour       int64
+	keyspaceID uint32
+	group      string
+}
+
+func usageHour(t time.Time) int64 {
+	return t.Truncate(usageRecordInterval).Unix()
+}
+
+// usageLedger aggregates the resource usage per resource group per keyspace per hour, and
+// persists it to the storage periodically so that it can be used for chargeback.
+type usageLedger struct {
+	storage   endpoint.ResourceGroupStorage
+	retention time.Duration
+
+	// flushMu makes sure the pending usage is either in memory or in the storage for the query.
+	flushMu    syncutil.RWMutex
+	lastPruned time.Time
+
+	mu struct {
+		syncutil.Mutex
+		// pending is the usage not persisted yet, which is merged into the persisted one when flushing.
+		pending map[usageKey]*UsageRecord
+	}
+}
+
+func newUsageLedger(storage endpoint.ResourceGroupStorage, retention time.Duration) *usageLedger {
+	l := &usageLedger{
+		storage:   storage,
+		retention: retention,
+	}
+	l.mu.pending = make(map[usageKey]*UsageRecord)
+	return l
+}
+
+// record adds the consumption to the usage of the resource group within the current hour.
+func (l *usageLedger) record(now time.Time, keyspaceID uint32, group string, c *rmpb.Consumption) {
+	if c == nil {
+		return
+	}
+	key := usageKey{hour: usageHour(now), keyspaceID: keyspaceID, group: group}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.mu.pending[key]
+	if !ok {
+		r = &UsageRecord{Hour: key.hour, KeyspaceID: keyspaceID, ResourceGroup: group}
+		l.mu.pending[key] = r
+	}
+	r.addConsumption(c)
+}
+
+// flush merges the pending usage into the storage and removes the expired usage.
+func (l *usageLedger) flush(now time.Time) error {
+	l.flushMu.Lock()
+	defer l.flushMu.Unlock()
+	l.mu.Lock()
+	pending := l.mu.pending
+	l.mu.pending = make(map[usageKey]*UsageRecord)
+	l.mu.Unlock()
+
+	for key, delta := range pending {
+		if err := l.merge(key, delta); err != nil {
+			// Put the usage back to retry in the next flush.
+			l.mu.Lock()
+			for key, delta := range pending {
+				if r, ok := l.mu.pending[key]; ok {
+					r.add(delta)
+				} else {
+					l.mu.pending[key] = delta
+				}
+			}
+			l.mu.Unlock()
+			return err
+		}
+		delete(pending, key)
+	}
+
+	if l.retention > 0 && now.Sub(l.lastPruned) >= usagePruneInterval {
+		if err := l.storage.RemoveResourceGroupUsageBefore(usageHour(now.Add(-l.retention))); err != nil {
+			return err
+		}
+		l.lastPruned = now
+	}
+	return nil
+}
+
+// merge adds the delta to the persisted usage. The usage is updated in a transaction and retried
+// on conflicts, so the delta is neither lost nor double counted if the usage is written concurrently,
+// e.g. by the previous primary which has not stepped down yet.
+func (l *usageLedger) merge(key usageKey, delta *UsageRecord) error {
+	update := func(v string) any {
+		r := &UsageRecord{Hour: key.hour, KeyspaceID: key.keyspaceID, ResourceGroup: key.group}
+		if len(v) > 0 {
+			if err := json.Unmarshal([]byte(v), r); err != nil {
+				log.Warn("failed to parse the resource group usage, overwrite it", zap.Error(err), zap.String("v", v))
+				r = &UsageRecord{Hour: key.hour, KeyspaceID: key.keyspaceID, ResourceGroup: key.group}
+			}
+		}
+		r.add(delta)
+		return r
+	}
+	var err error
+	for range usageMergeMaxRetry {
+		err = l.storage.UpdateResourceGroupUsage(key.hour, key.keyspaceID, key.group, update)
+		if !errors.Is(err, errs.ErrEtcdTxnConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// drop discards the pending usage, it's used when stepping down since the storage may be written by
+// the new primary, and returns the number of the discarded records.
+func (l *usageLedger) drop() int {
+	l.flushMu.Lock()
+	defer l.flushMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.mu.pending)
+	l.mu.pending = make(map[usageKey]*UsageRecord)
+	return n
+}
+
+// query returns the usage within the hours overlapping [start, end) which matches the filter,
+// sorted by the hour, the keyspace and the resource group.
+func (l *usageLedger) query(start, end time.Time, filter *UsageFilter) ([]*UsageRecord, error) {
+	startHour := usageHour(start)
+	endHour := usageHour(end)
+	if time.Unix(endHour, 0).Before(end) {
+		endHour += int64(usageRecordInterval / time.Second)
+	}
+	if startHour >= endHour {
+		return nil, nil
+	}
+
+	l.flushMu.RLock()
+	defer l.flushMu.RUnlock()
+	records := make(map[usageKey]*UsageRecord)
+	err := l.storage.LoadResourceGroupUsageRange(startHour, endHour, func(k, v string) {
+		r := &UsageRecord{}
+		if err := json.Unmarshal([]byte(v), r); err != nil {
+			log.Error("failed to parse the resource group usage", zap.Error(err), zap.String("k", k), zap.String("v", v))
+			return
+		}
+		if filter.match(r) {
+			records[usageKey{hour: r.Hour, keyspaceID: r.KeyspaceID, group: r.ResourceGroup}] = r
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	for key, delta := range l.mu.pending {
+		if key.hour < startHour || key.hour >= endHour || !filter.match(delta) {
+			continue
+		}
+		if r, ok := records[key]; ok {
+			r.add(delta)
+		} else {
+			r := *delta
+			records[key] = &r
+		}
+	}
+	l.mu.Unlock()
+
+	res := make([]*UsageRecord, 0, len(records))
+	for _, r := range records {
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Hour != res[j].Hour {
+			return res[i].Hour < res[j].Hour
+		}
+		if res[i].KeyspaceID != res[j].KeyspaceID {
+			return res[i].KeyspaceID < res[j].KeyspaceID
+		}
+		return res[i].ResourceGroup < res[j].ResourceGroup
+	})
+	return res, nil
+}
+
+// WriteUsageCSV writes the usage records in CSV format with a header line.
+func WriteUsageCSV(w io.Writer, records []*UsageRecord) error {
+	cw := csv.NewWriter(w)
+	header := []string{"hour", "keyspace_id", "resource_group", "rru", "wru",
+		"read_bytes", "write_bytes", "total_cpu_time_ms", "sql_layer_cpu_time_ms"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	for _, r := range records {
+		row := []string{
+			time.Unix(r.Hour, 0).UTC().Format(time.RFC3339),
+			strconv.FormatUint(uint64(r.KeyspaceID), 10),
+			r.ResourceGroup,
+			formatFloat(r.RRU),
+			formatFloat(r.WRU),
+			formatFloat(r.ReadBytes),
+			formatFloat(r.WriteBytes),
+			formatFloat(r.TotalCPUTimeMs),
+			formatFloat(r.SQLLayerCPUTimeMs),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestUsageLedger(t *testing.T) {
+	re := require.New(t)
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	l := newUsageLedger(storage, 48*time.Hour)
+	hour := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	l.record(hour.Add(10*time.Minute), 1, "a", &rmpb.Consumption{
+		RRU: 10, WRU: 5, ReadBytes: 100, WriteBytes: 50, TotalCpuTimeMs: 20, SqlLayerCpuTimeMs: 8,
+	})
+	l.record(hour.Add(20*time.Minute), 1, "a", &rmpb.Consumption{RRU: 10})
+	l.record(hour.Add(30*time.Minute), 2, "b", &rmpb.Consumption{WRU: 1})
+	l.record(hour.Add(70*time.Minute), 1, "a", &rmpb.Consumption{RRU: 1})
+
+	// The pending usage can be queried before flushing.
+	check := func(l *usageLedger) {
+		records, err := l.query(hour, hour.Add(2*time.Hour), nil)
+		re.NoError(err)
+		re.Len(records, 3)
+		re.Equal(&UsageRecord{
+			Hour: hour.Unix(), KeyspaceID: 1, ResourceGroup: "a",
+			RRU: 20, WRU: 5, ReadBytes: 100, WriteBytes: 50, TotalCPUTimeMs: 20, SQLLayerCPUTimeMs: 8,
+		}, records[0])
+		re.Equal(&UsageRecord{Hour: hour.Unix(), KeyspaceID: 2, ResourceGroup: "b", WRU: 1}, records[1])
+		re.Equal(&UsageRecord{Hour: hour.Add(time.Hour).Unix(), KeyspaceID: 1, ResourceGroup: "a", RRU: 1}, records[2])
+	}
+	check(l)
+	re.NoError(l.flush(hour.Add(80 * time.Minute)))
+	check(l)
+	// The usage is persisted.
+	check(newUsageLedger(storage, 48*time.Hour))
+
+	// The new usage is merged into the persisted one.
+	l.record(hour.Add(80*time.Minute), 1, "a", &rmpb.Consumption{RRU: 2})
+	records, err := l.query(hour.Add(time.Hour), hour.Add(time.Hour+time.Minute), nil)
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal(3.0, records[0].RRU)
+	re.NoError(l.flush(hour.Add(90 * time.Minute)))
+	records, err = l.query(hour.Add(time.Hour), hour.Add(time.Hour+time.Minute), nil)
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal(3.0, records[0].RRU)
+
+	// Filter the usage.
+	keyspaceID := uint32(2)
+	records, err = l.query(hour, hour.Add(2*time.Hour), &UsageFilter{KeyspaceID: &keyspaceID})
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal("b", records[0].ResourceGroup)
+	records, err = l.query(hour, hour.Add(2*time.Hour), &UsageFilter{ResourceGroup: "a"})
+	re.NoError(err)
+	re.Len(records, 2)
+	records, err = l.query(hour.Add(2*time.Hour), hour.Add(3*time.Hour), nil)
+	re.NoError(err)
+	re.Empty(records)
+
+	// The expired usage is removed.
+	re.NoError(l.flush(hour.Add(49*time.Hour + 30*time.Minute)))
+	records, err = l.query(hour, hour.Add(2*time.Hour), nil)
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal(hour.Add(time.Hour).Unix(), records[0].Hour)
+}
+
+func TestDropUsageOnStepDown(t *testing.T) {
+	re := require.New(t)
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	m := &Manager{storage: storage, usageLedger: newUsageLedger(storage, defaultUsageRetention)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.persistLoop(ctx)
+	}()
+	now := time.Now()
+	m.usageLedger.record(now, 1, "a", &rmpb.Consumption{RRU: 10})
+	cancel()
+	<-done
+
+	// The usage is not persisted after stepping down, and the pending one is dropped.
+	records, err := newUsageLedger(storage, defaultUsageRetention).query(now, now.Add(time.Minute), nil)
+	re.NoError(err)
+	re.Empty(records)
+	records, err = m.usageLedger.query(now, now.Add(time.Minute), nil)
+	re.NoError(err)
+	re.Empty(records)
+}
+
+func TestWriteUsageCSV(t *testing.T) {
+	re := require.New(t)
+	hour := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	re.NoError(WriteUsageCSV(&buf, []*UsageRecord{
+		{Hour: hour.Unix(), KeyspaceID: 1, ResourceGroup: "a", RRU: 1.5, WRU: 2, ReadBytes: 100, WriteBytes: 50, TotalCPUTimeMs: 20, SQLLayerCPUTimeMs: 8},
+	}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	re.Equal([]string{
+		"hour,keyspace_id,resource_group,rru,wru,read_bytes,write_bytes,total_cpu_time_ms,sql_layer_cpu_time_ms",
+		"2025-01-01T10:00:00Z,1,a,1.5,2,100,50,20,8",
+	}, lines)
+}
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/gzip"
@@ -140,6 +141,7 @@ func (s *Service) RegisterSchedulersRouter() {
 	router := s.root.Group("schedulers")
 	router.GET("", getSchedulers)
 	router.GET("/diagnostic/:name", getDiagnosticResult)
+	router.GET("/dry-run/:name", dryRunScheduler)
 	router.GET("/config", getSchedulerConfig)
 	router.GET("/config/:name/list", getSchedulerConfigByName)
 	// TODO: in the future, we should split pauseOrResumeScheduler to two different APIs.
@@ -173,6 +175,13 @@ func (s *Service) RegisterOperatorsRouter() {
 	router.GET("/:id", getOperatorByRegion)
 	router.DELETE("/:id", deleteOperatorByRegion)
 	router.GET("/records", getOperatorRecords)
+	router.GET("/history", getOperatorHistory)
+	router.GET("/groups", getOperatorGroups)
+	router.POST("/groups", createOperatorGroup)
+	router.GET("/groups/:id", getOperatorGroup)
+	router.DELETE("/groups/:id", cancelOperatorGroup)
+	router.POST("/groups/:id/pause", pauseOperatorGroup)
+	router.POST("/groups/:id/resume", resumeOperatorGroup)
 }
 
 // RegisterStoresRouter registers the router of the stores handler.
@@ -442,6 +451,67 @@ func getOperatorRecords(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, records)
 }
 
+// @Tags     operator
+// @Summary  List the persisted finished operators in the given time range.
+// @Param    start      query  integer  false  "Start Unix timestamp"
+// @Param    end        query  integer  false  "End Unix timestamp, default is now"
+// @Param    region_id  query  integer  false  "Only return the operators of the region"
+// @Param    store_id   query  integer  false  "Only return the operators related to the store"
+// @Produce  json
+// @Success  200  {object}  storage.HistoryOperators
+// @Failure  400  {string}  string  "The request is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/history [get]
+func getOperatorHistory(c *gin.Context) {
+	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*scheserver.Server)
+	historyStorage := svr.GetOperatorHistoryStorage()
+	if historyStorage == nil {
+		c.String(http.StatusInternalServerError, "the operator history is not persisted since the data dir is not set")
+		return
+	}
+	start, err := apiutil.ParseTime(c.Query("start"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	end := time.Now()
+	if endStr := c.Query("end"); len(endStr) > 0 {
+		end, err = apiutil.ParseTime(endStr)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	regionIDs, err := parseUint64Query(c.QueryArray("region_id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	storeIDs, err := parseUint64Query(c.QueryArray("store_id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	history, err := historyStorage.LoadOperatorHistory(start.UnixMilli(), end.UnixMilli(), regionIDs, storeIDs)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, history)
+}
+
+func parseUint64Query(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // FIXME: details of input json body params
 // @Tags     operator
 // @Summary  Create an operator.
@@ -471,6 +541,128 @@ func createOperator(c *gin.Context) {
 	c.IndentedJSON(statusCode, result)
 }
 
+// @Tags     operator
+// @Summary  List all operator groups and their progress.
+// @Produce  json
+// @Success  200  {array}   operator.OperatorGroupStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups [get]
+func getOperatorGroups(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	groups, err := handler.GetOperatorGroups()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, groups)
+}
+
+// @Tags     operator
+// @Summary  Create a group of operators with dependencies and a shared concurrency.
+// @Accept   json
+// @Param    body  body  handler.OperatorGroupInput  true  "The operators of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is created."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups [post]
+func createOperatorGroup(c *gin.Context) {
+	h := c.MustGet(handlerKey).(*handler.Handler)
+	var input handler.OperatorGroupInput
+	if err := c.BindJSON(&input); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if statusCode, err := h.AddOperatorGroup(&input); err != nil {
+		c.String(statusCode, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The operator group is created.")
+}
+
+// @Tags     operator
+// @Summary  Get the operator group and its progress.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {object}  operator.OperatorGroupStatus
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id} [get]
+func getOperatorGroup(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	group, err := handler.GetOperatorGroup(c.Param("id"))
+	if err != nil {
+		c.String(operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, group)
+}
+
+// @Tags     operator
+// @Summary  Stop dispatching the operators of the group, the running operators are not affected.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is paused."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id}/pause [post]
+func pauseOperatorGroup(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	if err := handler.PauseOperatorGroup(c.Param("id")); err != nil {
+		c.String(operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The operator group is paused.")
+}
+
+// @Tags     operator
+// @Summary  Continue dispatching the operators of the group.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is resumed."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id}/resume [post]
+func resumeOperatorGroup(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	if err := handler.ResumeOperatorGroup(c.Param("id")); err != nil {
+		c.String(operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The operator group is resumed.")
+}
+
+// @Tags     operator
+// @Summary  Cancel the running and pending operators of the group.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is canceled."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id} [delete]
+func cancelOperatorGroup(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	if err := handler.CancelOperatorGroup(c.Param("id")); err != nil {
+		c.String(operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The operator group is canceled.")
+}
+
+func operatorGroupErrorStatus(err error) int {
+	switch {
+	case errs.ErrOperatorGroupNotFound.Equal(err):
+		return http.StatusNotFound
+	case errs.ErrOperatorGroupInvalid.Equal(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // @Tags     checkers
 // @Summary  Get checker by name
 // @Param    name  path  string  true  "The name of the checker."
@@ -618,6 +810,29 @@ func getDiagnosticResult(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, result)
 }
 
+// @Tags     schedulers
+// @Summary  Show the operators a scheduler would create now without executing them.
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Produce  json
+// @Success  200  {object}  schedulers.DryRunResult
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/dry-run/{name} [get]
+func dryRunScheduler(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	name := c.Param("name")
+	result, err := handler.DryRunScheduler(name)
+	if err != nil {
+		if errs.ErrSchedulerNotFound.Equal(err) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
 // FIXME: details of input json body params
 // @Tags     scheduler
 // @Summary  Pause or resume a scheduler.
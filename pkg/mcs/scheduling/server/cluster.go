@@ -33,6 +33,7 @@ import (
 	"github.com/tikv/pd/pkg/cluster"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/mcs/scheduling/server/config"
 	"github.com/tikv/pd/pkg/ratelimit"
 	"github.com/tikv/pd/pkg/schedule"
@@ -71,6 +72,8 @@ type Cluster struct {
 	checkMembershipCh chan struct{}
 	pdLeader          atomic.Value
 	running           atomic.Bool
+	// splitGuard is used to deny the split of the regions of the keyspaces which reach their quotas.
+	splitGuard *keyspace.QuotaSplitGuard
 
 	// heartbeatRunner is used to process the subtree update task asynchronously.
 	heartbeatRunner ratelimit.Runner
@@ -135,6 +138,13 @@ func NewCluster(
 	return c, nil
 }
 
+// SetSplitGuard sets the guard to deny the split of some regions by the split checker or asked by TiKV.
+// It should be called before the background jobs are started.
+func (c *Cluster) SetSplitGuard(guard *keyspace.QuotaSplitGuard) {
+	c.splitGuard = guard
+	c.coordinator.GetCheckerController().SetSplitGuard(guard)
+}
+
 // GetCoordinator returns the coordinator
 func (c *Cluster) GetCoordinator() *schedule.Coordinator {
 	return c.coordinator
@@ -538,6 +548,14 @@ func (c *Cluster) runMetricsCollectionJob() {
 	}
 }
 
+func (c *Cluster) runSplitGuard() {
+	defer c.wg.Done()
+	if c.splitGuard == nil {
+		return
+	}
+	c.splitGuard.Run(c.ctx)
+}
+
 func (c *Cluster) collectMetrics() {
 	statsMap := statistics.NewStoreStatisticsMap(c.persistConfig)
 	stores := c.GetStores()
@@ -568,11 +586,12 @@ func resetMetrics() {
 
 // StartBackgroundJobs starts background jobs.
 func (c *Cluster) StartBackgroundJobs() {
-	c.wg.Add(4)
+	c.wg.Add(5)
 	go c.updateScheduler()
 	go c.runUpdateStoreStats()
 	go c.runCoordinator()
 	go c.runMetricsCollectionJob()
+	go c.runSplitGuard()
 	c.heartbeatRunner.Start(c.ctx)
 	c.miscRunner.Start(c.ctx)
 	c.logRunner.Start(c.ctx)
@@ -57,6 +57,9 @@ type Config struct {
 	ListenAddr          string `toml:"listen-addr" json:"listen-addr"`
 	AdvertiseListenAddr string `toml:"advertise-listen-addr" json:"advertise-listen-addr"`
 	Name                string `toml:"name" json:"name"`
+	// DataDir is the path to persist the local data such as the finished operators.
+	// The finished operators are not persisted if it's empty.
+	DataDir string `toml:"data-dir" json:"data-dir"`
 
 	Metric metricutil.MetricConfig `toml:"metric" json:"metric"`
 
@@ -103,6 +106,7 @@ func (c *Config) Parse(flagSet *pflag.FlagSet) error {
 
 	// Ignore the error check here
 	configutil.AdjustCommandLineString(flagSet, &c.Name, "name")
+	configutil.AdjustCommandLineString(flagSet, &c.DataDir, "data-dir")
 	configutil.AdjustCommandLineString(flagSet, &c.Log.Level, "log-level")
 	configutil.AdjustCommandLineString(flagSet, &c.Log.File.Filename, "log-file")
 	configutil.AdjustCommandLineString(flagSet, &c.Metric.PushAddress, "metrics-addr")
@@ -133,6 +137,9 @@ func (c *Config) adjust(meta *toml.MetaData) error {
 	configutil.AdjustString(&c.BackendEndpoints, defaultBackendEndpoints)
 	configutil.AdjustString(&c.ListenAddr, defaultListenAddr)
 	configutil.AdjustString(&c.AdvertiseListenAddr, c.ListenAddr)
+	if c.DataDir != "" {
+		configutil.AdjustPath(&c.DataDir)
+	}
 
 	c.adjustLog(configMetaData.Child("log"))
 	if err := c.Security.Encryption.Adjust(); err != nil {
@@ -321,6 +328,11 @@ func (o *PersistConfig) GetLeaderSchedulePolicy() constant.SchedulePolicy {
 	return constant.StringToSchedulePolicy(o.GetScheduleConfig().LeaderSchedulePolicy)
 }
 
+// GetOperatorHistoryReservedDays returns the days the finished operators are kept.
+func (o *PersistConfig) GetOperatorHistoryReservedDays() uint64 {
+	return o.GetScheduleConfig().OperatorHistoryReservedDays
+}
+
 // GetMaxStoreDownTime returns the max store downtime.
 func (o *PersistConfig) GetMaxStoreDownTime() time.Duration {
 	return o.GetScheduleConfig().MaxStoreDownTime.Duration
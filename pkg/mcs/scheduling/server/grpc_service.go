@@ -285,6 +285,9 @@ func (s *Service) AskBatchSplit(_ context.Context, request *schedulingpb.AskBatc
 	if err != nil {
 		return nil, err
 	}
+	if c.splitGuard != nil && !c.splitGuard.AllowSplit(core.NewRegionInfo(reqRegion, nil)) {
+		return nil, errors.New("region split is denied by the keyspace quota")
+	}
 	splitIDs := make([]*pdpb.SplitID, 0, splitCount)
 	recordRegions := make([]uint64, 0, splitCount+1)
 
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -44,6 +45,7 @@ import (
 	"github.com/tikv/pd/pkg/cache"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/mcs/discovery"
 	"github.com/tikv/pd/pkg/mcs/scheduling/server/config"
 	"github.com/tikv/pd/pkg/mcs/scheduling/server/meta"
@@ -56,6 +58,7 @@ import (
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/schedulers"
+	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/utils/apiutil"
@@ -109,6 +112,9 @@ type Server struct {
 	cluster   *Cluster
 	hbStreams *hbstream.HeartbeatStreams
 	storage   *endpoint.StorageEndpoint
+	// operatorHistoryStorage persists the finished operators, it is nil if the data dir is not set
+	// or the cluster is stopped. It's read by the API handlers concurrently with the primary changes.
+	operatorHistoryStorage atomic.Pointer[storage.OperatorHistoryStorage]
 
 	// for watching the PD meta info updates that are related to the scheduling.
 	configWatcher *config.Watcher
@@ -503,6 +509,9 @@ func (s *Server) startCluster(context.Context) error {
 	if err != nil {
 		return err
 	}
+	// The keyspace quotas are not synced to the storage of the scheduling service, so load them from etcd directly.
+	s.cluster.SetSplitGuard(keyspace.NewQuotaSplitGuard(
+		endpoint.NewStorageEndpoint(kv.NewEtcdKVBase(s.GetClient()), nil), s.basicCluster))
 	// Inject the cluster components into the config watcher after the scheduler controller is created.
 	s.configWatcher.SetSchedulersController(s.cluster.GetCoordinator().GetSchedulersController())
 	// Start the rule watcher after the cluster is created.
@@ -510,6 +519,16 @@ func (s *Server) startCluster(context.Context) error {
 	if err != nil {
 		return err
 	}
+	if s.cfg.DataDir != "" {
+		var historyStorage *storage.OperatorHistoryStorage
+		historyStorage, err = storage.NewOperatorHistoryStorage(
+			s.Context(), filepath.Join(s.cfg.DataDir, "operator-history"), s.persistConfig)
+		if err != nil {
+			return err
+		}
+		s.operatorHistoryStorage.Store(historyStorage)
+		s.cluster.GetCoordinator().GetOperatorController().SetHistoryRecorder(historyStorage)
+	}
 	s.cluster.StartBackgroundJobs()
 	return nil
 }
@@ -517,6 +536,19 @@ func (s *Server) startCluster(context.Context) error {
 func (s *Server) stopCluster() {
 	s.cluster.StopBackgroundJobs()
 	s.stopWatcher()
+	if historyStorage := s.operatorHistoryStorage.Swap(nil); historyStorage != nil {
+		// Clear the recorder first, so no finished operator is put into the closed storage.
+		s.cluster.GetCoordinator().GetOperatorController().SetHistoryRecorder(nil)
+		if err := historyStorage.Close(); err != nil {
+			log.Error("close operator history storage meet error", errs.ZapError(err))
+		}
+	}
+}
+
+// GetOperatorHistoryStorage returns the storage of the finished operators, it is nil if the
+// data dir is not set or the cluster is stopped.
+func (s *Server) GetOperatorHistoryStorage() *storage.OperatorHistoryStorage {
+	return s.operatorHistoryStorage.Load()
 }
 
 func (s *Server) startMetaConfWatcher() (err error) {
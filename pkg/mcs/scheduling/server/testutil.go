@@ -53,10 +53,12 @@ func GenerateConfig(c *config.Config) (*config.Config, error) {
 		"--listen-addr=" + c.ListenAddr,
 		"--advertise-listen-addr=" + c.AdvertiseListenAddr,
 		"--backend-endpoints=" + c.BackendEndpoints,
+		"--data-dir=" + c.DataDir,
 	}
 
 	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
 	flagSet.StringP("name", "", "", "human-readable name for this scheduling member")
+	flagSet.StringP("data-dir", "", "", "path to the data directory, the finished operators are not persisted if it's empty")
 	flagSet.BoolP("version", "V", false, "print version information and exit")
 	flagSet.StringP("config", "", "", "config file")
 	flagSet.StringP("backend-endpoints", "", "", "url for etcd client")
@@ -108,6 +108,7 @@ func NewService(srv *tsoserver.Service) *Service {
 func (s *Service) RegisterAdminRouter() {
 	router := s.root.Group("admin")
 	router.POST("/reset-ts", ResetTS)
+	router.GET("/tso/audit", VerifyTSOAudit)
 	router.PUT("/log", changeLogLevel)
 }
 
@@ -151,6 +152,34 @@ func changeLogLevel(c *gin.Context) {
 	c.String(http.StatusOK, "The log level is updated.")
 }
 
+// VerifyTSOAudit is the http.HandlerFunc of VerifyTSOAudit
+// @Tags     admin
+// @Summary  Verify the monotonicity of the TSO with the audit log and the etcd history.
+// @Param    keyspace-group-id  query  integer  false  "The keyspace group ID"
+// @Produce  json
+// @Success  200  {object}  tso.AuditReport
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "TSO server failed to proceed the request."
+// @Router   /admin/tso/audit [get]
+func VerifyTSOAudit(c *gin.Context) {
+	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*tsoserver.Service)
+	var keyspaceGroupID uint32
+	if value := c.Query("keyspace-group-id"); len(value) > 0 {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.String(http.StatusBadRequest, "invalid keyspace group id")
+			return
+		}
+		keyspaceGroupID = uint32(id)
+	}
+	report, err := svr.VerifyTSOAudit(keyspaceGroupID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, report)
+}
+
 // ResetTSParams is the input json body params of ResetTS
 type ResetTSParams struct {
 	TSO           string `json:"tso"`
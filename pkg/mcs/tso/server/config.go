@@ -30,6 +30,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/configutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
@@ -44,6 +45,7 @@ const (
 	defaultTSOUpdatePhysicalInterval = 50 * time.Millisecond
 	maxTSOUpdatePhysicalInterval     = 10 * time.Second
 	minTSOUpdatePhysicalInterval     = 1 * time.Millisecond
+	defaultTSOMaxClockDrift          = 500 * time.Millisecond
 )
 
 var _ tso.ServiceConfig = (*Config)(nil)
@@ -77,6 +79,24 @@ type Config struct {
 	// MaxResetTSGap is the max gap to reset the TSO.
 	MaxResetTSGap typeutil.Duration `toml:"max-gap-reset-ts" json:"max-gap-reset-ts"`
 
+	// TSOAuditLogDir is the directory to record the persisted timestamp windows, the leader
+	// terms and the reset requests, which are used to verify the monotonicity of the TSO.
+	// The audit log is disabled if it is empty.
+	TSOAuditLogDir string `toml:"tso-audit-log-dir" json:"tso-audit-log-dir"`
+
+	// TSOMode is the mode of the TSO allocation, which can be "window" or "hlc". In the "window" mode,
+	// the TSO is allocated within a time window which is extended in etcd synchronously, so the
+	// allocation may be stalled by a slow etcd. In the "hlc" mode, the TSO is allocated from a hybrid
+	// logical clock whose drift from the system time is bounded by `TSOMaxClockDrift`, and etcd is
+	// only written to fence the TSO of the previous leader term.
+	TSOMode string `toml:"tso-mode" json:"tso-mode"`
+	// TSOKeyspaceGroupModes specifies the TSO mode of the keyspace groups, whose keys are the
+	// keyspace group IDs. The keyspace groups not specified here use `TSOMode`.
+	TSOKeyspaceGroupModes map[string]string `toml:"tso-keyspace-group-modes" json:"tso-keyspace-group-modes"`
+	// TSOMaxClockDrift is the max drift of the TSO physical time from the system time in the "hlc"
+	// mode. It should be larger than the clock skew between the servers.
+	TSOMaxClockDrift typeutil.Duration `toml:"tso-max-clock-drift" json:"tso-max-clock-drift"`
+
 	Metric metricutil.MetricConfig `toml:"metric" json:"metric"`
 
 	// WarningMsgs contains all warnings during parsing.
@@ -136,6 +156,21 @@ func (c *Config) GetMaxResetTSGap() time.Duration {
 	return c.MaxResetTSGap.Duration
 }
 
+// GetTSOAuditLogDir returns the directory of the TSO audit log.
+func (c *Config) GetTSOAuditLogDir() string {
+	return c.TSOAuditLogDir
+}
+
+// GetTSOMode returns the TSO mode of the keyspace group.
+func (c *Config) GetTSOMode(keyspaceGroupID uint32) string {
+	return tsoutil.GetKeyspaceGroupMode(c.TSOMode, c.TSOKeyspaceGroupModes, keyspaceGroupID)
+}
+
+// GetTSOMaxClockDrift returns the max clock drift of the TSO in the "hlc" mode.
+func (c *Config) GetTSOMaxClockDrift() time.Duration {
+	return c.TSOMaxClockDrift.Duration
+}
+
 // GetTLSConfig returns the TLS config.
 func (c *Config) GetTLSConfig() *grpcutil.TLSConfig {
 	return &c.Security.TLSConfig
@@ -203,6 +238,12 @@ func (c *Config) Adjust(meta *toml.MetaData) error {
 			zap.Duration("update-physical-interval", c.TSOUpdatePhysicalInterval.Duration))
 	}
 
+	configutil.AdjustString(&c.TSOMode, tsoutil.WindowMode)
+	configutil.AdjustDuration(&c.TSOMaxClockDrift, defaultTSOMaxClockDrift)
+	if err := tsoutil.ValidateModes(c.TSOMode, c.TSOKeyspaceGroupModes); err != nil {
+		return err
+	}
+
 	c.adjustLog(configMetaData.Child("log"))
 	return c.Security.Encryption.Adjust()
 }
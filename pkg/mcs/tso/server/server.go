@@ -314,6 +314,18 @@ func (s *Server) ResetTS(ts uint64, ignoreSmaller, skipUpperBoundCheck bool, key
 	return tsoAllocator.SetTSO(ts, ignoreSmaller, skipUpperBoundCheck)
 }
 
+// VerifyTSOAudit verifies the TSO audit log of the keyspace group.
+func (s *Server) VerifyTSOAudit(keyspaceGroupID uint32) (*tso.AuditReport, error) {
+	tsoAllocator, err := s.GetTSOAllocator(keyspaceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if tsoAllocator == nil {
+		return nil, errs.ErrServerNotStarted
+	}
+	return tsoAllocator.VerifyAudit(s.Context())
+}
+
 // GetConfig gets the config.
 func (s *Server) GetConfig() *Config {
 	return s.cfg
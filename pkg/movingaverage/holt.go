@@ -0,0 +1,134 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package movingaverage
+
+import "math"
+
+const (
+	// DefaultHoltAlpha and DefaultHoltBeta are the default smoothing factors of
+	// the level and the trend.
+	DefaultHoltAlpha = 0.5
+	DefaultHoltBeta  = 0.3
+	// holtMinForecastCount is the count of records needed to evaluate the forecast.
+	holtMinForecastCount = 3
+)
+
+// Holt works as Holt's linear trend method, which is also known as double
+// exponential smoothing. Besides the smoothed level, it tracks the trend of
+// the data set, so it can forecast the following records.
+// References: https://en.wikipedia.org/wiki/Exponential_smoothing#Double_exponential_smoothing_(Holt_linear).
+type Holt struct {
+	// alpha is the smoothing factor of the level, beta is the smoothing factor of the trend.
+	// They must be less than 1 and greater than 0.
+	alpha float64
+	beta  float64
+
+	count         uint64
+	level         float64
+	trend         float64
+	instantaneous float64
+	// absErr is the smoothed absolute error of the one-step forecast, and absValue
+	// is the smoothed absolute value of the records, they are used to evaluate the forecast.
+	absErr   float64
+	absValue float64
+}
+
+// NewHolt returns a Holt with the smoothing factors of the level and the trend.
+func NewHolt(alpha, beta float64) *Holt {
+	if alpha <= 0 || alpha >= 1 {
+		alpha = DefaultHoltAlpha
+	}
+	if beta <= 0 || beta >= 1 {
+		beta = DefaultHoltBeta
+	}
+	return &Holt{
+		alpha: alpha,
+		beta:  beta,
+	}
+}
+
+// Add adds a data point.
+func (h *Holt) Add(data float64) {
+	h.instantaneous = data
+	switch h.count {
+	case 0:
+		h.level = data
+		h.absValue = math.Abs(data)
+	case 1:
+		h.trend = data - h.level
+		h.level = data
+		h.absValue = h.alpha*math.Abs(data) + (1-h.alpha)*h.absValue
+	default:
+		forecastErr := math.Abs(data - h.level - h.trend)
+		if h.count == holtMinForecastCount-1 {
+			h.absErr = forecastErr
+		} else {
+			h.absErr = h.alpha*forecastErr + (1-h.alpha)*h.absErr
+		}
+		h.absValue = h.alpha*math.Abs(data) + (1-h.alpha)*h.absValue
+		lastLevel := h.level
+		h.level = h.alpha*data + (1-h.alpha)*(h.level+h.trend)
+		h.trend = h.beta*(h.level-lastLevel) + (1-h.beta)*h.trend
+	}
+	h.count++
+}
+
+// Get returns the smoothed level of the data set.
+func (h *Holt) Get() float64 {
+	return h.level
+}
+
+// Forecast returns the forecast of the record after the given steps.
+func (h *Holt) Forecast(steps int) float64 {
+	return h.level + float64(steps)*h.trend
+}
+
+// Confidence returns how well the recent records are forecast, which is in [0, 1].
+// It returns 0 if there are not enough records to evaluate the forecast.
+func (h *Holt) Confidence() float64 {
+	if h.count < holtMinForecastCount {
+		return 0
+	}
+	if h.absValue == 0 {
+		return 1
+	}
+	return math.Max(0, 1-h.absErr/h.absValue)
+}
+
+// GetInstantaneous returns the value just added.
+func (h *Holt) GetInstantaneous() float64 {
+	return h.instantaneous
+}
+
+// Reset cleans the data set.
+func (h *Holt) Reset() {
+	h.count = 0
+	h.level = 0
+	h.trend = 0
+	h.absErr = 0
+	h.absValue = 0
+}
+
+// Set = Reset + Add.
+func (h *Holt) Set(data float64) {
+	h.Reset()
+	h.Add(data)
+}
+
+// Clone returns a copy of Holt.
+func (h *Holt) Clone() *Holt {
+	ret := *h
+	return &ret
+}
@@ -109,6 +109,9 @@ func TestMovingAvg(t *testing.T) {
 	}, {
 		ma:       NewMaxFilter(5),
 		expected: []float64{1.000000, 1.000000, 1.000000, 1.000000, 5.000000, 5.000000, 5.000000, 5.000000},
+	}, {
+		ma:       NewHolt(0.5, 0.5),
+		expected: []float64{1.000000, 1.000000, 1.000000, 1.000000, 3.000000, 2.500000, 1.875000, 1.343750},
 	},
 	}
 	for _, testCase := range testCases {
@@ -119,3 +122,27 @@ func TestMovingAvg(t *testing.T) {
 		checkInstantaneous(re, testCase.ma)
 	}
 }
+
+func TestHolt(t *testing.T) {
+	re := require.New(t)
+	h := NewHolt(0.5, 0.3)
+	re.Zero(h.Confidence())
+	// The linear trend is forecast exactly.
+	for i := 1; i <= 10; i++ {
+		h.Add(float64(i * 10))
+	}
+	re.InDelta(100, h.Get(), 1e-7)
+	re.InDelta(110, h.Forecast(1), 1e-7)
+	re.InDelta(130, h.Forecast(3), 1e-7)
+	re.InDelta(1, h.Confidence(), 1e-7)
+
+	// The confidence is low if the data set is not forecastable.
+	h.Reset()
+	for i := range 10 {
+		h.Add(float64(10 + 90*(i%2)))
+	}
+	re.Less(h.Confidence(), 0.5)
+	c := h.Clone()
+	c.Add(1000)
+	re.NotEqual(c.Get(), h.Get())
+}
@@ -363,6 +363,11 @@ func (c *Controller) tryAddOperators(region *core.RegionInfo) {
 	}
 }
 
+// SetSplitGuard sets the guard to deny the split of some regions.
+func (c *Controller) SetSplitGuard(guard SplitGuard) {
+	c.splitChecker.SetSplitGuard(guard)
+}
+
 // GetMergeChecker returns the merge checker.
 func (c *Controller) GetMergeChecker() *MergeChecker {
 	return c.mergeChecker
@@ -32,6 +32,15 @@ var (
 			Help:      "Number of region about different type.",
 		}, []string{"type"})
 
+	// simulationCheckerCounter is not registered, see simulationRuleCheckerCounters.
+	simulationCheckerCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "checker",
+			Name:      "simulation_event_count",
+			Help:      "Counter of checker events in the simulations.",
+		}, []string{"event"})
+
 	patrolCheckRegionsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "pd",
@@ -74,38 +83,84 @@ func replicaCheckerCounterWithEvent(event string) prometheus.Counter {
 	return checkerCounter.WithLabelValues(replicaChecker, event)
 }
 
+// ruleCheckerCounters is the counters of the events of a rule checker.
+type ruleCheckerCounters struct {
+	check                       prometheus.Counter
+	paused                      prometheus.Counter
+	regionNoLeader              prometheus.Counter
+	needSplit                   prometheus.Counter
+	setCache                    prometheus.Counter
+	replaceDown                 prometheus.Counter
+	promoteWitness              prometheus.Counter
+	replaceOffline              prometheus.Counter
+	addRulePeer                 prometheus.Counter
+	noStoreAdd                  prometheus.Counter
+	noStoreThenTryReplace       prometheus.Counter
+	noStoreReplace              prometheus.Counter
+	fixPeerRole                 prometheus.Counter
+	fixLeaderRole               prometheus.Counter
+	notAllowLeader              prometheus.Counter
+	fixFollowerRole             prometheus.Counter
+	noNewLeader                 prometheus.Counter
+	demoteVoterRole             prometheus.Counter
+	recentlyPromoteToNonWitness prometheus.Counter
+	cancelSwitchToWitness       prometheus.Counter
+	setVoterWitness             prometheus.Counter
+	setLearnerWitness           prometheus.Counter
+	setVoterNonWitness          prometheus.Counter
+	setLearnerNonWitness        prometheus.Counter
+	moveToBetterLocation        prometheus.Counter
+	skipRemoveOrphanPeer        prometheus.Counter
+	removeOrphanPeer            prometheus.Counter
+	replaceOrphanPeer           prometheus.Counter
+	replaceOrphanPeerNoFit      prometheus.Counter
+}
+
+// newRuleCheckerCounters creates the counters of the rule checker events by the given function.
+func newRuleCheckerCounters(counterWithEvent func(event string) prometheus.Counter) *ruleCheckerCounters {
+	return &ruleCheckerCounters{
+		check:                       counterWithEvent("check"),
+		paused:                      counterWithEvent("paused"),
+		regionNoLeader:              counterWithEvent("region-no-leader"),
+		needSplit:                   counterWithEvent("need-split"),
+		setCache:                    counterWithEvent("set-cache"),
+		replaceDown:                 counterWithEvent("replace-down"),
+		promoteWitness:              counterWithEvent("promote-witness"),
+		replaceOffline:              counterWithEvent("replace-offline"),
+		addRulePeer:                 counterWithEvent("add-rule-peer"),
+		noStoreAdd:                  counterWithEvent("no-store-add"),
+		noStoreThenTryReplace:       counterWithEvent("no-store-then-try-replace"),
+		noStoreReplace:              counterWithEvent("no-store-replace"),
+		fixPeerRole:                 counterWithEvent("fix-peer-role"),
+		fixLeaderRole:               counterWithEvent("fix-leader-role"),
+		notAllowLeader:              counterWithEvent("not-allow-leader"),
+		fixFollowerRole:             counterWithEvent("fix-follower-role"),
+		noNewLeader:                 counterWithEvent("no-new-leader"),
+		demoteVoterRole:             counterWithEvent("demote-voter-role"),
+		recentlyPromoteToNonWitness: counterWithEvent("recently-promote-to-non-witness"),
+		cancelSwitchToWitness:       counterWithEvent("cancel-switch-to-witness"),
+		setVoterWitness:             counterWithEvent("set-voter-witness"),
+		setLearnerWitness:           counterWithEvent("set-learner-witness"),
+		setVoterNonWitness:          counterWithEvent("set-voter-non-witness"),
+		setLearnerNonWitness:        counterWithEvent("set-learner-non-witness"),
+		moveToBetterLocation:        counterWithEvent("move-to-better-location"),
+		skipRemoveOrphanPeer:        counterWithEvent("skip-remove-orphan-peer"),
+		removeOrphanPeer:            counterWithEvent("remove-orphan-peer"),
+		replaceOrphanPeer:           counterWithEvent("replace-orphan-peer"),
+		replaceOrphanPeerNoFit:      counterWithEvent("replace-orphan-peer-no-fit"),
+	}
+}
+
 // WithLabelValues is a heavy operation, define variable to avoid call it every time.
 var (
-	ruleCheckerCounter                            = ruleCheckerCounterWithEvent("check")
-	ruleCheckerPausedCounter                      = ruleCheckerCounterWithEvent("paused")
-	ruleCheckerRegionNoLeaderCounter              = ruleCheckerCounterWithEvent("region-no-leader")
-	ruleCheckerGetCacheCounter                    = ruleCheckerCounterWithEvent("get-cache")
-	ruleCheckerNeedSplitCounter                   = ruleCheckerCounterWithEvent("need-split")
-	ruleCheckerSetCacheCounter                    = ruleCheckerCounterWithEvent("set-cache")
-	ruleCheckerReplaceDownCounter                 = ruleCheckerCounterWithEvent("replace-down")
-	ruleCheckerPromoteWitnessCounter              = ruleCheckerCounterWithEvent("promote-witness")
-	ruleCheckerReplaceOfflineCounter              = ruleCheckerCounterWithEvent("replace-offline")
-	ruleCheckerAddRulePeerCounter                 = ruleCheckerCounterWithEvent("add-rule-peer")
-	ruleCheckerNoStoreAddCounter                  = ruleCheckerCounterWithEvent("no-store-add")
-	ruleCheckerNoStoreThenTryReplace              = ruleCheckerCounterWithEvent("no-store-then-try-replace")
-	ruleCheckerNoStoreReplaceCounter              = ruleCheckerCounterWithEvent("no-store-replace")
-	ruleCheckerFixPeerRoleCounter                 = ruleCheckerCounterWithEvent("fix-peer-role")
-	ruleCheckerFixLeaderRoleCounter               = ruleCheckerCounterWithEvent("fix-leader-role")
-	ruleCheckerNotAllowLeaderCounter              = ruleCheckerCounterWithEvent("not-allow-leader")
-	ruleCheckerFixFollowerRoleCounter             = ruleCheckerCounterWithEvent("fix-follower-role")
-	ruleCheckerNoNewLeaderCounter                 = ruleCheckerCounterWithEvent("no-new-leader")
-	ruleCheckerDemoteVoterRoleCounter             = ruleCheckerCounterWithEvent("demote-voter-role")
-	ruleCheckerRecentlyPromoteToNonWitnessCounter = ruleCheckerCounterWithEvent("recently-promote-to-non-witness")
-	ruleCheckerCancelSwitchToWitnessCounter       = ruleCheckerCounterWithEvent("cancel-switch-to-witness")
-	ruleCheckerSetVoterWitnessCounter             = ruleCheckerCounterWithEvent("set-voter-witness")
-	ruleCheckerSetLearnerWitnessCounter           = ruleCheckerCounterWithEvent("set-learner-witness")
-	ruleCheckerSetVoterNonWitnessCounter          = ruleCheckerCounterWithEvent("set-voter-non-witness")
-	ruleCheckerSetLearnerNonWitnessCounter        = ruleCheckerCounterWithEvent("set-learner-non-witness")
-	ruleCheckerMoveToBetterLocationCounter        = ruleCheckerCounterWithEvent("move-to-better-location")
-	ruleCheckerSkipRemoveOrphanPeerCounter        = ruleCheckerCounterWithEvent("skip-remove-orphan-peer")
-	ruleCheckerRemoveOrphanPeerCounter            = ruleCheckerCounterWithEvent("remove-orphan-peer")
-	ruleCheckerReplaceOrphanPeerCounter           = ruleCheckerCounterWithEvent("replace-orphan-peer")
-	ruleCheckerReplaceOrphanPeerNoFitCounter      = ruleCheckerCounterWithEvent("replace-orphan-peer-no-fit")
+	// defaultRuleCheckerCounters is reported by the rule checkers of the cluster.
+	defaultRuleCheckerCounters = newRuleCheckerCounters(ruleCheckerCounterWithEvent)
+	// simulationRuleCheckerCounters is not registered, so that the rule checkers which
+	// simulate the placement rules don't affect the metrics of the cluster.
+	simulationRuleCheckerCounters = newRuleCheckerCounters(func(event string) prometheus.Counter {
+		return simulationCheckerCounter.WithLabelValues(event)
+	})
+	ruleCheckerGetCacheCounter = ruleCheckerCounterWithEvent("get-cache")
 
 	jointCheckCounter                 = jointStateCheckerCounterWithEvent("check")
 	jointCheckerPausedCounter         = jointStateCheckerCounterWithEvent("paused")
@@ -155,4 +210,5 @@ var (
 
 	splitCheckerCounter       = checkerCounter.WithLabelValues(splitChecker, "check")
 	splitCheckerPausedCounter = checkerCounter.WithLabelValues(splitChecker, "paused")
+	splitCheckerDeniedCounter = checkerCounter.WithLabelValues(splitChecker, "denied")
 )
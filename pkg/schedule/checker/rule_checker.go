@@ -51,6 +51,7 @@ type RuleChecker struct {
 	switchWitnessCache      *cache.TTLUint64
 	record                  *recorder
 	r                       *rand.Rand
+	counters                *ruleCheckerCounters
 }
 
 // NewRuleChecker creates a checker instance.
@@ -63,9 +64,18 @@ func NewRuleChecker(ctx context.Context, cluster sche.CheckerCluster, ruleManage
 		switchWitnessCache:      cache.NewIDTTL(ctx, time.Minute, cluster.GetCheckerConfig().GetSwitchWitnessInterval()),
 		record:                  newRecord(),
 		r:                       rand.New(rand.NewSource(time.Now().UnixNano())),
+		counters:                defaultRuleCheckerCounters,
 	}
 }
 
+// NewSimulationRuleChecker creates a checker instance to simulate the placement rules, which
+// neither reports the metrics nor shares the pending regions with the checkers of the cluster.
+func NewSimulationRuleChecker(ctx context.Context, cluster sche.CheckerCluster, ruleManager *placement.RuleManager) *RuleChecker {
+	c := NewRuleChecker(ctx, cluster, ruleManager, cache.NewIDTTL(ctx, time.Minute, 3*time.Minute))
+	c.counters = simulationRuleCheckerCounters
+	return c
+}
+
 // Name returns RuleChecker's name.
 func (*RuleChecker) Name() string {
 	return types.RuleChecker.String()
@@ -87,12 +97,12 @@ func (c *RuleChecker) Check(region *core.RegionInfo) *operator.Operator {
 func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.RegionFit) (op *operator.Operator) {
 	// checker is paused
 	if c.IsPaused() {
-		ruleCheckerPausedCounter.Inc()
+		c.counters.paused.Inc()
 		return nil
 	}
 	// skip no leader region
 	if region.GetLeader() == nil {
-		ruleCheckerRegionNoLeaderCounter.Inc()
+		c.counters.regionNoLeader.Inc()
 		log.Debug("fail to check region", zap.Uint64("region-id", region.GetID()), errs.ZapError(errs.ErrRegionNoLeader))
 		return
 	}
@@ -106,11 +116,11 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 	// invalid the cache if it exists
 	c.ruleManager.InvalidCache(region.GetID())
 
-	ruleCheckerCounter.Inc()
+	c.counters.check.Inc()
 	c.record.refresh(c.cluster)
 
 	if len(fit.RuleFits) == 0 {
-		ruleCheckerNeedSplitCounter.Inc()
+		c.counters.needSplit.Inc()
 		// If the region matches no rules, the most possible reason is it spans across
 		// multiple rules.
 		return nil
@@ -137,7 +147,7 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 		if placement.ValidateFit(fit) && placement.ValidateRegion(region) && placement.ValidateStores(fit.GetRegionStores()) {
 			// If there is no need to fix, we will cache the fit
 			c.ruleManager.SetRegionFitCache(region, fit)
-			ruleCheckerSetCacheCounter.Inc()
+			c.counters.setCache.Inc()
 		}
 	}
 	return nil
@@ -163,19 +173,19 @@ func (c *RuleChecker) fixRulePeer(region *core.RegionInfo, fit *placement.Region
 	for _, peer := range rf.Peers {
 		if c.isDownPeer(region, peer) {
 			if c.isStoreDownTimeHitMaxDownTime(peer.GetStoreId()) {
-				ruleCheckerReplaceDownCounter.Inc()
+				c.counters.replaceDown.Inc()
 				return c.replaceUnexpectedRulePeer(region, rf, fit, peer, downStatus)
 			}
 			// When witness placement rule is enabled, promotes the witness to voter when region has down voter.
 			if c.isWitnessEnabled() && core.IsVoter(peer) {
 				if witness, ok := c.hasAvailableWitness(region, peer); ok {
-					ruleCheckerPromoteWitnessCounter.Inc()
+					c.counters.promoteWitness.Inc()
 					return operator.CreateNonWitnessPeerOperator("promote-witness-for-down", c.cluster, region, witness)
 				}
 			}
 		}
 		if c.isOfflinePeer(peer) {
-			ruleCheckerReplaceOfflineCounter.Inc()
+			c.counters.replaceOffline.Inc()
 			return c.replaceUnexpectedRulePeer(region, rf, fit, peer, offlineStatus)
 		}
 	}
@@ -193,13 +203,13 @@ func (c *RuleChecker) fixRulePeer(region *core.RegionInfo, fit *placement.Region
 }
 
 func (c *RuleChecker) addRulePeer(region *core.RegionInfo, fit *placement.RegionFit, rf *placement.RuleFit) (*operator.Operator, error) {
-	ruleCheckerAddRulePeerCounter.Inc()
+	c.counters.addRulePeer.Inc()
 	ruleStores := c.getRuleFitStores(rf)
 	isWitness := rf.Rule.IsWitness && c.isWitnessEnabled()
 	// If the peer to be added is a witness, since no snapshot is needed, we also reuse the fast failover logic.
 	store, filterByTempState := c.strategy(c.r, region, rf.Rule, isWitness).SelectStoreToAdd(ruleStores)
 	if store == 0 {
-		ruleCheckerNoStoreAddCounter.Inc()
+		c.counters.noStoreAdd.Inc()
 		c.handleFilterState(region, filterByTempState)
 		// try to replace an existing peer that matches the label constraints.
 		// issue: https://github.com/tikv/pd/issues/7185
@@ -210,7 +220,7 @@ func (c *RuleChecker) addRulePeer(region *core.RegionInfo, fit *placement.Region
 				if oldPeerRuleFit == nil || !oldPeerRuleFit.IsSatisfied() || oldPeerRuleFit == rf {
 					continue
 				}
-				ruleCheckerNoStoreThenTryReplace.Inc()
+				c.counters.noStoreThenTryReplace.Inc()
 				op, err := c.replaceUnexpectedRulePeer(region, oldPeerRuleFit, fit, p, "swap-fit")
 				if err != nil {
 					return nil, err
@@ -250,7 +260,7 @@ func (c *RuleChecker) replaceUnexpectedRulePeer(region *core.RegionInfo, rf *pla
 	ruleStores := c.getRuleFitStores(rf)
 	store, filterByTempState := c.strategy(c.r, region, rf.Rule, fastFailover).SelectStoreToFix(ruleStores, peer.GetStoreId())
 	if store == 0 {
-		ruleCheckerNoStoreReplaceCounter.Inc()
+		c.counters.noStoreReplace.Inc()
 		c.handleFilterState(region, filterByTempState)
 		return nil, errs.ErrNoStoreToReplace
 	}
@@ -306,29 +316,29 @@ func (c *RuleChecker) replaceUnexpectedRulePeer(region *core.RegionInfo, rf *pla
 
 func (c *RuleChecker) fixLooseMatchPeer(region *core.RegionInfo, fit *placement.RegionFit, rf *placement.RuleFit, peer *metapb.Peer) (*operator.Operator, error) {
 	if core.IsLearner(peer) && rf.Rule.Role != placement.Learner {
-		ruleCheckerFixPeerRoleCounter.Inc()
+		c.counters.fixPeerRole.Inc()
 		return operator.CreatePromoteLearnerOperator("fix-peer-role", c.cluster, region, peer)
 	}
 	if region.GetLeader().GetId() != peer.GetId() && rf.Rule.Role == placement.Leader {
-		ruleCheckerFixLeaderRoleCounter.Inc()
+		c.counters.fixLeaderRole.Inc()
 		if c.allowLeader(fit, peer) {
 			return operator.CreateTransferLeaderOperator("fix-leader-role", c.cluster, region, peer.GetStoreId(), []uint64{}, 0)
 		}
-		ruleCheckerNotAllowLeaderCounter.Inc()
+		c.counters.notAllowLeader.Inc()
 		return nil, errs.ErrPeerCannotBeLeader
 	}
 	if region.GetLeader().GetId() == peer.GetId() && rf.Rule.Role == placement.Follower {
-		ruleCheckerFixFollowerRoleCounter.Inc()
+		c.counters.fixFollowerRole.Inc()
 		for _, p := range region.GetPeers() {
 			if c.allowLeader(fit, p) {
 				return operator.CreateTransferLeaderOperator("fix-follower-role", c.cluster, region, p.GetStoreId(), []uint64{}, 0)
 			}
 		}
-		ruleCheckerNoNewLeaderCounter.Inc()
+		c.counters.noNewLeader.Inc()
 		return nil, errs.ErrNoNewLeader
 	}
 	if core.IsVoter(peer) && rf.Rule.Role == placement.Learner {
-		ruleCheckerDemoteVoterRoleCounter.Inc()
+		c.counters.demoteVoterRole.Inc()
 		return operator.CreateDemoteVoterOperator("fix-demote-voter", c.cluster, region, peer)
 	}
 	if region.GetLeader().GetId() == peer.GetId() && rf.Rule.IsWitness {
@@ -337,24 +347,24 @@ func (c *RuleChecker) fixLooseMatchPeer(region *core.RegionInfo, fit *placement.
 	if !core.IsWitness(peer) && rf.Rule.IsWitness && c.isWitnessEnabled() {
 		c.switchWitnessCache.UpdateTTL(c.cluster.GetCheckerConfig().GetSwitchWitnessInterval())
 		if c.switchWitnessCache.Exists(region.GetID()) {
-			ruleCheckerRecentlyPromoteToNonWitnessCounter.Inc()
+			c.counters.recentlyPromoteToNonWitness.Inc()
 			return nil, nil
 		}
 		if len(region.GetPendingPeers()) > 0 {
-			ruleCheckerCancelSwitchToWitnessCounter.Inc()
+			c.counters.cancelSwitchToWitness.Inc()
 			return nil, nil
 		}
 		if core.IsLearner(peer) {
-			ruleCheckerSetLearnerWitnessCounter.Inc()
+			c.counters.setLearnerWitness.Inc()
 		} else {
-			ruleCheckerSetVoterWitnessCounter.Inc()
+			c.counters.setVoterWitness.Inc()
 		}
 		return operator.CreateWitnessPeerOperator("fix-witness-peer", c.cluster, region, peer)
 	} else if core.IsWitness(peer) && (!rf.Rule.IsWitness || !c.isWitnessEnabled()) {
 		if core.IsLearner(peer) {
-			ruleCheckerSetLearnerNonWitnessCounter.Inc()
+			c.counters.setLearnerNonWitness.Inc()
 		} else {
-			ruleCheckerSetVoterNonWitnessCounter.Inc()
+			c.counters.setVoterNonWitness.Inc()
 		}
 		return operator.CreateNonWitnessPeerOperator("fix-non-witness-peer", c.cluster, region, peer)
 	}
@@ -409,7 +419,7 @@ func (c *RuleChecker) fixBetterLocation(region *core.RegionInfo, rf *placement.R
 		c.handleFilterState(region, filterByTempState)
 		return nil, nil
 	}
-	ruleCheckerMoveToBetterLocationCounter.Inc()
+	c.counters.moveToBetterLocation.Inc()
 	newPeer := &metapb.Peer{StoreId: newStore, Role: rf.Rule.Role.MetaPeerRole(), IsWitness: isWitness}
 	return operator.CreateMovePeerOperator("move-to-better-location", c.cluster, region, operator.OpReplica, oldStore, newPeer)
 }
@@ -480,7 +490,7 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 
 	// If hasUnhealthyFit is false, it is safe to delete the OrphanPeer.
 	if !hasUnhealthyFit {
-		ruleCheckerRemoveOrphanPeerCounter.Inc()
+		c.counters.removeOrphanPeer.Inc()
 		return operator.CreateRemovePeerOperator("remove-orphan-peer", c.cluster, 0, region, fit.OrphanPeers[0].StoreId)
 	}
 
@@ -507,7 +517,7 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 			if fit.Replace(pinDownPeer.GetStoreId(), dstStore) {
 				destRole := pinDownPeer.GetRole()
 				orphanPeerRole := orphanPeer.GetRole()
-				ruleCheckerReplaceOrphanPeerCounter.Inc()
+				c.counters.replaceOrphanPeer.Inc()
 				switch {
 				case orphanPeerRole == metapb.PeerRole_Learner && destRole == metapb.PeerRole_Voter:
 					return operator.CreatePromoteLearnerOperatorAndRemovePeer("replace-down-peer-with-orphan-peer", c.cluster, region, orphanPeer, pinDownPeer)
@@ -520,7 +530,7 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 					// destRole never be leader, so we not consider it.
 				}
 			} else {
-				ruleCheckerReplaceOrphanPeerNoFitCounter.Inc()
+				c.counters.replaceOrphanPeerNoFit.Inc()
 			}
 		}
 	}
@@ -539,13 +549,13 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 		}
 		for _, orphanPeer := range fit.OrphanPeers {
 			if isUnhealthyPeer(orphanPeer.GetId()) {
-				ruleCheckerRemoveOrphanPeerCounter.Inc()
+				c.counters.removeOrphanPeer.Inc()
 				return operator.CreateRemovePeerOperator("remove-unhealthy-orphan-peer", c.cluster, 0, region, orphanPeer.StoreId)
 			}
 			// The healthy orphan peer can be removed to keep the high availability only if the peer count is greater than the rule requirement.
 			if hasHealthPeer && extra > 0 {
 				// there already exists a healthy orphan peer, so we can remove other orphan Peers.
-				ruleCheckerRemoveOrphanPeerCounter.Inc()
+				c.counters.removeOrphanPeer.Inc()
 				// if there exists a disconnected orphan peer, we will pick it to remove firstly.
 				if disconnectedPeer != nil {
 					return operator.CreateRemovePeerOperator("remove-orphan-peer", c.cluster, 0, region, disconnectedPeer.StoreId)
@@ -555,7 +565,7 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 			hasHealthPeer = true
 		}
 	}
-	ruleCheckerSkipRemoveOrphanPeerCounter.Inc()
+	c.counters.skipRemoveOrphanPeer.Inc()
 	return nil, nil
 }
 
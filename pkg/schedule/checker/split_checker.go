@@ -24,14 +24,26 @@ import (
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
+// SplitGuard decides whether the split checker is allowed to split a region.
+type SplitGuard interface {
+	// AllowSplit returns false if the region should not be split.
+	AllowSplit(region *core.RegionInfo) bool
+}
+
 // SplitChecker splits regions when the key range spans across rule/label boundary.
 type SplitChecker struct {
 	PauseController
 	cluster     sche.CheckerCluster
 	ruleManager *placement.RuleManager
 	labeler     *labeler.RegionLabeler
+	// guard is used to deny the split of some regions, it is nil if not set.
+	guard struct {
+		syncutil.RWMutex
+		SplitGuard
+	}
 }
 
 // NewSplitChecker creates a new SplitChecker.
@@ -43,6 +55,13 @@ func NewSplitChecker(cluster sche.CheckerCluster, ruleManager *placement.RuleMan
 	}
 }
 
+// SetSplitGuard sets the guard to deny the split of some regions.
+func (c *SplitChecker) SetSplitGuard(guard SplitGuard) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.guard.SplitGuard = guard
+}
+
 // GetType returns the checker type.
 func (*SplitChecker) GetType() string {
 	return "split-checker"
@@ -72,6 +91,10 @@ func (c *SplitChecker) Check(region *core.RegionInfo) *operator.Operator {
 	if len(keys) == 0 {
 		return nil
 	}
+	if !c.allowSplit(region) {
+		splitCheckerDeniedCounter.Inc()
+		return nil
+	}
 
 	op, err := operator.CreateSplitRegionOperator(desc, region, 0, pdpb.CheckPolicy_USEKEY, keys)
 	if err != nil {
@@ -80,3 +103,9 @@ func (c *SplitChecker) Check(region *core.RegionInfo) *operator.Operator {
 	}
 	return op
 }
+
+func (c *SplitChecker) allowSplit(region *core.RegionInfo) bool {
+	c.guard.RLock()
+	defer c.guard.RUnlock()
+	return c.guard.SplitGuard == nil || c.guard.AllowSplit(region)
+}
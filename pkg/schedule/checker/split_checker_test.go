@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
 	"github.com/tikv/pd/pkg/schedule/labeler"
@@ -69,3 +70,40 @@ func TestSplit(t *testing.T) {
 	re.Equal("bb", hex.EncodeToString(splitKeys[0]))
 	re.Equal("dd", hex.EncodeToString(splitKeys[1]))
 }
+
+type mockSplitGuard struct {
+	denied map[uint64]struct{}
+}
+
+func (g *mockSplitGuard) AllowSplit(region *core.RegionInfo) bool {
+	_, ok := g.denied[region.GetID()]
+	return !ok
+}
+
+func TestSplitGuard(t *testing.T) {
+	re := require.New(t)
+	cfg := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster := mockcluster.NewCluster(ctx, cfg)
+	regionLabeler := cluster.RegionLabeler
+	sc := NewSplitChecker(cluster, cluster.RuleManager, regionLabeler)
+	cluster.AddLeaderStore(1, 1)
+	cluster.AddLeaderRegionWithRange(1, "", "", 1)
+	regionLabeler.SetLabelRule(&labeler.LabelRule{
+		ID:       "test",
+		Labels:   []labeler.RegionLabel{{Key: "test", Value: "test"}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges("bb", "dd"),
+	})
+	re.NotNil(sc.Check(cluster.GetRegion(1)))
+
+	guard := &mockSplitGuard{denied: map[uint64]struct{}{1: {}}}
+	sc.SetSplitGuard(guard)
+	re.Nil(sc.Check(cluster.GetRegion(1)))
+	delete(guard.denied, 1)
+	re.NotNil(sc.Check(cluster.GetRegion(1)))
+	guard.denied[1] = struct{}{}
+	sc.SetSplitGuard(nil)
+	re.NotNil(sc.Check(cluster.GetRegion(1)))
+}
@@ -50,6 +50,7 @@ const (
 	defaultHotRegionCacheHitsThreshold = 3
 	defaultSchedulerMaxWaitingOperator = 5
 	defaultHotRegionsReservedDays      = 7
+	defaultOperatorHistoryReservedDays = 7
 	// When a slow store affected more than 30% of total stores, it will trigger evicting.
 	defaultSlowStoreEvictingAffectedStoreRatioThreshold = 0.3
 	defaultMaxMovableHotPeerSize                        = int64(512)
@@ -288,6 +289,9 @@ type ScheduleConfig struct {
 	// The day of hot regions data to be reserved. 0 means close.
 	HotRegionsReservedDays uint64 `toml:"hot-regions-reserved-days" json:"hot-regions-reserved-days"`
 
+	// The day of finished operators to be reserved. 0 means close.
+	OperatorHistoryReservedDays uint64 `toml:"operator-history-reserved-days" json:"operator-history-reserved-days"`
+
 	// MaxMovableHotPeerSize is the threshold of region size for balance hot region and split bucket scheduler.
 	// Hot region must be split before moved if it's region size is greater than MaxMovableHotPeerSize.
 	MaxMovableHotPeerSize int64 `toml:"max-movable-hot-peer-size" json:"max-movable-hot-peer-size,omitempty"`
@@ -445,6 +449,10 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 		configutil.AdjustUint64(&c.HotRegionsReservedDays, defaultHotRegionsReservedDays)
 	}
 
+	if !meta.IsDefined("operator-history-reserved-days") {
+		configutil.AdjustUint64(&c.OperatorHistoryReservedDays, defaultOperatorHistoryReservedDays)
+	}
+
 	if !meta.IsDefined("max-movable-hot-peer-size") {
 		configutil.AdjustInt64(&c.MaxMovableHotPeerSize, defaultMaxMovableHotPeerSize)
 	}
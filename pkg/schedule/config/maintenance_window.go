@@ -0,0 +1,442 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+// maxMaintenanceWindowDuration is the upper bound of the duration of a maintenance window.
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// MaintenanceWindowConfig is the persisted config of the maintenance windows.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type MaintenanceWindowConfig struct {
+	Windows []*MaintenanceWindow `json:"windows"`
+	// Active is the window which is taking effect now, it is nil if there is no active window.
+	Active *ActiveMaintenanceWindow `json:"active,omitempty"`
+}
+
+// Clone returns a deep copy of the maintenance window config.
+func (c *MaintenanceWindowConfig) Clone() *MaintenanceWindowConfig {
+	data, _ := json.Marshal(c)
+	cfg := &MaintenanceWindowConfig{}
+	_ = json.Unmarshal(data, cfg)
+	return cfg
+}
+
+// GetWindow returns the window with the given name, nil is returned if it does not exist.
+func (c *MaintenanceWindowConfig) GetWindow(name string) *MaintenanceWindow {
+	for _, w := range c.Windows {
+		if w.Name == name {
+			return w
+		}
+	}
+	return nil
+}
+
+// MaintenanceWindow is a recurring time window during which the schedule config is
+// overlaid and some schedulers are enabled or disabled.
+type MaintenanceWindow struct {
+	Name string `json:"name"`
+	// Cron decides when the window starts. It is a standard cron expression with
+	// 5 fields: minute, hour, day of month, month and day of week.
+	Cron     string            `json:"cron"`
+	Duration typeutil.Duration `json:"duration"`
+	// TimeZone is the IANA time zone name used to evaluate Cron, the local
+	// time zone of PD is used if it is empty.
+	TimeZone string `json:"time-zone,omitempty"`
+	// ScheduleOverlay is a partial ScheduleConfig whose keys are the JSON names of
+	// the schedule config items. Nested objects like store-limit are merged.
+	ScheduleOverlay map[string]any `json:"schedule-overlay,omitempty"`
+	// EnableSchedulers are resumed when the window starts and paused until
+	// the next start of the window when it ends.
+	EnableSchedulers []string `json:"enable-schedulers,omitempty"`
+	// DisableSchedulers are paused during the window.
+	DisableSchedulers []string `json:"disable-schedulers,omitempty"`
+}
+
+// ActiveMaintenanceWindow records what an active maintenance window has changed,
+// so that the changes can be reverted even if the PD leader changes.
+type ActiveMaintenanceWindow struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start-time"`
+	EndTime   time.Time `json:"end-time"`
+	// Applied and Original are the values of the overlaid schedule config
+	// items during and before the window.
+	Applied           map[string]json.RawMessage `json:"applied,omitempty"`
+	Original          map[string]json.RawMessage `json:"original,omitempty"`
+	PausedSchedulers  []string                   `json:"paused-schedulers,omitempty"`
+	ResumedSchedulers []string                   `json:"resumed-schedulers,omitempty"`
+}
+
+// Validate checks whether the maintenance window is valid.
+func (w *MaintenanceWindow) Validate() error {
+	if len(w.Name) == 0 {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs("name should not be empty")
+	}
+	if _, err := parseCron(w.Cron); err != nil {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs(err.Error())
+	}
+	if w.Duration.Duration < time.Minute || w.Duration.Duration > maxMaintenanceWindowDuration {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs(
+			fmt.Sprintf("duration should be in [%s, %s]", time.Minute, maxMaintenanceWindowDuration))
+	}
+	if _, err := w.location(); err != nil {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs(err.Error())
+	}
+	for key := range w.ScheduleOverlay {
+		if reflectutil.FindFieldByJSONTag(reflect.TypeOf(ScheduleConfig{}), []string{key}) == nil {
+			return errs.ErrMaintenanceWindowConfig.FastGenByArgs(fmt.Sprintf("unknown schedule config %s", key))
+		}
+	}
+	// Only check the types of the values here, the overlaid config is validated
+	// against the current config when the window starts.
+	if _, _, err := overlayScheduleConfig(&ScheduleConfig{}, w.ScheduleOverlay); err != nil {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs(err.Error())
+	}
+	for _, name := range w.EnableSchedulers {
+		if slices.Contains(w.DisableSchedulers, name) {
+			return errs.ErrMaintenanceWindowConfig.FastGenByArgs(
+				fmt.Sprintf("scheduler %s is both enabled and disabled", name))
+		}
+	}
+	return nil
+}
+
+func (w *MaintenanceWindow) location() (*time.Location, error) {
+	if len(w.TimeZone) == 0 {
+		return time.Local, nil
+	}
+	return time.LoadLocation(w.TimeZone)
+}
+
+// ActiveAt returns the start time of the window if it is active at the given time.
+func (w *MaintenanceWindow) ActiveAt(t time.Time) (time.Time, bool) {
+	schedule, err := parseCron(w.Cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+	loc, err := w.location()
+	if err != nil {
+		return time.Time{}, false
+	}
+	t = t.In(loc)
+	// Check the minutes backward to find the latest start which covers t.
+	for candidate := t.Truncate(time.Minute); t.Before(candidate.Add(w.Duration.Duration)); candidate = candidate.Add(-time.Minute) {
+		if schedule.match(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextStart returns the first start time of the window after the given time.
+// The zero time is returned if the window does not start within a year.
+func (w *MaintenanceWindow) NextStart(t time.Time) time.Time {
+	schedule, err := parseCron(w.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	loc, err := w.location()
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.next(t.In(loc))
+}
+
+// ApplyScheduleOverlay merges the overlay into a copy of the schedule config. It
+// also returns the values of the overlaid items after and before the merge.
+func ApplyScheduleOverlay(cfg *ScheduleConfig, overlay map[string]any) (*ScheduleConfig, map[string]json.RawMessage, map[string]json.RawMessage, error) {
+	newCfg, original, err := overlayScheduleConfig(cfg, overlay)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := newCfg.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	// Marshal the new config again to get the values in the canonical format.
+	updated, err := scheduleConfigToMap(newCfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	applied := make(map[string]json.RawMessage, len(overlay))
+	for key := range overlay {
+		if applied[key], err = json.Marshal(updated[key]); err != nil {
+			return nil, nil, nil, errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+		}
+	}
+	return newCfg, applied, original, nil
+}
+
+func overlayScheduleConfig(cfg *ScheduleConfig, overlay map[string]any) (*ScheduleConfig, map[string]json.RawMessage, error) {
+	current, err := scheduleConfigToMap(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	original := make(map[string]json.RawMessage, len(overlay))
+	for key, value := range overlay {
+		if v, ok := current[key]; ok {
+			if original[key], err = json.Marshal(v); err != nil {
+				return nil, nil, errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+			}
+		}
+		current[key] = mergeJSONValue(current[key], value)
+	}
+	newCfg, err := scheduleConfigFromMap(current)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newCfg, original, nil
+}
+
+// RevertScheduleOverlay restores the overlaid items of a copy of the schedule
+// config to the original values. The items which have been changed since the
+// overlay was applied are kept as they are.
+func RevertScheduleOverlay(cfg *ScheduleConfig, applied, original map[string]json.RawMessage) (*ScheduleConfig, error) {
+	current, err := scheduleConfigToMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range applied {
+		var appliedValue any
+		if err := json.Unmarshal(value, &appliedValue); err != nil {
+			return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+		origin, ok := original[key]
+		if !ok {
+			if reflect.DeepEqual(current[key], appliedValue) {
+				delete(current, key)
+			}
+			continue
+		}
+		var originalValue any
+		if err := json.Unmarshal(origin, &originalValue); err != nil {
+			return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+		current[key] = revertJSONValue(current[key], appliedValue, originalValue)
+	}
+	return scheduleConfigFromMap(current)
+}
+
+func scheduleConfigToMap(cfg *ScheduleConfig) (map[string]any, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return m, nil
+}
+
+func scheduleConfigFromMap(m map[string]any) (*ScheduleConfig, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	cfg := &ScheduleConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return cfg, nil
+}
+
+// revertJSONValue restores current to original if it still equals applied. The
+// JSON objects are compared and restored per key recursively, so that the keys
+// changed after the overlay was applied are kept.
+func revertJSONValue(current, applied, original any) any {
+	currentMap, ok1 := current.(map[string]any)
+	appliedMap, ok2 := applied.(map[string]any)
+	originalMap, ok3 := original.(map[string]any)
+	if !ok1 || !ok2 || !ok3 {
+		if reflect.DeepEqual(current, applied) {
+			return original
+		}
+		return current
+	}
+	reverted := make(map[string]any, len(currentMap))
+	for k, v := range currentMap {
+		reverted[k] = v
+	}
+	for k, v := range appliedMap {
+		cur, ok := currentMap[k]
+		if !ok {
+			continue
+		}
+		origin, ok := originalMap[k]
+		if !ok {
+			if reflect.DeepEqual(cur, v) {
+				delete(reverted, k)
+			}
+			continue
+		}
+		reverted[k] = revertJSONValue(cur, v, origin)
+	}
+	return reverted
+}
+
+// mergeJSONValue merges src into dst recursively if both of them are JSON objects,
+// otherwise src is returned.
+func mergeJSONValue(dst, src any) any {
+	dstMap, ok1 := dst.(map[string]any)
+	srcMap, ok2 := src.(map[string]any)
+	if !ok1 || !ok2 {
+		return src
+	}
+	merged := make(map[string]any, len(dstMap)+len(srcMap))
+	for k, v := range dstMap {
+		merged[k] = v
+	}
+	for k, v := range srcMap {
+		merged[k] = mergeJSONValue(merged[k], v)
+	}
+	return merged
+}
+
+// cronSchedule is a parsed cron expression, each field is a bitmap of the matched values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if the day of month or the day of week is `*`.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// parseCron parses a cron expression with 5 fields. Every field supports `*`,
+// values, ranges `a-b`, lists `a,b` and steps `*/n` or `a-b/n`.
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q should have %d fields", expr, len(cronFields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, err
+		}
+	}
+	return &cronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s", part, f.name)
+			}
+		}
+		start, end := f.min, f.max
+		if rangePart != "*" {
+			var err error
+			bounds := strings.SplitN(rangePart, "-", 2)
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q in %s", part, f.name)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q in %s", part, f.name)
+				}
+			} else if step > 1 {
+				end = f.max
+			}
+		}
+		if start < f.min || end > f.max || start > end {
+			return 0, fmt.Errorf("value %q in %s is out of range [%d, %d]", part, f.name, f.min, f.max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	// Follow the cron convention: if both day of month and day of week are
+	// restricted, the day matches if either of them matches.
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *cronSchedule) match(t time.Time) bool {
+	return s.minute&(1<<uint(t.Minute())) != 0 &&
+		s.hour&(1<<uint(t.Hour())) != 0 &&
+		s.month&(1<<uint(t.Month())) != 0 &&
+		s.matchDay(t)
+}
+
+// next returns the first matched minute after t, or the zero time if there is
+// no matched minute within a year.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.AddDate(1, 0, 0)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/utils/configutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func TestParseCron(t *testing.T) {
+	re := require.New(t)
+	tests := []struct {
+		expr   string
+		hasErr bool
+	}{
+		{"* * * * *", false},
+		{"0 22 * * *", false},
+		{"*/15 0-6 * * 1-5", false},
+		{"0 1,13 1 */2 0", false},
+		{"5/10 * * * *", false},
+		{"", true},
+		{"* * * *", true},
+		{"60 * * * *", true},
+		{"* 24 * * *", true},
+		{"* * 0 * *", true},
+		{"* * * 13 *", true},
+		{"* * * * 7", true},
+		{"*/0 * * * *", true},
+		{"5-1 * * * *", true},
+		{"a * * * *", true},
+	}
+	for _, test := range tests {
+		_, err := parseCron(test.expr)
+		re.Equal(test.hasErr, err != nil, test.expr)
+	}
+}
+
+func TestMaintenanceWindowActive(t *testing.T) {
+	re := require.New(t)
+	// Starts at 22:00 from Monday to Friday and lasts for 8 hours.
+	w := &MaintenanceWindow{
+		Name:     "night",
+		Cron:     "0 22 * * 1-5",
+		Duration: typeutil.NewDuration(8 * time.Hour),
+		TimeZone: "UTC",
+	}
+	re.NoError(w.Validate())
+	// 2025-01-06 is Monday.
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	_, ok := w.ActiveAt(monday.Add(21*time.Hour + 59*time.Minute))
+	re.False(ok)
+	start, ok := w.ActiveAt(monday.Add(22 * time.Hour))
+	re.True(ok)
+	re.Equal(monday.Add(22*time.Hour), start.UTC())
+	start, ok = w.ActiveAt(monday.Add(29*time.Hour + 59*time.Minute))
+	re.True(ok)
+	re.Equal(monday.Add(22*time.Hour), start.UTC())
+	_, ok = w.ActiveAt(monday.Add(30 * time.Hour))
+	re.False(ok)
+	// The window starting on Saturday does not exist.
+	_, ok = w.ActiveAt(monday.AddDate(0, 0, 5).Add(23 * time.Hour))
+	re.False(ok)
+
+	re.Equal(monday.Add(22*time.Hour), w.NextStart(monday).UTC())
+	// The next start after Friday's window is the next Monday.
+	re.Equal(monday.AddDate(0, 0, 7).Add(22*time.Hour), w.NextStart(monday.AddDate(0, 0, 4).Add(22*time.Hour)).UTC())
+
+	// The start in the time zone is used.
+	w.TimeZone = "Asia/Shanghai"
+	re.NoError(w.Validate())
+	_, ok = w.ActiveAt(monday.Add(22 * time.Hour))
+	re.False(ok)
+	start, ok = w.ActiveAt(monday.Add(14 * time.Hour))
+	re.True(ok)
+	re.Equal(monday.Add(14*time.Hour), start.UTC())
+
+	w.TimeZone = "Unknown/Zone"
+	re.Error(w.Validate())
+	w.TimeZone = ""
+	w.Duration = typeutil.NewDuration(30 * 24 * time.Hour)
+	re.Error(w.Validate())
+	w.Duration = typeutil.NewDuration(time.Hour)
+	w.ScheduleOverlay = map[string]any{"unknown-config": 1}
+	re.Error(w.Validate())
+	w.ScheduleOverlay = map[string]any{"region-schedule-limit": "abc"}
+	re.Error(w.Validate())
+	w.ScheduleOverlay = nil
+	w.EnableSchedulers = []string{"balance-hot-region-scheduler"}
+	w.DisableSchedulers = []string{"balance-hot-region-scheduler"}
+	re.Error(w.Validate())
+}
+
+func TestScheduleOverlay(t *testing.T) {
+	re := require.New(t)
+	cfg := &ScheduleConfig{}
+	re.NoError(cfg.Adjust(configutil.NewConfigMetadata(nil), false))
+	cfg.StoreLimit[1] = StoreLimitConfig{AddPeer: 15, RemovePeer: 15}
+	cfg.StoreLimit[2] = StoreLimitConfig{AddPeer: 15, RemovePeer: 15}
+
+	overlay := map[string]any{
+		"region-schedule-limit":     4096,
+		"hot-region-schedule-limit": 16,
+		"store-limit": map[string]any{
+			"1": map[string]any{"add-peer": 200},
+		},
+	}
+	newCfg, applied, original, err := ApplyScheduleOverlay(cfg, overlay)
+	re.NoError(err)
+	re.Len(applied, 3)
+	re.Len(original, 3)
+	re.Equal(uint64(4096), newCfg.RegionScheduleLimit)
+	re.Equal(uint64(16), newCfg.HotRegionScheduleLimit)
+	// The nested objects are merged.
+	re.Equal(StoreLimitConfig{AddPeer: 200, RemovePeer: 15}, newCfg.StoreLimit[1])
+	re.Equal(StoreLimitConfig{AddPeer: 15, RemovePeer: 15}, newCfg.StoreLimit[2])
+	re.Equal(cfg.LeaderScheduleLimit, newCfg.LeaderScheduleLimit)
+	// The original config is not changed.
+	re.Equal(uint64(defaultRegionScheduleLimit), cfg.RegionScheduleLimit)
+
+	// The items changed during the window are kept.
+	newCfg.HotRegionScheduleLimit = 8
+	reverted, err := RevertScheduleOverlay(newCfg, applied, original)
+	re.NoError(err)
+	re.Equal(cfg.RegionScheduleLimit, reverted.RegionScheduleLimit)
+	re.Equal(uint64(8), reverted.HotRegionScheduleLimit)
+	re.Equal(cfg.StoreLimit, reverted.StoreLimit)
+
+	// The nested items are compared per key, only the changed ones are kept.
+	newCfg.StoreLimit[1] = StoreLimitConfig{AddPeer: 200, RemovePeer: 30}
+	newCfg.StoreLimit[2] = StoreLimitConfig{AddPeer: 20, RemovePeer: 15}
+	newCfg.StoreLimit[3] = StoreLimitConfig{AddPeer: 10, RemovePeer: 10}
+	reverted, err = RevertScheduleOverlay(newCfg, applied, original)
+	re.NoError(err)
+	re.Equal(StoreLimitConfig{AddPeer: 15, RemovePeer: 30}, reverted.StoreLimit[1])
+	re.Equal(StoreLimitConfig{AddPeer: 20, RemovePeer: 15}, reverted.StoreLimit[2])
+	re.Equal(StoreLimitConfig{AddPeer: 10, RemovePeer: 10}, reverted.StoreLimit[3])
+
+	// The invalid config can not be applied.
+	_, _, _, err = ApplyScheduleOverlay(cfg, map[string]any{"low-space-ratio": 2})
+	re.Error(err)
+}
@@ -150,7 +150,8 @@ func (c *Coordinator) GetPatrolRegionsDuration() time.Duration {
 	return c.checkers.GetPatrolRegionsDuration()
 }
 
-// drivePushOperator is used to push the unfinished operator to the executor.
+// drivePushOperator is used to push the unfinished operator to the executor,
+// and to dispatch the operators of the operator groups.
 func (c *Coordinator) drivePushOperator() {
 	defer logutil.LogPanic()
 
@@ -165,6 +166,7 @@ func (c *Coordinator) drivePushOperator() {
 			return
 		case <-ticker.C:
 			c.opController.PushOperators(c.RecordOpStepWithTTL)
+			c.opController.CheckOperatorGroups()
 		}
 	}
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
 	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/slice"
@@ -84,6 +85,24 @@ func (*regionPendingFilter) Select(region *core.RegionInfo) *plan.Status {
 	return statusOK
 }
 
+type regionLabelFilter struct {
+	labeler *labeler.RegionLabeler
+	key     string
+}
+
+// NewRegionLabelFilter creates a RegionFilter that filters all regions labeled with the given key.
+func NewRegionLabelFilter(labeler *labeler.RegionLabeler, key string) RegionFilter {
+	return &regionLabelFilter{labeler: labeler, key: key}
+}
+
+// Select implements the RegionFilter interface.
+func (f *regionLabelFilter) Select(region *core.RegionInfo) *plan.Status {
+	if f.labeler != nil && f.labeler.GetRegionLabel(region, f.key) != "" {
+		return statusRegionLabelReject
+	}
+	return statusOK
+}
+
 type regionDownFilter struct {
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -27,6 +28,9 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
 )
 
 func TestRegionPendingFilter(t *testing.T) {
@@ -129,3 +133,24 @@ func TestRegionWitnessFilter(t *testing.T) {
 	}}, &metapb.Peer{StoreId: 1, Id: 1})
 	re.Equal(filter.Select(region), statusOK)
 }
+
+func TestRegionLabelFilter(t *testing.T) {
+	re := require.New(t)
+
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	regionLabeler, err := labeler.NewRegionLabeler(context.Background(), store, time.Millisecond*10)
+	re.NoError(err)
+	re.NoError(regionLabeler.SetLabelRule(&labeler.LabelRule{
+		ID:       "rule1",
+		Labels:   []labeler.RegionLabel{{Key: "k1", Value: "v1"}},
+		RuleType: labeler.KeyRange,
+		Data:     labeler.MakeKeyRanges("1234", "5678"),
+	}))
+	filter := NewRegionLabelFilter(regionLabeler, "k1")
+	region := core.NewRegionInfo(&metapb.Region{Id: 1, StartKey: []byte{0x12, 0x34}, EndKey: []byte{0x56, 0x78}}, nil)
+	re.Equal(filter.Select(region), statusRegionLabelReject)
+	region = core.NewRegionInfo(&metapb.Region{Id: 2, StartKey: []byte{0x56, 0x78}, EndKey: []byte{0x9a}}, nil)
+	re.Equal(filter.Select(region), statusOK)
+	re.Equal(NewRegionLabelFilter(regionLabeler, "k2").Select(region), statusOK)
+	re.Equal(NewRegionLabelFilter(nil, "k1").Select(region), statusOK)
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/labeler"
@@ -249,83 +251,12 @@ func (h *Handler) HandleOperatorCreation(input map[string]any) (int, any, error)
 		return http.StatusBadRequest, nil, errors.Errorf("missing operator name")
 	}
 	switch name {
-	case "transfer-leader":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		storeID, ok := input["to_store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing store id to transfer leader to")
-		}
-		if err := h.AddTransferLeaderOperator(uint64(regionID), uint64(storeID)); err != nil {
-			return http.StatusInternalServerError, nil, err
-		}
-	case "transfer-region":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		storeIDs, ok := parseStoreIDsAndPeerRole(input["to_store_ids"], input["peer_roles"])
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store ids to transfer region to")
-		}
-		if len(storeIDs) == 0 {
-			return http.StatusBadRequest, nil, errors.Errorf("missing store ids to transfer region to")
-		}
-		if err := h.AddTransferRegionOperator(uint64(regionID), storeIDs); err != nil {
-			return http.StatusInternalServerError, nil, err
-		}
-	case "transfer-peer":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		fromID, ok := input["from_store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer from")
-		}
-		toID, ok := input["to_store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
-		}
-		if err := h.AddTransferPeerOperator(uint64(regionID), uint64(fromID), uint64(toID)); err != nil {
-			return http.StatusInternalServerError, nil, err
-		}
-	case "add-peer":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		storeID, ok := input["store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
-		}
-		if err := h.AddAddPeerOperator(uint64(regionID), uint64(storeID)); err != nil {
-			return http.StatusInternalServerError, nil, err
-		}
-	case "add-learner":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		storeID, ok := input["store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
-		}
-		if err := h.AddAddLearnerOperator(uint64(regionID), uint64(storeID)); err != nil {
-			return http.StatusInternalServerError, nil, err
-		}
-	case "remove-peer":
-		regionID, ok := input["region_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
-		}
-		storeID, ok := input["store_id"].(float64)
-		if !ok {
-			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
+	case "transfer-leader", "transfer-region", "transfer-peer", "add-peer", "add-learner", "remove-peer":
+		statusCode, op, err := h.CreateOperator(input)
+		if err != nil {
+			return statusCode, nil, err
 		}
-		if err := h.AddRemovePeerOperator(uint64(regionID), uint64(storeID)); err != nil {
+		if err := h.addOperator(op); err != nil {
 			return http.StatusInternalServerError, nil, err
 		}
 	case "merge-region":
@@ -404,52 +335,283 @@ func (h *Handler) HandleOperatorCreation(input map[string]any) (int, any, error)
 	return http.StatusOK, nil, nil
 }
 
+// CreateOperator creates an operator with the input without adding it, only
+// the operators of a single region are supported.
+func (h *Handler) CreateOperator(input map[string]any) (int, *operator.Operator, error) {
+	name, ok := input["name"].(string)
+	if !ok {
+		return http.StatusBadRequest, nil, errors.Errorf("missing operator name")
+	}
+	switch name {
+	case "transfer-leader":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		storeID, ok := input["to_store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing store id to transfer leader to")
+		}
+		op, err := h.createTransferLeaderOperator(uint64(regionID), uint64(storeID))
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	case "transfer-region":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		storeIDs, ok := parseStoreIDsAndPeerRole(input["to_store_ids"], input["peer_roles"])
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store ids to transfer region to")
+		}
+		if len(storeIDs) == 0 {
+			return http.StatusBadRequest, nil, errors.Errorf("missing store ids to transfer region to")
+		}
+		op, err := h.createTransferRegionOperator(uint64(regionID), storeIDs)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	case "transfer-peer":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		fromID, ok := input["from_store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer from")
+		}
+		toID, ok := input["to_store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
+		}
+		op, err := h.createTransferPeerOperator(uint64(regionID), uint64(fromID), uint64(toID))
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	case "add-peer":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
+		}
+		op, err := h.createAddPeerOperator(uint64(regionID), uint64(storeID))
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	case "add-learner":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
+		}
+		op, err := h.createAddLearnerOperator(uint64(regionID), uint64(storeID))
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	case "remove-peer":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("missing region id")
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			return http.StatusBadRequest, nil, errors.Errorf("invalid store id to transfer peer to")
+		}
+		op, err := h.createRemovePeerOperator(uint64(regionID), uint64(storeID))
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		return http.StatusOK, op, nil
+	default:
+		return http.StatusBadRequest, nil, errors.Errorf("unknown operator")
+	}
+}
+
+// OperatorGroupInput is the input to create an operator group.
+type OperatorGroupInput struct {
+	ID          string                    `json:"id"`
+	Concurrency int                       `json:"concurrency"`
+	Operators   []*OperatorGroupItemInput `json:"operators"`
+}
+
+// OperatorGroupItemInput is the input of an operator in the group, the
+// operator is described in the same way as creating a single operator.
+type OperatorGroupItemInput struct {
+	Name      string         `json:"name"`
+	DependsOn []string       `json:"depends-on"`
+	Operator  map[string]any `json:"operator"`
+}
+
+// AddOperatorGroup adds an operator group, the operators are created when
+// their dependencies are finished.
+func (h *Handler) AddOperatorGroup(input *OperatorGroupInput) (int, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	items := make([]*operator.GroupItem, 0, len(input.Operators))
+	for _, in := range input.Operators {
+		if name, _ := in.Operator["name"].(string); !isSingleRegionOperator(name) {
+			return http.StatusBadRequest, errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("operator %s is not supported", name))
+		}
+		opInput := in.Operator
+		items = append(items, &operator.GroupItem{
+			Name:      in.Name,
+			DependsOn: in.DependsOn,
+			Create: func() (*operator.Operator, error) {
+				_, op, err := h.CreateOperator(opInput)
+				return op, err
+			},
+		})
+	}
+	group, err := operator.NewOperatorGroup(input.ID, input.Concurrency, items)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := oc.AddOperatorGroup(group); err != nil {
+		return http.StatusBadRequest, err
+	}
+	return http.StatusOK, nil
+}
+
+func isSingleRegionOperator(name string) bool {
+	switch name {
+	case "transfer-leader", "transfer-region", "transfer-peer", "add-peer", "add-learner", "remove-peer":
+		return true
+	}
+	return false
+}
+
+// GetOperatorGroups returns the status of all operator groups.
+func (h *Handler) GetOperatorGroups() ([]*operator.OperatorGroupStatus, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	groups := oc.GetOperatorGroups()
+	status := make([]*operator.OperatorGroupStatus, 0, len(groups))
+	for _, g := range groups {
+		status = append(status, g.GetStatus())
+	}
+	return status, nil
+}
+
+// GetOperatorGroup returns the status of the operator group.
+func (h *Handler) GetOperatorGroup(id string) (*operator.OperatorGroupStatus, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	g, err := oc.GetOperatorGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	return g.GetStatus(), nil
+}
+
+// PauseOperatorGroup pauses the operator group.
+func (h *Handler) PauseOperatorGroup(id string) error {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return err
+	}
+	return oc.PauseOperatorGroup(id)
+}
+
+// ResumeOperatorGroup resumes the operator group.
+func (h *Handler) ResumeOperatorGroup(id string) error {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return err
+	}
+	return oc.ResumeOperatorGroup(id)
+}
+
+// CancelOperatorGroup cancels all operators of the operator group.
+func (h *Handler) CancelOperatorGroup(id string) error {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return err
+	}
+	return oc.CancelOperatorGroup(id)
+}
+
 // AddTransferLeaderOperator adds an operator to transfer leader to the store.
 func (h *Handler) AddTransferLeaderOperator(regionID uint64, storeID uint64) error {
+	op, err := h.createTransferLeaderOperator(regionID, storeID)
+	if err != nil {
+		return err
+	}
+	return h.addOperator(op)
+}
+
+// createTransferLeaderOperator creates an operator to transfer leader to the store.
+func (h *Handler) createTransferLeaderOperator(regionID uint64, storeID uint64) (*operator.Operator, error) {
 	c := h.GetCluster()
 	if c == nil {
-		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
 	}
 	region := c.GetRegion(regionID)
 	if region == nil {
-		return errs.ErrRegionNotFound.FastGenByArgs(regionID)
+		return nil, errs.ErrRegionNotFound.FastGenByArgs(regionID)
 	}
 
 	newLeader := region.GetStoreVoter(storeID)
 	if newLeader == nil {
-		return errors.Errorf("region has no voter in store %v", storeID)
+		return nil, errors.Errorf("region has no voter in store %v", storeID)
 	}
 
 	op, err := operator.CreateTransferLeaderOperator("admin-transfer-leader", c, region, newLeader.GetStoreId(), []uint64{}, operator.OpAdmin)
 	if err != nil {
 		log.Debug("fail to create transfer leader operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // AddTransferRegionOperator adds an operator to transfer region to the stores.
 func (h *Handler) AddTransferRegionOperator(regionID uint64, storeIDs map[uint64]placement.PeerRoleType) error {
+	op, err := h.createTransferRegionOperator(regionID, storeIDs)
+	if err != nil {
+		return err
+	}
+	return h.addOperator(op)
+}
+
+// createTransferRegionOperator creates an operator to transfer region to the stores.
+func (h *Handler) createTransferRegionOperator(regionID uint64, storeIDs map[uint64]placement.PeerRoleType) (*operator.Operator, error) {
 	c := h.GetCluster()
 	if c == nil {
-		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
 	}
 	region := c.GetRegion(regionID)
 	if region == nil {
-		return errs.ErrRegionNotFound.FastGenByArgs(regionID)
+		return nil, errs.ErrRegionNotFound.FastGenByArgs(regionID)
 	}
 
 	if c.GetSharedConfig().IsPlacementRulesEnabled() {
 		// Cannot determine role without peer role when placement rules enabled. Not supported now.
 		for _, role := range storeIDs {
 			if len(role) == 0 {
-				return errors.New("transfer region without peer role is not supported when placement rules enabled")
+				return nil, errors.New("transfer region without peer role is not supported when placement rules enabled")
 			}
 		}
 	}
 	for id := range storeIDs {
 		if err := checkStoreState(c, id); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -463,38 +625,47 @@ func (h *Handler) AddTransferRegionOperator(regionID uint64, storeIDs map[uint64
 	op, err := operator.CreateMoveRegionOperator("admin-move-region", c, region, operator.OpAdmin, roles)
 	if err != nil {
 		log.Debug("fail to create move region operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // AddTransferPeerOperator adds an operator to transfer peer.
 func (h *Handler) AddTransferPeerOperator(regionID uint64, fromStoreID, toStoreID uint64) error {
+	op, err := h.createTransferPeerOperator(regionID, fromStoreID, toStoreID)
+	if err != nil {
+		return err
+	}
+	return h.addOperator(op)
+}
+
+// createTransferPeerOperator creates an operator to transfer peer.
+func (h *Handler) createTransferPeerOperator(regionID uint64, fromStoreID, toStoreID uint64) (*operator.Operator, error) {
 	c := h.GetCluster()
 	if c == nil {
-		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
 	}
 	region := c.GetRegion(regionID)
 	if region == nil {
-		return errs.ErrRegionNotFound.FastGenByArgs(regionID)
+		return nil, errs.ErrRegionNotFound.FastGenByArgs(regionID)
 	}
 
 	oldPeer := region.GetStorePeer(fromStoreID)
 	if oldPeer == nil {
-		return errors.Errorf("region has no peer in store %v", fromStoreID)
+		return nil, errors.Errorf("region has no peer in store %v", fromStoreID)
 	}
 
 	if err := checkStoreState(c, toStoreID); err != nil {
-		return err
+		return nil, err
 	}
 
 	newPeer := &metapb.Peer{StoreId: toStoreID, Role: oldPeer.GetRole(), IsWitness: oldPeer.GetIsWitness()}
 	op, err := operator.CreateMovePeerOperator("admin-move-peer", c, region, operator.OpAdmin, fromStoreID, newPeer)
 	if err != nil {
 		log.Debug("fail to create move peer operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // checkAdminAddPeerOperator checks adminAddPeer operator with given region ID and store ID.
@@ -521,26 +692,44 @@ func (h *Handler) checkAdminAddPeerOperator(regionID uint64, toStoreID uint64) (
 
 // AddAddPeerOperator adds an operator to add peer.
 func (h *Handler) AddAddPeerOperator(regionID uint64, toStoreID uint64) error {
-	c, region, err := h.checkAdminAddPeerOperator(regionID, toStoreID)
+	op, err := h.createAddPeerOperator(regionID, toStoreID)
 	if err != nil {
 		return err
 	}
+	return h.addOperator(op)
+}
+
+// createAddPeerOperator creates an operator to add peer.
+func (h *Handler) createAddPeerOperator(regionID uint64, toStoreID uint64) (*operator.Operator, error) {
+	c, region, err := h.checkAdminAddPeerOperator(regionID, toStoreID)
+	if err != nil {
+		return nil, err
+	}
 
 	newPeer := &metapb.Peer{StoreId: toStoreID}
 	op, err := operator.CreateAddPeerOperator("admin-add-peer", c, region, newPeer, operator.OpAdmin)
 	if err != nil {
 		log.Debug("fail to create add peer operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // AddAddLearnerOperator adds an operator to add learner.
 func (h *Handler) AddAddLearnerOperator(regionID uint64, toStoreID uint64) error {
-	c, region, err := h.checkAdminAddPeerOperator(regionID, toStoreID)
+	op, err := h.createAddLearnerOperator(regionID, toStoreID)
 	if err != nil {
 		return err
 	}
+	return h.addOperator(op)
+}
+
+// createAddLearnerOperator creates an operator to add learner.
+func (h *Handler) createAddLearnerOperator(regionID uint64, toStoreID uint64) (*operator.Operator, error) {
+	c, region, err := h.checkAdminAddPeerOperator(regionID, toStoreID)
+	if err != nil {
+		return nil, err
+	}
 
 	newPeer := &metapb.Peer{
 		StoreId: toStoreID,
@@ -550,32 +739,41 @@ func (h *Handler) AddAddLearnerOperator(regionID uint64, toStoreID uint64) error
 	op, err := operator.CreateAddPeerOperator("admin-add-learner", c, region, newPeer, operator.OpAdmin)
 	if err != nil {
 		log.Debug("fail to create add learner operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // AddRemovePeerOperator adds an operator to remove peer.
 func (h *Handler) AddRemovePeerOperator(regionID uint64, fromStoreID uint64) error {
+	op, err := h.createRemovePeerOperator(regionID, fromStoreID)
+	if err != nil {
+		return err
+	}
+	return h.addOperator(op)
+}
+
+// createRemovePeerOperator creates an operator to remove peer.
+func (h *Handler) createRemovePeerOperator(regionID uint64, fromStoreID uint64) (*operator.Operator, error) {
 	c := h.GetCluster()
 	if c == nil {
-		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
 	}
 	region := c.GetRegion(regionID)
 	if region == nil {
-		return errs.ErrRegionNotFound.FastGenByArgs(regionID)
+		return nil, errs.ErrRegionNotFound.FastGenByArgs(regionID)
 	}
 
 	if region.GetStorePeer(fromStoreID) == nil {
-		return errors.Errorf("region has no peer in store %v", fromStoreID)
+		return nil, errors.Errorf("region has no peer in store %v", fromStoreID)
 	}
 
 	op, err := operator.CreateRemovePeerOperator("admin-remove-peer", c, operator.OpAdmin, region, fromStoreID)
 	if err != nil {
 		log.Debug("fail to create move peer operator", errs.ZapError(err))
-		return err
+		return nil, err
 	}
-	return h.addOperator(op)
+	return op, nil
 }
 
 // AddMergeRegionOperator adds an operator to merge region.
@@ -902,6 +1100,16 @@ func (h *Handler) GetDiagnosticResult(name string) (*schedulers.DiagnosticResult
 	return result, nil
 }
 
+// DryRunScheduler returns the operators which the specified scheduler would create now
+// without executing them.
+func (h *Handler) DryRunScheduler(name string) (*schedulers.DryRunResult, error) {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return nil, err
+	}
+	return sc.DryRunScheduler(name)
+}
+
 // PauseOrResumeScheduler pauses a scheduler for delay seconds or resume a paused scheduler.
 // t == 0 : resume scheduler.
 // t > 0 : scheduler delays t seconds.
@@ -1344,3 +1552,70 @@ func (h *Handler) CheckRegionPlacementRule(region *core.RegionInfo) (*placement.
 	}
 	return manager.FitRegion(c, region), nil
 }
+
+// RegionPlacementSimulation is the result of simulating the placement rules on a region.
+type RegionPlacementSimulation struct {
+	RegionID      uint64               `json:"region-id"`
+	StartKey      string               `json:"start-key"`
+	EndKey        string               `json:"end-key"`
+	CurrentFit    *placement.RegionFit `json:"current-fit"`
+	CandidateFit  *placement.RegionFit `json:"candidate-fit"`
+	OperatorDesc  string               `json:"operator-desc,omitempty"`
+	OperatorSteps []string             `json:"operator-steps,omitempty"`
+}
+
+// simulatedCluster replaces the rule manager of the cluster, so that the
+// operators are built with the candidate rules.
+type simulatedCluster struct {
+	sche.CheckerCluster
+	ruleManager *placement.RuleManager
+}
+
+// GetRuleManager returns the simulated rule manager.
+func (c *simulatedCluster) GetRuleManager() *placement.RuleManager {
+	return c.ruleManager
+}
+
+// SimulatePlacementRules checks the regions in the range with the candidate
+// bundles which are not saved, and returns the fits before and after applying
+// the bundles and the operator which the rule checker would create.
+func (h *Handler) SimulatePlacementRules(bundles []placement.GroupBundle, partial bool, startKey, endKey []byte, limit int) ([]*RegionPlacementSimulation, error) {
+	manager, err := h.GetRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := h.GetCluster().(sche.CheckerCluster)
+	if !ok {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	simManager, err := manager.NewSimulatedRuleManager(bundles, !partial)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	simCluster := &simulatedCluster{CheckerCluster: c, ruleManager: simManager}
+	rc := checker.NewSimulationRuleChecker(ctx, simCluster, simManager)
+
+	regions := c.ScanRegions(startKey, endKey, limit)
+	results := make([]*RegionPlacementSimulation, 0, len(regions))
+	for _, region := range regions {
+		candidateFit := simManager.FitRegion(c, region)
+		result := &RegionPlacementSimulation{
+			RegionID:     region.GetID(),
+			StartKey:     core.HexRegionKeyStr(region.GetStartKey()),
+			EndKey:       core.HexRegionKeyStr(region.GetEndKey()),
+			CurrentFit:   manager.FitRegion(c, region),
+			CandidateFit: candidateFit,
+		}
+		if op := rc.CheckWithFit(region, candidateFit); op != nil {
+			result.OperatorDesc = op.Desc()
+			result.OperatorSteps = make([]string, 0, op.Len())
+			for i := range op.Len() {
+				result.OperatorSteps = append(result.OperatorSteps, op.Step(i).String())
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/storage"
 )
 
 const (
@@ -65,6 +66,8 @@ var (
 	ExceedWaitLimit CancelReasonType = "exceed wait limit"
 	// RelatedMergeRegion is the cancel reason when the operator is cancelled by related merge region.
 	RelatedMergeRegion CancelReasonType = "related merge region"
+	// DryRun is the cancel reason when the operator is only created by a scheduler dry-run.
+	DryRun CancelReasonType = "dry run"
 	// Unknown is the cancel reason when the operator is cancelled by an unknown reason.
 	Unknown CancelReasonType = "unknown"
 )
@@ -513,6 +516,86 @@ func (o *Operator) Record(finishTime time.Time) *OpRecord {
 	return record
 }
 
+// ToHistoryOperator transfers the finished operator to the format persisted in the operator history storage.
+func (o *Operator) ToHistoryOperator(finishTime time.Time) *storage.HistoryOperator {
+	startTime := o.GetStartTime()
+	if startTime.IsZero() {
+		startTime = o.GetCreateTime()
+	}
+	steps := make([]string, 0, len(o.steps))
+	for _, step := range o.steps {
+		steps = append(steps, step.String())
+	}
+	return &storage.HistoryOperator{
+		RegionID:     o.regionID,
+		Desc:         o.desc,
+		Brief:        o.brief,
+		Kind:         o.kind.String(),
+		Status:       OpStatusToString(o.Status()),
+		Steps:        steps,
+		StoreIDs:     o.relatedStoreIDs(),
+		CancelReason: o.GetAdditionalInfo(cancelReason),
+		StartTime:    startTime.UnixMilli(),
+		FinishTime:   finishTime.UnixMilli(),
+	}
+}
+
+// relatedStoreIDs returns the IDs of the stores involved in the steps of the operator.
+func (o *Operator) relatedStoreIDs() []uint64 {
+	var storeIDs []uint64
+	seen := make(map[uint64]struct{})
+	add := func(ids ...uint64) {
+		for _, id := range ids {
+			if _, ok := seen[id]; ok || id == 0 {
+				continue
+			}
+			seen[id] = struct{}{}
+			storeIDs = append(storeIDs, id)
+		}
+	}
+	for _, step := range o.steps {
+		switch s := step.(type) {
+		case TransferLeader:
+			add(s.FromStore, s.ToStore)
+			add(s.ToStores...)
+		case AddPeer:
+			add(s.ToStore)
+		case AddLearner:
+			add(s.ToStore)
+		case RemovePeer:
+			add(s.FromStore)
+		case PromoteLearner:
+			add(s.ToStore)
+		case BecomeWitness:
+			add(s.StoreID)
+		case BecomeNonWitness:
+			add(s.StoreID)
+		case BatchSwitchWitness:
+			for _, w := range s.ToWitnesses {
+				add(w.StoreID)
+			}
+			for _, nw := range s.ToNonWitnesses {
+				add(nw.StoreID)
+			}
+		case ChangePeerV2Enter:
+			for _, pl := range s.PromoteLearners {
+				add(pl.ToStore)
+			}
+			for _, dv := range s.DemoteVoters {
+				add(dv.ToStore)
+			}
+		case ChangePeerV2Leave:
+			for _, pl := range s.PromoteLearners {
+				add(pl.ToStore)
+			}
+			for _, dv := range s.DemoteVoters {
+				add(dv.ToStore)
+			}
+		}
+	}
+	return storeIDs
+}
+
 // IsLeaveJointStateOperator returns true if the desc is OpDescLeaveJointState.
 func (o *Operator) IsLeaveJointStateOperator() bool {
 	return strings.EqualFold(o.desc, OpDescLeaveJointState)
@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
+	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/utils/keyutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/pkg/versioninfo"
@@ -101,11 +102,29 @@ type Controller struct {
 	wop       WaitingOperator
 	wopStatus *waitingOperatorStatus
 	counts    *opCounter
+
+	// historyRecorder is used to persist the finished operators, it is nil if not set.
+	historyRecorder struct {
+		syncutil.RWMutex
+		HistoryRecorder
+	}
+
+	// groups are the operator groups which dispatch operators with dependencies.
+	groups struct {
+		syncutil.RWMutex
+		m map[string]*OperatorGroup
+	}
+}
+
+// HistoryRecorder is used to persist the finished operators, so that they can
+// still be queried after the records are expired or the leader is changed.
+type HistoryRecorder interface {
+	PutOperatorHistory(*storage.HistoryOperator)
 }
 
 // NewController creates a Controller.
 func NewController(ctx context.Context, cluster *core.BasicCluster, config config.SharedConfigProvider, hbStreams *hbstream.HeartbeatStreams) *Controller {
-	return &Controller{
+	oc := &Controller{
 		ctx:             ctx,
 		cluster:         cluster,
 		config:          config,
@@ -118,6 +137,15 @@ func NewController(ctx context.Context, cluster *core.BasicCluster, config confi
 		wopStatus: newWaitingOperatorStatus(),
 		counts:    &opCounter{count: make(map[OpKind]uint64)},
 	}
+	oc.groups.m = make(map[string]*OperatorGroup)
+	return oc
+}
+
+// SetHistoryRecorder sets the recorder to persist the finished operators.
+func (oc *Controller) SetHistoryRecorder(recorder HistoryRecorder) {
+	oc.historyRecorder.Lock()
+	defer oc.historyRecorder.Unlock()
+	oc.historyRecorder.HistoryRecorder = recorder
 }
 
 // Ctx returns a context which will be canceled once RaftCluster is stopped.
@@ -742,6 +770,11 @@ func (oc *Controller) buryOperator(op *Operator) {
 	}
 
 	oc.records.Put(op)
+	oc.historyRecorder.RLock()
+	defer oc.historyRecorder.RUnlock()
+	if oc.historyRecorder.HistoryRecorder != nil {
+		oc.historyRecorder.PutOperatorHistory(op.ToHistoryOperator(time.Now()))
+	}
 }
 
 // GetOperatorStatus gets the operator and its status with the specify id.
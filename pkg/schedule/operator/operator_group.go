@@ -0,0 +1,510 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// operatorGroupRetention is the time to keep the ended operator groups.
+const operatorGroupRetention = 30 * time.Minute
+
+// GroupStatus is the status of an operator group.
+type GroupStatus string
+
+const (
+	// GroupRunning means the group is dispatching its operators.
+	GroupRunning GroupStatus = "running"
+	// GroupPaused means the group does not dispatch new operators, but the
+	// running ones are not affected.
+	GroupPaused GroupStatus = "paused"
+	// GroupCanceled means the group is canceled by the user.
+	GroupCanceled GroupStatus = "canceled"
+	// GroupFinished means all operators of the group are ended.
+	GroupFinished GroupStatus = "finished"
+)
+
+// GroupItemStatus is the status of an operator in the group.
+type GroupItemStatus string
+
+const (
+	// GroupItemPending means the operator is waiting for its dependencies or the concurrency budget.
+	GroupItemPending GroupItemStatus = "pending"
+	// GroupItemRunning means the operator is added into the controller.
+	GroupItemRunning GroupItemStatus = "running"
+	// GroupItemSuccess means the operator is finished successfully.
+	GroupItemSuccess GroupItemStatus = "success"
+	// GroupItemFailed means the operator can not be created or is not finished successfully.
+	GroupItemFailed GroupItemStatus = "failed"
+	// GroupItemSkipped means the operator is not created because one of its dependencies is not finished successfully.
+	GroupItemSkipped GroupItemStatus = "skipped"
+	// GroupItemCanceled means the operator is canceled with the group.
+	GroupItemCanceled GroupItemStatus = "canceled"
+)
+
+func (s GroupItemStatus) isEnd() bool {
+	return s != GroupItemPending && s != GroupItemRunning
+}
+
+// GroupItem describes an operator of a group. The operator is created when
+// all its dependencies are finished successfully, so that it is built with
+// the latest state of the region.
+type GroupItem struct {
+	Name      string
+	DependsOn []string
+	Create    func() (*Operator, error)
+}
+
+type groupItem struct {
+	*GroupItem
+	status GroupItemStatus
+	op     *Operator
+	err    string
+}
+
+// OperatorGroup is a set of operators which are dispatched as a unit. An
+// operator is dispatched after all operators it depends on are finished
+// successfully, and at most `concurrency` operators are running at the same time.
+type OperatorGroup struct {
+	syncutil.RWMutex
+	id          string
+	concurrency int
+	status      GroupStatus
+	items       []*groupItem
+	createTime  time.Time
+	endTime     time.Time
+}
+
+// NewOperatorGroup creates an operator group, the dependencies should not have a cycle.
+func NewOperatorGroup(id string, concurrency int, items []*GroupItem) (*OperatorGroup, error) {
+	if len(id) == 0 {
+		return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs("the id is empty")
+	}
+	if concurrency <= 0 {
+		return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs("the concurrency should be positive")
+	}
+	if len(items) == 0 {
+		return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs("no operator in the group")
+	}
+	names := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		if len(item.Name) == 0 {
+			return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs("the operator name is empty")
+		}
+		if item.Create == nil {
+			return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("operator %s can not be created", item.Name))
+		}
+		if _, ok := names[item.Name]; ok {
+			return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("duplicated operator %s", item.Name))
+		}
+		names[item.Name] = struct{}{}
+	}
+	for _, item := range items {
+		for _, dep := range item.DependsOn {
+			if _, ok := names[dep]; !ok {
+				return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("operator %s depends on unknown operator %s", item.Name, dep))
+			}
+		}
+	}
+	if name, ok := findDependencyCycle(items); ok {
+		return nil, errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("operator %s is in a dependency cycle", name))
+	}
+	g := &OperatorGroup{
+		id:          id,
+		concurrency: concurrency,
+		status:      GroupRunning,
+		items:       make([]*groupItem, 0, len(items)),
+		createTime:  time.Now(),
+	}
+	for _, item := range items {
+		g.items = append(g.items, &groupItem{GroupItem: item, status: GroupItemPending})
+	}
+	return g, nil
+}
+
+// findDependencyCycle returns one of the operators in a cycle if there is any.
+func findDependencyCycle(items []*GroupItem) (string, bool) {
+	inDegree := make(map[string]int, len(items))
+	dependents := make(map[string][]string, len(items))
+	for _, item := range items {
+		inDegree[item.Name] += len(item.DependsOn)
+		for _, dep := range item.DependsOn {
+			dependents[dep] = append(dependents[dep], item.Name)
+		}
+	}
+	queue := make([]string, 0, len(items))
+	for _, item := range items {
+		if inDegree[item.Name] == 0 {
+			queue = append(queue, item.Name)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, next := range dependents[name] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if visited == len(items) {
+		return "", false
+	}
+	for _, item := range items {
+		if inDegree[item.Name] > 0 {
+			return item.Name, true
+		}
+	}
+	return "", false
+}
+
+// ID returns the id of the group.
+func (g *OperatorGroup) ID() string {
+	return g.id
+}
+
+// Status returns the status of the group.
+func (g *OperatorGroup) Status() GroupStatus {
+	g.RLock()
+	defer g.RUnlock()
+	return g.status
+}
+
+func (g *OperatorGroup) isEnd() bool {
+	return g.status == GroupCanceled || g.status == GroupFinished
+}
+
+// OperatorGroupItemStatus is the status of an operator in the group.
+type OperatorGroupItemStatus struct {
+	Name      string          `json:"name"`
+	DependsOn []string        `json:"depends-on,omitempty"`
+	Status    GroupItemStatus `json:"status"`
+	RegionID  uint64          `json:"region-id,omitempty"`
+	Operator  string          `json:"operator,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// OperatorGroupStatus is the status of an operator group.
+type OperatorGroupStatus struct {
+	ID          string                     `json:"id"`
+	Status      GroupStatus                `json:"status"`
+	Concurrency int                        `json:"concurrency"`
+	Total       int                        `json:"total"`
+	Ended       int                        `json:"ended"`
+	Progress    float64                    `json:"progress"`
+	CreateTime  time.Time                  `json:"create-time"`
+	EndTime     *time.Time                 `json:"end-time,omitempty"`
+	Operators   []*OperatorGroupItemStatus `json:"operators"`
+}
+
+// GetStatus returns the status of the group and its operators.
+func (g *OperatorGroup) GetStatus() *OperatorGroupStatus {
+	g.RLock()
+	defer g.RUnlock()
+	s := &OperatorGroupStatus{
+		ID:          g.id,
+		Status:      g.status,
+		Concurrency: g.concurrency,
+		Total:       len(g.items),
+		CreateTime:  g.createTime,
+		Operators:   make([]*OperatorGroupItemStatus, 0, len(g.items)),
+	}
+	if !g.endTime.IsZero() {
+		endTime := g.endTime
+		s.EndTime = &endTime
+	}
+	for _, item := range g.items {
+		is := &OperatorGroupItemStatus{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Status:    item.status,
+			Error:     item.err,
+		}
+		if item.op != nil {
+			is.RegionID = item.op.RegionID()
+			is.Operator = item.op.String()
+		}
+		if item.status.isEnd() {
+			s.Ended++
+		}
+		s.Operators = append(s.Operators, is)
+	}
+	s.Progress = float64(s.Ended) / float64(s.Total)
+	return s
+}
+
+// check updates the status of the operators, and dispatches the operators
+// whose dependencies are finished if the group is running.
+func (g *OperatorGroup) check(oc *Controller) {
+	g.Lock()
+	defer g.Unlock()
+	if g.isEnd() {
+		return
+	}
+	running := 0
+	for _, item := range g.items {
+		if item.status != GroupItemRunning {
+			continue
+		}
+		switch st := item.op.Status(); {
+		case st == SUCCESS:
+			item.status = GroupItemSuccess
+		case IsEndStatus(st):
+			item.status = GroupItemFailed
+			item.err = fmt.Sprintf("operator is %s", OpStatusToString(st))
+		default:
+			running++
+		}
+	}
+	g.skipUnreachableLocked()
+	if g.status == GroupRunning {
+		for _, item := range g.items {
+			if running >= g.concurrency {
+				break
+			}
+			if item.status != GroupItemPending || !g.dependenciesFinishedLocked(item) {
+				continue
+			}
+			op, err := item.Create()
+			if err != nil {
+				item.status, item.err = GroupItemFailed, err.Error()
+				continue
+			}
+			op.SetAdditionalInfo("operator-group", g.id)
+			item.op = op
+			if !oc.AddOperator(op) {
+				item.status, item.err = GroupItemFailed, errs.ErrAddOperator.Error()
+				continue
+			}
+			item.status = GroupItemRunning
+			running++
+		}
+		// The failed operators may make others unreachable.
+		g.skipUnreachableLocked()
+	}
+	for _, item := range g.items {
+		if !item.status.isEnd() {
+			return
+		}
+	}
+	g.status = GroupFinished
+	g.endTime = time.Now()
+	log.Info("operator group is finished", zap.String("id", g.id))
+}
+
+func (g *OperatorGroup) getItemLocked(name string) *groupItem {
+	for _, item := range g.items {
+		if item.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func (g *OperatorGroup) dependenciesFinishedLocked(item *groupItem) bool {
+	for _, dep := range item.DependsOn {
+		if g.getItemLocked(dep).status != GroupItemSuccess {
+			return false
+		}
+	}
+	return true
+}
+
+// skipUnreachableLocked skips the pending operators which depend on an
+// operator that is not finished successfully.
+func (g *OperatorGroup) skipUnreachableLocked() {
+	for changed := true; changed; {
+		changed = false
+		for _, item := range g.items {
+			if item.status != GroupItemPending {
+				continue
+			}
+			for _, dep := range item.DependsOn {
+				if st := g.getItemLocked(dep).status; st.isEnd() && st != GroupItemSuccess {
+					item.status = GroupItemSkipped
+					item.err = fmt.Sprintf("dependency %s is %s", dep, st)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *OperatorGroup) pause() error {
+	g.Lock()
+	defer g.Unlock()
+	if g.isEnd() {
+		return errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("the group is %s", g.status))
+	}
+	g.status = GroupPaused
+	return nil
+}
+
+func (g *OperatorGroup) resume() error {
+	g.Lock()
+	defer g.Unlock()
+	if g.isEnd() {
+		return errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("the group is %s", g.status))
+	}
+	g.status = GroupRunning
+	return nil
+}
+
+func (g *OperatorGroup) cancel(oc *Controller) error {
+	g.Lock()
+	defer g.Unlock()
+	if g.isEnd() {
+		return errs.ErrOperatorGroupInvalid.FastGenByArgs(fmt.Sprintf("the group is %s", g.status))
+	}
+	for _, item := range g.items {
+		switch item.status {
+		case GroupItemPending:
+			item.status = GroupItemCanceled
+		case GroupItemRunning:
+			switch st := item.op.Status(); {
+			case oc.RemoveOperator(item.op, AdminStop):
+				item.status = GroupItemCanceled
+			case st == SUCCESS:
+				item.status = GroupItemSuccess
+			default:
+				// The operator is ended before it is removed.
+				item.status = GroupItemFailed
+				item.err = fmt.Sprintf("operator is %s", OpStatusToString(st))
+			}
+		}
+	}
+	g.status = GroupCanceled
+	g.endTime = time.Now()
+	return nil
+}
+
+// AddOperatorGroup adds an operator group and dispatches the operators without dependencies.
+// An ended group with the same id is replaced.
+func (oc *Controller) AddOperatorGroup(g *OperatorGroup) error {
+	oc.groups.Lock()
+	if old, ok := oc.groups.m[g.id]; ok && !isOperatorGroupEnd(old) {
+		oc.groups.Unlock()
+		return errs.ErrOperatorGroupExists.FastGenByArgs(g.id)
+	}
+	oc.groups.m[g.id] = g
+	oc.groups.Unlock()
+	log.Info("operator group is added", zap.String("id", g.id), zap.Int("operators", len(g.items)), zap.Int("concurrency", g.concurrency))
+	g.check(oc)
+	return nil
+}
+
+func isOperatorGroupEnd(g *OperatorGroup) bool {
+	g.RLock()
+	defer g.RUnlock()
+	return g.isEnd()
+}
+
+// GetOperatorGroup returns the operator group with the given id.
+func (oc *Controller) GetOperatorGroup(id string) (*OperatorGroup, error) {
+	oc.groups.RLock()
+	defer oc.groups.RUnlock()
+	g, ok := oc.groups.m[id]
+	if !ok {
+		return nil, errs.ErrOperatorGroupNotFound.FastGenByArgs(id)
+	}
+	return g, nil
+}
+
+// GetOperatorGroups returns all operator groups sorted by the create time.
+func (oc *Controller) GetOperatorGroups() []*OperatorGroup {
+	oc.groups.RLock()
+	groups := make([]*OperatorGroup, 0, len(oc.groups.m))
+	for _, g := range oc.groups.m {
+		groups = append(groups, g)
+	}
+	oc.groups.RUnlock()
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].createTime.Before(groups[j].createTime)
+	})
+	return groups
+}
+
+// PauseOperatorGroup stops dispatching new operators of the group.
+func (oc *Controller) PauseOperatorGroup(id string) error {
+	g, err := oc.GetOperatorGroup(id)
+	if err != nil {
+		return err
+	}
+	if err := g.pause(); err != nil {
+		return err
+	}
+	log.Info("operator group is paused", zap.String("id", id))
+	return nil
+}
+
+// ResumeOperatorGroup continues dispatching the operators of the group.
+func (oc *Controller) ResumeOperatorGroup(id string) error {
+	g, err := oc.GetOperatorGroup(id)
+	if err != nil {
+		return err
+	}
+	if err := g.resume(); err != nil {
+		return err
+	}
+	log.Info("operator group is resumed", zap.String("id", id))
+	g.check(oc)
+	return nil
+}
+
+// CancelOperatorGroup cancels the running operators of the group and the pending ones.
+func (oc *Controller) CancelOperatorGroup(id string) error {
+	g, err := oc.GetOperatorGroup(id)
+	if err != nil {
+		return err
+	}
+	if err := g.cancel(oc); err != nil {
+		return err
+	}
+	log.Info("operator group is canceled", zap.String("id", id))
+	return nil
+}
+
+// CheckOperatorGroups updates the operator groups and dispatches their
+// operators, the groups ended for a while are removed.
+func (oc *Controller) CheckOperatorGroups() {
+	now := time.Now()
+	for _, g := range oc.GetOperatorGroups() {
+		g.check(oc)
+		g.RLock()
+		expired := g.isEnd() && now.Sub(g.endTime) > operatorGroupRetention
+		g.RUnlock()
+		if expired {
+			oc.groups.Lock()
+			// The group may have been replaced by a new one with the same ID.
+			if oc.groups.m[g.id] == g {
+				delete(oc.groups.m, g.id)
+			}
+			oc.groups.Unlock()
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+)
+
+func TestNewOperatorGroup(t *testing.T) {
+	re := require.New(t)
+	create := func() (*Operator, error) { return nil, nil }
+	_, err := NewOperatorGroup("g", 1, []*GroupItem{{Name: "a", Create: create}, {Name: "b", DependsOn: []string{"a"}, Create: create}})
+	re.NoError(err)
+
+	invalid := [][]*GroupItem{
+		nil,
+		{{Name: "", Create: create}},
+		{{Name: "a"}},
+		{{Name: "a", Create: create}, {Name: "a", Create: create}},
+		{{Name: "a", DependsOn: []string{"b"}, Create: create}},
+		{{Name: "a", DependsOn: []string{"c"}, Create: create}, {Name: "b", DependsOn: []string{"a"}, Create: create}, {Name: "c", DependsOn: []string{"b"}, Create: create}},
+	}
+	for _, items := range invalid {
+		_, err := NewOperatorGroup("g", 1, items)
+		re.True(errs.ErrOperatorGroupInvalid.Equal(err))
+	}
+	_, err = NewOperatorGroup("g", 0, []*GroupItem{{Name: "a", Create: create}})
+	re.Error(err)
+	_, err = NewOperatorGroup("", 1, []*GroupItem{{Name: "a", Create: create}})
+	re.Error(err)
+}
+
+func TestOperatorGroup(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc, false /* no need to run */)
+	oc := NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddLeaderRegion(i, 1, 2)
+	}
+	ops := make(map[uint64]*Operator)
+	removePeer := func(regionID uint64) func() (*Operator, error) {
+		return func() (*Operator, error) {
+			region := tc.GetRegion(regionID)
+			ops[regionID] = NewTestOperator(regionID, region.GetRegionEpoch(), OpRegion, RemovePeer{FromStore: 2})
+			return ops[regionID], nil
+		}
+	}
+	finish := func(regionID uint64) {
+		ApplyOperator(tc, ops[regionID])
+		oc.Dispatch(tc.GetRegion(regionID), "test", nil)
+		re.Equal(SUCCESS, ops[regionID].Status())
+	}
+	itemStatus := func(g *OperatorGroup) []GroupItemStatus {
+		var status []GroupItemStatus
+		for _, item := range g.GetStatus().Operators {
+			status = append(status, item.Status)
+		}
+		return status
+	}
+
+	g, err := NewOperatorGroup("g1", 1, []*GroupItem{
+		{Name: "a", Create: removePeer(1)},
+		{Name: "b", DependsOn: []string{"a"}, Create: removePeer(2)},
+		{Name: "c", DependsOn: []string{"a"}, Create: removePeer(3)},
+	})
+	re.NoError(err)
+	re.NoError(oc.AddOperatorGroup(g))
+	re.True(errs.ErrOperatorGroupExists.Equal(oc.AddOperatorGroup(g)))
+	re.Equal([]GroupItemStatus{GroupItemRunning, GroupItemPending, GroupItemPending}, itemStatus(g))
+	re.Equal(ops[1], oc.GetOperator(1))
+
+	// Only one operator can be running at the same time.
+	finish(1)
+	oc.CheckOperatorGroups()
+	re.Equal([]GroupItemStatus{GroupItemSuccess, GroupItemRunning, GroupItemPending}, itemStatus(g))
+	re.InDelta(1.0/3, g.GetStatus().Progress, 1e-6)
+
+	// The paused group does not dispatch new operators.
+	re.NoError(oc.PauseOperatorGroup("g1"))
+	finish(2)
+	oc.CheckOperatorGroups()
+	re.Equal([]GroupItemStatus{GroupItemSuccess, GroupItemSuccess, GroupItemPending}, itemStatus(g))
+	re.NoError(oc.ResumeOperatorGroup("g1"))
+	re.Equal([]GroupItemStatus{GroupItemSuccess, GroupItemSuccess, GroupItemRunning}, itemStatus(g))
+
+	// Cancel the running operators.
+	re.NoError(oc.CancelOperatorGroup("g1"))
+	re.Equal(GroupCanceled, g.Status())
+	re.Equal([]GroupItemStatus{GroupItemSuccess, GroupItemSuccess, GroupItemCanceled}, itemStatus(g))
+	re.Nil(oc.GetOperator(3))
+	re.Error(oc.ResumeOperatorGroup("g1"))
+
+	// The dependents of the failed operator are skipped.
+	g, err = NewOperatorGroup("g2", 2, []*GroupItem{
+		{Name: "a", Create: func() (*Operator, error) { return nil, errors.New("no region") }},
+		{Name: "b", DependsOn: []string{"a"}, Create: removePeer(2)},
+		{Name: "c", DependsOn: []string{"b"}, Create: removePeer(3)},
+	})
+	re.NoError(err)
+	re.NoError(oc.AddOperatorGroup(g))
+	re.Equal(GroupFinished, g.Status())
+	re.Equal([]GroupItemStatus{GroupItemFailed, GroupItemSkipped, GroupItemSkipped}, itemStatus(g))
+	re.Len(oc.GetOperatorGroups(), 2)
+	_, err = oc.GetOperatorGroup("g3")
+	re.True(errs.ErrOperatorGroupNotFound.Equal(err))
+}
@@ -833,6 +833,44 @@ func checkRule(rule *Rule, stores []*core.StoreInfo) bool {
 	})
 }
 
+// NewSimulatedRuleManager creates a rule manager which holds the same rules as
+// the current one and then applies the candidate bundles in memory. The bundles
+// are applied in the same way as SetAllGroupBundles, but nothing is persisted,
+// so it can be used to check the result of the bundles before saving them.
+func (m *RuleManager) NewSimulatedRuleManager(bundles []GroupBundle, override bool) (*RuleManager, error) {
+	sm := NewRuleManager(m.ctx, endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil), m.storeSetInformer, m.conf)
+	m.RLock()
+	sm.keyType = m.keyType
+	for key, r := range m.ruleConfig.rules {
+		sm.ruleConfig.rules[key] = r.Clone()
+	}
+	for id, g := range m.ruleConfig.groups {
+		group := *g
+		sm.ruleConfig.groups[id] = &group
+	}
+	m.RUnlock()
+	sm.ruleConfig.adjust()
+	ruleList, err := buildRuleList(sm.ruleConfig)
+	if err != nil {
+		return nil, err
+	}
+	sm.ruleList = ruleList
+	sm.initialized = true
+
+	candidate := make([]GroupBundle, len(bundles))
+	for i := range bundles {
+		candidate[i] = bundles[i]
+		candidate[i].Rules = make([]*Rule, 0, len(bundles[i].Rules))
+		for _, r := range bundles[i].Rules {
+			candidate[i].Rules = append(candidate[i].Rules, r.Clone())
+		}
+	}
+	if err := sm.SetAllGroupBundles(candidate, override); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
 // SetKeyType will update keyType for adjustRule()
 func (m *RuleManager) SetKeyType(h string) *RuleManager {
 	m.Lock()
@@ -360,6 +360,37 @@ func TestGroupConfig(t *testing.T) {
 	re.Equal([]*RuleGroup{g2}, manager.GetRuleGroups())
 }
 
+func TestSimulatedRuleManager(t *testing.T) {
+	re := require.New(t)
+	store, manager := newTestManager(t, false)
+	re.NoError(manager.SetRule(&Rule{GroupID: "g", ID: "1", Role: Voter, Count: 1}))
+
+	// Partially update the rules, the group g is kept.
+	bundle := GroupBundle{ID: "g2", Index: 1, Rules: []*Rule{{GroupID: "g2", ID: "1", Role: Learner, Count: 1}}}
+	sm, err := manager.NewSimulatedRuleManager([]GroupBundle{bundle}, false)
+	re.NoError(err)
+	re.Len(sm.GetAllRules(), 3)
+	re.NotNil(sm.GetRule("g2", "1"))
+	re.Len(sm.GetRulesForApplyRange([]byte("a"), []byte("b")), 3)
+	// The current rules are not changed.
+	re.Len(manager.GetAllRules(), 2)
+	re.Nil(manager.GetRule("g2", "1"))
+	var saved []string
+	re.NoError(store.LoadRules(func(k, _ string) { saved = append(saved, k) }))
+	re.Len(saved, 2)
+
+	// Override all rules.
+	sm, err = manager.NewSimulatedRuleManager([]GroupBundle{bundle}, true)
+	re.NoError(err)
+	re.Len(sm.GetAllRules(), 1)
+	re.Len(manager.GetAllRules(), 2)
+
+	// The invalid rules can not be simulated.
+	bundle.Rules[0].Count = 0
+	_, err = manager.NewSimulatedRuleManager([]GroupBundle{bundle}, true)
+	re.Error(err)
+}
+
 func TestRuleVersion(t *testing.T) {
 	re := require.New(t)
 	_, manager := newTestManager(t, false)
@@ -27,6 +27,9 @@ import (
 )
 
 // PluginInterface is used to manage all plugin.
+// The plugins loaded by it must be built with the same toolchain and dependencies
+// as PD, prefer the out-of-process plugins called by remote-plugin-scheduler,
+// see package remoteplugin.
 type PluginInterface struct {
 	pluginMap     map[string]*plugin.Plugin
 	pluginMapLock syncutil.RWMutex
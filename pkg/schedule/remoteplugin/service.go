@@ -0,0 +1,142 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteplugin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	serviceName    = "pd.schedulerplugin.SchedulerPlugin"
+	scheduleMethod = "/" + serviceName + "/Schedule"
+	// defaultChunkSize is the max number of the items in one snapshot chunk.
+	defaultChunkSize = 1024
+)
+
+// jsonCodec encodes the messages as JSON, so that the plugins do not depend
+// on any generated code and can be written in other languages easily.
+type jsonCodec struct{}
+
+// Marshal implements encoding.Codec.
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements encoding.Codec.
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name implements encoding.Codec.
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+// Scheduler is the interface implemented by the plugins.
+type Scheduler interface {
+	// Schedule returns the operators proposed for the snapshot. The snapshot
+	// must not be modified.
+	Schedule(ctx context.Context, snapshot *Snapshot) ([]*Proposal, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Scheduler)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Schedule",
+			Handler:       scheduleHandler,
+			ClientStreams: true,
+		},
+	},
+}
+
+func scheduleHandler(srv any, stream grpc.ServerStream) error {
+	snapshot := &Snapshot{}
+	for {
+		chunk := &Snapshot{}
+		err := stream.RecvMsg(chunk)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		snapshot.merge(chunk)
+	}
+	snapshot.buildIndex()
+	proposals, err := srv.(Scheduler).Schedule(stream.Context(), snapshot)
+	if err != nil {
+		return err
+	}
+	return stream.SendMsg(&ScheduleResponse{Proposals: proposals})
+}
+
+// NewServer creates a gRPC server which serves the scheduler.
+func NewServer(scheduler Scheduler, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ForceServerCodec(jsonCodec{}))
+	s := grpc.NewServer(opts...)
+	s.RegisterService(&serviceDesc, scheduler)
+	return s
+}
+
+// Serve serves the scheduler on the listener. It blocks until the listener
+// is closed or the server fails.
+func Serve(lis net.Listener, scheduler Scheduler) error {
+	return NewServer(scheduler).Serve(lis)
+}
+
+// Client is used by PD to call a plugin.
+type Client struct {
+	conn      grpc.ClientConnInterface
+	chunkSize int
+}
+
+// NewClient creates a client on the connection.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn, chunkSize: defaultChunkSize}
+}
+
+// Schedule streams the snapshot to the plugin and returns the proposals.
+func (c *Client) Schedule(ctx context.Context, snapshot *Snapshot) ([]*Proposal, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], scheduleMethod, grpc.ForceCodec(jsonCodec{}))
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range snapshot.split(c.chunkSize) {
+		if err := stream.SendMsg(chunk); err != nil {
+			if err == io.EOF {
+				// The real error is returned by RecvMsg.
+				break
+			}
+			return nil, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	resp := &ScheduleResponse{}
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp.Proposals, nil
+}
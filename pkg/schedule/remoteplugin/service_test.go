@@ -0,0 +1,109 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteplugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type evictStoreScheduler struct {
+	storeID  uint64
+	snapshot *Snapshot
+}
+
+func (s *evictStoreScheduler) Schedule(_ context.Context, snapshot *Snapshot) ([]*Proposal, error) {
+	s.snapshot = snapshot
+	if snapshot.GetStore(s.storeID) == nil {
+		return nil, errors.New("store not found")
+	}
+	var proposals []*Proposal
+	for _, region := range snapshot.Regions {
+		if region.LeaderStoreID != s.storeID {
+			continue
+		}
+		for _, id := range region.GetFollowerStoreIDs() {
+			if snapshot.GetStore(id).IsServing() {
+				proposals = append(proposals, NewTransferLeaderProposal(region, id))
+				break
+			}
+		}
+	}
+	return proposals, nil
+}
+
+func TestSchedule(t *testing.T) {
+	re := require.New(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	re.NoError(err)
+	plugin := &evictStoreScheduler{storeID: 1}
+	server := NewServer(plugin)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	re.NoError(err)
+	defer conn.Close()
+	client := NewClient(conn)
+	client.chunkSize = 100
+
+	snapshot := &Snapshot{
+		Stores: []*Store{
+			{ID: 1, State: "Serving"},
+			{ID: 2, State: "Serving", Disconnected: true},
+			{ID: 3, State: "Serving"},
+		},
+		WriteHotPeers: []*HotPeer{{RegionID: 1, StoreID: 1, IsLeader: true, ByteRate: 100}},
+	}
+	for i := uint64(1); i <= 250; i++ {
+		snapshot.Regions = append(snapshot.Regions, &Region{
+			ID:      i,
+			ConfVer: 1,
+			Version: i,
+			Peers: []*Peer{
+				{ID: i * 10, StoreID: 1},
+				{ID: i*10 + 1, StoreID: 2},
+				{ID: i*10 + 2, StoreID: 3},
+			},
+			LeaderStoreID: i%2 + 1,
+		})
+	}
+	proposals, err := client.Schedule(context.Background(), snapshot)
+	re.NoError(err)
+	re.Len(plugin.snapshot.Stores, 3)
+	re.Len(plugin.snapshot.Regions, 250)
+	re.Len(plugin.snapshot.WriteHotPeers, 1)
+	re.Empty(plugin.snapshot.ReadHotPeers)
+	re.Len(proposals, 125)
+	for _, p := range proposals {
+		re.Equal(TransferLeader, p.Kind)
+		re.Equal(uint64(1), p.SourceStoreID)
+		re.Equal(uint64(3), p.TargetStoreID)
+		re.Equal(p.RegionID, p.Version)
+		re.Equal(uint64(1), p.ConfVer)
+	}
+
+	plugin.storeID = 4
+	_, err = client.Schedule(context.Background(), snapshot)
+	re.ErrorContains(err, "store not found")
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remoteplugin implements the protocol between PD and the scheduler
+// plugins running out of process. PD streams a read-only snapshot of the
+// cluster to the plugin over gRPC and the plugin answers with the operators
+// it proposes. PD validates every proposal before it is queued, so a plugin
+// can never bypass the safety checks of the built-in schedulers.
+package remoteplugin
+
+import "time"
+
+// OperatorKind is the kind of the operator proposed by a plugin.
+type OperatorKind string
+
+const (
+	// TransferLeader transfers the leader of the region to the target store.
+	TransferLeader OperatorKind = "transfer-leader"
+	// MovePeer moves the peer of the region from the source store to the target store.
+	MovePeer OperatorKind = "move-peer"
+)
+
+// Store is the read-only view of a store.
+type Store struct {
+	ID            uint64            `json:"id"`
+	Address       string            `json:"address"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	State         string            `json:"state"`
+	Disconnected  bool              `json:"disconnected"`
+	LastHeartbeat time.Time         `json:"last_heartbeat"`
+	Capacity      uint64            `json:"capacity"`
+	Available     uint64            `json:"available"`
+	LeaderCount   int               `json:"leader_count"`
+	RegionCount   int               `json:"region_count"`
+	LeaderWeight  float64           `json:"leader_weight"`
+	RegionWeight  float64           `json:"region_weight"`
+}
+
+// IsServing returns true if the store is up and connected.
+func (s *Store) IsServing() bool {
+	return s.State == "Serving" && !s.Disconnected
+}
+
+// Peer is the read-only view of a region peer.
+type Peer struct {
+	ID        uint64 `json:"id"`
+	StoreID   uint64 `json:"store_id"`
+	IsLearner bool   `json:"is_learner,omitempty"`
+}
+
+// Region is the read-only view of a region.
+type Region struct {
+	ID              uint64   `json:"id"`
+	StartKey        []byte   `json:"start_key"`
+	EndKey          []byte   `json:"end_key"`
+	ConfVer         uint64   `json:"conf_ver"`
+	Version         uint64   `json:"version"`
+	Peers           []*Peer  `json:"peers"`
+	LeaderStoreID   uint64   `json:"leader_store_id"`
+	DownStoreIDs    []uint64 `json:"down_store_ids,omitempty"`
+	PendingStoreIDs []uint64 `json:"pending_store_ids,omitempty"`
+	ApproximateSize int64    `json:"approximate_size"`
+	ApproximateKeys int64    `json:"approximate_keys"`
+}
+
+// GetStorePeer returns the peer on the given store, or nil if there is none.
+func (r *Region) GetStorePeer(storeID uint64) *Peer {
+	for _, peer := range r.Peers {
+		if peer.StoreID == storeID {
+			return peer
+		}
+	}
+	return nil
+}
+
+// GetFollowerStoreIDs returns the stores of the voters which are not the leader.
+func (r *Region) GetFollowerStoreIDs() []uint64 {
+	var ids []uint64
+	for _, peer := range r.Peers {
+		if peer.StoreID != r.LeaderStoreID && !peer.IsLearner {
+			ids = append(ids, peer.StoreID)
+		}
+	}
+	return ids
+}
+
+// HotPeer is the read-only view of the statistics of a hot peer.
+type HotPeer struct {
+	RegionID  uint64  `json:"region_id"`
+	StoreID   uint64  `json:"store_id"`
+	IsLeader  bool    `json:"is_leader"`
+	HotDegree int     `json:"hot_degree"`
+	ByteRate  float64 `json:"byte_rate"`
+	KeyRate   float64 `json:"key_rate"`
+	QueryRate float64 `json:"query_rate"`
+}
+
+// Snapshot is the read-only snapshot of the cluster. It is streamed to the
+// plugin in several chunks, each of which carries a part of the items.
+type Snapshot struct {
+	Stores        []*Store   `json:"stores,omitempty"`
+	Regions       []*Region  `json:"regions,omitempty"`
+	ReadHotPeers  []*HotPeer `json:"read_hot_peers,omitempty"`
+	WriteHotPeers []*HotPeer `json:"write_hot_peers,omitempty"`
+
+	stores  map[uint64]*Store
+	regions map[uint64]*Region
+}
+
+// GetStore returns the store with the given ID, or nil if it does not exist.
+func (s *Snapshot) GetStore(id uint64) *Store {
+	s.buildIndex()
+	return s.stores[id]
+}
+
+// GetRegion returns the region with the given ID, or nil if it does not exist.
+func (s *Snapshot) GetRegion(id uint64) *Region {
+	s.buildIndex()
+	return s.regions[id]
+}
+
+func (s *Snapshot) buildIndex() {
+	if s.stores != nil && s.regions != nil {
+		return
+	}
+	s.stores = make(map[uint64]*Store, len(s.Stores))
+	for _, store := range s.Stores {
+		s.stores[store.ID] = store
+	}
+	s.regions = make(map[uint64]*Region, len(s.Regions))
+	for _, region := range s.Regions {
+		s.regions[region.ID] = region
+	}
+}
+
+// merge appends the items of the chunk to the snapshot.
+func (s *Snapshot) merge(chunk *Snapshot) {
+	s.Stores = append(s.Stores, chunk.Stores...)
+	s.Regions = append(s.Regions, chunk.Regions...)
+	s.ReadHotPeers = append(s.ReadHotPeers, chunk.ReadHotPeers...)
+	s.WriteHotPeers = append(s.WriteHotPeers, chunk.WriteHotPeers...)
+	s.stores, s.regions = nil, nil
+}
+
+// split splits the snapshot into chunks which carry at most chunkSize items each.
+func (s *Snapshot) split(chunkSize int) []*Snapshot {
+	chunks := []*Snapshot{{Stores: s.Stores}}
+	for i := 0; i < len(s.Regions); i += chunkSize {
+		chunks = append(chunks, &Snapshot{Regions: s.Regions[i:min(i+chunkSize, len(s.Regions))]})
+	}
+	for i := 0; i < len(s.ReadHotPeers); i += chunkSize {
+		chunks = append(chunks, &Snapshot{ReadHotPeers: s.ReadHotPeers[i:min(i+chunkSize, len(s.ReadHotPeers))]})
+	}
+	for i := 0; i < len(s.WriteHotPeers); i += chunkSize {
+		chunks = append(chunks, &Snapshot{WriteHotPeers: s.WriteHotPeers[i:min(i+chunkSize, len(s.WriteHotPeers))]})
+	}
+	return chunks
+}
+
+// Proposal is an operator proposed by a plugin. The epoch of the region the
+// proposal is based on must be carried, PD rejects the proposal if the region
+// has changed since the snapshot was taken.
+type Proposal struct {
+	Kind          OperatorKind `json:"kind"`
+	RegionID      uint64       `json:"region_id"`
+	ConfVer       uint64       `json:"conf_ver"`
+	Version       uint64       `json:"version"`
+	SourceStoreID uint64       `json:"source_store_id,omitempty"`
+	TargetStoreID uint64       `json:"target_store_id"`
+}
+
+// NewTransferLeaderProposal proposes to transfer the leader of the region to the target store.
+func NewTransferLeaderProposal(region *Region, targetStoreID uint64) *Proposal {
+	return &Proposal{
+		Kind:          TransferLeader,
+		RegionID:      region.ID,
+		ConfVer:       region.ConfVer,
+		Version:       region.Version,
+		SourceStoreID: region.LeaderStoreID,
+		TargetStoreID: targetStoreID,
+	}
+}
+
+// NewMovePeerProposal proposes to move the peer of the region from the source store to the target store.
+func NewMovePeerProposal(region *Region, sourceStoreID, targetStoreID uint64) *Proposal {
+	return &Proposal{
+		Kind:          MovePeer,
+		RegionID:      region.ID,
+		ConfVer:       region.ConfVer,
+		Version:       region.Version,
+		SourceStoreID: sourceStoreID,
+		TargetStoreID: targetStoreID,
+	}
+}
+
+// ScheduleResponse is the response of the plugin.
+type ScheduleResponse struct {
+	Proposals []*Proposal `json:"proposals,omitempty"`
+}
@@ -0,0 +1,639 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/keyutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	defaultBalanceCostWeight         = 1.0
+	defaultBalanceCostToleranceRatio = 0.05
+	balanceCostRetryLimit            = 10
+)
+
+func initBalanceCostSchedulerConfig() *balanceCostSchedulerConfig {
+	return &balanceCostSchedulerConfig{
+		schedulerConfig: &baseSchedulerConfig{},
+		DiskWeight:      defaultBalanceCostWeight,
+		CPUWeight:       defaultBalanceCostWeight,
+		IOWeight:        defaultBalanceCostWeight,
+		QPSWeight:       defaultBalanceCostWeight,
+		ToleranceRatio:  defaultBalanceCostToleranceRatio,
+	}
+}
+
+type balanceCostSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// DiskWeight is the weight of the used size of the store.
+	DiskWeight float64 `json:"disk-weight"`
+	// CPUWeight is the weight of the CPU usage of the store.
+	CPUWeight float64 `json:"cpu-weight"`
+	// IOWeight is the weight of the disk read and write rate of the store.
+	IOWeight float64 `json:"io-weight"`
+	// QPSWeight is the weight of the read and write query rate of the store.
+	QPSWeight float64 `json:"qps-weight"`
+	// ToleranceRatio is the ratio of the average cost that the cost difference
+	// between two stores should exceed before scheduling.
+	ToleranceRatio float64            `json:"tolerance-ratio"`
+	Ranges         []keyutil.KeyRange `json:"ranges"`
+}
+
+func (conf *balanceCostSchedulerConfig) clone() *balanceCostSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	ranges := make([]keyutil.KeyRange, len(conf.Ranges))
+	copy(ranges, conf.Ranges)
+	return &balanceCostSchedulerConfig{
+		DiskWeight:     conf.DiskWeight,
+		CPUWeight:      conf.CPUWeight,
+		IOWeight:       conf.IOWeight,
+		QPSWeight:      conf.QPSWeight,
+		ToleranceRatio: conf.ToleranceRatio,
+		Ranges:         ranges,
+	}
+}
+
+// assign copies the config items from other, the caller should hold the lock.
+func (conf *balanceCostSchedulerConfig) assign(other *balanceCostSchedulerConfig) {
+	conf.DiskWeight = other.DiskWeight
+	conf.CPUWeight = other.CPUWeight
+	conf.IOWeight = other.IOWeight
+	conf.QPSWeight = other.QPSWeight
+	conf.ToleranceRatio = other.ToleranceRatio
+	conf.Ranges = other.Ranges
+}
+
+func (conf *balanceCostSchedulerConfig) validate() error {
+	weights := map[string]float64{
+		"disk-weight": conf.DiskWeight,
+		"cpu-weight":  conf.CPUWeight,
+		"io-weight":   conf.IOWeight,
+		"qps-weight":  conf.QPSWeight,
+	}
+	var sum float64
+	for name, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("%s should not be negative", name)
+		}
+		sum += weight
+	}
+	if sum == 0 {
+		return fmt.Errorf("at least one weight should be positive")
+	}
+	if conf.ToleranceRatio < 0 || conf.ToleranceRatio >= 1 {
+		return fmt.Errorf("tolerance-ratio should be in [0, 1)")
+	}
+	return nil
+}
+
+func (conf *balanceCostSchedulerConfig) getWeights() [costDimensionCount]float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return [costDimensionCount]float64{
+		diskCost: conf.DiskWeight,
+		cpuCost:  conf.CPUWeight,
+		ioCost:   conf.IOWeight,
+		qpsCost:  conf.QPSWeight,
+	}
+}
+
+func (conf *balanceCostSchedulerConfig) getToleranceRatio() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ToleranceRatio
+}
+
+func (conf *balanceCostSchedulerConfig) getRanges() []keyutil.KeyRange {
+	conf.RLock()
+	defer conf.RUnlock()
+	ranges := make([]keyutil.KeyRange, len(conf.Ranges))
+	copy(ranges, conf.Ranges)
+	return ranges
+}
+
+type balanceCostHandler struct {
+	conf *balanceCostSchedulerConfig
+	rd   *render.Render
+}
+
+func newBalanceCostHandler(conf *balanceCostSchedulerConfig) http.Handler {
+	h := &balanceCostHandler{
+		conf: conf,
+		rd:   render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/list", h.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/config", h.updateConfig).Methods(http.MethodPost)
+	return router
+}
+
+func (h *balanceCostHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := h.conf.clone()
+	h.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (h *balanceCostHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	defer r.Body.Close()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	newc := h.conf.clone()
+	if err := json.Unmarshal(data, newc); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := newc.validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.conf.Lock()
+	defer h.conf.Unlock()
+	old := &balanceCostSchedulerConfig{}
+	old.assign(h.conf)
+	h.conf.assign(newc)
+	if err := h.conf.save(); err != nil {
+		h.conf.assign(old)
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.Info("balance-cost-scheduler update config", zap.ByteString("config", data))
+	h.rd.JSON(w, http.StatusOK, "Config is updated.")
+}
+
+type balanceCostScheduler struct {
+	*BaseScheduler
+	conf          *balanceCostSchedulerConfig
+	handler       http.Handler
+	filters       []filter.Filter
+	leaderFilters []filter.Filter
+	filterCounter *filter.Counter
+}
+
+// newBalanceCostScheduler creates a scheduler that tends to keep the weighted
+// cost of disk usage, CPU usage, disk IO and QPS on each store balanced.
+func newBalanceCostScheduler(opController *operator.Controller, conf *balanceCostSchedulerConfig) Scheduler {
+	scheduler := &balanceCostScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.BalanceCostScheduler, conf),
+		conf:          conf,
+		handler:       newBalanceCostHandler(conf),
+	}
+	scheduler.filters = []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: scheduler.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
+		filter.NewSpecialUseFilter(scheduler.GetName()),
+	}
+	scheduler.leaderFilters = []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: scheduler.GetName(), TransferLeader: true, OperatorLevel: constant.Medium},
+		filter.NewSpecialUseFilter(scheduler.GetName()),
+	}
+	scheduler.filterCounter = filter.NewCounter(scheduler.GetName())
+	return scheduler
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *balanceCostScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *balanceCostScheduler) EncodeConfig() ([]byte, error) {
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *balanceCostScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+	newCfg := &balanceCostSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.assign(newCfg)
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *balanceCostScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	regionAllowed, leaderAllowed := s.allowedKinds(cluster)
+	if !regionAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	if !leaderAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	return regionAllowed || leaderAllowed
+}
+
+func (s *balanceCostScheduler) allowedKinds(cluster sche.SchedulerCluster) (regionAllowed, leaderAllowed bool) {
+	conf := cluster.GetSchedulerConfig()
+	regionAllowed = s.OpController.OperatorCount(operator.OpRegion) < conf.GetRegionScheduleLimit()
+	leaderAllowed = s.OpController.OperatorCount(operator.OpLeader) < conf.GetLeaderScheduleLimit()
+	return
+}
+
+// Schedule implements the Scheduler interface.
+//
+// It picks the store with the highest cost as the source and moves a region or
+// transfers a leader to a store with a lower cost. An operator is only created
+// if it does not make the source cost lower than the target cost, so every
+// operator reduces the variance of the store costs.
+func (s *balanceCostScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	basePlan := plan.NewBalanceSchedulerPlan()
+	defer s.filterCounter.Flush()
+	var collector *plan.Collector
+	if dryRun {
+		collector = plan.NewCollector(basePlan)
+	}
+	balanceCostScheduleCounter.Inc()
+	stores := cluster.GetStores()
+	conf := cluster.GetSchedulerConfig()
+	sourceStores := filter.SelectSourceStores(stores, s.filters, conf, collector, s.filterCounter)
+	targetStores := filter.SelectTargetStores(stores, s.filters, conf, nil, s.filterCounter)
+	opInfluence := s.OpController.GetOpInfluence(cluster.GetBasicCluster())
+	costs := newStoreCosts(s.conf.getWeights(), append(sourceStores, targetStores...), cluster.GetStoresLoads(), opInfluence)
+	kind := constant.NewScheduleKind(constant.RegionKind, constant.BySize)
+	solver := newSolver(basePlan, kind, cluster, opInfluence)
+	regionAllowed, leaderAllowed := s.allowedKinds(cluster)
+
+	sort.Slice(sourceStores, func(i, j int) bool {
+		return costs.cost(sourceStores[i].GetID()) > costs.cost(sourceStores[j].GetID())
+	})
+	if collector != nil && len(sourceStores) > 0 {
+		collector.Collect(plan.SetResource(sourceStores[0]), plan.SetStatus(plan.NewStatus(plan.StatusStoreScoreDisallowed)))
+	}
+
+	solver.Step++
+	tolerance := costs.average() * s.conf.getToleranceRatio()
+	for _, solver.Source = range sourceStores {
+		solver.sourceScore = costs.cost(solver.sourceStoreID())
+		// The sources are sorted by cost desc, so no store can be a target of the rest.
+		if solver.sourceScore-costs.minCost() <= tolerance {
+			break
+		}
+		if regionAllowed {
+			if op := s.moveRegion(solver, collector, costs, targetStores, tolerance); op != nil {
+				return []*operator.Operator{op}, collector.GetPlans()
+			}
+		}
+		if leaderAllowed {
+			if op := s.transferLeader(solver, collector, costs, tolerance); op != nil {
+				return []*operator.Operator{op}, collector.GetPlans()
+			}
+		}
+	}
+	return nil, collector.GetPlans()
+}
+
+// moveRegion tries to move a peer from the source store to a store with a lower cost.
+func (s *balanceCostScheduler) moveRegion(solver *solver, collector *plan.Collector, costs *storeCosts,
+	targetStores []*core.StoreInfo, tolerance float64) *operator.Operator {
+	ranges := s.conf.getRanges()
+	pendingFilter := filter.NewRegionPendingFilter()
+	downFilter := filter.NewRegionDownFilter()
+	replicaFilter := filter.NewRegionReplicatedFilter(solver)
+	snapshotFilter := filter.NewSnapshotSendFilter(solver.GetStores(), constant.Medium)
+	regionFilters := []filter.RegionFilter{downFilter, replicaFilter, snapshotFilter, pendingFilter,
+		filter.NewRegionEmptyFilter(solver), filter.NewRegionWitnessFilter(solver.sourceStoreID())}
+	for range balanceCostRetryLimit {
+		solver.Region = filter.SelectOneRegion(solver.RandFollowerRegions(solver.sourceStoreID(), ranges), collector, regionFilters...)
+		if solver.Region == nil {
+			solver.Region = filter.SelectOneRegion(solver.RandLeaderRegions(solver.sourceStoreID(), ranges), collector, regionFilters...)
+		}
+		if solver.Region == nil {
+			balanceCostNoRegionCounter.Inc()
+			continue
+		}
+		delta := costs.regionCost(solver.Region, solver.Source, false)
+		if delta <= 0 {
+			balanceCostNoLoadCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetResource(solver.Region), plan.SetStatus(plan.NewStatus(plan.StatusRegionEmpty)))
+			}
+			continue
+		}
+		solver.Step++
+		solver.fit = replicaFilter.(*filter.RegionReplicatedFilter).GetFit()
+		if op := s.transferPeer(solver, collector, costs, targetStores, delta, tolerance); op != nil {
+			op.Counters = append(op.Counters, balanceCostNewRegionOpCounter)
+			return op
+		}
+		solver.Step--
+	}
+	return nil
+}
+
+// transferPeer selects the store with the lowest cost to create a new peer to replace the old peer.
+func (s *balanceCostScheduler) transferPeer(solver *solver, collector *plan.Collector, costs *storeCosts,
+	targetStores []*core.StoreInfo, delta, tolerance float64) *operator.Operator {
+	conf := solver.GetSchedulerConfig()
+	filters := []filter.Filter{
+		filter.NewExcludedFilter(s.GetName(), nil, solver.Region.GetStoreIDs()),
+		filter.NewPlacementSafeguard(s.GetName(), conf, solver.GetBasicCluster(), solver.GetRuleManager(),
+			solver.Region, solver.Source, solver.fit),
+	}
+	candidates := filter.NewCandidates(s.R, targetStores).FilterTarget(conf, collector, s.filterCounter, filters...)
+	if len(candidates.Stores) == 0 {
+		return nil
+	}
+	solver.Step++
+	defer func() { solver.Step-- }()
+	sort.Slice(candidates.Stores, func(i, j int) bool {
+		return costs.cost(candidates.Stores[i].GetID()) < costs.cost(candidates.Stores[j].GetID())
+	})
+	for _, solver.Target = range candidates.Stores {
+		solver.targetScore = costs.cost(solver.targetStoreID())
+		if !shouldBalanceCost(solver.sourceScore, solver.targetScore, delta, tolerance) {
+			balanceCostSkipCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusStoreScoreDisallowed)))
+			}
+			continue
+		}
+		oldPeer := solver.Region.GetStorePeer(solver.sourceStoreID())
+		newPeer := &metapb.Peer{StoreId: solver.targetStoreID(), Role: oldPeer.Role}
+		op, err := operator.CreateMovePeerOperator(s.GetName(), solver, solver.Region, operator.OpRegion, oldPeer.GetStoreId(), newPeer)
+		if err != nil {
+			balanceCostCreateOpFailCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusCreateOperatorFailed)))
+			}
+			return nil
+		}
+		s.finishOperator(solver, collector, op)
+		return op
+	}
+	return nil
+}
+
+// transferLeader tries to transfer a leader from the source store to a follower in a store with a lower cost.
+func (s *balanceCostScheduler) transferLeader(solver *solver, collector *plan.Collector, costs *storeCosts, tolerance float64) *operator.Operator {
+	conf := solver.GetSchedulerConfig()
+	if filter.NewCandidates(s.R, []*core.StoreInfo{solver.Source}).
+		FilterSource(conf, nil, s.filterCounter, s.leaderFilters...).Len() == 0 {
+		return nil
+	}
+	ranges := s.conf.getRanges()
+	for range balanceCostRetryLimit {
+		solver.Region = filter.SelectOneRegion(solver.RandLeaderRegions(solver.sourceStoreID(), ranges), collector,
+			filter.NewRegionPendingFilter(), filter.NewRegionDownFilter())
+		if solver.Region == nil {
+			balanceCostNoLeaderCounter.Inc()
+			continue
+		}
+		delta := costs.regionCost(solver.Region, solver.Source, true)
+		if delta <= 0 {
+			balanceCostNoLoadCounter.Inc()
+			continue
+		}
+		solver.Step++
+		op := s.transferLeaderToFollower(solver, collector, costs, delta, tolerance)
+		solver.Step--
+		if op != nil {
+			op.Counters = append(op.Counters, balanceCostNewLeaderOpCounter)
+			return op
+		}
+	}
+	return nil
+}
+
+func (s *balanceCostScheduler) transferLeaderToFollower(solver *solver, collector *plan.Collector, costs *storeCosts,
+	delta, tolerance float64) *operator.Operator {
+	conf := solver.GetSchedulerConfig()
+	finalFilters := s.leaderFilters
+	if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, solver.GetBasicCluster(), solver.GetRuleManager(),
+		solver.Region, solver.Source, false /*allowMoveLeader*/); leaderFilter != nil {
+		finalFilters = append(s.leaderFilters, leaderFilter)
+	}
+	candidates := filter.NewCandidates(s.R, solver.GetFollowerStores(solver.Region)).
+		FilterTarget(conf, collector, s.filterCounter, finalFilters...)
+	if len(candidates.Stores) == 0 {
+		return nil
+	}
+	solver.Step++
+	defer func() { solver.Step-- }()
+	sort.Slice(candidates.Stores, func(i, j int) bool {
+		return costs.cost(candidates.Stores[i].GetID()) < costs.cost(candidates.Stores[j].GetID())
+	})
+	for _, solver.Target = range candidates.Stores {
+		solver.targetScore = costs.cost(solver.targetStoreID())
+		if !shouldBalanceCost(solver.sourceScore, solver.targetScore, delta, tolerance) {
+			balanceCostSkipCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusStoreScoreDisallowed)))
+			}
+			continue
+		}
+		op, err := operator.CreateTransferLeaderOperator(s.GetName(), solver, solver.Region, solver.targetStoreID(), []uint64{}, operator.OpLeader)
+		if err != nil {
+			log.Debug("fail to create balance cost operator", zap.Uint64("region-id", solver.Region.GetID()), zap.Error(err))
+			balanceCostCreateOpFailCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusCreateOperatorFailed)))
+			}
+			return nil
+		}
+		s.finishOperator(solver, collector, op)
+		return op
+	}
+	return nil
+}
+
+func (s *balanceCostScheduler) finishOperator(solver *solver, collector *plan.Collector, op *operator.Operator) {
+	if collector != nil {
+		collector.Collect()
+	}
+	sourceLabel := strconv.FormatUint(solver.sourceStoreID(), 10)
+	targetLabel := strconv.FormatUint(solver.targetStoreID(), 10)
+	op.FinishedCounters = append(op.FinishedCounters,
+		balanceDirectionCounter.WithLabelValues(s.GetName(), sourceLabel, targetLabel),
+	)
+	op.SetAdditionalInfo("sourceCost", strconv.FormatFloat(solver.sourceScore, 'f', 2, 64))
+	op.SetAdditionalInfo("targetCost", strconv.FormatFloat(solver.targetScore, 'f', 2, 64))
+}
+
+// shouldBalanceCost returns whether moving the cost of delta from the source to
+// the target reduces the variance without making the source cost lower than the
+// target cost.
+func shouldBalanceCost(sourceCost, targetCost, delta, tolerance float64) bool {
+	return sourceCost-targetCost > tolerance && sourceCost-delta >= targetCost+delta
+}
+
+const (
+	// storeCPUUsagePerCore is the CPU usage reported in the store heartbeats when
+	// a core is fully used, the usages of the threads are reported in percentage.
+	storeCPUUsagePerCore = 100
+	// regionCPUUsagePerCore is the CPU usage reported in the region heartbeats
+	// when a core is fully used, the usage is reported in millicores.
+	regionCPUUsagePerCore = 1000
+)
+
+type costDimension int
+
+const (
+	diskCost costDimension = iota
+	cpuCost
+	ioCost
+	qpsCost
+	costDimensionCount
+)
+
+// storeCosts holds the load of each dimension for the stores and the weighted
+// cost derived from them. Every dimension is normalized by its mean across the
+// stores, so the dimensions with different units are comparable and the mean
+// cost is the sum of the weights of the dimensions with loads.
+type storeCosts struct {
+	weights [costDimensionCount]float64
+	means   [costDimensionCount]float64
+	loads   map[uint64][costDimensionCount]float64
+	// flows are the read and write bytes rates of the stores, which are used
+	// to estimate the share of the disk IO of a region.
+	flows map[uint64]float64
+	costs map[uint64]float64
+}
+
+func newStoreCosts(weights [costDimensionCount]float64, stores []*core.StoreInfo,
+	storesLoads map[uint64][]float64, opInfluence operator.OpInfluence) *storeCosts {
+	c := &storeCosts{
+		weights: weights,
+		loads:   make(map[uint64][costDimensionCount]float64, len(stores)),
+		flows:   make(map[uint64]float64, len(stores)),
+		costs:   make(map[uint64]float64, len(stores)),
+	}
+	for _, store := range stores {
+		id := store.GetID()
+		if _, ok := c.loads[id]; ok {
+			continue
+		}
+		var loads [costDimensionCount]float64
+		used := float64(store.GetUsedSize())
+		if influence := opInfluence.GetStoreInfluence(id); influence != nil {
+			used += float64(influence.RegionSize * units.MiB)
+		}
+		loads[diskCost] = max(used, 0)
+		if storeLoads := storesLoads[id]; len(storeLoads) == int(utils.StoreStatCount) {
+			// The CPU usages of the stores and the regions are both measured in cores.
+			loads[cpuCost] = storeLoads[utils.StoreCPUUsage] / storeCPUUsagePerCore
+			loads[ioCost] = storeLoads[utils.StoreDiskReadRate] + storeLoads[utils.StoreDiskWriteRate]
+			loads[qpsCost] = storeLoads[utils.StoreReadQuery] + storeLoads[utils.StoreWriteQuery]
+			c.flows[id] = storeLoads[utils.StoreReadBytes] + storeLoads[utils.StoreWriteBytes]
+		}
+		c.loads[id] = loads
+		for i := range loads {
+			c.means[i] += loads[i]
+		}
+	}
+	if len(c.loads) == 0 {
+		return c
+	}
+	for i := range c.means {
+		c.means[i] /= float64(len(c.loads))
+	}
+	for id, loads := range c.loads {
+		c.costs[id] = c.weightedCost(loads)
+	}
+	return c
+}
+
+func (c *storeCosts) weightedCost(loads [costDimensionCount]float64) float64 {
+	var cost float64
+	for i := range loads {
+		if c.means[i] > 0 {
+			cost += c.weights[i] * loads[i] / c.means[i]
+		}
+	}
+	return cost
+}
+
+// cost returns the weighted cost of the store.
+func (c *storeCosts) cost(storeID uint64) float64 {
+	return c.costs[storeID]
+}
+
+// average returns the mean cost of the stores.
+func (c *storeCosts) average() float64 {
+	var sum float64
+	for i := range c.means {
+		if c.means[i] > 0 {
+			sum += c.weights[i]
+		}
+	}
+	return sum
+}
+
+// minCost returns the lowest cost of the stores.
+func (c *storeCosts) minCost() float64 {
+	first, res := true, 0.0
+	for _, cost := range c.costs {
+		if first || cost < res {
+			first, res = false, cost
+		}
+	}
+	return res
+}
+
+// regionCost estimates the cost which is moved from the store with the region.
+// Moving a peer moves the size and the write load, since the writes are applied
+// on every peer. Transferring a leader moves the CPU usage and the read load,
+// since the reads are served by the leader.
+func (c *storeCosts) regionCost(region *core.RegionInfo, store *core.StoreInfo, leader bool) float64 {
+	interval := float64(utils.RegionHeartBeatReportInterval)
+	if i := region.GetInterval(); i.GetEndTimestamp() > i.GetStartTimestamp() {
+		interval = float64(i.GetEndTimestamp() - i.GetStartTimestamp())
+	}
+	var loads [costDimensionCount]float64
+	var bytesRate float64
+	if leader {
+		loads[cpuCost] = float64(region.GetCPUUsage()) / regionCPUUsagePerCore
+		loads[qpsCost] = float64(region.GetReadQueryNum()) / interval
+		bytesRate = float64(region.GetBytesRead()) / interval
+	} else {
+		loads[diskCost] = float64(region.GetApproximateSize() * units.MiB)
+		loads[qpsCost] = float64(region.GetWriteQueryNum()) / interval
+		bytesRate = float64(region.GetBytesWritten()) / interval
+	}
+	if flow := c.flows[store.GetID()]; flow > 0 {
+		loads[ioCost] = c.loads[store.GetID()][ioCost] * min(bytesRate/flow, 1)
+	}
+	return c.weightedCost(loads)
+}
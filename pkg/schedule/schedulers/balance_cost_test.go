@@ -0,0 +1,158 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/operatorutil"
+)
+
+func TestBalanceCostConfig(t *testing.T) {
+	re := require.New(t)
+	conf := initBalanceCostSchedulerConfig()
+	re.NoError(conf.validate())
+	conf.CPUWeight = -1
+	re.Error(conf.validate())
+	conf.DiskWeight, conf.CPUWeight, conf.IOWeight, conf.QPSWeight = 0, 0, 0, 0
+	re.Error(conf.validate())
+	conf.QPSWeight = 1
+	re.NoError(conf.validate())
+	conf.ToleranceRatio = 1
+	re.Error(conf.validate())
+}
+
+func TestBalanceCostStoreCosts(t *testing.T) {
+	re := require.New(t)
+	newStore := func(id, used uint64) *core.StoreInfo {
+		return core.NewStoreInfo(&metapb.Store{Id: id}, core.SetStoreStats(&pdpb.StoreStats{UsedSize: used}))
+	}
+	newLoads := func(cpu, qps, flow float64) []float64 {
+		loads := make([]float64, utils.StoreStatCount)
+		loads[utils.StoreCPUUsage] = cpu
+		loads[utils.StoreReadQuery] = qps
+		loads[utils.StoreReadBytes] = flow
+		loads[utils.StoreDiskReadRate] = flow * 2
+		return loads
+	}
+	stores := []*core.StoreInfo{
+		newStore(1, 300*units.MiB),
+		newStore(2, 100*units.MiB),
+		newStore(3, 200*units.MiB),
+	}
+	storesLoads := map[uint64][]float64{
+		1: newLoads(100, 300, 1000),
+		2: newLoads(300, 100, 1000),
+		3: newLoads(200, 200, 1000),
+	}
+	weights := [costDimensionCount]float64{diskCost: 1, cpuCost: 1, ioCost: 1, qpsCost: 2}
+	costs := newStoreCosts(weights, stores, storesLoads, operator.OpInfluence{})
+	// Every dimension is normalized by its mean.
+	re.InDelta(1.5+0.5+1+3, costs.cost(1), 1e-6)
+	re.InDelta(0.5+1.5+1+1, costs.cost(2), 1e-6)
+	re.InDelta(1+1+1+2, costs.cost(3), 1e-6)
+	re.InDelta(5, costs.average(), 1e-6)
+	re.InDelta(4, costs.minCost(), 1e-6)
+
+	// Moving a peer moves the size and the write load.
+	region := core.NewRegionInfo(&metapb.Region{Id: 1}, nil,
+		core.SetApproximateSize(20), core.SetReadQuery(600), core.SetReadBytes(6000), core.SetReportInterval(0, 60))
+	re.InDelta(0.1, costs.regionCost(region, stores[0], false), 1e-6)
+	// Transferring a leader moves the read load, and the disk IO is estimated by the flow.
+	re.InDelta(2*10.0/200+0.1, costs.regionCost(region, stores[0], true), 1e-6)
+	// The CPU usage of the region in millicores is compared with the one of the
+	// store in percentage, half of a core is a quarter of the mean of 2 cores.
+	region = core.NewRegionInfo(&metapb.Region{Id: 2}, nil, core.SetCPUUsage(500), core.SetReportInterval(0, 60))
+	re.InDelta(0.25, costs.regionCost(region, stores[0], true), 1e-6)
+	re.InDelta(0, costs.regionCost(region, stores[0], false), 1e-6)
+
+	// The dimension without load is ignored.
+	weights[cpuCost] = 0
+	costs = newStoreCosts(weights, stores, nil, operator.OpInfluence{})
+	re.InDelta(1.5, costs.cost(1), 1e-6)
+	re.InDelta(1, costs.average(), 1e-6)
+
+	re.True(shouldBalanceCost(3, 1, 0.5, 0.1))
+	re.False(shouldBalanceCost(3, 1, 1.5, 0.1))
+	re.False(shouldBalanceCost(1.05, 1, 0.01, 0.1))
+}
+
+func TestBalanceCostSchedulerMoveRegion(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	sb, err := CreateScheduler(types.BalanceCostScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceCostScheduler, []string{"", ""}))
+	re.NoError(err)
+
+	// Only the disk usage is different, the store 4 has the lowest cost.
+	tc.AddRegionStore(1, 40)
+	tc.AddRegionStore(2, 20)
+	tc.AddRegionStore(3, 20)
+	tc.AddRegionStore(4, 0)
+	for i := uint64(1); i <= 5; i++ {
+		tc.AddLeaderRegion(i, 1, 2, 3)
+	}
+	ops, plans := sb.Schedule(tc, true)
+	re.Len(ops, 1)
+	re.NotEmpty(plans)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpKind(0), 1, 4)
+
+	// The costs are balanced.
+	tc.AddRegionStore(1, 20)
+	tc.AddRegionStore(4, 20)
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+}
+
+func TestBalanceCostSchedulerTransferLeader(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	sb, err := CreateScheduler(types.BalanceCostScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceCostScheduler, []string{"", ""}))
+	re.NoError(err)
+
+	// The disk usage is balanced, but the reads are concentrated on the store 1.
+	tc.AddRegionStore(1, 10)
+	tc.AddRegionStore(2, 10)
+	tc.AddRegionStore(3, 10)
+	tc.UpdateStorageReadQuery(1, 100*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadQuery(2, 10*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadQuery(3, 10*utils.StoreHeartBeatReportInterval)
+	for i := uint64(1); i <= 10; i++ {
+		region := tc.AddLeaderRegion(i, 1, 2, 3)
+		tc.PutRegion(region.Clone(core.SetReadQuery(600), core.SetReportInterval(0, 60)))
+	}
+	// Each region holds 3 peers, so no peer can be moved.
+	ops, _ := sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(operator.OpLeader, ops[0].Kind()&operator.OpLeader)
+	re.Equal(uint64(1), ops[0].Step(0).(operator.TransferLeader).FromStore)
+
+	// No operator is created when the reads are balanced.
+	tc.UpdateStorageReadQuery(1, 10*utils.StoreHeartBeatReportInterval)
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+}
@@ -164,6 +164,9 @@ type balanceLeaderScheduler struct {
 	handler       http.Handler
 	filters       []filter.Filter
 	filterCounter *filter.Counter
+	// isZoneLeaderActive returns whether zone-leader-scheduler is running, it's set by the
+	// scheduler controller.
+	isZoneLeaderActive func() bool
 }
 
 // newBalanceLeaderScheduler creates a scheduler that tends to keep leaders on
@@ -425,12 +428,22 @@ func makeInfluence(op *operator.Operator, plan *solver, usedRegions map[uint64]s
 	}
 }
 
+// regionFilters returns the filters of the regions whose leaders are transferred. The
+// regions with leader zone weights are left to zone-leader-scheduler if it is running.
+func (s *balanceLeaderScheduler) regionFilters(solver *solver) []filter.RegionFilter {
+	filters := []filter.RegionFilter{filter.NewRegionPendingFilter(), filter.NewRegionDownFilter()}
+	if s.isZoneLeaderActive != nil && s.isZoneLeaderActive() {
+		filters = append(filters, filter.NewRegionLabelFilter(solver.GetRegionLabeler(), zoneLeaderWeightsLabel))
+	}
+	return filters
+}
+
 // transferLeaderOut transfers leader from the source store.
 // It randomly selects a health region from the source store, then picks
 // the best follower peer and transfers the leader.
 func (s *balanceLeaderScheduler) transferLeaderOut(solver *solver, collector *plan.Collector) *operator.Operator {
 	solver.Region = filter.SelectOneRegion(solver.RandLeaderRegions(solver.sourceStoreID(), s.conf.getRanges()),
-		collector, filter.NewRegionPendingFilter(), filter.NewRegionDownFilter())
+		collector, s.regionFilters(solver)...)
 	if solver.Region == nil {
 		log.Debug("store has no leader", zap.String("scheduler", s.GetName()), zap.Uint64("store-id", solver.sourceStoreID()))
 		balanceLeaderNoLeaderRegionCounter.Inc()
@@ -474,7 +487,7 @@ func (s *balanceLeaderScheduler) transferLeaderOut(solver *solver, collector *pl
 // the worst follower peer and transfers the leader.
 func (s *balanceLeaderScheduler) transferLeaderIn(solver *solver, collector *plan.Collector) *operator.Operator {
 	solver.Region = filter.SelectOneRegion(solver.RandFollowerRegions(solver.targetStoreID(), s.conf.getRanges()),
-		nil, filter.NewRegionPendingFilter(), filter.NewRegionDownFilter())
+		nil, s.regionFilters(solver)...)
 	if solver.Region == nil {
 		log.Debug("store has no follower", zap.String("scheduler", s.GetName()), zap.Uint64("store-id", solver.targetStoreID()))
 		balanceLeaderNoFollowerRegionCounter.Inc()
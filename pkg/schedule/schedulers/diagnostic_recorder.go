@@ -50,6 +50,7 @@ const (
 var DiagnosableSummaryFunc = map[types.CheckerSchedulerType]plan.Summary{
 	types.BalanceRegionScheduler: plan.BalancePlanSummary,
 	types.BalanceLeaderScheduler: plan.BalancePlanSummary,
+	types.BalanceCostScheduler:   plan.BalancePlanSummary,
 }
 
 // DiagnosticRecorder is used to manage diagnostic for one scheduler.
@@ -173,7 +174,7 @@ func (d *DiagnosticRecorder) analyze(ops []*operator.Operator, plans []plan.Plan
 	}
 	// TODO: support more schedulers and checkers
 	switch d.schedulerType {
-	case types.BalanceRegionScheduler, types.BalanceLeaderScheduler:
+	case types.BalanceRegionScheduler, types.BalanceLeaderScheduler, types.BalanceCostScheduler:
 		if len(ops) != 0 {
 			res.Status = Scheduling
 			return res
@@ -0,0 +1,114 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+)
+
+// dryRunScheduler is implemented by the schedulers which keep state across the scheduling
+// rounds, such as the pending influence of the hot region scheduler. A dry run of them must
+// not change the state, otherwise it affects the real scheduling.
+type dryRunScheduler interface {
+	scheduleDryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan)
+}
+
+// DryRunResult is the output of running a scheduler once without executing
+// the operators it produces.
+type DryRunResult struct {
+	Name      string `json:"name"`
+	Timestamp uint64 `json:"timestamp"`
+	// Allowed shows whether the scheduler would be allowed to schedule now,
+	// e.g. it is false if the scheduler is paused or reaches its limit.
+	Allowed   bool              `json:"allowed"`
+	Operators []*DryRunOperator `json:"operators"`
+	// Summary and StoreStatus explain the plans of the schedulers which
+	// support diagnosis, such as why a store is not selected.
+	Summary     string            `json:"summary,omitempty"`
+	StoreStatus map[uint64]string `json:"store-status,omitempty"`
+}
+
+// DryRunOperator describes an operator proposed by a dry-run.
+type DryRunOperator struct {
+	Desc      string                              `json:"desc"`
+	Brief     string                              `json:"brief"`
+	RegionID  uint64                              `json:"region-id"`
+	Kind      string                              `json:"kind"`
+	Steps     []string                            `json:"steps"`
+	Influence map[uint64]*operator.StoreInfluence `json:"influence"`
+	// Denied is true if the region is labeled to deny scheduling, so the
+	// operator would be dropped by the schedule controller.
+	Denied bool `json:"denied,omitempty"`
+}
+
+func newDryRunResult(name string, allowed bool, cluster sche.SchedulerCluster, ops []*operator.Operator, plans []plan.Plan, summaryFunc plan.Summary) *DryRunResult {
+	result := &DryRunResult{
+		Name:      name,
+		Timestamp: uint64(time.Now().Unix()),
+		Allowed:   allowed,
+		Operators: make([]*DryRunOperator, 0, len(ops)),
+	}
+	labelMgr := cluster.GetRegionLabeler()
+	for _, op := range ops {
+		region := cluster.GetRegion(op.RegionID())
+		influence := operator.NewOpInfluence()
+		op.TotalInfluence(*influence, region)
+		steps := make([]string, 0, op.Len())
+		for i := range op.Len() {
+			steps = append(steps, op.Step(i).String())
+		}
+		result.Operators = append(result.Operators, &DryRunOperator{
+			Desc:      op.Desc(),
+			Brief:     op.Brief(),
+			RegionID:  op.RegionID(),
+			Kind:      op.Kind().String(),
+			Steps:     steps,
+			Influence: influence.StoresInfluence,
+			Denied:    region != nil && labelMgr != nil && labelMgr.ScheduleDisabled(region),
+		})
+		// The operator will never be executed, cancel it to make sure that
+		// the influence kept by the scheduler can be released in time.
+		_ = op.Cancel(operator.DryRun)
+	}
+	if summaryFunc == nil || len(plans) == 0 {
+		return result
+	}
+	storeStatus, _, err := summaryFunc(plans)
+	if err != nil {
+		result.Summary = err.Error()
+		return result
+	}
+	result.StoreStatus = make(map[uint64]string, len(storeStatus))
+	statusCounter := make(map[string]uint64)
+	for storeID, status := range storeStatus {
+		result.StoreStatus[storeID] = status.String()
+		statusCounter[status.String()]++
+	}
+	statuses := make([]string, 0, len(statusCounter))
+	for status := range statusCounter {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		result.Summary += fmt.Sprintf("%d store(s) %s; ", statusCounter[status], status)
+	}
+	return result
+}
@@ -77,6 +77,9 @@ type baseHotScheduler struct {
 	r               *rand.Rand
 	updateReadTime  time.Time
 	updateWriteTime time.Time
+	// getForecastConfidence returns the minimum confidence of the prediction and whether to plan on the predicted loads.
+	// It is nil if the scheduler does not support forecasting.
+	getForecastConfidence func() (float64, bool)
 }
 
 func newBaseHotScheduler(
@@ -108,9 +111,17 @@ func (s *baseHotScheduler) prepareForBalance(typ resourceType, cluster sche.Sche
 
 	prepare := func(regionStats map[uint64][]*statistics.HotPeerStat, rw utils.RWType, resource constant.ResourceKind) {
 		ty := buildResourceType(rw, resource)
+		loads := storesLoads
+		if s.getForecastConfidence != nil {
+			if confidence, enabled := s.getForecastConfidence(); enabled {
+				// Plan on the predicted loads of the next interval, so that the regions which are getting hotter
+				// are scheduled in advance.
+				loads, regionStats = statistics.PredictHotPeers(storesLoads, regionStats, rw, confidence)
+			}
+		}
 		s.stLoadInfos[ty] = statistics.SummaryStoresLoad(
 			storeInfos,
-			storesLoads,
+			loads,
 			s.stHistoryLoads,
 			regionStats,
 			isTraceRegionFlow,
@@ -189,11 +200,15 @@ type hotScheduler struct {
 	// config of hot scheduler
 	conf                *hotRegionSchedulerConfig
 	searchRevertRegions [resourceTypeLen]bool // Whether to search revert regions.
+	// dryRun is true while the scheduler runs without executing the operators, the pending
+	// influence of the operators is not recorded so they never block the real scheduling.
+	dryRun bool
 }
 
 func newHotScheduler(opController *operator.Controller, conf *hotRegionSchedulerConfig) *hotScheduler {
 	base := newBaseHotScheduler(opController, conf.getHistorySampleDuration(),
 		conf.getHistorySampleInterval(), conf)
+	base.getForecastConfidence = conf.getForecastConfidence
 	ret := &hotScheduler{
 		baseHotScheduler: base,
 		conf:             conf,
@@ -239,6 +254,8 @@ func (s *hotScheduler) ReloadConfig() error {
 	s.conf.RankFormulaVersion = newCfg.RankFormulaVersion
 	s.conf.ForbidRWType = newCfg.ForbidRWType
 	s.conf.SplitThresholds = newCfg.SplitThresholds
+	s.conf.EnableForecast = newCfg.EnableForecast
+	s.conf.ForecastConfidence = newCfg.ForecastConfidence
 	s.conf.HistorySampleDuration = newCfg.HistorySampleDuration
 	s.conf.HistorySampleInterval = newCfg.HistorySampleInterval
 	return nil
@@ -272,12 +289,26 @@ func (s *hotScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
 func (s *hotScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
 	hotSchedulerCounter.Inc()
 	typ := s.randomType()
-	return s.dispatch(typ, cluster), nil
+	return s.dispatch(typ, cluster, false), nil
 }
 
-func (s *hotScheduler) dispatch(typ resourceType, cluster sche.SchedulerCluster) (ops []*operator.Operator) {
+// scheduleDryRun implements the dryRunScheduler interface.
+func (s *hotScheduler) scheduleDryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	typ := s.randomType()
+	return s.dispatch(typ, cluster, true), nil
+}
+
+func (s *hotScheduler) dispatch(typ resourceType, cluster sche.SchedulerCluster, dryRun bool) (ops []*operator.Operator) {
 	s.Lock()
 	defer s.Unlock()
+	if dryRun {
+		searchRevertRegions := s.searchRevertRegions
+		s.dryRun = true
+		defer func() {
+			s.searchRevertRegions = searchRevertRegions
+			s.dryRun = false
+		}()
+	}
 	s.updateHistoryLoadConfig(s.conf.getHistorySampleDuration(), s.conf.getHistorySampleInterval())
 	s.prepareForBalance(typ, cluster)
 	// isForbidRWType can not be move earlier to support to use api and metrics.
@@ -311,6 +342,9 @@ func (s *hotScheduler) tryAddPendingInfluence(op *operator.Operator, srcStore []
 		pendingOpFailsStoreCounter.Inc()
 		return false
 	}
+	if s.dryRun {
+		return true
+	}
 
 	influence := newPendingInfluence(op, srcStore, dstStore, infl, maxZombieDur)
 	s.regionPendings[regionID] = influence
@@ -79,6 +79,8 @@ func initHotRegionScheduleConfig() *hotRegionSchedulerConfig {
 			RankFormulaVersion:     "v2",
 			ForbidRWType:           "none",
 			SplitThresholds:        0.2,
+			EnableForecast:         false,
+			ForecastConfidence:     0.8,
 			HistorySampleDuration:  typeutil.NewDuration(statistics.DefaultHistorySampleDuration),
 			HistorySampleInterval:  typeutil.NewDuration(statistics.DefaultHistorySampleInterval),
 		},
@@ -110,6 +112,8 @@ func (conf *hotRegionSchedulerConfig) getValidConf() *hotRegionSchedulerParam {
 		RankFormulaVersion:     conf.getRankFormulaVersionLocked(),
 		ForbidRWType:           conf.getForbidRWTypeLocked(),
 		SplitThresholds:        conf.SplitThresholds,
+		EnableForecast:         conf.EnableForecast,
+		ForecastConfidence:     conf.ForecastConfidence,
 		HistorySampleDuration:  conf.HistorySampleDuration,
 		HistorySampleInterval:  conf.HistorySampleInterval,
 	}
@@ -151,6 +155,10 @@ type hotRegionSchedulerParam struct {
 	ForbidRWType string `json:"forbid-rw-type,omitempty"`
 	// SplitThresholds is the threshold to split hot region if the first priority flow of on hot region exceeds it.
 	SplitThresholds float64 `json:"split-thresholds"`
+	// EnableForecast controls whether to plan on the predicted loads of the next interval instead of the current loads.
+	EnableForecast bool `json:"enable-forecast,string"`
+	// ForecastConfidence is the minimum confidence of the prediction to use the predicted loads of a hot peer.
+	ForecastConfidence float64 `json:"forecast-confidence"`
 
 	HistorySampleDuration typeutil.Duration `json:"history-sample-duration"`
 	HistorySampleInterval typeutil.Duration `json:"history-sample-interval"`
@@ -366,6 +374,12 @@ func (conf *hotRegionSchedulerConfig) getSplitThresholds() float64 {
 	return conf.SplitThresholds
 }
 
+func (conf *hotRegionSchedulerConfig) getForecastConfidence() (float64, bool) {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ForecastConfidence, conf.EnableForecast
+}
+
 func (conf *hotRegionSchedulerConfig) getForbidRWTypeLocked() string {
 	switch conf.ForbidRWType {
 	case utils.Read.String(), utils.Write.String():
@@ -431,6 +445,9 @@ func (conf *hotRegionSchedulerParam) validateLocked() error {
 	if conf.SplitThresholds < 0.01 || conf.SplitThresholds > 1.0 {
 		return errs.ErrSchedulerConfig.FastGenByArgs("invalid split-thresholds, should be in range [0.01, 1.0]")
 	}
+	if conf.ForecastConfidence < 0 || conf.ForecastConfidence > 1.0 {
+		return errs.ErrSchedulerConfig.FastGenByArgs("invalid forecast-confidence, should be in range [0, 1.0]")
+	}
 	return nil
 }
 
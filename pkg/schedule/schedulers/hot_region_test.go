@@ -1261,6 +1261,103 @@ func TestHotReadRegionScheduleByteRateOnly(t *testing.T) {
 	clearPendingInfluence(hb)
 }
 
+func TestHotReadRegionScheduleDryRun(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	scheduler, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb := scheduler.(*hotScheduler)
+	hb.conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+	hb.conf.setHistorySampleDuration(0)
+
+	tc.AddRegionStore(1, 3)
+	tc.AddRegionStore(2, 2)
+	tc.AddRegionStore(3, 2)
+	tc.AddRegionStore(4, 2)
+	tc.AddRegionStore(5, 0)
+	tc.UpdateStorageReadBytes(1, 7.5*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(2, 4.9*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(3, 3.7*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(4, 6*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(5, 0)
+	addRegionInfo(tc, utils.Read, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 512 * units.KiB, 0, 0},
+		{2, []uint64{2, 1, 3}, 511 * units.KiB, 0, 0},
+		{3, []uint64{1, 2, 3}, 510 * units.KiB, 0, 0},
+	})
+	testutil.Eventually(re, func() bool {
+		return tc.IsRegionHot(tc.GetRegion(1))
+	})
+
+	// The dry run proposes the operator without recording the pending influence.
+	ops, _ := hb.scheduleDryRun(tc)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpHotRegion, 1, 5)
+	re.Empty(hb.regionPendings)
+	// The dry run doesn't affect the following scheduling.
+	ops, _ = hb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpHotRegion, 1, 5)
+	re.Contains(hb.regionPendings, uint64(1))
+}
+
+func TestHotReadRegionScheduleWithForecast(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	scheduler, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb := scheduler.(*hotScheduler)
+	hb.conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+	hb.conf.setHistorySampleDuration(0)
+
+	tc.AddRegionStore(1, 3)
+	tc.AddRegionStore(2, 2)
+	tc.AddRegionStore(3, 2)
+	tc.AddRegionStore(4, 2)
+	tc.AddRegionStore(5, 0)
+	tc.UpdateStorageReadBytes(1, 7.5*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(2, 4.9*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(3, 3.7*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(4, 6*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(5, 0)
+
+	// The read bytes rate of region 1 is falling linearly, it is predicted to be 0 in the next interval.
+	for _, rate := range []float64{4, 3, 2, 1} {
+		addRegionInfo(tc, utils.Read, []testRegionInfo{
+			{1, []uint64{1, 2, 3}, rate * units.MiB, 0, 0},
+		})
+	}
+	testutil.Eventually(re, func() bool {
+		return tc.IsRegionHot(tc.GetRegion(1))
+	})
+	stats := tc.HotCache.GetHotPeerStats(utils.Read, 0)
+	re.Len(stats[1], 1)
+	re.Zero(stats[1][0].PredictedLoads[utils.ByteDim])
+	re.InDelta(1.0, stats[1][0].PredictionConfidence, 1e-6)
+	currentLoad := stats[1][0].GetLoad(utils.ByteDim)
+	re.Positive(currentLoad)
+
+	// Planning on the predicted loads, region 1 is not worth moving out of store 1.
+	hb.conf.EnableForecast = true
+	hb.conf.ForecastConfidence = 0.8
+	ops, _ := hb.Schedule(tc, false)
+	re.Empty(ops)
+	re.InDelta(7.5*units.MiB-currentLoad, hb.stLoadInfos[readLeader][1].LoadPred.Current.Loads[utils.ByteDim], 1)
+
+	// Planning on the current loads, region 1 is moved out of the hottest store 1.
+	hb.conf.EnableForecast = false
+	hb.updateReadTime = time.Now().Add(-time.Second)
+	ops, _ = hb.Schedule(tc, false)
+	re.NotEmpty(ops)
+	re.Equal(uint64(1), ops[0].RegionID())
+	re.InDelta(7.5*units.MiB, hb.stLoadInfos[readLeader][1].LoadPred.Current.Loads[utils.ByteDim], 1)
+	clearPendingInfluence(hb)
+}
+
 func TestHotReadRegionScheduleWithQuery(t *testing.T) {
 	re := require.New(t)
 
@@ -2442,6 +2539,19 @@ func TestConfigValidation(t *testing.T) {
 	hc.SplitThresholds = 1.1
 	err = hc.validateLocked()
 	re.Error(err)
+	hc.SplitThresholds = 0.2
+
+	// forecast
+	hc.EnableForecast = true
+	hc.ForecastConfidence = 0.5
+	err = hc.validateLocked()
+	re.NoError(err)
+	hc.ForecastConfidence = -0.1
+	err = hc.validateLocked()
+	re.Error(err)
+	hc.ForecastConfidence = 1.1
+	err = hc.validateLocked()
+	re.Error(err)
 }
 
 // ref https://github.com/tikv/pd/issues/5701
@@ -27,6 +27,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/keyutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
 var registerOnce sync.Once
@@ -624,4 +625,96 @@ func schedulersRegister() {
 		conf.init(sche.GetName(), storage, conf)
 		return sche, nil
 	})
+
+	// balance cost
+	RegisterSliceDecoderBuilder(types.BalanceCostScheduler, func(args []string) ConfigDecoder {
+		return func(v any) error {
+			conf, ok := v.(*balanceCostSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			ranges, err := getKeyRanges(args)
+			if err != nil {
+				return err
+			}
+			conf.Ranges = ranges
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.BalanceCostScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := initBalanceCostSchedulerConfig()
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		sche := newBalanceCostScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
+	// zone leader
+	RegisterSliceDecoderBuilder(types.ZoneLeaderScheduler, func([]string) ConfigDecoder {
+		return func(v any) error {
+			if _, ok := v.(*zoneLeaderSchedulerConfig); !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.ZoneLeaderScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := initZoneLeaderSchedulerConfig()
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		sche := newZoneLeaderScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
+	// remote plugin
+	RegisterSliceDecoderBuilder(types.RemotePluginScheduler, func(args []string) ConfigDecoder {
+		return func(v any) error {
+			if len(args) < 2 {
+				return errs.ErrSchedulerConfig.FastGenByArgs("plugin name and address")
+			}
+			conf, ok := v.(*remotePluginSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			plugin := &remotePluginConfig{
+				Address: args[1],
+				Timeout: typeutil.NewDuration(defaultRemotePluginTimeout),
+			}
+			if len(args) > 2 {
+				timeout, err := time.ParseDuration(args[2])
+				if err != nil {
+					return errs.ErrSchedulerConfig.FastGenByArgs(err.Error())
+				}
+				plugin.Timeout = typeutil.NewDuration(timeout)
+			}
+			if err := plugin.validate(); err != nil {
+				return errs.ErrSchedulerConfig.FastGenByArgs(err.Error())
+			}
+			conf.Plugins[args[0]] = plugin
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.RemotePluginScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, removeSchedulerCb ...func(string) error) (Scheduler, error) {
+		conf := &remotePluginSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+			Plugins:         make(map[string]*remotePluginConfig),
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		conf.removeSchedulerCb = removeSchedulerCb[0]
+		sche := newRemotePluginScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
 }
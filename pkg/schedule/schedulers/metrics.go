@@ -226,6 +226,18 @@ func balanceRangeCounterWithEvent(event string) prometheus.Counter {
 	return schedulerCounter.WithLabelValues(types.BalanceRangeScheduler.String(), event)
 }
 
+func balanceCostCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.BalanceCostScheduler.String(), event)
+}
+
+func zoneLeaderCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.ZoneLeaderScheduler.String(), event)
+}
+
+func remotePluginCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.RemotePluginScheduler.String(), event)
+}
+
 // WithLabelValues is a heavy operation, define variable to avoid call it every time.
 var (
 	balanceLeaderScheduleCounter         = balanceLeaderCounterWithEvent("schedule")
@@ -356,4 +368,29 @@ var (
 	balanceRangeCreateOpFailCounter  = balanceRangeCounterWithEvent("create-operator-fail")
 	balanceRangeNoReplacementCounter = balanceRangeCounterWithEvent("no-replacement")
 	balanceRangeNoJobCounter         = balanceRangeCounterWithEvent("no-job")
+
+	balanceCostScheduleCounter     = balanceCostCounterWithEvent("schedule")
+	balanceCostNoRegionCounter     = balanceCostCounterWithEvent("no-region")
+	balanceCostNoLeaderCounter     = balanceCostCounterWithEvent("no-leader")
+	balanceCostNoLoadCounter       = balanceCostCounterWithEvent("no-load")
+	balanceCostSkipCounter         = balanceCostCounterWithEvent("skip")
+	balanceCostCreateOpFailCounter = balanceCostCounterWithEvent("create-operator-fail")
+	balanceCostNewRegionOpCounter  = balanceCostCounterWithEvent("new-region-operator")
+	balanceCostNewLeaderOpCounter  = balanceCostCounterWithEvent("new-leader-operator")
+
+	zoneLeaderScheduleCounter       = zoneLeaderCounterWithEvent("schedule")
+	zoneLeaderNoRegionCounter       = zoneLeaderCounterWithEvent("no-region")
+	zoneLeaderInvalidWeightsCounter = zoneLeaderCounterWithEvent("invalid-weights")
+	zoneLeaderNoTargetCounter       = zoneLeaderCounterWithEvent("no-target-store")
+	zoneLeaderCreateOpFailCounter   = zoneLeaderCounterWithEvent("create-operator-fail")
+	zoneLeaderNewOperatorCounter    = zoneLeaderCounterWithEvent("new-operator")
+
+	remotePluginCounter             = remotePluginCounterWithEvent("schedule")
+	remotePluginCallFailCounter     = remotePluginCounterWithEvent("call-plugin-fail")
+	remotePluginRoundRunningCounter = remotePluginCounterWithEvent("round-running")
+	remotePluginNoRegionCounter     = remotePluginCounterWithEvent("no-region")
+	remotePluginStaleEpochCounter   = remotePluginCounterWithEvent("stale-epoch")
+	remotePluginFilteredCounter     = remotePluginCounterWithEvent("filtered")
+	remotePluginCreateOpFailCounter = remotePluginCounterWithEvent("create-operator-fail")
+	remotePluginNewOperatorCounter  = remotePluginCounterWithEvent("new-operator")
 )
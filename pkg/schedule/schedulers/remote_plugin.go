@@ -0,0 +1,651 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/remoteplugin"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/grpcutil"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	defaultRemotePluginTimeout = 3 * time.Second
+	lastPluginDeleteInfo       = "The last plugin has been deleted"
+	// remotePluginSnapshotRegionLimit is the max count of the regions in a snapshot.
+	// If there are more regions, the next snapshot continues from where the last one
+	// stops, so the plugins see all the regions in several rounds.
+	remotePluginSnapshotRegionLimit = 64 * 1024
+)
+
+type remotePluginConfig struct {
+	// Address is the URL of the plugin, e.g. http://127.0.0.1:20190.
+	Address string `json:"address"`
+	// Timeout is the max duration of one call to the plugin, including
+	// streaming the snapshot.
+	Timeout typeutil.Duration `json:"timeout"`
+}
+
+func (conf *remotePluginConfig) validate() error {
+	u, err := url.Parse(conf.Address)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid plugin address %q", conf.Address)
+	}
+	if conf.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout should be positive")
+	}
+	return nil
+}
+
+type remotePluginSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// Plugins are the plugins called by the scheduler, keyed by the name.
+	Plugins           map[string]*remotePluginConfig `json:"plugins"`
+	removeSchedulerCb func(string) error
+}
+
+func (conf *remotePluginSchedulerConfig) clone() *remotePluginSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &remotePluginSchedulerConfig{Plugins: conf.clonePluginsLocked()}
+}
+
+func (conf *remotePluginSchedulerConfig) clonePluginsLocked() map[string]*remotePluginConfig {
+	plugins := make(map[string]*remotePluginConfig, len(conf.Plugins))
+	for name, plugin := range conf.Plugins {
+		p := *plugin
+		plugins[name] = &p
+	}
+	return plugins
+}
+
+func (conf *remotePluginSchedulerConfig) getPlugins() map[string]*remotePluginConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.clonePluginsLocked()
+}
+
+func (conf *remotePluginSchedulerConfig) update(name string, plugin *remotePluginConfig) error {
+	conf.Lock()
+	defer conf.Unlock()
+	old, exist := conf.Plugins[name]
+	conf.Plugins[name] = plugin
+	if err := conf.save(); err != nil {
+		if exist {
+			conf.Plugins[name] = old
+		} else {
+			delete(conf.Plugins, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (conf *remotePluginSchedulerConfig) delete(name string) (any, error) {
+	conf.Lock()
+	plugin, ok := conf.Plugins[name]
+	if !ok {
+		conf.Unlock()
+		return nil, errs.ErrScheduleConfigNotExist.FastGenByArgs()
+	}
+	delete(conf.Plugins, name)
+	if err := conf.save(); err != nil {
+		conf.Plugins[name] = plugin
+		conf.Unlock()
+		return nil, err
+	}
+	last := len(conf.Plugins) == 0
+	conf.Unlock()
+	if !last {
+		return nil, nil
+	}
+	if err := conf.removeSchedulerCb(types.RemotePluginScheduler.String()); err != nil {
+		if !errors.ErrorEqual(err, errs.ErrSchedulerNotFound.FastGenByArgs()) {
+			_ = conf.update(name, plugin)
+		}
+		return nil, err
+	}
+	return lastPluginDeleteInfo, nil
+}
+
+type remotePluginHandler struct {
+	conf *remotePluginSchedulerConfig
+	rd   *render.Render
+}
+
+func newRemotePluginHandler(conf *remotePluginSchedulerConfig) http.Handler {
+	h := &remotePluginHandler{
+		conf: conf,
+		rd:   render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", h.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", h.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/delete/{name}", h.deleteConfig).Methods(http.MethodDelete)
+	return router
+}
+
+func (h *remotePluginHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	defer r.Body.Close()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var input struct {
+		Name    string `json:"plugin_name"`
+		Address string `json:"address"`
+		Timeout string `json:"timeout"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.Name == "" {
+		h.rd.JSON(w, http.StatusBadRequest, "missing plugin name")
+		return
+	}
+	plugin := &remotePluginConfig{Address: input.Address, Timeout: typeutil.NewDuration(defaultRemotePluginTimeout)}
+	if old, ok := h.conf.getPlugins()[input.Name]; ok {
+		plugin = old
+		if input.Address != "" {
+			plugin.Address = input.Address
+		}
+	}
+	if input.Timeout != "" {
+		timeout, err := time.ParseDuration(input.Timeout)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		plugin.Timeout = typeutil.NewDuration(timeout)
+	}
+	if err := plugin.validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.conf.update(input.Name, plugin); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.Info("remote-plugin-scheduler update config", zap.ByteString("config", data))
+	h.rd.JSON(w, http.StatusOK, "The plugin has been applied.")
+}
+
+func (h *remotePluginHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := h.conf.clone()
+	h.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (h *remotePluginHandler) deleteConfig(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.conf.delete(mux.Vars(r)["name"])
+	if err != nil {
+		if errors.ErrorEqual(err, errs.ErrSchedulerNotFound.FastGenByArgs()) || errors.ErrorEqual(err, errs.ErrScheduleConfigNotExist.FastGenByArgs()) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, resp)
+}
+
+type remotePluginScheduler struct {
+	*BaseScheduler
+	conf    *remotePluginSchedulerConfig
+	handler http.Handler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    syncutil.Mutex
+	conns map[string]*grpc.ClientConn
+	// running is true while a round of the plugin calls is in progress.
+	running bool
+	// proposals are the proposals of the last finished round keyed by the plugin name,
+	// they are taken by the next Schedule call.
+	proposals map[string][]*remoteplugin.Proposal
+	// snapshotStartKey is the start key of the regions in the next snapshot.
+	snapshotStartKey []byte
+}
+
+// newRemotePluginScheduler creates a scheduler that calls the scheduler plugins running
+// out of process. The plugins receive a read-only snapshot of the cluster and propose
+// operators, which are validated by the filters before they are created.
+func newRemotePluginScheduler(opController *operator.Controller, conf *remotePluginSchedulerConfig) Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &remotePluginScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.RemotePluginScheduler, conf),
+		conf:          conf,
+		handler:       newRemotePluginHandler(conf),
+		ctx:           ctx,
+		cancel:        cancel,
+		conns:         make(map[string]*grpc.ClientConn),
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *remotePluginScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *remotePluginScheduler) EncodeConfig() ([]byte, error) {
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *remotePluginScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+	newCfg := &remotePluginSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.Plugins = newCfg.Plugins
+	return nil
+}
+
+// CleanConfig implements the Scheduler interface.
+func (s *remotePluginScheduler) CleanConfig(sche.SchedulerCluster) {
+	// Stop the running round before closing the connections.
+	s.cancel()
+	s.wg.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for address, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, address)
+	}
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *remotePluginScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	conf := cluster.GetSchedulerConfig()
+	leaderAllowed := s.OpController.OperatorCount(operator.OpLeader) < conf.GetLeaderScheduleLimit()
+	regionAllowed := s.OpController.OperatorCount(operator.OpRegion) < conf.GetRegionScheduleLimit()
+	if !leaderAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	if !regionAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	return leaderAllowed || regionAllowed
+}
+
+// Schedule implements the Scheduler interface.
+//
+// The plugins are called asynchronously, so a slow plugin never blocks the scheduling. Each
+// call starts a round which takes a snapshot of the cluster and calls the plugins one by one
+// in the background, and creates the operators proposed in the last finished round. The
+// proposals are validated against the current cluster, the ones based on stale regions or
+// breaking the filters are dropped. Only the scheduling loop starts the rounds, see scheduleDryRun.
+func (s *remotePluginScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
+	remotePluginCounter.Inc()
+	plugins := s.conf.getPlugins()
+	if len(plugins) == 0 {
+		return nil, nil
+	}
+	return s.createOperators(cluster, plugins, s.startRound(cluster, plugins)), nil
+}
+
+// scheduleDryRun implements the dryRunScheduler interface. It validates the proposals of the
+// last finished round without taking them, and doesn't start a new round, so that the dry run
+// doesn't affect the scheduling loop.
+func (s *remotePluginScheduler) scheduleDryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	plugins := s.conf.getPlugins()
+	if len(plugins) == 0 {
+		return nil, nil
+	}
+	s.mu.Lock()
+	proposals := s.proposals
+	s.mu.Unlock()
+	return s.createOperators(cluster, plugins, proposals), nil
+}
+
+// createOperators creates the operators of the valid proposals within the schedule limits.
+func (s *remotePluginScheduler) createOperators(cluster sche.SchedulerCluster, plugins map[string]*remotePluginConfig,
+	proposals map[string][]*remoteplugin.Proposal) []*operator.Operator {
+	names := make([]string, 0, len(proposals))
+	for name := range proposals {
+		// The proposals of the deleted plugins are dropped.
+		if _, ok := plugins[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	conf := cluster.GetSchedulerConfig()
+	budget := map[operator.OpKind]int{
+		operator.OpLeader: int(conf.GetLeaderScheduleLimit()) - int(s.OpController.OperatorCount(operator.OpLeader)),
+		operator.OpRegion: int(conf.GetRegionScheduleLimit()) - int(s.OpController.OperatorCount(operator.OpRegion)),
+	}
+	var ops []*operator.Operator
+	for _, name := range names {
+		for _, proposal := range proposals[name] {
+			op := s.createOperator(cluster, proposal)
+			if op == nil {
+				continue
+			}
+			kind := operator.OpLeader
+			if proposal.Kind == remoteplugin.MovePeer {
+				kind = operator.OpRegion
+			}
+			if budget[kind] <= 0 {
+				continue
+			}
+			budget[kind]--
+			op.SetAdditionalInfo("plugin", name)
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// startRound takes the proposals of the last finished round and starts a new round
+// if there is no running one.
+func (s *remotePluginScheduler) startRound(cluster sche.SchedulerCluster, plugins map[string]*remotePluginConfig) map[string][]*remoteplugin.Proposal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	proposals := s.proposals
+	s.proposals = nil
+	if s.running {
+		remotePluginRoundRunningCounter.Inc()
+		return proposals
+	}
+	if s.ctx.Err() != nil {
+		return proposals
+	}
+	s.running = true
+	startKey := s.snapshotStartKey
+	s.wg.Add(1)
+	go func() {
+		defer logutil.LogPanic()
+		defer s.wg.Done()
+		snapshot, nextKey := buildRemotePluginSnapshot(cluster, startKey, remotePluginSnapshotRegionLimit)
+		results := make(map[string][]*remoteplugin.Proposal, len(plugins))
+		for name, plugin := range plugins {
+			proposals, err := s.callPlugin(plugin, snapshot)
+			if err != nil {
+				log.Warn("failed to call the scheduler plugin", zap.String("plugin", name),
+					zap.String("address", plugin.Address), errs.ZapError(err))
+				remotePluginCallFailCounter.Inc()
+				continue
+			}
+			results[name] = proposals
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.running = false
+		s.proposals = results
+		s.snapshotStartKey = nextKey
+	}()
+	return proposals
+}
+
+func (s *remotePluginScheduler) isRoundRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+func (s *remotePluginScheduler) callPlugin(plugin *remotePluginConfig, snapshot *remoteplugin.Snapshot) ([]*remoteplugin.Proposal, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, plugin.Timeout.Duration)
+	defer cancel()
+	conn, err := s.getConn(ctx, plugin.Address)
+	if err != nil {
+		return nil, err
+	}
+	return remoteplugin.NewClient(conn).Schedule(ctx, snapshot)
+}
+
+func (s *remotePluginScheduler) getConn(ctx context.Context, address string) (*grpc.ClientConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conn, ok := s.conns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpcutil.GetClientConn(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[address] = conn
+	return conn, nil
+}
+
+// createOperator validates the proposal and creates the operator, it returns nil if the
+// proposal is invalid.
+func (s *remotePluginScheduler) createOperator(cluster sche.SchedulerCluster, proposal *remoteplugin.Proposal) *operator.Operator {
+	region := cluster.GetRegion(proposal.RegionID)
+	if region == nil {
+		remotePluginNoRegionCounter.Inc()
+		return nil
+	}
+	epoch := region.GetRegionEpoch()
+	if epoch.GetConfVer() != proposal.ConfVer || epoch.GetVersion() != proposal.Version {
+		remotePluginStaleEpochCounter.Inc()
+		return nil
+	}
+	for _, f := range []filter.RegionFilter{filter.NewRegionPendingFilter(), filter.NewRegionDownFilter()} {
+		if !f.Select(region).IsOK() {
+			remotePluginFilteredCounter.Inc()
+			return nil
+		}
+	}
+	var (
+		op  *operator.Operator
+		err error
+	)
+	switch proposal.Kind {
+	case remoteplugin.TransferLeader:
+		op, err = s.createTransferLeaderOperator(cluster, region, proposal)
+	case remoteplugin.MovePeer:
+		op, err = s.createMovePeerOperator(cluster, region, proposal)
+	default:
+		err = errors.Errorf("unknown operator kind %q", proposal.Kind)
+	}
+	if err != nil {
+		log.Debug("fail to create operator from the proposal", zap.String("scheduler", s.GetName()),
+			zap.Uint64("region-id", proposal.RegionID), zap.String("kind", string(proposal.Kind)), errs.ZapError(err))
+		remotePluginCreateOpFailCounter.Inc()
+		return nil
+	}
+	if op == nil {
+		remotePluginFilteredCounter.Inc()
+		return nil
+	}
+	op.SetPriorityLevel(constant.Medium)
+	op.Counters = append(op.Counters, remotePluginNewOperatorCounter)
+	return op
+}
+
+func (s *remotePluginScheduler) createTransferLeaderOperator(cluster sche.SchedulerCluster, region *core.RegionInfo,
+	proposal *remoteplugin.Proposal) (*operator.Operator, error) {
+	if _, ok := region.GetFollowers()[proposal.TargetStoreID]; !ok {
+		return nil, nil
+	}
+	source := cluster.GetStore(region.GetLeader().GetStoreId())
+	target := cluster.GetStore(proposal.TargetStoreID)
+	if source == nil || target == nil {
+		return nil, nil
+	}
+	conf := cluster.GetSchedulerConfig()
+	stateFilter := &filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.Medium}
+	if len(filter.SelectSourceStores([]*core.StoreInfo{source}, []filter.Filter{stateFilter}, conf, nil, nil)) == 0 {
+		return nil, nil
+	}
+	filters := []filter.Filter{
+		stateFilter,
+		filter.NewSpecialUseFilter(s.GetName()),
+		filter.NewPlacementLeaderSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, false),
+	}
+	if !filter.Target(conf, target, filters) {
+		return nil, nil
+	}
+	return operator.CreateTransferLeaderOperator(s.GetName(), cluster, region, target.GetID(), []uint64{}, operator.OpLeader)
+}
+
+func (s *remotePluginScheduler) createMovePeerOperator(cluster sche.SchedulerCluster, region *core.RegionInfo,
+	proposal *remoteplugin.Proposal) (*operator.Operator, error) {
+	oldPeer := region.GetStorePeer(proposal.SourceStoreID)
+	if oldPeer == nil {
+		return nil, nil
+	}
+	source := cluster.GetStore(proposal.SourceStoreID)
+	target := cluster.GetStore(proposal.TargetStoreID)
+	if source == nil || target == nil {
+		return nil, nil
+	}
+	conf := cluster.GetSchedulerConfig()
+	stateFilter := &filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Medium}
+	if len(filter.SelectSourceStores([]*core.StoreInfo{source}, []filter.Filter{stateFilter}, conf, nil, nil)) == 0 {
+		return nil, nil
+	}
+	filters := []filter.Filter{
+		stateFilter,
+		filter.NewExcludedFilter(s.GetName(), nil, region.GetStoreIDs()),
+		filter.NewSpecialUseFilter(s.GetName()),
+		filter.NewPlacementSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, nil),
+	}
+	if !filter.Target(conf, target, filters) {
+		return nil, nil
+	}
+	newPeer := &metapb.Peer{StoreId: target.GetID(), Role: oldPeer.GetRole()}
+	return operator.CreateMovePeerOperator(s.GetName(), cluster, region, operator.OpRegion, source.GetID(), newPeer)
+}
+
+// buildRemotePluginSnapshot takes a read-only snapshot of the cluster for the plugins. It
+// carries at most limit regions from the start key, wrapping around the end of the key
+// space, and returns the start key of the next snapshot.
+func buildRemotePluginSnapshot(cluster sche.SchedulerCluster, startKey []byte, limit int) (*remoteplugin.Snapshot, []byte) {
+	snapshot := &remoteplugin.Snapshot{}
+	for _, store := range cluster.GetStores() {
+		if store.IsRemoved() {
+			continue
+		}
+		labels := make(map[string]string, len(store.GetLabels()))
+		for _, label := range store.GetLabels() {
+			labels[label.GetKey()] = label.GetValue()
+		}
+		snapshot.Stores = append(snapshot.Stores, &remoteplugin.Store{
+			ID:            store.GetID(),
+			Address:       store.GetAddress(),
+			Labels:        labels,
+			State:         store.GetNodeState().String(),
+			Disconnected:  store.IsDisconnected(),
+			LastHeartbeat: store.GetLastHeartbeatTS(),
+			Capacity:      store.GetCapacity(),
+			Available:     store.GetAvailable(),
+			LeaderCount:   store.GetLeaderCount(),
+			RegionCount:   store.GetRegionCount(),
+			LeaderWeight:  store.GetLeaderWeight(),
+			RegionWeight:  store.GetRegionWeight(),
+		})
+	}
+	regions := cluster.ScanRegions(startKey, nil, limit)
+	if len(regions) < limit && len(startKey) > 0 {
+		regions = append(regions, cluster.ScanRegions(nil, startKey, limit-len(regions))...)
+	}
+	var nextKey []byte
+	if len(regions) >= limit {
+		nextKey = regions[len(regions)-1].GetEndKey()
+	}
+	visited := make(map[uint64]struct{}, len(regions))
+	for _, region := range regions {
+		// The region across the start key may be scanned twice.
+		if _, ok := visited[region.GetID()]; ok {
+			continue
+		}
+		visited[region.GetID()] = struct{}{}
+		r := &remoteplugin.Region{
+			ID:              region.GetID(),
+			StartKey:        region.GetStartKey(),
+			EndKey:          region.GetEndKey(),
+			ConfVer:         region.GetRegionEpoch().GetConfVer(),
+			Version:         region.GetRegionEpoch().GetVersion(),
+			LeaderStoreID:   region.GetLeader().GetStoreId(),
+			ApproximateSize: region.GetApproximateSize(),
+			ApproximateKeys: region.GetApproximateKeys(),
+		}
+		for _, peer := range region.GetPeers() {
+			r.Peers = append(r.Peers, &remoteplugin.Peer{
+				ID:        peer.GetId(),
+				StoreID:   peer.GetStoreId(),
+				IsLearner: peer.GetRole() == metapb.PeerRole_Learner,
+			})
+		}
+		for _, peer := range region.GetDownPeers() {
+			r.DownStoreIDs = append(r.DownStoreIDs, peer.GetPeer().GetStoreId())
+		}
+		for _, peer := range region.GetPendingPeers() {
+			r.PendingStoreIDs = append(r.PendingStoreIDs, peer.GetStoreId())
+		}
+		snapshot.Regions = append(snapshot.Regions, r)
+	}
+	snapshot.ReadHotPeers = convertHotPeers(cluster.GetHotPeerStats(utils.Read))
+	snapshot.WriteHotPeers = convertHotPeers(cluster.GetHotPeerStats(utils.Write))
+	return snapshot, nextKey
+}
+
+func convertHotPeers(stats map[uint64][]*statistics.HotPeerStat) []*remoteplugin.HotPeer {
+	var peers []*remoteplugin.HotPeer
+	for _, storeStats := range stats {
+		for _, stat := range storeStats {
+			peers = append(peers, &remoteplugin.HotPeer{
+				RegionID:  stat.RegionID,
+				StoreID:   stat.StoreID,
+				IsLeader:  stat.IsLeader(),
+				HotDegree: stat.HotDegree,
+				ByteRate:  stat.GetLoad(utils.ByteDim),
+				KeyRate:   stat.GetLoad(utils.KeyDim),
+				QueryRate: stat.GetLoad(utils.QueryDim),
+			})
+		}
+	}
+	return peers
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/remoteplugin"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/operatorutil"
+	"github.com/tikv/pd/pkg/utils/testutil"
+)
+
+type mockRemotePlugin func(*remoteplugin.Snapshot) []*remoteplugin.Proposal
+
+func (f mockRemotePlugin) Schedule(_ context.Context, snapshot *remoteplugin.Snapshot) ([]*remoteplugin.Proposal, error) {
+	return f(snapshot), nil
+}
+
+func TestRemotePluginScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	tc.AddLeaderStore(1, 10)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderStore(3, 0)
+	tc.AddLeaderStore(4, 0)
+	tc.AddLeaderRegion(1, 1, 2, 3)
+	tc.AddLeaderRegion(2, 1, 2, 3)
+
+	var snapshot *remoteplugin.Snapshot
+	plugin := mockRemotePlugin(func(s *remoteplugin.Snapshot) []*remoteplugin.Proposal {
+		snapshot = s
+		region1, region2 := s.GetRegion(1), s.GetRegion(2)
+		stale := remoteplugin.NewTransferLeaderProposal(region2, 2)
+		stale.Version++
+		return []*remoteplugin.Proposal{
+			remoteplugin.NewTransferLeaderProposal(region1, 2),
+			// The target is not a follower.
+			remoteplugin.NewTransferLeaderProposal(region1, 4),
+			stale,
+			// The target already has a peer.
+			remoteplugin.NewMovePeerProposal(region2, 3, 2),
+			remoteplugin.NewMovePeerProposal(region2, 3, 4),
+			// The region does not exist.
+			{Kind: remoteplugin.TransferLeader, RegionID: 3, TargetStoreID: 2},
+		}
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	re.NoError(err)
+	server := remoteplugin.NewServer(plugin)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	_, err = CreateScheduler(types.RemotePluginScheduler, oc, storage.NewStorageWithMemoryBackend(),
+		ConfigSliceDecoder(types.RemotePluginScheduler, []string{"test"}), func(string) error { return nil })
+	re.Error(err)
+	_, err = CreateScheduler(types.RemotePluginScheduler, oc, storage.NewStorageWithMemoryBackend(),
+		ConfigSliceDecoder(types.RemotePluginScheduler, []string{"test", "127.0.0.1"}), func(string) error { return nil })
+	re.Error(err)
+	sl, err := CreateScheduler(types.RemotePluginScheduler, oc, storage.NewStorageWithMemoryBackend(),
+		ConfigSliceDecoder(types.RemotePluginScheduler, []string{"test", "http://" + lis.Addr().String()}), func(string) error { return nil })
+	re.NoError(err)
+	defer sl.CleanConfig(tc)
+
+	re.True(sl.IsScheduleAllowed(tc))
+	// The plugin is called in the background, the proposals are used by the next call.
+	waitRound := func() {
+		testutil.Eventually(re, func() bool {
+			return !sl.(*remotePluginScheduler).isRoundRunning()
+		})
+	}
+	ops, _ := sl.Schedule(tc, false)
+	re.Empty(ops)
+	waitRound()
+	// The dry run neither takes the proposals nor starts a new round.
+	for range 2 {
+		ops, _ = sl.(*remotePluginScheduler).scheduleDryRun(tc)
+		re.Len(ops, 2)
+		re.False(sl.(*remotePluginScheduler).isRoundRunning())
+	}
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 2)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpLeader, 1, 2)
+	operatorutil.CheckTransferPeer(re, ops[1], operator.OpRegion, 3, 4)
+	re.Equal("test", ops[0].GetAdditionalInfo("plugin"))
+
+	waitRound()
+	re.Len(snapshot.Stores, 4)
+	re.Len(snapshot.Regions, 2)
+	re.Equal(uint64(1), snapshot.GetRegion(1).LeaderStoreID)
+	re.Equal([]uint64{2, 3}, snapshot.GetRegion(1).GetFollowerStoreIDs())
+	re.Equal(10, snapshot.GetStore(1).LeaderCount)
+	re.True(snapshot.GetStore(4).IsServing())
+
+	// The proposals exceeding the schedule limit are dropped.
+	tc.SetLeaderScheduleLimit(0)
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeer(re, ops[0], operator.OpRegion, 3, 4)
+}
+
+func TestRemotePluginSnapshotLimit(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, _ := prepareSchedulersTest()
+	defer cancel()
+
+	tc.AddLeaderStore(1, 5)
+	for i := uint64(1); i <= 5; i++ {
+		tc.AddLeaderRegionWithRange(i, string(rune('a'+i-1)), string(rune('a'+i)), 1)
+	}
+	regionIDs := func(snapshot *remoteplugin.Snapshot) []uint64 {
+		var ids []uint64
+		for _, region := range snapshot.Regions {
+			ids = append(ids, region.ID)
+		}
+		return ids
+	}
+	// The snapshots continue from where the last one stops.
+	snapshot, nextKey := buildRemotePluginSnapshot(tc, nil, 3)
+	re.Equal([]uint64{1, 2, 3}, regionIDs(snapshot))
+	re.Equal([]byte("d"), nextKey)
+	snapshot, nextKey = buildRemotePluginSnapshot(tc, nextKey, 3)
+	re.Equal([]uint64{4, 5, 1}, regionIDs(snapshot))
+	re.Equal([]byte("b"), nextKey)
+	// All the regions fit in the snapshot.
+	snapshot, nextKey = buildRemotePluginSnapshot(tc, nextKey, 10)
+	re.Equal([]uint64{2, 3, 4, 5, 1}, regionIDs(snapshot))
+	re.Nil(nextKey)
+}
@@ -58,6 +58,9 @@ type Controller struct {
 	// which will only be initialized and used in the microservice env now.
 	schedulerHandlers map[string]http.Handler
 	opController      *operator.Controller
+	// zoneLeader is the running zone-leader-scheduler. It's read by balance-leader-scheduler
+	// without the lock, which may be held when the scheduler runs, e.g. by DryRunScheduler.
+	zoneLeader atomic.Pointer[ScheduleController]
 }
 
 // NewController creates a scheduler controller.
@@ -216,6 +219,12 @@ func (c *Controller) AddScheduler(scheduler Scheduler, args ...string) error {
 	c.wg.Add(1)
 	go c.runScheduler(s)
 	c.schedulers[s.GetName()] = s
+	switch scheduler := scheduler.(type) {
+	case *zoneLeaderScheduler:
+		c.zoneLeader.Store(s)
+	case *balanceLeaderScheduler:
+		scheduler.isZoneLeaderActive = c.isZoneLeaderSchedulerActive
+	}
 	if err := scheduler.SetDisable(false); err != nil {
 		log.Error("can not update scheduler status", zap.String("scheduler-name", name),
 			errs.ZapError(err))
@@ -261,9 +270,16 @@ func (c *Controller) RemoveScheduler(name string) error {
 	s.Stop()
 	schedulerStatusGauge.DeleteLabelValues(name, "allow")
 	delete(c.schedulers, name)
+	c.zoneLeader.CompareAndSwap(s, nil)
 	return nil
 }
 
+// isZoneLeaderSchedulerActive returns whether zone-leader-scheduler is added and not paused.
+func (c *Controller) isZoneLeaderSchedulerActive() bool {
+	s := c.zoneLeader.Load()
+	return s != nil && !s.IsPaused()
+}
+
 // PauseOrResumeScheduler pauses or resumes a scheduler by name.
 func (c *Controller) PauseOrResumeScheduler(name string, t int64) error {
 	c.Lock()
@@ -433,6 +449,21 @@ func (c *Controller) CheckTransferWitnessLeader(region *core.RegionInfo) {
 	}
 }
 
+// DryRunScheduler runs the scheduler once against the current cluster and
+// returns the operators it would create without adding them to the operator controller.
+func (c *Controller) DryRunScheduler(name string) (*DryRunResult, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return nil, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	s, ok := c.schedulers[name]
+	if !ok {
+		return nil, errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	return s.DryRun(), nil
+}
+
 // GetAllSchedulerConfigs returns all scheduler configs.
 func (c *Controller) GetAllSchedulerConfigs() (sches, configs []string, err error) {
 	return c.storage.LoadAllSchedulerConfigs()
@@ -529,9 +560,20 @@ retry:
 // DiagnoseDryRun returns the operators and plans of a scheduler.
 func (s *ScheduleController) DiagnoseDryRun() ([]*operator.Operator, []plan.Plan) {
 	cacheCluster := newCacheCluster(s.cluster)
+	if d, ok := s.Scheduler.(dryRunScheduler); ok {
+		return d.scheduleDryRun(cacheCluster)
+	}
 	return s.Scheduler.Schedule(cacheCluster, true)
 }
 
+// DryRun runs the scheduler once and returns the proposed operators along
+// with their steps, influence and diagnostic reasons. The operators are not executed.
+func (s *ScheduleController) DryRun() *DryRunResult {
+	allowed := s.IsScheduleAllowed(s.cluster) && !s.cluster.IsSchedulingHalted() && !s.IsPaused()
+	ops, plans := s.DiagnoseDryRun()
+	return newDryRunResult(s.GetName(), allowed, s.cluster, ops, plans, DiagnosableSummaryFunc[s.GetType()])
+}
+
 // GetInterval returns the interval of scheduling for a scheduler.
 func (s *ScheduleController) GetInterval() time.Duration {
 	return s.nextInterval
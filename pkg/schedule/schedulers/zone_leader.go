@@ -0,0 +1,520 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// zoneLeaderWeightsLabel is the key of the region label which sets the leader zone preference
+	// of the key ranges. Its value is a comma separated list of the zones with the weights of the
+	// leaders, e.g. "z1:70,z2:20,z3:10". The leaders of the labeled regions are distributed by
+	// zone-leader-scheduler, so balance-leader-scheduler skips them while zone-leader-scheduler is running.
+	zoneLeaderWeightsLabel = "leader-zone-weights"
+
+	defaultZoneLeaderLocationLabel  = "zone"
+	defaultZoneLeaderToleranceRatio = 0.05
+	zoneLeaderRetryLimit            = 10
+	// zoneLeaderScanBatchSize is the count of the regions scanned in a batch.
+	zoneLeaderScanBatchSize = 1024
+	// zoneLeaderScanRegionLimit is the max count of the labeled regions scanned in
+	// a round, the leaders are distributed by the scanned regions if there are more.
+	zoneLeaderScanRegionLimit = 100 * zoneLeaderScanBatchSize
+	// zoneLeaderGroupRefreshInterval is the interval to rescan the labeled regions if the
+	// label rules are not changed, so that the split and merged regions are grouped again.
+	zoneLeaderGroupRefreshInterval = time.Minute
+)
+
+func initZoneLeaderSchedulerConfig() *zoneLeaderSchedulerConfig {
+	return &zoneLeaderSchedulerConfig{
+		schedulerConfig: &baseSchedulerConfig{},
+		LocationLabel:   defaultZoneLeaderLocationLabel,
+		ToleranceRatio:  defaultZoneLeaderToleranceRatio,
+	}
+}
+
+type zoneLeaderSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// LocationLabel is the key of the store label which indicates the zone of the store.
+	LocationLabel string `json:"location-label"`
+	// ToleranceRatio is the ratio of the leaders of a key range that the leader count
+	// of a zone can differ from the expected count before scheduling.
+	ToleranceRatio float64 `json:"tolerance-ratio"`
+}
+
+func (conf *zoneLeaderSchedulerConfig) clone() *zoneLeaderSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &zoneLeaderSchedulerConfig{
+		LocationLabel:  conf.LocationLabel,
+		ToleranceRatio: conf.ToleranceRatio,
+	}
+}
+
+// assign copies the config items from other, the caller should hold the lock.
+func (conf *zoneLeaderSchedulerConfig) assign(other *zoneLeaderSchedulerConfig) {
+	conf.LocationLabel = other.LocationLabel
+	conf.ToleranceRatio = other.ToleranceRatio
+}
+
+func (conf *zoneLeaderSchedulerConfig) validate() error {
+	if conf.LocationLabel == "" {
+		return fmt.Errorf("location-label should not be empty")
+	}
+	if conf.ToleranceRatio < 0 || conf.ToleranceRatio >= 1 {
+		return fmt.Errorf("tolerance-ratio should be in [0, 1)")
+	}
+	return nil
+}
+
+func (conf *zoneLeaderSchedulerConfig) getLocationLabel() string {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.LocationLabel
+}
+
+func (conf *zoneLeaderSchedulerConfig) getToleranceRatio() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ToleranceRatio
+}
+
+type zoneLeaderHandler struct {
+	conf *zoneLeaderSchedulerConfig
+	rd   *render.Render
+}
+
+func newZoneLeaderHandler(conf *zoneLeaderSchedulerConfig) http.Handler {
+	h := &zoneLeaderHandler{
+		conf: conf,
+		rd:   render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/list", h.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/config", h.updateConfig).Methods(http.MethodPost)
+	return router
+}
+
+func (h *zoneLeaderHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := h.conf.clone()
+	h.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (h *zoneLeaderHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	defer r.Body.Close()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	newc := h.conf.clone()
+	if err := json.Unmarshal(data, newc); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := newc.validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.conf.Lock()
+	defer h.conf.Unlock()
+	old := &zoneLeaderSchedulerConfig{}
+	old.assign(h.conf)
+	h.conf.assign(newc)
+	if err := h.conf.save(); err != nil {
+		h.conf.assign(old)
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.Info("zone-leader-scheduler update config", zap.ByteString("config", data))
+	h.rd.JSON(w, http.StatusOK, "Config is updated.")
+}
+
+type zoneLeaderScheduler struct {
+	*BaseScheduler
+	conf          *zoneLeaderSchedulerConfig
+	handler       http.Handler
+	filters       []filter.Filter
+	filterCounter *filter.Counter
+	// mu serializes Schedule, which may be called by a dry run at the same time as the scheduling
+	// loop, since the group cache, the filter counter and the random source are not thread-safe.
+	mu syncutil.Mutex
+	// groupCache is the IDs of the labeled regions grouped by the value of the label. Scanning
+	// and labeling the regions is expensive, so they are only regrouped when the label rules
+	// change or the cache expires.
+	groupCache struct {
+		rules      map[string]*labeler.LabelRule
+		regionIDs  map[string][]uint64
+		lastUpdate time.Time
+	}
+}
+
+// newZoneLeaderScheduler creates a scheduler that distributes the leaders of the key ranges
+// labeled with the leader zone preference across the zones according to the weights.
+func newZoneLeaderScheduler(opController *operator.Controller, conf *zoneLeaderSchedulerConfig) Scheduler {
+	scheduler := &zoneLeaderScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.ZoneLeaderScheduler, conf),
+		conf:          conf,
+		handler:       newZoneLeaderHandler(conf),
+	}
+	scheduler.filters = []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: scheduler.GetName(), TransferLeader: true, OperatorLevel: constant.Medium},
+		filter.NewSpecialUseFilter(scheduler.GetName()),
+	}
+	scheduler.filterCounter = filter.NewCounter(scheduler.GetName())
+	return scheduler
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *zoneLeaderScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *zoneLeaderScheduler) EncodeConfig() ([]byte, error) {
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *zoneLeaderScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+	newCfg := &zoneLeaderSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.assign(newCfg)
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *zoneLeaderScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpLeader) < cluster.GetSchedulerConfig().GetLeaderScheduleLimit()
+	if !allowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	return allowed
+}
+
+// Schedule implements the Scheduler interface.
+//
+// The regions are grouped by the value of the leader zone preference label. For each group,
+// it transfers a leader from the zone with the most surplus leaders to a follower in the zone
+// with the most deficient leaders, where the expected leader count of a zone is proportional
+// to its weight. The leader is transferred to the store with the fewest leaders in the zone.
+func (s *zoneLeaderScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
+	zoneLeaderScheduleCounter.Inc()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	regionLabeler := cluster.GetRegionLabeler()
+	if regionLabeler == nil {
+		return nil, nil
+	}
+	defer s.filterCounter.Flush()
+	locationLabel := s.conf.getLocationLabel()
+	storeZones := make(map[uint64]string)
+	for _, store := range cluster.GetStores() {
+		if zone := store.GetLabelValue(locationLabel); zone != "" {
+			storeZones[store.GetID()] = zone
+		}
+	}
+	groups := s.collectGroups(cluster, regionLabeler)
+	if len(groups) == 0 {
+		zoneLeaderNoRegionCounter.Inc()
+		return nil, nil
+	}
+	values := make([]string, 0, len(groups))
+	for value := range groups {
+		values = append(values, value)
+	}
+	s.R.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+	for _, value := range values {
+		weights, err := parseZoneLeaderWeights(value)
+		if err != nil {
+			log.Debug("invalid leader zone weights", zap.String("scheduler", s.GetName()), zap.String("weights", value), errs.ZapError(err))
+			zoneLeaderInvalidWeightsCounter.Inc()
+			continue
+		}
+		group := groups[value]
+		sources, targets := group.plan(weights, storeZones, s.conf.getToleranceRatio())
+		for _, source := range sources {
+			for _, target := range targets {
+				if op := s.transferLeader(cluster, group, storeZones, source, target); op != nil {
+					op.SetAdditionalInfo("sourceZone", source)
+					op.SetAdditionalInfo("targetZone", target)
+					return []*operator.Operator{op}, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// collectGroups groups the regions in the labeled key ranges by the value of the label.
+// The caller should hold the lock.
+func (s *zoneLeaderScheduler) collectGroups(cluster sche.SchedulerCluster, regionLabeler *labeler.RegionLabeler) map[string]*zoneLeaderGroup {
+	rules := make(map[string]*labeler.LabelRule)
+	for _, rule := range regionLabeler.GetAllLabelRules() {
+		if hasRegionLabel(rule, zoneLeaderWeightsLabel) {
+			rules[rule.ID] = rule
+		}
+	}
+	// The rules are replaced rather than modified in place when they are updated.
+	cache := &s.groupCache
+	if cache.regionIDs == nil || time.Since(cache.lastUpdate) >= zoneLeaderGroupRefreshInterval || !maps.Equal(rules, cache.rules) {
+		cache.regionIDs = groupZoneLeaderRegions(cluster, regionLabeler, rules)
+		cache.rules = rules
+		cache.lastUpdate = time.Now()
+	}
+
+	groups := make(map[string]*zoneLeaderGroup, len(cache.regionIDs))
+	for value, regionIDs := range cache.regionIDs {
+		group := &zoneLeaderGroup{}
+		for _, id := range regionIDs {
+			region := cluster.GetRegion(id)
+			if region == nil {
+				continue
+			}
+			group.regions = append(group.regions, region)
+			group.leaders = append(group.leaders, s.expectedLeaderStore(region))
+		}
+		if len(group.regions) > 0 {
+			groups[value] = group
+		}
+	}
+	return groups
+}
+
+// groupZoneLeaderRegions scans the regions in the key ranges of the rules, and groups
+// their IDs by the value of the label.
+func groupZoneLeaderRegions(cluster sche.SchedulerCluster, regionLabeler *labeler.RegionLabeler, rules map[string]*labeler.LabelRule) map[string][]uint64 {
+	groups := make(map[string][]uint64)
+	visited := make(map[uint64]struct{})
+	for _, rule := range rules {
+		keyRanges, ok := rule.Data.([]*labeler.KeyRangeRule)
+		if !ok {
+			continue
+		}
+		for _, keyRange := range keyRanges {
+			for _, region := range scanZoneLeaderRegions(cluster, keyRange.StartKey, keyRange.EndKey, zoneLeaderScanRegionLimit-len(visited)) {
+				if _, ok := visited[region.GetID()]; ok {
+					continue
+				}
+				visited[region.GetID()] = struct{}{}
+				// The rules may overlap, so use the label of the rule with the max index.
+				value := regionLabeler.GetRegionLabel(region, zoneLeaderWeightsLabel)
+				if value == "" {
+					continue
+				}
+				groups[value] = append(groups[value], region.GetID())
+			}
+		}
+	}
+	return groups
+}
+
+// scanZoneLeaderRegions scans at most limit regions in the key range by batches.
+func scanZoneLeaderRegions(cluster sche.SchedulerCluster, startKey, endKey []byte, limit int) []*core.RegionInfo {
+	var regions []*core.RegionInfo
+	for len(regions) < limit {
+		batch := cluster.ScanRegions(startKey, endKey, min(zoneLeaderScanBatchSize, limit-len(regions)))
+		if len(batch) == 0 {
+			break
+		}
+		regions = append(regions, batch...)
+		startKey = batch[len(batch)-1].GetEndKey()
+		if len(startKey) == 0 || (len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0) {
+			break
+		}
+	}
+	return regions
+}
+
+// expectedLeaderStore returns the store of the leader after the running operator of the region finishes,
+// so that the leaders being transferred are not scheduled again.
+func (s *zoneLeaderScheduler) expectedLeaderStore(region *core.RegionInfo) uint64 {
+	leaderStoreID := region.GetLeader().GetStoreId()
+	if op := s.OpController.GetOperator(region.GetID()); op != nil {
+		for i := range op.Len() {
+			if step, ok := op.Step(i).(operator.TransferLeader); ok {
+				leaderStoreID = step.ToStore
+			}
+		}
+	}
+	return leaderStoreID
+}
+
+// transferLeader tries to transfer a leader of the group from the source zone to the target zone.
+func (s *zoneLeaderScheduler) transferLeader(cluster sche.SchedulerCluster, group *zoneLeaderGroup,
+	storeZones map[uint64]string, sourceZone, targetZone string) *operator.Operator {
+	conf := cluster.GetSchedulerConfig()
+	regionFilters := []filter.RegionFilter{filter.NewRegionPendingFilter(), filter.NewRegionDownFilter()}
+	retry := 0
+	for _, i := range s.R.Perm(len(group.regions)) {
+		if retry >= zoneLeaderRetryLimit {
+			break
+		}
+		region := group.regions[i]
+		if storeZones[region.GetLeader().GetStoreId()] != sourceZone || s.OpController.GetOperator(region.GetID()) != nil {
+			continue
+		}
+		retry++
+		if filter.SelectOneRegion([]*core.RegionInfo{region}, nil, regionFilters...) == nil {
+			continue
+		}
+		source := cluster.GetStore(region.GetLeader().GetStoreId())
+		if source == nil {
+			continue
+		}
+		var followers []*core.StoreInfo
+		for _, store := range cluster.GetFollowerStores(region) {
+			if storeZones[store.GetID()] == targetZone {
+				followers = append(followers, store)
+			}
+		}
+		finalFilters := s.filters
+		if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(),
+			region, source, false /*allowMoveLeader*/); leaderFilter != nil {
+			finalFilters = append(s.filters, leaderFilter)
+		}
+		targets := filter.SelectTargetStores(followers, finalFilters, conf, nil, s.filterCounter)
+		if len(targets) == 0 {
+			zoneLeaderNoTargetCounter.Inc()
+			continue
+		}
+		sort.Slice(targets, func(i, j int) bool {
+			return targets[i].GetLeaderCount() < targets[j].GetLeaderCount()
+		})
+		op, err := operator.CreateTransferLeaderOperator(s.GetName(), cluster, region, targets[0].GetID(), []uint64{}, operator.OpLeader)
+		if err != nil {
+			log.Debug("fail to create zone leader operator", zap.Uint64("region-id", region.GetID()), errs.ZapError(err))
+			zoneLeaderCreateOpFailCounter.Inc()
+			continue
+		}
+		op.Counters = append(op.Counters, zoneLeaderNewOperatorCounter)
+		op.FinishedCounters = append(op.FinishedCounters,
+			balanceDirectionCounter.WithLabelValues(s.GetName(), strconv.FormatUint(source.GetID(), 10), strconv.FormatUint(targets[0].GetID(), 10)),
+		)
+		return op
+	}
+	return nil
+}
+
+func hasRegionLabel(rule *labeler.LabelRule, key string) bool {
+	for _, label := range rule.Labels {
+		if label.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneLeaderGroup is the regions with the same leader zone preference.
+type zoneLeaderGroup struct {
+	regions []*core.RegionInfo
+	// leaders are the stores of the leaders of the regions.
+	leaders []uint64
+}
+
+// plan returns the zones which have more leaders than expected and the zones which have fewer
+// leaders than expected, both of them are sorted by the difference in descending order.
+// The weights of the zones without any store are ignored, and the leaders in the zones without
+// weights are all surplus.
+func (g *zoneLeaderGroup) plan(weights map[string]float64, storeZones map[uint64]string, toleranceRatio float64) (sources, targets []string) {
+	zones := make(map[string]struct{})
+	for _, zone := range storeZones {
+		zones[zone] = struct{}{}
+	}
+	var weightSum float64
+	for zone, weight := range weights {
+		if _, ok := zones[zone]; ok {
+			weightSum += weight
+		}
+	}
+	if weightSum == 0 || len(g.leaders) == 0 {
+		return nil, nil
+	}
+	diffs := make(map[string]float64)
+	for zone := range zones {
+		diffs[zone] = -float64(len(g.leaders)) * weights[zone] / weightSum
+	}
+	for _, storeID := range g.leaders {
+		if zone, ok := storeZones[storeID]; ok {
+			diffs[zone]++
+		}
+	}
+	tolerance := math.Max(0.5, float64(len(g.leaders))*toleranceRatio)
+	for zone, diff := range diffs {
+		if diff > tolerance {
+			sources = append(sources, zone)
+		} else if diff < -tolerance {
+			targets = append(targets, zone)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return diffs[sources[i]] > diffs[sources[j]] })
+	sort.Slice(targets, func(i, j int) bool { return diffs[targets[i]] < diffs[targets[j]] })
+	return sources, targets
+}
+
+// parseZoneLeaderWeights parses the value of the leader zone preference label, e.g. "z1:70,z2:20,z3:10".
+func parseZoneLeaderWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, item := range strings.Split(value, ",") {
+		zone, weight, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || zone == "" {
+			return nil, fmt.Errorf("invalid zone weight %q", item)
+		}
+		w, err := strconv.ParseFloat(weight, 64)
+		if err != nil || w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid weight of zone %s", zone)
+		}
+		if _, ok := weights[zone]; ok {
+			return nil, fmt.Errorf("zone %s is repeated", zone)
+		}
+		weights[zone] = w
+	}
+	return weights, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/operatorutil"
+)
+
+func TestParseZoneLeaderWeights(t *testing.T) {
+	re := require.New(t)
+	weights, err := parseZoneLeaderWeights("z1:70, z2:20,z3:10")
+	re.NoError(err)
+	re.Equal(map[string]float64{"z1": 70, "z2": 20, "z3": 10}, weights)
+	for _, value := range []string{"z1", "z1:", ":10", "z1:-1", "z1:a", "z1:1,z1:2"} {
+		_, err := parseZoneLeaderWeights(value)
+		re.Error(err, value)
+	}
+
+	conf := initZoneLeaderSchedulerConfig()
+	re.NoError(conf.validate())
+	conf.LocationLabel = ""
+	re.Error(conf.validate())
+	conf.LocationLabel = "rack"
+	conf.ToleranceRatio = 1
+	re.Error(conf.validate())
+}
+
+func TestZoneLeaderGroupPlan(t *testing.T) {
+	re := require.New(t)
+	storeZones := map[uint64]string{1: "z1", 2: "z2", 3: "z3", 4: "z4"}
+	weights := map[string]float64{"z1": 70, "z2": 20, "z3": 10, "z5": 100}
+	group := &zoneLeaderGroup{}
+	for range 10 {
+		group.leaders = append(group.leaders, 1)
+	}
+	// The weight of z5 is ignored since there is no store in it.
+	sources, targets := group.plan(weights, storeZones, 0.05)
+	re.Equal([]string{"z1"}, sources)
+	re.Equal([]string{"z2", "z3"}, targets)
+
+	// The leaders in the zone without weight are surplus.
+	group.leaders = []uint64{1, 1, 1, 1, 1, 1, 2, 2, 3, 4}
+	sources, targets = group.plan(weights, storeZones, 0.05)
+	re.Equal([]string{"z4"}, sources)
+	re.Equal([]string{"z1"}, targets)
+
+	// The difference is tolerable.
+	sources, targets = group.plan(weights, storeZones, 0.2)
+	re.Empty(sources)
+	re.Empty(targets)
+	group.leaders = []uint64{1, 1, 1, 1, 1, 1, 1, 2, 2, 3}
+	sources, targets = group.plan(weights, storeZones, 0)
+	re.Empty(sources)
+	re.Empty(targets)
+}
+
+func TestZoneLeaderScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	sl, err := CreateScheduler(types.ZoneLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ZoneLeaderScheduler, nil))
+	re.NoError(err)
+	bl, err := CreateScheduler(types.BalanceLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceLeaderScheduler, []string{"", ""}))
+	re.NoError(err)
+
+	tc.AddLabelsStore(1, 0, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(2, 0, map[string]string{"zone": "z2"})
+	tc.AddLabelsStore(3, 0, map[string]string{"zone": "z3"})
+	addRegions := func(leaders ...uint64) {
+		for i, leader := range leaders {
+			start, end := string(rune('a'+i)), string(rune('a'+i+1))
+			followers := make([]uint64, 0, 2)
+			for id := uint64(1); id <= 3; id++ {
+				if id != leader {
+					followers = append(followers, id)
+				}
+			}
+			tc.AddLeaderRegionWithRange(uint64(i+1), start, end, leader, followers...)
+		}
+	}
+	addRegions(1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+	tc.UpdateLeaderCount(1, 10)
+
+	// No region is labeled.
+	ops, _ := sl.Schedule(tc, false)
+	re.Empty(ops)
+	// The leaders are balanced by balance-leader-scheduler.
+	ops, _ = bl.Schedule(tc, false)
+	re.NotEmpty(ops)
+
+	re.NoError(tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "leader-zone",
+		Labels:   []labeler.RegionLabel{{Key: zoneLeaderWeightsLabel, Value: "z1:70,z2:20,z3:10"}},
+		RuleType: labeler.KeyRange,
+		Data:     labeler.MakeKeyRanges("61", "6b"),
+	}))
+	// The labeled regions are balanced by balance-leader-scheduler until
+	// zone-leader-scheduler runs.
+	ops, _ = bl.Schedule(tc, false)
+	re.NotEmpty(ops)
+	// Register zone-leader-scheduler without running it in the background.
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	controller := NewController(ctx, tc, storage.NewStorageWithMemoryBackend(), oc)
+	slController := NewScheduleController(ctx, tc, oc, sl)
+	controller.zoneLeader.Store(slController)
+	bl.(*balanceLeaderScheduler).isZoneLeaderActive = controller.isZoneLeaderSchedulerActive
+	ops, _ = bl.Schedule(tc, false)
+	re.Empty(ops)
+	// The labeled regions are balanced by balance-leader-scheduler while
+	// zone-leader-scheduler is paused.
+	now := time.Now().Unix()
+	slController.SetDelay(now, now+60)
+	ops, _ = bl.Schedule(tc, false)
+	re.NotEmpty(ops)
+	slController.SetDelay(0, 0)
+	ops, _ = bl.Schedule(tc, false)
+	re.Empty(ops)
+	// The regions are scanned with a limit.
+	re.Len(scanZoneLeaderRegions(tc, []byte("a"), []byte("k"), 3), 3)
+	re.Len(scanZoneLeaderRegions(tc, []byte("a"), []byte("k"), zoneLeaderScanRegionLimit), 10)
+	re.Len(scanZoneLeaderRegions(tc, []byte("c"), nil, zoneLeaderScanRegionLimit), 8)
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpLeader, 1, 2)
+
+	// The leader being transferred is counted in the target zone.
+	re.Equal(1, oc.AddWaitingOperator(ops...))
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(uint64(1), ops[0].Step(0).(operator.TransferLeader).FromStore)
+
+	// The leaders are distributed by the weights.
+	oc.RemoveOperators()
+	addRegions(1, 1, 1, 1, 1, 1, 1, 2, 2, 3)
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	// The regions are not regrouped until the label rules change or the cache expires.
+	cache := &sl.(*zoneLeaderScheduler).groupCache
+	lastUpdate := cache.lastUpdate
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	re.Equal(lastUpdate, cache.lastUpdate)
+	cache.lastUpdate = lastUpdate.Add(-zoneLeaderGroupRefreshInterval)
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	re.True(cache.lastUpdate.After(lastUpdate))
+
+	// The transfer which violates the placement rules is not allowed.
+	tc.SetRule(&placement.Rule{
+		GroupID: "pd", ID: "leader", Role: placement.Leader, Count: 1, LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: "in", Values: []string{"z1", "z2"}}},
+	})
+	tc.SetRule(&placement.Rule{
+		GroupID: "pd", ID: "voter", Role: placement.Follower, Count: 2,
+	})
+	tc.RuleManager.DeleteRule("pd", "default")
+	re.NoError(tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "leader-zone",
+		Labels:   []labeler.RegionLabel{{Key: zoneLeaderWeightsLabel, Value: "z1:0,z2:0,z3:1"}},
+		RuleType: labeler.KeyRange,
+		Data:     labeler.MakeKeyRanges("61", "6b"),
+	}))
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+}
+
+func TestZoneLeaderSchedulerConcurrentDryRun(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	sl, err := CreateScheduler(types.ZoneLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ZoneLeaderScheduler, nil))
+	re.NoError(err)
+	for id := uint64(1); id <= 3; id++ {
+		tc.AddLabelsStore(id, 0, map[string]string{"zone": fmt.Sprintf("z%d", id)})
+	}
+	for i := range 10 {
+		start, end := string(rune('a'+i)), string(rune('a'+i+1))
+		tc.AddLeaderRegionWithRange(uint64(i+1), start, end, 1, 2, 3)
+	}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	controller := NewScheduleController(ctx, tc, oc, sl)
+
+	// The label rule is updated repeatedly, so that the groups are refreshed by both
+	// the dry run and the scheduling loop.
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := range 50 {
+			re.NoError(tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+				ID:       "leader-zone",
+				Labels:   []labeler.RegionLabel{{Key: zoneLeaderWeightsLabel, Value: fmt.Sprintf("z1:%d,z2:20,z3:10", 50+i)}},
+				RuleType: labeler.KeyRange,
+				Data:     labeler.MakeKeyRanges("61", "6b"),
+			}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			controller.Schedule(false)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			controller.DryRun()
+		}
+	}()
+	wg.Wait()
+}
@@ -72,6 +72,12 @@ const (
 	LabelScheduler CheckerSchedulerType = "label-scheduler"
 	// BalanceRangeScheduler is balance key range scheduler name.
 	BalanceRangeScheduler CheckerSchedulerType = "balance-range-scheduler"
+	// BalanceCostScheduler is balance cost scheduler name.
+	BalanceCostScheduler CheckerSchedulerType = "balance-cost-scheduler"
+	// ZoneLeaderScheduler is zone leader scheduler name.
+	ZoneLeaderScheduler CheckerSchedulerType = "zone-leader-scheduler"
+	// RemotePluginScheduler is remote plugin scheduler name.
+	RemotePluginScheduler CheckerSchedulerType = "remote-plugin-scheduler"
 )
 
 // TODO: SchedulerTypeCompatibleMap and ConvertOldStrToType should be removed after
@@ -100,6 +106,9 @@ var (
 		TransferWitnessLeaderScheduler: "transfer-witness-leader",
 		LabelScheduler:                 "label",
 		BalanceRangeScheduler:          "balance-range",
+		BalanceCostScheduler:           "balance-cost",
+		ZoneLeaderScheduler:            "zone-leader",
+		RemotePluginScheduler:          "remote-plugin",
 	}
 
 	// ConvertOldStrToType exists for compatibility.
@@ -124,6 +133,9 @@ var (
 		"transfer-witness-leader": TransferWitnessLeaderScheduler,
 		"label":                   LabelScheduler,
 		"balance-range":           BalanceRangeScheduler,
+		"balance-cost":            BalanceCostScheduler,
+		"zone-leader":             ZoneLeaderScheduler,
+		"remote-plugin":           RemotePluginScheduler,
 	}
 
 	// StringToSchedulerType is a map to convert the scheduler string to the CheckerSchedulerType.
@@ -148,6 +160,9 @@ var (
 		"transfer-witness-leader-scheduler": TransferWitnessLeaderScheduler,
 		"label-scheduler":                   LabelScheduler,
 		"balance-range-scheduler":           BalanceRangeScheduler,
+		"balance-cost-scheduler":            BalanceCostScheduler,
+		"zone-leader-scheduler":             ZoneLeaderScheduler,
+		"remote-plugin-scheduler":           RemotePluginScheduler,
 	}
 
 	// DefaultSchedulers is the default scheduler types.
@@ -0,0 +1,83 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"math"
+
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+// storeStatKinds returns the store stat kinds for each dimension of the given rw type.
+// The write load of TiFlash is counted by region heartbeats, so it is also included.
+func storeStatKinds(rwTy utils.RWType) [][]utils.StoreStatKind {
+	switch rwTy {
+	case utils.Read:
+		return [][]utils.StoreStatKind{
+			utils.ByteDim:  {utils.StoreReadBytes},
+			utils.KeyDim:   {utils.StoreReadKeys},
+			utils.QueryDim: {utils.StoreReadQuery},
+		}
+	case utils.Write:
+		return [][]utils.StoreStatKind{
+			utils.ByteDim:  {utils.StoreWriteBytes, utils.StoreRegionsWriteBytes},
+			utils.KeyDim:   {utils.StoreWriteKeys, utils.StoreRegionsWriteKeys},
+			utils.QueryDim: {utils.StoreWriteQuery},
+		}
+	}
+	return nil
+}
+
+// PredictHotPeers replaces the loads of the hot peers with the predicted loads of the next interval
+// if the confidence of the prediction is not less than minConfidence, and adjusts the loads of the
+// stores by the difference between the predicted loads and the current loads of their hot peers.
+// The given maps are not modified, the hot peers and the store loads are copied.
+func PredictHotPeers(
+	storesLoads map[uint64][]float64,
+	storeHotPeers map[uint64][]*HotPeerStat,
+	rwTy utils.RWType,
+	minConfidence float64,
+) (map[uint64][]float64, map[uint64][]*HotPeerStat) {
+	kinds := storeStatKinds(rwTy)
+	predictedStoresLoads := make(map[uint64][]float64, len(storesLoads))
+	for id, loads := range storesLoads {
+		predictedStoresLoads[id] = append(loads[:0:0], loads...)
+	}
+	predictedHotPeers := make(map[uint64][]*HotPeerStat, len(storeHotPeers))
+	for id, peers := range storeHotPeers {
+		storeLoads := predictedStoresLoads[id]
+		predictedPeers := make([]*HotPeerStat, 0, len(peers))
+		for _, peer := range peers {
+			predicted := peer.Clone()
+			if predicted.PredictionConfidence >= minConfidence {
+				for dim := range predicted.Loads {
+					delta := predicted.PredictedLoads[dim] - predicted.Loads[dim]
+					predicted.Loads[dim] = predicted.PredictedLoads[dim]
+					if storeLoads == nil || dim >= len(kinds) {
+						continue
+					}
+					for _, kind := range kinds[dim] {
+						if int(kind) < len(storeLoads) {
+							storeLoads[kind] = math.Max(storeLoads[kind]+delta, 0)
+						}
+					}
+				}
+			}
+			predictedPeers = append(predictedPeers, predicted)
+		}
+		predictedHotPeers[id] = predictedPeers
+	}
+	return predictedStoresLoads, predictedHotPeers
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+func newForecastPeer(storeID, regionID uint64, rates []float64) *HotPeerStat {
+	interval := time.Duration(utils.Read.ReportInterval()) * time.Second
+	peer := &HotPeerStat{StoreID: storeID, RegionID: regionID, Loads: make([]float64, utils.DimLen)}
+	for range utils.DimLen {
+		peer.rollingLoads = append(peer.rollingLoads, newDimStat(interval))
+	}
+	for _, rate := range rates {
+		for _, l := range peer.rollingLoads {
+			l.add(rate*interval.Seconds(), interval)
+		}
+		peer.clearLastAverage()
+	}
+	return peer
+}
+
+func TestHotPeerPrediction(t *testing.T) {
+	re := require.New(t)
+	// The load is increasing linearly.
+	peer := newForecastPeer(1, 1, []float64{100, 200, 300, 400})
+	for dim := range utils.DimLen {
+		re.Equal(500.0, peer.GetPredictedLoad(dim))
+	}
+	re.Equal(1.0, peer.GetPredictionConfidence())
+	cloned := peer.Clone()
+	re.Equal([]float64{500, 500, 500}, cloned.PredictedLoads)
+	re.Equal(1.0, cloned.PredictionConfidence)
+	re.Equal(500.0, cloned.GetPredictedLoad(utils.ByteDim))
+
+	// The predicted load is never negative.
+	peer = newForecastPeer(1, 2, []float64{300, 200, 100, 0})
+	re.Equal(0.0, peer.GetPredictedLoad(utils.ByteDim))
+
+	// There are not enough records to evaluate the prediction.
+	peer = newForecastPeer(1, 3, []float64{100, 200})
+	re.Equal(0.0, peer.GetPredictionConfidence())
+
+	// The unstable load has a low confidence.
+	peer = newForecastPeer(1, 4, []float64{100, 1000, 100, 1000, 100})
+	re.Less(peer.GetPredictionConfidence(), 0.5)
+}
+
+func TestPredictHotPeers(t *testing.T) {
+	re := require.New(t)
+	rising := newForecastPeer(1, 1, []float64{100, 200, 300, 400})
+	unstable := newForecastPeer(2, 2, []float64{100, 1000, 100, 1000, 100})
+	storeHotPeers := map[uint64][]*HotPeerStat{
+		1: {rising},
+		2: {unstable},
+	}
+	storesLoads := map[uint64][]float64{
+		1: make([]float64, utils.StoreStatCount),
+		2: make([]float64, utils.StoreStatCount),
+	}
+	storesLoads[1][utils.StoreReadBytes] = 1000
+	storesLoads[2][utils.StoreReadBytes] = 1000
+
+	predictedLoads, predictedPeers := PredictHotPeers(storesLoads, storeHotPeers, utils.Read, 0.8)
+	// The given loads are not modified.
+	re.Equal(1000.0, storesLoads[1][utils.StoreReadBytes])
+	// The prediction of the rising peer is trusted.
+	re.Equal(500.0, predictedPeers[1][0].GetLoad(utils.ByteDim))
+	re.Equal(1000+500-rising.GetLoad(utils.ByteDim), predictedLoads[1][utils.StoreReadBytes])
+	re.Equal(500-rising.GetLoad(utils.QueryDim), predictedLoads[1][utils.StoreReadQuery])
+	re.Zero(predictedLoads[1][utils.StoreWriteBytes])
+	// The prediction of the unstable peer is ignored.
+	re.Equal(unstable.GetLoad(utils.ByteDim), predictedPeers[2][0].GetLoad(utils.ByteDim))
+	re.Equal(1000.0, predictedLoads[2][utils.StoreReadBytes])
+
+	// The write loads of the stores are adjusted.
+	predictedLoads, _ = PredictHotPeers(storesLoads, storeHotPeers, utils.Write, 0)
+	re.Equal(500-rising.GetLoad(utils.KeyDim), predictedLoads[1][utils.StoreWriteKeys])
+	re.Equal(500-rising.GetLoad(utils.KeyDim), predictedLoads[1][utils.StoreRegionsWriteKeys])
+	re.Equal(1000.0, predictedLoads[1][utils.StoreReadBytes])
+}
@@ -33,6 +33,8 @@ type dimStat struct {
 	rolling         *movingaverage.TimeMedian // it's used to statistic hot degree and average speed.
 	lastIntervalSum int                       // lastIntervalSum and lastDelta are used to calculate the average speed of the last interval.
 	lastDelta       float64
+	// forecast is used to predict the average speed of the next interval with the average speed of the past intervals.
+	forecast *movingaverage.Holt
 }
 
 func newDimStat(reportInterval time.Duration) *dimStat {
@@ -40,6 +42,7 @@ func newDimStat(reportInterval time.Duration) *dimStat {
 		rolling:         movingaverage.NewTimeMedian(utils.DefaultAotSize, rollingWindowsSize, reportInterval),
 		lastIntervalSum: 0,
 		lastDelta:       0,
+		forecast:        movingaverage.NewHolt(movingaverage.DefaultHoltAlpha, movingaverage.DefaultHoltBeta),
 	}
 }
 
@@ -72,6 +75,9 @@ func (d *dimStat) isFull(interval time.Duration) bool {
 func (d *dimStat) clearLastAverage() {
 	d.Lock()
 	defer d.Unlock()
+	if d.lastIntervalSum > 0 {
+		d.forecast.Add(d.lastDelta / float64(d.lastIntervalSum))
+	}
 	d.lastIntervalSum = 0
 	d.lastDelta = 0
 }
@@ -82,12 +88,19 @@ func (d *dimStat) get() float64 {
 	return d.rolling.Get()
 }
 
+func (d *dimStat) predict() (float64, float64) {
+	d.RLock()
+	defer d.RUnlock()
+	return d.forecast.Forecast(1), d.forecast.Confidence()
+}
+
 func (d *dimStat) clone() *dimStat {
 	d.RLock()
 	defer d.RUnlock()
 	return &dimStat{
 		rolling:         d.rolling.Clone(),
 		lastIntervalSum: d.lastIntervalSum,
+		forecast:        d.forecast.Clone(),
 	}
 }
 
@@ -101,6 +114,11 @@ type HotPeerStat struct {
 	AntiCount int `json:"anti_count"`
 	// Loads contains only Kind-related statistics and is DimLen in length.
 	Loads []float64 `json:"loads"`
+	// PredictedLoads contains the predicted loads of the next interval and is DimLen in length.
+	// It is only filled in the cloned HotPeerStat.
+	PredictedLoads []float64 `json:"predicted_loads,omitempty"`
+	// PredictionConfidence is the confidence of PredictedLoads, which is in [0, 1].
+	PredictionConfidence float64 `json:"prediction_confidence"`
 	// rolling statistics contains denoising data, it's DimLen in length.
 	rollingLoads []*dimStat
 	// stores contains the all peer's storeID in this region.
@@ -181,13 +199,41 @@ func (stat *HotPeerStat) GetLoads() []float64 {
 	return stat.Loads
 }
 
+// GetPredictedLoad returns the predicted load of the next interval if possible.
+func (stat *HotPeerStat) GetPredictedLoad(dim int) float64 {
+	if stat.rollingLoads != nil {
+		predicted, _ := stat.rollingLoads[dim].predict()
+		return math.Round(math.Max(predicted, 0))
+	}
+	if stat.PredictedLoads != nil {
+		return stat.PredictedLoads[dim]
+	}
+	return stat.GetLoad(dim)
+}
+
+// GetPredictionConfidence returns the lowest confidence of the predicted loads among all dimensions.
+func (stat *HotPeerStat) GetPredictionConfidence() float64 {
+	if stat.rollingLoads == nil {
+		return stat.PredictionConfidence
+	}
+	confidence := 1.0
+	for _, l := range stat.rollingLoads {
+		_, c := l.predict()
+		confidence = math.Min(confidence, c)
+	}
+	return confidence
+}
+
 // Clone clones the HotPeerStat.
 func (stat *HotPeerStat) Clone() *HotPeerStat {
 	ret := *stat
 	ret.Loads = make([]float64, utils.DimLen)
+	ret.PredictedLoads = make([]float64, utils.DimLen)
 	for i := range utils.DimLen {
 		ret.Loads[i] = stat.GetLoad(i) // replace with denoising loads
+		ret.PredictedLoads[i] = stat.GetPredictedLoad(i)
 	}
+	ret.PredictionConfidence = stat.GetPredictionConfidence()
 	ret.rollingLoads = nil
 	return &ret
 }
@@ -41,4 +41,9 @@ type HotPeerStatShow struct {
 	QueryRate      float64   `json:"flow_query"`
 	AntiCount      int       `json:"anti_count"`
 	LastUpdateTime time.Time `json:"last_update_time,omitempty"`
+	// PredictedByteRate, PredictedKeyRate and PredictedQueryRate are the predicted rates of the next interval.
+	PredictedByteRate    float64 `json:"predicted_flow_bytes"`
+	PredictedKeyRate     float64 `json:"predicted_flow_keys"`
+	PredictedQueryRate   float64 `json:"predicted_flow_query"`
+	PredictionConfidence float64 `json:"prediction_confidence"`
 }
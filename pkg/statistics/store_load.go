@@ -88,6 +88,11 @@ func toHotPeerStatShow(p *HotPeerStat) HotPeerStatShow {
 		KeyRate:   keyRate,
 		QueryRate: queryRate,
 		AntiCount: p.AntiCount,
+
+		PredictedByteRate:    p.GetPredictedLoad(utils.ByteDim),
+		PredictedKeyRate:     p.GetPredictedLoad(utils.KeyDim),
+		PredictedQueryRate:   p.GetPredictedLoad(utils.QueryDim),
+		PredictionConfidence: p.GetPredictionConfidence(),
 	}
 }
 
@@ -34,6 +34,9 @@ type ConfigStorage interface {
 	LoadSchedulerConfig(schedulerName string) (string, error)
 	SaveSchedulerConfig(schedulerName string, data []byte) error
 	RemoveSchedulerConfig(schedulerName string) error
+	// Maintenance windows are stored with the state of the active window.
+	LoadMaintenanceWindows(cfg any) (bool, error)
+	SaveMaintenanceWindows(cfg any) error
 }
 
 var _ ConfigStorage = (*StorageEndpoint)(nil)
@@ -80,3 +83,21 @@ func (se *StorageEndpoint) SaveSchedulerConfig(schedulerName string, data []byte
 func (se *StorageEndpoint) RemoveSchedulerConfig(schedulerName string) error {
 	return se.Remove(keypath.SchedulerConfigPath(schedulerName))
 }
+
+// LoadMaintenanceWindows loads the maintenance windows from keypath.MaintenanceWindowPath then unmarshal it to cfg.
+func (se *StorageEndpoint) LoadMaintenanceWindows(cfg any) (bool, error) {
+	value, err := se.Load(keypath.MaintenanceWindowPath())
+	if err != nil || value == "" {
+		return false, err
+	}
+	err = json.Unmarshal([]byte(value), cfg)
+	if err != nil {
+		return false, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return true, nil
+}
+
+// SaveMaintenanceWindows stores marshallable cfg to the keypath.MaintenanceWindowPath.
+func (se *StorageEndpoint) SaveMaintenanceWindows(cfg any) error {
+	return se.saveJSON(keypath.MaintenanceWindowPath(), cfg)
+}
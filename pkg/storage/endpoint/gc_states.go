@@ -477,6 +477,18 @@ func (wb *GCStateWriteBatch) setGCSafePointForKeyspaceLevelGC(keyspaceID uint32,
 	})
 }
 
+// DeleteGCSafePoint deletes the GC safe point of the given keyspace. It only works for keyspace-level GC.
+func (wb *GCStateWriteBatch) DeleteGCSafePoint(keyspaceID uint32) error {
+	if keyspaceID == constant.NullKeyspaceID {
+		return errors.New("cannot delete the GC safe point of the unified GC")
+	}
+	wb.ops = append(wb.ops, kv.RawTxnOp{
+		Key:    keypath.GCSafePointPath(keyspaceID),
+		OpType: kv.RawTxnOpDelete,
+	})
+	return nil
+}
+
 // SetTxnSafePoint sets the transaction safe point for the given keyspace.
 func (wb *GCStateWriteBatch) SetTxnSafePoint(keyspaceID uint32, txnSafePoint uint64) error {
 	key := keypath.TxnSafePointPath(keyspaceID)
@@ -489,6 +501,18 @@ func (wb *GCStateWriteBatch) SetTxnSafePoint(keyspaceID uint32, txnSafePoint uin
 	return nil
 }
 
+// DeleteTxnSafePoint deletes the transaction safe point of the given keyspace. It only works for keyspace-level GC.
+func (wb *GCStateWriteBatch) DeleteTxnSafePoint(keyspaceID uint32) error {
+	if keyspaceID == constant.NullKeyspaceID {
+		return errors.New("cannot delete the txn safe point of the unified GC")
+	}
+	wb.ops = append(wb.ops, kv.RawTxnOp{
+		Key:    keypath.TxnSafePointPath(keyspaceID),
+		OpType: kv.RawTxnOpDelete,
+	})
+	return nil
+}
+
 // SetGCBarrier sets a GCBarrier with the given barrierID for a specific keyspace.
 func (wb *GCStateWriteBatch) SetGCBarrier(keyspaceID uint32, newGCBarrier *GCBarrier) error {
 	key := keypath.GCBarrierPath(keyspaceID, newGCBarrier.BarrierID)
@@ -46,6 +46,9 @@ type KeyspaceStorage interface {
 	// LoadRangeKeyspace loads no more than limit keyspaces starting at startID.
 	LoadRangeKeyspace(txn kv.Txn, startID uint32, limit int) ([]*keyspacepb.KeyspaceMeta, error)
 	RunInTxn(ctx context.Context, f func(txn kv.Txn) error) error
+	KeyspaceLifecycleStorage
+	KeyspaceQuotaStorage
+	KeyspaceTemplateStorage
 }
 
 var _ KeyspaceStorage = (*StorageEndpoint)(nil)
@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"encoding/json"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keypath"
+)
+
+// KeyspaceLifecycleRecord records a step applied to a keyspace by the keyspace lifecycle controller.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type KeyspaceLifecycleRecord struct {
+	Step string `json:"step"`
+	// Time is the unix timestamp in seconds when the step is applied.
+	Time   int64  `json:"time"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// KeyspaceKeyRange is a hex encoded key range [StartKey, EndKey).
+type KeyspaceKeyRange struct {
+	StartKey string `json:"start-key"`
+	EndKey   string `json:"end-key"`
+}
+
+// KeyspaceDestroyRange is a pending task to destroy the data of an archived keyspace. The GC worker
+// deletes the data in the key ranges with UnsafeDestroyRange, and then finishes the task.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type KeyspaceDestroyRange struct {
+	KeyspaceID uint32              `json:"keyspace-id"`
+	Ranges     []*KeyspaceKeyRange `json:"ranges"`
+	// CreateTime is the unix timestamp in seconds when the task is scheduled.
+	CreateTime int64 `json:"create-time"`
+}
+
+// KeyspaceLifecycleStorage defines storage operations on the keyspace lifecycle related data.
+type KeyspaceLifecycleStorage interface {
+	LoadKeyspaceLifecycleRecords(txn kv.Txn, id uint32) ([]*KeyspaceLifecycleRecord, error)
+	SaveKeyspaceLifecycleRecords(txn kv.Txn, id uint32, records []*KeyspaceLifecycleRecord) error
+	LoadKeyspaceDestroyRange(txn kv.Txn, id uint32) (*KeyspaceDestroyRange, error)
+	// LoadKeyspaceDestroyRanges loads all the pending destroy range tasks.
+	LoadKeyspaceDestroyRanges() ([]*KeyspaceDestroyRange, error)
+	SaveKeyspaceDestroyRange(txn kv.Txn, task *KeyspaceDestroyRange) error
+	DeleteKeyspaceDestroyRange(txn kv.Txn, id uint32) error
+}
+
+var _ KeyspaceLifecycleStorage = (*StorageEndpoint)(nil)
+
+// LoadKeyspaceLifecycleRecords loads the lifecycle records of the given keyspace in the order they are applied.
+func (*StorageEndpoint) LoadKeyspaceLifecycleRecords(txn kv.Txn, id uint32) ([]*KeyspaceLifecycleRecord, error) {
+	value, err := txn.Load(keypath.KeyspaceLifecyclePath(id))
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var records []*KeyspaceLifecycleRecord
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return records, nil
+}
+
+// SaveKeyspaceLifecycleRecords saves the lifecycle records of the given keyspace.
+func (*StorageEndpoint) SaveKeyspaceLifecycleRecords(txn kv.Txn, id uint32, records []*KeyspaceLifecycleRecord) error {
+	return saveJSONInTxn(txn, keypath.KeyspaceLifecyclePath(id), records)
+}
+
+// LoadKeyspaceDestroyRange loads the pending destroy range task of the given keyspace.
+// If the task does not exist or error occurs, the returned task will be nil.
+func (*StorageEndpoint) LoadKeyspaceDestroyRange(txn kv.Txn, id uint32) (*KeyspaceDestroyRange, error) {
+	value, err := txn.Load(keypath.KeyspaceDestroyRangePath(id))
+	if err != nil || value == "" {
+		return nil, err
+	}
+	task := &KeyspaceDestroyRange{}
+	if err := json.Unmarshal([]byte(value), task); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return task, nil
+}
+
+// LoadKeyspaceDestroyRanges loads all the pending destroy range tasks.
+func (se *StorageEndpoint) LoadKeyspaceDestroyRanges() ([]*KeyspaceDestroyRange, error) {
+	tasks := make([]*KeyspaceDestroyRange, 0)
+	var err error
+	loadErr := se.loadRangeByPrefix(keypath.KeyspaceDestroyRangePrefix(), func(_, v string) {
+		task := &KeyspaceDestroyRange{}
+		if e := json.Unmarshal([]byte(v), task); e != nil {
+			err = errs.ErrJSONUnmarshal.Wrap(e).GenWithStackByCause()
+			return
+		}
+		tasks = append(tasks, task)
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// SaveKeyspaceDestroyRange saves the destroy range task of the keyspace.
+func (*StorageEndpoint) SaveKeyspaceDestroyRange(txn kv.Txn, task *KeyspaceDestroyRange) error {
+	return saveJSONInTxn(txn, keypath.KeyspaceDestroyRangePath(task.KeyspaceID), task)
+}
+
+// DeleteKeyspaceDestroyRange deletes the destroy range task of the given keyspace.
+func (*StorageEndpoint) DeleteKeyspaceDestroyRange(txn kv.Txn, id uint32) error {
+	return txn.Remove(keypath.KeyspaceDestroyRangePath(id))
+}
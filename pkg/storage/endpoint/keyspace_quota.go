@@ -0,0 +1,107 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"encoding/json"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keypath"
+)
+
+// KeyspaceQuotaLimit is the soft and hard limits of a resource, 0 means no limit.
+// Exceeding the soft limit only raises alerts, while exceeding the hard limit
+// also blocks the keyspace from consuming more resources.
+type KeyspaceQuotaLimit struct {
+	Soft uint64 `json:"soft"`
+	Hard uint64 `json:"hard"`
+}
+
+// IsEmpty returns true if neither the soft limit nor the hard limit is set.
+func (l KeyspaceQuotaLimit) IsEmpty() bool {
+	return l.Soft == 0 && l.Hard == 0
+}
+
+// KeyspaceQuota is the resource quota of a keyspace.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type KeyspaceQuota struct {
+	KeyspaceID  uint32             `json:"keyspace-id"`
+	RegionCount KeyspaceQuotaLimit `json:"region-count"`
+	// StorageSize is the limit of the approximate size of the keyspace in MiB.
+	StorageSize        KeyspaceQuotaLimit `json:"storage-size"`
+	ResourceGroupCount KeyspaceQuotaLimit `json:"resource-group-count"`
+}
+
+// IsEmpty returns true if no limit is set in the quota.
+func (q *KeyspaceQuota) IsEmpty() bool {
+	return q.RegionCount.IsEmpty() && q.StorageSize.IsEmpty() && q.ResourceGroupCount.IsEmpty()
+}
+
+// KeyspaceQuotaStorage defines storage operations on the keyspace quotas.
+type KeyspaceQuotaStorage interface {
+	LoadKeyspaceQuota(txn kv.Txn, id uint32) (*KeyspaceQuota, error)
+	// LoadKeyspaceQuotas loads the quotas of all keyspaces.
+	LoadKeyspaceQuotas() ([]*KeyspaceQuota, error)
+	SaveKeyspaceQuota(txn kv.Txn, quota *KeyspaceQuota) error
+	DeleteKeyspaceQuota(txn kv.Txn, id uint32) error
+}
+
+var _ KeyspaceQuotaStorage = (*StorageEndpoint)(nil)
+
+// LoadKeyspaceQuota loads the quota of the given keyspace.
+// If the quota does not exist or error occurs, the returned quota will be nil.
+func (*StorageEndpoint) LoadKeyspaceQuota(txn kv.Txn, id uint32) (*KeyspaceQuota, error) {
+	value, err := txn.Load(keypath.KeyspaceQuotaPath(id))
+	if err != nil || value == "" {
+		return nil, err
+	}
+	quota := &KeyspaceQuota{}
+	if err := json.Unmarshal([]byte(value), quota); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return quota, nil
+}
+
+// LoadKeyspaceQuotas loads the quotas of all keyspaces.
+func (se *StorageEndpoint) LoadKeyspaceQuotas() ([]*KeyspaceQuota, error) {
+	quotas := make([]*KeyspaceQuota, 0)
+	var err error
+	loadErr := se.loadRangeByPrefix(keypath.KeyspaceQuotaPrefix(), func(_, v string) {
+		quota := &KeyspaceQuota{}
+		if e := json.Unmarshal([]byte(v), quota); e != nil {
+			err = errs.ErrJSONUnmarshal.Wrap(e).GenWithStackByCause()
+			return
+		}
+		quotas = append(quotas, quota)
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
+
+// SaveKeyspaceQuota saves the quota of the keyspace.
+func (*StorageEndpoint) SaveKeyspaceQuota(txn kv.Txn, quota *KeyspaceQuota) error {
+	return saveJSONInTxn(txn, keypath.KeyspaceQuotaPath(quota.KeyspaceID), quota)
+}
+
+// DeleteKeyspaceQuota deletes the quota of the given keyspace.
+func (*StorageEndpoint) DeleteKeyspaceQuota(txn kv.Txn, id uint32) error {
+	return txn.Remove(keypath.KeyspaceQuotaPath(id))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keypath"
+)
+
+// KeyspaceTemplateStorage defines storage operations on the keyspace templates.
+type KeyspaceTemplateStorage interface {
+	LoadKeyspaceTemplate(txn kv.Txn, name string) (string, error)
+	// LoadKeyspaceTemplates loads all keyspace templates.
+	LoadKeyspaceTemplates(f func(k, v string)) error
+	SaveKeyspaceTemplate(txn kv.Txn, name string, template any) error
+	DeleteKeyspaceTemplate(txn kv.Txn, name string) error
+}
+
+var _ KeyspaceTemplateStorage = (*StorageEndpoint)(nil)
+
+// LoadKeyspaceTemplate loads the keyspace template with the given name.
+// It returns an empty string if the template does not exist.
+func (*StorageEndpoint) LoadKeyspaceTemplate(txn kv.Txn, name string) (string, error) {
+	return txn.Load(keypath.KeyspaceTemplatePath(name))
+}
+
+// LoadKeyspaceTemplates loads all keyspace templates.
+func (se *StorageEndpoint) LoadKeyspaceTemplates(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.KeyspaceTemplatePrefix(), f)
+}
+
+// SaveKeyspaceTemplate saves the keyspace template.
+func (*StorageEndpoint) SaveKeyspaceTemplate(txn kv.Txn, name string, template any) error {
+	return saveJSONInTxn(txn, keypath.KeyspaceTemplatePath(name), template)
+}
+
+// DeleteKeyspaceTemplate deletes the keyspace template with the given name.
+func (*StorageEndpoint) DeleteKeyspaceTemplate(txn kv.Txn, name string) error {
+	return txn.Remove(keypath.KeyspaceTemplatePath(name))
+}
@@ -15,8 +15,12 @@
 package endpoint
 
 import (
+	"context"
+	"strings"
+
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/utils/keypath"
 )
 
@@ -28,6 +32,12 @@ type ResourceGroupStorage interface {
 	LoadResourceGroupStates(f func(k, v string)) error
 	SaveResourceGroupStates(name string, obj any) error
 	DeleteResourceGroupStates(name string) error
+	LoadResourceGroupParents(f func(k, v string)) error
+	SaveResourceGroupParent(name string, obj any) error
+	DeleteResourceGroupParent(name string) error
+	UpdateResourceGroupUsage(hour int64, keyspaceID uint32, name string, f func(v string) any) error
+	LoadResourceGroupUsageRange(startHour, endHour int64, f func(k, v string)) error
+	RemoveResourceGroupUsageBefore(hour int64) error
 	SaveControllerConfig(config any) error
 	LoadControllerConfig() (string, error)
 }
@@ -64,6 +74,74 @@ func (se *StorageEndpoint) LoadResourceGroupStates(f func(k, v string)) error {
 	return se.loadRangeByPrefix(keypath.ResourceGroupStatePrefix(), f)
 }
 
+// SaveResourceGroupParent stores a parent resource group to storage.
+func (se *StorageEndpoint) SaveResourceGroupParent(name string, obj any) error {
+	return se.saveJSON(keypath.ResourceGroupParentPath(name), obj)
+}
+
+// DeleteResourceGroupParent removes a parent resource group from storage.
+func (se *StorageEndpoint) DeleteResourceGroupParent(name string) error {
+	return se.Remove(keypath.ResourceGroupParentPath(name))
+}
+
+// LoadResourceGroupParents loads all parent resource groups from storage.
+func (se *StorageEndpoint) LoadResourceGroupParents(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.ResourceGroupParentPrefix(), f)
+}
+
+// UpdateResourceGroupUsage updates the resource usage of a resource group in a keyspace within the hour
+// in a transaction, f returns the new usage from the stored one, which is empty if it doesn't exist.
+// The transaction fails with `ErrEtcdTxnConflict` if the usage is modified by others before committing.
+func (se *StorageEndpoint) UpdateResourceGroupUsage(hour int64, keyspaceID uint32, name string, f func(v string) any) error {
+	key := keypath.ResourceGroupUsagePath(hour, keyspaceID, name)
+	return se.RunInTxn(context.Background(), func(txn kv.Txn) error {
+		v, err := txn.Load(key)
+		if err != nil {
+			return err
+		}
+		return saveJSONInTxn(txn, key, f(v))
+	})
+}
+
+// LoadResourceGroupUsageRange loads the resource usage within the hours in [startHour, endHour).
+func (se *StorageEndpoint) LoadResourceGroupUsageRange(startHour, endHour int64, f func(k, v string)) error {
+	nextKey := keypath.ResourceGroupUsageHourPrefix(startHour)
+	endKey := keypath.ResourceGroupUsageHourPrefix(endHour)
+	for {
+		keys, values, err := se.LoadRange(nextKey, endKey, MinKVRangeLimit)
+		if err != nil {
+			return err
+		}
+		for i := range keys {
+			f(strings.TrimPrefix(keys[i], keypath.ResourceGroupUsagePrefix()), values[i])
+		}
+		if len(keys) < MinKVRangeLimit {
+			return nil
+		}
+		nextKey = keys[len(keys)-1] + "\x00"
+	}
+}
+
+// RemoveResourceGroupUsageBefore removes the resource usage within the hours before the given one.
+func (se *StorageEndpoint) RemoveResourceGroupUsageBefore(hour int64) error {
+	startKey := keypath.ResourceGroupUsagePrefix()
+	endKey := keypath.ResourceGroupUsageHourPrefix(hour)
+	for {
+		keys, _, err := se.LoadRange(startKey, endKey, MinKVRangeLimit)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := se.Remove(key); err != nil {
+				return err
+			}
+		}
+		if len(keys) < MinKVRangeLimit {
+			return nil
+		}
+	}
+}
+
 // SaveControllerConfig stores the resource controller config to storage.
 func (se *StorageEndpoint) SaveControllerConfig(config any) error {
 	return se.saveJSON(keypath.ControllerConfigPath(), config)
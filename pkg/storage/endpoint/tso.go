@@ -35,6 +35,15 @@ type TSOStorage interface {
 	LoadTimestamp(groupID uint32) (time.Time, error)
 	SaveTimestamp(groupID uint32, ts time.Time, leadership *election.Leadership) error
 	DeleteTimestamp(groupID uint32) error
+	LoadTimestampMode(groupID uint32) (*TimestampMode, error)
+	SaveTimestampMode(groupID uint32, mode *TimestampMode, leadership *election.Leadership) error
+}
+
+// TimestampMode is the TSO mode of the last leader term which has saved the timestamp. The next
+// leader term needs it to know how far the previous term may have allocated beyond the timestamp.
+type TimestampMode struct {
+	Mode          string        `json:"mode"`
+	MaxClockDrift time.Duration `json:"max-clock-drift"`
 }
 
 var _ TSOStorage = (*StorageEndpoint)(nil)
@@ -82,15 +91,9 @@ func (se *StorageEndpoint) SaveTimestamp(groupID uint32, ts time.Time, leadershi
 		return errors.Errorf("%s due to leadership has not been granted yet", errs.NotLeaderErr)
 	}
 	return se.RunInTxn(context.Background(), func(txn kv.Txn) error {
-		// Ensure the current server is leader by reading and comparing the leader value.
-		leaderValue, err := txn.Load(leadership.GetLeaderKey())
-		if err != nil {
+		if err := checkLeaderInTxn(txn, leadership, logFilds); err != nil {
 			return err
 		}
-		if expected := leadership.GetLeaderValue(); leaderValue != expected {
-			log.Error("leader value does not match", append(logFilds, zap.String("current-leader-value", leaderValue))...)
-			return errors.Errorf("%s due to leader value does not match, current: %s, expected: %s", errs.NotLeaderErr, leaderValue, expected)
-		}
 
 		value, err := txn.Load(keypath.TimestampPath(groupID))
 		if err != nil {
@@ -113,9 +116,52 @@ func (se *StorageEndpoint) SaveTimestamp(groupID uint32, ts time.Time, leadershi
 	})
 }
 
-// DeleteTimestamp deletes the timestamp from the storage.
+// checkLeaderInTxn ensures the current server is leader by reading and comparing the leader value.
+func checkLeaderInTxn(txn kv.Txn, leadership *election.Leadership, logFields []zap.Field) error {
+	leaderValue, err := txn.Load(leadership.GetLeaderKey())
+	if err != nil {
+		return err
+	}
+	if expected := leadership.GetLeaderValue(); leaderValue != expected {
+		log.Error("leader value does not match", append(logFields, zap.String("current-leader-value", leaderValue))...)
+		return errors.Errorf("%s due to leader value does not match, current: %s, expected: %s", errs.NotLeaderErr, leaderValue, expected)
+	}
+	return nil
+}
+
+// DeleteTimestamp deletes the timestamp and its mode from the storage.
 func (se *StorageEndpoint) DeleteTimestamp(groupID uint32) error {
 	return se.RunInTxn(context.Background(), func(txn kv.Txn) error {
-		return txn.Remove(keypath.TimestampPath(groupID))
+		if err := txn.Remove(keypath.TimestampPath(groupID)); err != nil {
+			return err
+		}
+		return txn.Remove(keypath.TimestampModePath(groupID))
+	})
+}
+
+// LoadTimestampMode loads the TSO mode of the last leader term, it returns nil if the mode has
+// never been saved, which means the timestamp is saved in the window mode.
+func (se *StorageEndpoint) LoadTimestampMode(groupID uint32) (*TimestampMode, error) {
+	return loadJSON[*TimestampMode](se, keypath.TimestampModePath(groupID))
+}
+
+// SaveTimestampMode saves the TSO mode of the leader term. Like `SaveTimestamp`, the leadership is
+// checked before saving.
+func (se *StorageEndpoint) SaveTimestampMode(groupID uint32, mode *TimestampMode, leadership *election.Leadership) error {
+	logFields := []zap.Field{
+		zap.Uint32("group-id", groupID),
+		zap.String("mode", mode.Mode),
+		zap.Duration("max-clock-drift", mode.MaxClockDrift),
+		zap.String("leader-key", leadership.GetLeaderKey()),
+		zap.String("expected-leader-value", leadership.GetLeaderValue()),
+	}
+	if len(leadership.GetLeaderValue()) == 0 {
+		return errors.Errorf("%s due to leadership has not been granted yet", errs.NotLeaderErr)
+	}
+	return se.RunInTxn(context.Background(), func(txn kv.Txn) error {
+		if err := checkLeaderInTxn(txn, leadership, logFields); err != nil {
+			return err
+		}
+		return saveJSONInTxn(txn, keypath.TimestampModePath(groupID), mode)
 	})
 }
@@ -17,6 +17,7 @@ package endpoint
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 
@@ -122,6 +123,16 @@ type KeyspaceGroup struct {
 	KeyspaceLookupTable map[uint32]struct{} `json:"-"`
 }
 
+// TSONodeLoad is the TSO request load reported by a TSO node.
+type TSONodeLoad struct {
+	// Address is the service address of the TSO node.
+	Address string `json:"address"`
+	// KeyspaceLoads is the TSO request rate of each keyspace served by the node, in requests per second.
+	KeyspaceLoads map[uint32]float64 `json:"keyspace-loads"`
+	// ReportTime is the time when the load is reported.
+	ReportTime time.Time `json:"report-time"`
+}
+
 // IsSplitting checks if the keyspace group is in split state.
 func (kg *KeyspaceGroup) IsSplitting() bool {
 	return kg != nil && kg.SplitState != nil
@@ -0,0 +1,331 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// defaultOperatorHistoryFlushInterval is the interval to flush the buffered operators into the leveldb.
+const defaultOperatorHistoryFlushInterval = 10 * time.Second
+
+// OperatorHistoryStorage is used to store the finished operators.
+// The operators are buffered in memory and flushed every `defaultOperatorHistoryFlushInterval`,
+// and the data beyond the reserved days will be deleted.
+// Close() must be called after the use.
+type OperatorHistoryStorage struct {
+	*kv.LevelDBKV
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	helper OperatorHistoryStorageHelper
+
+	mu struct {
+		syncutil.Mutex
+		batch map[string]*HistoryOperator
+		// seq distinguishes the operators of the same region finished in the same millisecond.
+		seq uint64
+	}
+	// flushMu serializes the flushes, so the buffered operators are visible once a flush returns.
+	flushMu syncutil.Mutex
+}
+
+// HistoryOperators wraps HistoryOperator.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type HistoryOperators struct {
+	HistoryOperator []*HistoryOperator `json:"history_operator"`
+}
+
+// HistoryOperator is the storage format of a finished operator.
+type HistoryOperator struct {
+	RegionID uint64 `json:"region_id"`
+	// Desc is the name of the scheduler or checker which creates the operator.
+	Desc         string   `json:"desc"`
+	Brief        string   `json:"brief"`
+	Kind         string   `json:"kind"`
+	Status       string   `json:"status"`
+	Steps        []string `json:"steps"`
+	StoreIDs     []uint64 `json:"store_ids"`
+	CancelReason string   `json:"cancel_reason,omitempty"`
+	// StartTime and FinishTime are in ms.
+	StartTime  int64 `json:"start_time"`
+	FinishTime int64 `json:"finish_time"`
+}
+
+// OperatorHistoryStorageHelper helps operator history storage get its config.
+type OperatorHistoryStorageHelper interface {
+	// GetOperatorHistoryReservedDays gets days operator history is kept.
+	GetOperatorHistoryReservedDays() uint64
+}
+
+// NewOperatorHistoryStorage creates storage to store the finished operators.
+func NewOperatorHistoryStorage(
+	ctx context.Context,
+	filePath string,
+	helper OperatorHistoryStorageHelper,
+) (*OperatorHistoryStorage, error) {
+	levelDB, err := kv.NewLevelDBKV(filePath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &OperatorHistoryStorage{
+		LevelDBKV: levelDB,
+		ctx:       ctx,
+		cancel:    cancel,
+		helper:    helper,
+	}
+	s.mu.batch = make(map[string]*HistoryOperator)
+	s.wg.Add(2)
+	go s.backgroundFlush()
+	go s.backgroundDelete()
+	return s, nil
+}
+
+// PutOperatorHistory buffers a finished operator, it will be flushed in the background.
+func (s *OperatorHistoryStorage) PutOperatorHistory(op *HistoryOperator) {
+	if s.helper.GetOperatorHistoryReservedDays() == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.seq++
+	s.mu.batch[OperatorHistoryPath(op.FinishTime, op.RegionID, s.mu.seq)] = op
+}
+
+func (s *OperatorHistoryStorage) backgroundFlush() {
+	defer logutil.LogPanic()
+
+	ticker := time.NewTicker(defaultOperatorHistoryFlushInterval)
+	defer func() {
+		ticker.Stop()
+		s.wg.Done()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.flush(); err != nil {
+				log.Error("flush operator history meet error", errs.ZapError(err))
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Delete the operators whose finish time is before time.Now() minus reserved days in the background.
+func (s *OperatorHistoryStorage) backgroundDelete() {
+	defer logutil.LogPanic()
+
+	// make delete happened in defaultDeleteTime clock.
+	now := time.Now()
+	next := time.Date(now.Year(), now.Month(), now.Day(), defaultDeleteTime, 0, 0, 0, now.Location())
+	d := next.Sub(now)
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	isFirst := true
+	ticker := time.NewTicker(d)
+	defer func() {
+		ticker.Stop()
+		s.wg.Done()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			if isFirst {
+				ticker.Reset(24 * time.Hour)
+				isFirst = false
+			}
+			reservedDays := s.helper.GetOperatorHistoryReservedDays()
+			if reservedDays == 0 {
+				continue
+			}
+			if err := s.delete(int(reservedDays)); err != nil {
+				log.Error("delete operator history meet error", errs.ZapError(err))
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *OperatorHistoryStorage) flush() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+	// Take the buffered operators out and write them without holding the lock,
+	// so that buffering the finished operators is not blocked by the IO.
+	s.mu.Lock()
+	ops := s.mu.batch
+	s.mu.batch = make(map[string]*HistoryOperator)
+	s.mu.Unlock()
+	if len(ops) == 0 {
+		return nil
+	}
+	err := s.write(ops)
+	if err != nil {
+		// Put the operators back to retry in the next flush.
+		s.mu.Lock()
+		for key, op := range ops {
+			s.mu.batch[key] = op
+		}
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *OperatorHistoryStorage) write(ops map[string]*HistoryOperator) error {
+	batch := new(leveldb.Batch)
+	for key, op := range ops {
+		value, err := json.Marshal(op)
+		if err != nil {
+			return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+		}
+		batch.Put([]byte(key), value)
+	}
+	if err := s.Write(batch, nil); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	return nil
+}
+
+func (s *OperatorHistoryStorage) delete(reservedDays int) error {
+	endTime := time.Now().AddDate(0, 0, 0-reservedDays).UnixNano() / int64(time.Millisecond)
+	iter := s.LevelDBKV.NewIterator(&util.Range{
+		Start: []byte(OperatorHistoryPath(0, 0, 0)),
+		Limit: []byte(OperatorHistoryPath(endTime, math.MaxUint64, math.MaxUint64)),
+	}, nil)
+	defer iter.Release()
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	if err := s.Write(batch, nil); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	return nil
+}
+
+// NewIterator returns an iterator which traverses the operators finished in [startTime, endTime] in ms.
+// The buffered operators are flushed before iterating so that they can be found.
+func (s *OperatorHistoryStorage) NewIterator(startTime, endTime int64) (*OperatorHistoryIterator, error) {
+	if err := s.flush(); err != nil {
+		return nil, err
+	}
+	iter := s.LevelDBKV.NewIterator(&util.Range{
+		Start: []byte(OperatorHistoryPath(startTime, 0, 0)),
+		Limit: []byte(OperatorHistoryPath(endTime, math.MaxUint64, math.MaxUint64)),
+	}, nil)
+	return &OperatorHistoryIterator{iter: iter}, nil
+}
+
+// LoadOperatorHistory loads the operators finished in [startTime, endTime] in ms. If regionIDs or
+// storeIDs are given, only the operators of the regions or related to the stores are returned.
+func (s *OperatorHistoryStorage) LoadOperatorHistory(startTime, endTime int64, regionIDs, storeIDs []uint64) (*HistoryOperators, error) {
+	iter, err := s.NewIterator(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	regionSet, storeSet := make(map[uint64]bool), make(map[uint64]bool)
+	for _, id := range regionIDs {
+		regionSet[id] = true
+	}
+	for _, id := range storeIDs {
+		storeSet[id] = true
+	}
+	results := make([]*HistoryOperator, 0)
+	var next *HistoryOperator
+	for next, err = iter.Next(); next != nil && err == nil; next, err = iter.Next() {
+		if len(regionSet) != 0 && !regionSet[next.RegionID] {
+			continue
+		}
+		if len(storeSet) != 0 && !slices.ContainsFunc(next.StoreIDs, func(id uint64) bool { return storeSet[id] }) {
+			continue
+		}
+		results = append(results, next)
+	}
+	return &HistoryOperators{
+		HistoryOperator: results,
+	}, err
+}
+
+// Close flushes the buffered operators and closes the kv.
+func (s *OperatorHistoryStorage) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	if err := s.flush(); err != nil {
+		log.Error("flush operator history meet error", errs.ZapError(err))
+	}
+	if err := s.LevelDBKV.Close(); err != nil {
+		return errs.ErrLevelDBClose.Wrap(err).GenWithStackByArgs()
+	}
+	return nil
+}
+
+// OperatorHistoryIterator iterates over the history operators.
+// Release() must be called after the use.
+type OperatorHistoryIterator struct {
+	iter iterator.Iterator
+}
+
+// Next moves the iterator to the next operator and returns it.
+// It will return (nil, nil) if there is no more operator.
+func (it *OperatorHistoryIterator) Next() (*HistoryOperator, error) {
+	if !it.iter.Next() {
+		return nil, it.iter.Error()
+	}
+	var op HistoryOperator
+	if err := json.Unmarshal(it.iter.Value(), &op); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return &op, nil
+}
+
+// Release releases the iterator.
+func (it *OperatorHistoryIterator) Release() {
+	it.iter.Release()
+}
+
+// OperatorHistoryPath generates the key of an operator for OperatorHistoryStorage.
+// The seq distinguishes the operators of the same region finished in the same millisecond.
+func OperatorHistoryPath(finishTime int64, regionID, seq uint64) string {
+	return path.Join(
+		"schedule",
+		"operator_history",
+		fmt.Sprintf("%020d", finishTime),
+		fmt.Sprintf("%020d", regionID),
+		fmt.Sprintf("%020d", seq),
+	)
+}
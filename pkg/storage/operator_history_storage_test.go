@@ -0,0 +1,144 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockOperatorHistoryHelper struct {
+	reservedDays uint64
+}
+
+func (m *mockOperatorHistoryHelper) GetOperatorHistoryReservedDays() uint64 {
+	return m.reservedDays
+}
+
+func newTestOperatorHistoryStorage(t *testing.T, reservedDays uint64) *OperatorHistoryStorage {
+	s, err := NewOperatorHistoryStorage(context.Background(), t.TempDir(), &mockOperatorHistoryHelper{reservedDays: reservedDays})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	return s
+}
+
+func collectHistoryOperators(re *require.Assertions, s *OperatorHistoryStorage, startTime, endTime int64) []*HistoryOperator {
+	iter, err := s.NewIterator(startTime, endTime)
+	re.NoError(err)
+	defer iter.Release()
+	var ops []*HistoryOperator
+	for {
+		op, err := iter.Next()
+		re.NoError(err)
+		if op == nil {
+			return ops
+		}
+		ops = append(ops, op)
+	}
+}
+
+func TestOperatorHistoryWrite(t *testing.T) {
+	re := require.New(t)
+	s := newTestOperatorHistoryStorage(t, 1)
+	now := time.Now().UnixMilli()
+	for i := range 5 {
+		s.PutOperatorHistory(&HistoryOperator{
+			RegionID:   uint64(i),
+			Desc:       "balance-region",
+			Kind:       "region",
+			Status:     "SUCCESS",
+			Steps:      []string{"add learner peer 1 on store 1"},
+			StoreIDs:   []uint64{1},
+			StartTime:  now + int64(i)*1000 - 100,
+			FinishTime: now + int64(i)*1000,
+		})
+	}
+	ops := collectHistoryOperators(re, s, now, now+10*1000)
+	re.Len(ops, 5)
+	for i, op := range ops {
+		re.Equal(uint64(i), op.RegionID)
+		re.Equal("balance-region", op.Desc)
+		re.Equal([]uint64{1}, op.StoreIDs)
+	}
+	// only the operators finished in the time range are returned.
+	ops = collectHistoryOperators(re, s, now+1000, now+2000)
+	re.Len(ops, 2)
+	re.Equal(uint64(1), ops[0].RegionID)
+	re.Equal(uint64(2), ops[1].RegionID)
+}
+
+func TestOperatorHistoryDelete(t *testing.T) {
+	re := require.New(t)
+	s := newTestOperatorHistoryStorage(t, 7)
+	finishTime := time.Now()
+	for i := range 30 {
+		s.PutOperatorHistory(&HistoryOperator{
+			RegionID:   uint64(i),
+			FinishTime: finishTime.UnixMilli(),
+		})
+		finishTime = finishTime.AddDate(0, 0, -1)
+	}
+	re.NoError(s.flush())
+	re.NoError(s.delete(7))
+	ops := collectHistoryOperators(re, s, finishTime.UnixMilli(), time.Now().UnixMilli())
+	re.Len(ops, 7)
+}
+
+func TestOperatorHistoryDisabled(t *testing.T) {
+	re := require.New(t)
+	s := newTestOperatorHistoryStorage(t, 0)
+	s.PutOperatorHistory(&HistoryOperator{RegionID: 1, FinishTime: time.Now().UnixMilli()})
+	re.Empty(collectHistoryOperators(re, s, 0, time.Now().UnixMilli()))
+}
+
+func TestOperatorHistoryLoad(t *testing.T) {
+	re := require.New(t)
+	s := newTestOperatorHistoryStorage(t, 1)
+	now := time.Now().UnixMilli()
+	// The operators of the same region finished in the same millisecond are all kept.
+	for i := range 3 {
+		s.PutOperatorHistory(&HistoryOperator{
+			RegionID:   1,
+			Desc:       fmt.Sprintf("op-%d", i),
+			StoreIDs:   []uint64{uint64(i + 1)},
+			FinishTime: now,
+		})
+	}
+	s.PutOperatorHistory(&HistoryOperator{RegionID: 2, StoreIDs: []uint64{1}, FinishTime: now})
+	history, err := s.LoadOperatorHistory(now, now, nil, nil)
+	re.NoError(err)
+	re.Len(history.HistoryOperator, 4)
+	history, err = s.LoadOperatorHistory(now, now, []uint64{1}, nil)
+	re.NoError(err)
+	re.Len(history.HistoryOperator, 3)
+	history, err = s.LoadOperatorHistory(now, now, nil, []uint64{1})
+	re.NoError(err)
+	re.Len(history.HistoryOperator, 2)
+	history, err = s.LoadOperatorHistory(now, now, []uint64{1}, []uint64{3})
+	re.NoError(err)
+	re.Len(history.HistoryOperator, 1)
+	re.Equal("op-2", history.HistoryOperator[0].Desc)
+	// The flushed operators are not written again.
+	re.NoError(s.flush())
+	s.mu.Lock()
+	re.Empty(s.mu.batch)
+	s.mu.Unlock()
+}
@@ -48,6 +48,7 @@ type Storage interface {
 	endpoint.ResourceGroupStorage
 	endpoint.TSOStorage
 	endpoint.KeyspaceGroupStorage
+	endpoint.StoreDrainStorage
 }
 
 // NewStorageWithMemoryBackend creates a new storage with memory backend.
@@ -28,6 +28,7 @@ import (
 // Handler defines the common behaviors of a basic tso handler.
 type Handler interface {
 	ResetTS(ts uint64, ignoreSmaller, skipUpperBoundCheck bool, keyspaceGroupID uint32) error
+	VerifyTSOAudit(keyspaceGroupID uint32) (*AuditReport, error)
 }
 
 // AdminHandler wrap the basic tso handler to provide http service.
@@ -113,3 +114,34 @@ func (h *AdminHandler) ResetTS(w http.ResponseWriter, r *http.Request) {
 	}
 	h.rd.JSON(w, http.StatusOK, "Reset ts successfully.")
 }
+
+// VerifyTSOAudit is the http.HandlerFunc of VerifyTSOAudit
+// @Tags     admin
+// @Summary  Verify the monotonicity of the TSO with the audit log and the etcd history.
+// @Param    keyspace-group-id  query  integer  false  "The keyspace group ID"
+// @Produce  json
+// @Success  200  {object}  AuditReport
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "TSO server failed to proceed the request."
+// @Router   /admin/tso/audit [get]
+func (h *AdminHandler) VerifyTSOAudit(w http.ResponseWriter, r *http.Request) {
+	var keyspaceGroupID uint32
+	if value := r.URL.Query().Get("keyspace-group-id"); len(value) > 0 {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid keyspace group id")
+			return
+		}
+		keyspaceGroupID = uint32(id)
+	}
+	report, err := h.handler.VerifyTSOAudit(keyspaceGroupID)
+	if err != nil {
+		if errs.ErrKeyspaceGroupIDInvalid.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, report)
+}
@@ -144,6 +144,8 @@ func NewAllocator(
 			saveInterval:           cfg.GetTSOSaveInterval(),
 			updatePhysicalInterval: cfg.GetTSOUpdatePhysicalInterval(),
 			maxResetTSGap:          cfg.GetMaxResetTSGap,
+			mode:                   cfg.GetTSOMode(keyspaceGroupID),
+			maxClockDrift:          cfg.GetTSOMaxClockDrift(),
 			tsoMux:                 &tsoObject{},
 			metrics:                newTSOMetrics(keyspaceGroupIDStr, GlobalDCLocation),
 		},
@@ -155,6 +157,16 @@ func NewAllocator(
 		},
 	}
 
+	if dir := cfg.GetTSOAuditLogDir(); len(dir) > 0 {
+		auditLog, err := NewAuditLog(dir, keyspaceGroupID)
+		if err != nil {
+			// The audit log is optional, so it does not prevent the allocator from working.
+			log.Error("failed to open the tso audit log", append(a.logFields, errs.ZapError(err))...)
+		} else {
+			a.timestampOracle.auditLog = auditLog
+		}
+	}
+
 	a.wg.Add(1)
 	go a.allocatorUpdater()
 
@@ -200,6 +212,11 @@ func (a *Allocator) Close() {
 	log.Info("closing the allocator", a.logFields...)
 	a.cancel()
 	a.wg.Wait()
+	if a.timestampOracle.auditLog != nil {
+		if err := a.timestampOracle.auditLog.Close(); err != nil {
+			log.Warn("failed to close the tso audit log", append(a.logFields, errs.ZapError(err))...)
+		}
+	}
 	log.Info("closed the allocator", a.logFields...)
 }
 
@@ -0,0 +1,449 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/keypath"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	// maxAuditHistoryRevisions is the max number of the historical revisions of the timestamp
+	// window loaded from etcd when verifying the audit log.
+	maxAuditHistoryRevisions = 1000
+	// maxAuditTermRecords is the max number of the latest leader term records loaded from etcd
+	// when verifying the audit log.
+	maxAuditTermRecords = 1000
+	// auditTermRetention is how long the leader term records are kept in etcd.
+	auditTermRetention = 30 * 24 * time.Hour
+	// verifyAuditTimeout is the timeout of loading the audit data from etcd.
+	verifyAuditTimeout = 30 * time.Second
+)
+
+// AuditEvent is the type of the TSO audit record.
+type AuditEvent string
+
+const (
+	// AuditEventSync means the allocator is initialized after it becomes the leader, which
+	// starts a new leader term. It is recorded after the first window of the term is saved,
+	// and it is also persisted in etcd so the verifier on any member could see all the terms.
+	AuditEventSync AuditEvent = "sync"
+	// AuditEventSave means a timestamp window upper bound is persisted.
+	AuditEventSave AuditEvent = "save"
+	// AuditEventReset means the timestamp is reset by the user.
+	AuditEventReset AuditEvent = "reset"
+)
+
+// AuditRecord is a record of the TSO audit log.
+type AuditRecord struct {
+	Time            time.Time  `json:"time"`
+	Event           AuditEvent `json:"event"`
+	KeyspaceGroupID uint32     `json:"keyspace-group-id"`
+	MemberID        uint64     `json:"member-id"`
+	// Term is the lease ID of the leadership, which is granted for each election.
+	Term int64 `json:"term"`
+	// Physical is the physical time to allocate from, it is set by the sync and reset events.
+	Physical time.Time `json:"physical"`
+	Logical  int64     `json:"logical,omitempty"`
+	// Window is the persisted timestamp window upper bound, it is set by the save and sync events.
+	Window time.Time `json:"window"`
+}
+
+// AuditLog is an append-only local log of the TSO audit records.
+type AuditLog struct {
+	syncutil.Mutex
+	path string
+	file *os.File
+}
+
+// NewAuditLog opens the audit log of the keyspace group in the given directory.
+func NewAuditLog(dir string, keyspaceGroupID uint32) (*AuditLog, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errs.ErrTSOAudit.Wrap(err).GenWithStackByArgs("failed to create the audit log directory")
+	}
+	path := filepath.Join(dir, fmt.Sprintf("tso-audit-%05d.log", keyspaceGroupID))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, errs.ErrTSOAudit.Wrap(err).GenWithStackByArgs("failed to open the audit log")
+	}
+	return &AuditLog{path: path, file: file}, nil
+}
+
+// Append appends the record to the log and syncs it to the disk.
+func (l *AuditLog) Append(record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	l.Lock()
+	defer l.Unlock()
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return errs.ErrTSOAudit.Wrap(err).GenWithStackByArgs("failed to write the audit log")
+	}
+	return l.file.Sync()
+}
+
+// Read reads all the records of the log.
+func (l *AuditLog) Read() ([]*AuditRecord, error) {
+	l.Lock()
+	defer l.Unlock()
+	return ReadAuditLog(l.path)
+}
+
+// Close closes the log.
+func (l *AuditLog) Close() error {
+	l.Lock()
+	defer l.Unlock()
+	return l.file.Close()
+}
+
+// ReadAuditLog reads all the records of the audit log file.
+func ReadAuditLog(path string) ([]*AuditRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errs.ErrTSOAudit.Wrap(err).GenWithStackByArgs("failed to open the audit log")
+	}
+	defer file.Close()
+	var records []*AuditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			// The last record may be partially written if the process crashed.
+			log.Warn("skip the corrupted tso audit record", zap.String("path", path), zap.ByteString("record", scanner.Bytes()), zap.Error(err))
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.ErrTSOAudit.Wrap(err).GenWithStackByArgs("failed to read the audit log")
+	}
+	return records, nil
+}
+
+// TimestampRevision is a historical value of the timestamp window persisted in etcd.
+type TimestampRevision struct {
+	Revision int64     `json:"revision"`
+	Window   time.Time `json:"window"`
+}
+
+// AuditViolation is a violation of the TSO monotonicity found by the verifier.
+type AuditViolation struct {
+	// Source is where the violation is found, it is either "audit-log" or "etcd".
+	Source   string    `json:"source"`
+	Time     time.Time `json:"time"`
+	Revision int64     `json:"revision,omitempty"`
+	Term     int64     `json:"term,omitempty"`
+	Reason   string    `json:"reason"`
+}
+
+// AuditReport is the result of verifying the TSO audit log.
+type AuditReport struct {
+	KeyspaceGroupID uint32            `json:"keyspace-group-id"`
+	Records         int               `json:"records"`
+	Terms           int               `json:"terms"`
+	Revisions       int               `json:"revisions"`
+	MaxWindow       time.Time         `json:"max-window"`
+	Violations      []*AuditViolation `json:"violations"`
+}
+
+// mergeAuditRecords merges the leader term records of all the members loaded from etcd into
+// the local audit records in time order. The terms already in the local records are skipped.
+func mergeAuditRecords(records, terms []*AuditRecord) []*AuditRecord {
+	type termKey struct {
+		memberID uint64
+		term     int64
+	}
+	local := make(map[termKey]struct{})
+	for _, record := range records {
+		if record.Event == AuditEventSync {
+			local[termKey{record.MemberID, record.Term}] = struct{}{}
+		}
+	}
+	merged := make([]*AuditRecord, 0, len(records)+len(terms))
+	merged = append(merged, records...)
+	for _, record := range terms {
+		if _, ok := local[termKey{record.MemberID, record.Term}]; !ok {
+			merged = append(merged, record)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged
+}
+
+// VerifyAudit replays the audit records and the etcd history of the timestamp window, which
+// are both in time order, and reports the timestamp ranges that may overlap. Namely:
+//   - The persisted windows must be strictly increasing.
+//   - A new leader term must allocate from a time after all the windows of the previous terms,
+//     since the previous leader may have allocated the timestamps up to its window.
+//   - A reset must not move the physical time backwards in the same term.
+//   - The window in etcd must not be behind the windows recorded in the audit log.
+func VerifyAudit(keyspaceGroupID uint32, records []*AuditRecord, history []*TimestampRevision) *AuditReport {
+	report := &AuditReport{
+		KeyspaceGroupID: keyspaceGroupID,
+		Records:         len(records),
+		Revisions:       len(history),
+		Violations:      make([]*AuditViolation, 0),
+	}
+	var (
+		maxWindow time.Time
+		// prevWindow is the max window of the previous terms.
+		prevWindow   time.Time
+		lastTerm     int64
+		termPhysical time.Time
+	)
+	addViolation := func(record *AuditRecord, format string, args ...any) {
+		report.Violations = append(report.Violations, &AuditViolation{
+			Source: "audit-log",
+			Time:   record.Time,
+			Term:   record.Term,
+			Reason: fmt.Sprintf(format, args...),
+		})
+	}
+	for _, record := range records {
+		if record.Term != lastTerm {
+			report.Terms++
+			lastTerm = record.Term
+			termPhysical = typeutil.ZeroTime
+			prevWindow = maxWindow
+		}
+		switch record.Event {
+		case AuditEventSync:
+			if !prevWindow.IsZero() && typeutil.SubRealTimeByWallClock(record.Physical, prevWindow) < 0 {
+				addViolation(record, "the term starts from %s which overlaps with the previous window %s",
+					record.Physical.Format(time.RFC3339Nano), prevWindow.Format(time.RFC3339Nano))
+			}
+			termPhysical = record.Physical
+		case AuditEventSave:
+			if !maxWindow.IsZero() && typeutil.SubRealTimeByWallClock(record.Window, maxWindow) <= 0 {
+				addViolation(record, "the window %s is not greater than the previous window %s",
+					record.Window.Format(time.RFC3339Nano), maxWindow.Format(time.RFC3339Nano))
+			}
+		case AuditEventReset:
+			if !termPhysical.IsZero() && typeutil.SubRealTimeByWallClock(record.Physical, termPhysical) < 0 {
+				addViolation(record, "the timestamp is reset to %s which is less than %s",
+					record.Physical.Format(time.RFC3339Nano), termPhysical.Format(time.RFC3339Nano))
+			}
+			termPhysical = record.Physical
+		}
+		if record.Window.After(maxWindow) {
+			maxWindow = record.Window
+		}
+	}
+
+	var prev *TimestampRevision
+	for _, rev := range history {
+		if prev != nil && typeutil.SubRealTimeByWallClock(rev.Window, prev.Window) <= 0 {
+			report.Violations = append(report.Violations, &AuditViolation{
+				Source:   "etcd",
+				Revision: rev.Revision,
+				Reason: fmt.Sprintf("the window %s is not greater than the window %s of revision %d",
+					rev.Window.Format(time.RFC3339Nano), prev.Window.Format(time.RFC3339Nano), prev.Revision),
+			})
+		}
+		prev = rev
+	}
+	if prev != nil && !maxWindow.IsZero() && typeutil.SubRealTimeByWallClock(prev.Window, maxWindow) < 0 {
+		report.Violations = append(report.Violations, &AuditViolation{
+			Source:   "etcd",
+			Revision: prev.Revision,
+			Reason: fmt.Sprintf("the current window %s is behind the audited window %s",
+				prev.Window.Format(time.RFC3339Nano), maxWindow.Format(time.RFC3339Nano)),
+		})
+	}
+	if prev != nil && prev.Window.After(maxWindow) {
+		maxWindow = prev.Window
+	}
+	report.MaxWindow = maxWindow
+	return report
+}
+
+// loadTimestampHistory loads the historical values of the timestamp window from etcd in
+// revision order. The revisions which have been compacted are not returned.
+func loadTimestampHistory(ctx context.Context, client *clientv3.Client, keyspaceGroupID uint32) ([]*TimestampRevision, error) {
+	get := func(key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+		ctx, cancel := context.WithTimeout(ctx, etcdutil.DefaultRequestTimeout)
+		defer cancel()
+		return client.Get(ctx, key, opts...)
+	}
+	key := keypath.TimestampPath(keyspaceGroupID)
+	var (
+		history []*TimestampRevision
+		rev     int64
+	)
+	for len(history) < maxAuditHistoryRevisions {
+		opts := []clientv3.OpOption{}
+		if rev > 0 {
+			opts = append(opts, clientv3.WithRev(rev))
+		}
+		resp, err := get(key, opts...)
+		if errors.Is(err, rpctypes.ErrCompacted) {
+			break
+		}
+		if err != nil {
+			return nil, errs.ErrEtcdKVGet.Wrap(err).GenWithStackByCause()
+		}
+		if len(resp.Kvs) == 0 {
+			break
+		}
+		kv := resp.Kvs[0]
+		window, err := typeutil.ParseTimestamp(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, &TimestampRevision{Revision: kv.ModRevision, Window: window})
+		// The key is created or recreated at this revision.
+		if kv.Version <= 1 {
+			break
+		}
+		rev = kv.ModRevision - 1
+	}
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// loadAuditTerms loads the latest leader term records of all the members from etcd in time order.
+func loadAuditTerms(ctx context.Context, client *clientv3.Client, keyspaceGroupID uint32) ([]*AuditRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	resp, err := client.Get(ctx, keypath.TimestampAuditTermPrefix(keyspaceGroupID), clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend), clientv3.WithLimit(maxAuditTermRecords))
+	if err != nil {
+		return nil, errs.ErrEtcdKVGet.Wrap(err).GenWithStackByCause()
+	}
+	records := make([]*AuditRecord, 0, len(resp.Kvs))
+	for i := len(resp.Kvs) - 1; i >= 0; i-- {
+		record := &AuditRecord{}
+		if err := json.Unmarshal(resp.Kvs[i].Value, record); err != nil {
+			log.Warn("skip the corrupted tso audit term record", zap.ByteString("key", resp.Kvs[i].Key), zap.Error(err))
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// saveAuditTerm persists the record of the leader term in etcd and removes the expired ones.
+func (t *timestampOracle) saveAuditTerm(record *AuditRecord) error {
+	client := t.member.Client()
+	if client == nil {
+		return nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	key := keypath.TimestampAuditTermPath(t.keyspaceGroupID, record.Time.UnixNano())
+	if _, err := client.Put(ctx, key, string(data)); err != nil {
+		return errs.ErrEtcdKVPut.Wrap(err).GenWithStackByCause()
+	}
+	end := keypath.TimestampAuditTermPath(t.keyspaceGroupID, record.Time.Add(-auditTermRetention).UnixNano())
+	if _, err := client.Delete(ctx, keypath.TimestampAuditTermPrefix(t.keyspaceGroupID), clientv3.WithRange(end)); err != nil {
+		return errs.ErrEtcdKVDelete.Wrap(err).GenWithStackByCause()
+	}
+	return nil
+}
+
+func (t *timestampOracle) audit(record *AuditRecord) {
+	if t.auditLog == nil {
+		return
+	}
+	record.Time = time.Now()
+	record.KeyspaceGroupID = t.keyspaceGroupID
+	record.MemberID = t.member.ID()
+	record.Term = t.leaderTerm()
+	if err := t.auditLog.Append(record); err != nil {
+		log.Error("failed to append the tso audit record",
+			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+			zap.String("event", string(record.Event)), errs.ZapError(err))
+	}
+	if record.Event != AuditEventSync {
+		return
+	}
+	if err := t.saveAuditTerm(record); err != nil {
+		log.Error("failed to save the tso audit term record",
+			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+			errs.ZapError(err))
+	}
+}
+
+func (t *timestampOracle) leaderTerm() int64 {
+	leadership := t.member.GetLeadership()
+	if leadership == nil {
+		return 0
+	}
+	lease := leadership.GetLease()
+	if lease == nil {
+		return 0
+	}
+	if id, ok := lease.ID.Load().(clientv3.LeaseID); ok {
+		return int64(id)
+	}
+	return 0
+}
+
+// VerifyAudit verifies the audit log of the allocator and the leader terms of all the members
+// with the etcd history of the timestamp window.
+func (a *Allocator) VerifyAudit(ctx context.Context) (*AuditReport, error) {
+	auditLog := a.timestampOracle.auditLog
+	if auditLog == nil {
+		return nil, errs.ErrTSOAudit.FastGenByArgs("the audit log is not enabled")
+	}
+	records, err := auditLog.Read()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, verifyAuditTimeout)
+	defer cancel()
+	client := a.member.Client()
+	terms, err := loadAuditTerms(ctx, client, a.keyspaceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := loadTimestampHistory(ctx, client, a.keyspaceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyAudit(a.keyspaceGroupID, mergeAuditRecords(records, terms), history), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	re := require.New(t)
+	dir := t.TempDir()
+	auditLog, err := NewAuditLog(dir, 1)
+	re.NoError(err)
+	now := time.Now()
+	re.NoError(auditLog.Append(&AuditRecord{Event: AuditEventSync, Term: 1, Physical: now}))
+	re.NoError(auditLog.Append(&AuditRecord{Event: AuditEventSave, Term: 1, Window: now.Add(3 * time.Second)}))
+	re.NoError(auditLog.Close())
+
+	// The log is appended after reopening.
+	auditLog, err = NewAuditLog(dir, 1)
+	re.NoError(err)
+	defer auditLog.Close()
+	re.NoError(auditLog.Append(&AuditRecord{Event: AuditEventSave, Term: 1, Window: now.Add(6 * time.Second)}))
+	records, err := auditLog.Read()
+	re.NoError(err)
+	re.Len(records, 3)
+	re.Equal(AuditEventSync, records[0].Event)
+	re.True(now.Equal(records[0].Physical))
+	re.True(now.Add(6 * time.Second).Equal(records[2].Window))
+}
+
+func TestVerifyAudit(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	// The sync record is written after the first window of the term is saved.
+	records := []*AuditRecord{
+		{Event: AuditEventSave, Term: 1, Window: now.Add(3 * time.Second)},
+		{Event: AuditEventSync, Term: 1, Physical: now, Window: now.Add(3 * time.Second)},
+		{Event: AuditEventSave, Term: 1, Window: now.Add(6 * time.Second)},
+		{Event: AuditEventReset, Term: 1, Physical: now.Add(4 * time.Second)},
+		{Event: AuditEventSave, Term: 2, Window: now.Add(10 * time.Second)},
+		{Event: AuditEventSync, Term: 2, Physical: now.Add(7 * time.Second), Window: now.Add(10 * time.Second)},
+	}
+	history := []*TimestampRevision{
+		{Revision: 10, Window: now.Add(3 * time.Second)},
+		{Revision: 11, Window: now.Add(6 * time.Second)},
+		{Revision: 12, Window: now.Add(10 * time.Second)},
+	}
+	report := VerifyAudit(0, records, history)
+	re.Empty(report.Violations)
+	re.Equal(6, report.Records)
+	re.Equal(2, report.Terms)
+	re.Equal(3, report.Revisions)
+	re.True(now.Add(10 * time.Second).Equal(report.MaxWindow))
+
+	// The clock of the new leader jumps back.
+	records = append(records,
+		&AuditRecord{Event: AuditEventSave, Term: 3, Window: now.Add(9 * time.Second)},
+		&AuditRecord{Event: AuditEventSync, Term: 3, Physical: now.Add(8 * time.Second), Window: now.Add(9 * time.Second)},
+	)
+	// The window in etcd is restored to an old one.
+	history = append(history, &TimestampRevision{Revision: 13, Window: now.Add(5 * time.Second)})
+	report = VerifyAudit(0, records, history)
+	re.Len(report.Violations, 4)
+	re.Equal("audit-log", report.Violations[0].Source)
+	re.Equal(int64(3), report.Violations[0].Term)
+	re.Contains(report.Violations[0].Reason, "not greater than")
+	re.Contains(report.Violations[1].Reason, "overlaps")
+	re.Equal("etcd", report.Violations[2].Source)
+	re.Equal(int64(13), report.Violations[2].Revision)
+	re.Contains(report.Violations[3].Reason, "behind")
+}
+
+func TestVerifyAuditWithOtherMembers(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	// The local member serves term 1 and term 3.
+	records := []*AuditRecord{
+		{Time: now, Event: AuditEventSave, MemberID: 1, Term: 1, Window: now.Add(3 * time.Second)},
+		{Time: now, Event: AuditEventSync, MemberID: 1, Term: 1, Physical: now, Window: now.Add(3 * time.Second)},
+		{Time: now.Add(20 * time.Second), Event: AuditEventSave, MemberID: 1, Term: 3, Window: now.Add(23 * time.Second)},
+		{Time: now.Add(20 * time.Second), Event: AuditEventSync, MemberID: 1, Term: 3, Physical: now.Add(20 * time.Second), Window: now.Add(23 * time.Second)},
+	}
+	// The term records in etcd contain the terms of all the members.
+	terms := []*AuditRecord{
+		{Time: now, Event: AuditEventSync, MemberID: 1, Term: 1, Physical: now, Window: now.Add(3 * time.Second)},
+		{Time: now.Add(10 * time.Second), Event: AuditEventSync, MemberID: 2, Term: 2, Physical: now.Add(10 * time.Second), Window: now.Add(25 * time.Second)},
+		{Time: now.Add(20 * time.Second), Event: AuditEventSync, MemberID: 1, Term: 3, Physical: now.Add(20 * time.Second), Window: now.Add(23 * time.Second)},
+	}
+	merged := mergeAuditRecords(records, terms)
+	re.Len(merged, 5)
+	re.Equal(uint64(2), merged[2].MemberID)
+
+	// Only the local log can't find the overlap with the term of the other member.
+	re.Empty(VerifyAudit(0, records, nil).Violations)
+	report := VerifyAudit(0, merged, nil)
+	re.Equal(3, report.Terms)
+	re.Len(report.Violations, 2)
+	re.Equal(int64(3), report.Violations[0].Term)
+	re.Contains(report.Violations[0].Reason, "not greater than")
+	re.Contains(report.Violations[1].Reason, "overlaps")
+}
@@ -44,6 +44,12 @@ type Config interface {
 	GetTSOSaveInterval() time.Duration
 	// GetMaxResetTSGap returns the MaxResetTSGap.
 	GetMaxResetTSGap() time.Duration
+	// GetTSOAuditLogDir returns the directory of the TSO audit log, empty means disabled.
+	GetTSOAuditLogDir() string
+	// GetTSOMode returns the TSO mode of the keyspace group.
+	GetTSOMode(keyspaceGroupID uint32) string
+	// GetTSOMaxClockDrift returns the max clock drift of the TSO in the HLC mode.
+	GetTSOMaxClockDrift() time.Duration
 	// GetTLSConfig returns the TLS config.
 	GetTLSConfig() *grpcutil.TLSConfig
 }
@@ -358,6 +358,9 @@ type KeyspaceGroupManager struct {
 	// tsoNodesWatcher is the watcher for the registered tso servers.
 	tsoNodesWatcher *etcdutil.LoopWatcher
 
+	// keyspaceLoads records the TSO requests of each keyspace for the keyspace group rebalance.
+	keyspaceLoads keyspaceLoadRecorder
+
 	// pre-initialized metrics
 	metrics *keyspaceGroupMetrics
 }
@@ -411,10 +414,11 @@ func (kgm *KeyspaceGroupManager) Initialize() error {
 		return errs.ErrLoadKeyspaceGroupsTerminated.Wrap(err)
 	}
 
-	kgm.wg.Add(3)
+	kgm.wg.Add(4)
 	go kgm.primaryPriorityCheckLoop()
 	go kgm.groupSplitPatroller()
 	go kgm.deletedGroupCleaner()
+	go kgm.keyspaceLoadReporter()
 
 	return nil
 }
@@ -1046,6 +1050,9 @@ func (kgm *KeyspaceGroupManager) HandleTSORequest(
 		return pdpb.Timestamp{}, curKeyspaceGroupID, err
 	}
 	ts, err = allocator.GenerateTSO(ctx, count)
+	if err == nil {
+		kgm.keyspaceLoads.record(keyspaceID)
+	}
 	return ts, curKeyspaceGroupID, err
 }
 
@@ -1240,6 +1247,29 @@ func (kgm *KeyspaceGroupManager) finishMergeKeyspaceGroup(id uint32) error {
 	return nil
 }
 
+// loadMergeSourceTimestamp loads the timestamp which the merged TSO should start after to be larger
+// than any TSO allocated by the merge source keyspace group. If the last leader term of the source
+// allocated from the HLC, its TSO may exceed the saved fence, so the fence is padded in the same way
+// as `syncTimestamp`.
+func loadMergeSourceTimestamp(storage endpoint.TSOStorage, id uint32, now time.Time) (time.Time, error) {
+	ts, err := storage.LoadTimestamp(id)
+	if err != nil {
+		return typeutil.ZeroTime, err
+	}
+	mode, err := storage.LoadTimestampMode(id)
+	if err != nil {
+		return typeutil.ZeroTime, err
+	}
+	if mode == nil || mode.Mode != tsoutil.HLCMode {
+		return ts, nil
+	}
+	drift := mode.MaxClockDrift
+	if typeutil.SubRealTimeByWallClock(now.Add(drift), ts) > 0 {
+		ts = now.Add(drift)
+	}
+	return ts.Add(drift + updateTimestampGuard), nil
+}
+
 // mergingChecker is used to check if the keyspace group is in merge state, and if so, it will
 // make sure the newly merged TSO keep consistent with the original ones.
 func (kgm *KeyspaceGroupManager) mergingChecker(ctx context.Context, mergeTargetID uint32, mergeList []uint32) {
@@ -1325,7 +1355,7 @@ mergeLoop:
 		// calculate the newly merged TSO to make sure it is greater than the original ones.
 		var mergedTS time.Time
 		for _, id := range mergeList {
-			ts, err := kgm.storage.LoadTimestamp(id)
+			ts, err := loadMergeSourceTimestamp(kgm.storage, id, time.Now())
 			if err != nil {
 				log.Error("failed to load the keyspace group TSO",
 					zap.String("member", kgm.tsoServiceID.ServiceAddr),
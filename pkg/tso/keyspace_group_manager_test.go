@@ -1186,3 +1186,25 @@ func waitForPrimariesServing(
 		return true
 	}, testutil.WithWaitFor(10*time.Second), testutil.WithTickInterval(50*time.Millisecond))
 }
+
+func TestLoadMergeSourceTimestamp(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	storage := &mockTSOStorage{ts: now.Add(-time.Second)}
+	// The source allocated in the window mode never exceeds the saved window.
+	ts, err := loadMergeSourceTimestamp(storage, 1, now)
+	re.NoError(err)
+	re.Equal(storage.ts, ts)
+
+	// The source allocated from the HLC may exceed the fence by the max clock drift.
+	drift := 100 * time.Millisecond
+	storage.mode = &endpoint.TimestampMode{Mode: tsoutil.HLCMode, MaxClockDrift: drift}
+	ts, err = loadMergeSourceTimestamp(storage, 1, now)
+	re.NoError(err)
+	re.Equal(now.Add(2*drift+updateTimestampGuard), ts)
+	// The fence is ahead of the system time.
+	storage.ts = now.Add(time.Second)
+	ts, err = loadMergeSourceTimestamp(storage, 1, now)
+	re.NoError(err)
+	re.Equal(storage.ts.Add(drift+updateTimestampGuard), ts)
+}
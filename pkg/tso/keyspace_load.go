@@ -0,0 +1,121 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/keypath"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+const (
+	// keyspaceLoadReportInterval is the interval to report the TSO request load of the keyspaces.
+	keyspaceLoadReportInterval = 10 * time.Second
+	// keyspaceLoadTTL is the TTL of the reported load, so the load of a dead TSO node expires.
+	keyspaceLoadTTL = 3 * int64(keyspaceLoadReportInterval/time.Second)
+)
+
+// keyspaceLoadRecorder records the TSO requests of each keyspace served by this TSO node.
+type keyspaceLoadRecorder struct {
+	// counts is the count of the TSO requests since the last collection.
+	counts sync.Map // keyspaceID -> *atomic.Uint64
+	// lastCollect is the time of the last collection, it's only accessed by the reporter.
+	lastCollect time.Time
+}
+
+func (r *keyspaceLoadRecorder) record(keyspaceID uint32) {
+	count, ok := r.counts.Load(keyspaceID)
+	if !ok {
+		count, _ = r.counts.LoadOrStore(keyspaceID, &atomic.Uint64{})
+	}
+	count.(*atomic.Uint64).Add(1)
+}
+
+// collect returns the TSO request rate of each keyspace since the last collection.
+func (r *keyspaceLoadRecorder) collect(now time.Time) map[uint32]float64 {
+	elapsed := now.Sub(r.lastCollect).Seconds()
+	first := r.lastCollect.IsZero()
+	r.lastCollect = now
+	loads := make(map[uint32]float64)
+	r.counts.Range(func(key, value any) bool {
+		count := value.(*atomic.Uint64).Swap(0)
+		if count == 0 {
+			// Remove the idle keyspace, it may have been moved to other keyspace groups.
+			r.counts.Delete(key)
+			return true
+		}
+		if !first && elapsed > 0 {
+			loads[key.(uint32)] = float64(count) / elapsed
+		}
+		return true
+	})
+	return loads
+}
+
+// keyspaceLoadReporter reports the TSO request load of the keyspaces to etcd periodically,
+// which is used by PD to rebalance the keyspace groups across the TSO nodes.
+func (kgm *KeyspaceGroupManager) keyspaceLoadReporter() {
+	defer logutil.LogPanic()
+	defer kgm.wg.Done()
+
+	interval := keyspaceLoadReportInterval
+	failpoint.Inject("fastReportKeyspaceLoad", func() {
+		interval = 100 * time.Millisecond
+	})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	kgm.keyspaceLoads.collect(time.Now())
+	for {
+		select {
+		case <-kgm.ctx.Done():
+			log.Info("exit keyspace load reporter")
+			return
+		case now := <-ticker.C:
+			load := &endpoint.TSONodeLoad{
+				Address:       kgm.tsoServiceID.ServiceAddr,
+				KeyspaceLoads: kgm.keyspaceLoads.collect(now),
+				ReportTime:    now,
+			}
+			if err := kgm.reportKeyspaceLoad(load); err != nil {
+				log.Warn("failed to report the keyspace load",
+					zap.String("address", load.Address), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (kgm *KeyspaceGroupManager) reportKeyspaceLoad(load *endpoint.TSONodeLoad) error {
+	value, err := json.Marshal(load)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(kgm.ctx, etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	_, err = etcdutil.EtcdKVPutWithTTL(ctx, kgm.etcdClient,
+		keypath.TSONodeLoadPath(load.Address), string(value), keyspaceLoadTTL)
+	return err
+}
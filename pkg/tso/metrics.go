@@ -113,6 +113,7 @@ type tsoMetrics struct {
 	systemTimeSlowEvent          prometheus.Counter
 	skipSaveEvent                prometheus.Counter
 	errSaveUpdateTSEvent         prometheus.Counter
+	hlcDriftLimitEvent           prometheus.Counter
 	notLeaderAnymoreEvent        prometheus.Counter
 	logicalOverflowEvent         prometheus.Counter
 	exceededMaxRetryEvent        prometheus.Counter
@@ -150,6 +151,7 @@ func newTSOMetrics(groupID, dcLocation string) *tsoMetrics {
 		systemTimeSlowEvent:          tsoCounter.WithLabelValues("system_time_slow", groupID, dcLocation),
 		skipSaveEvent:                tsoCounter.WithLabelValues("skip_save", groupID, dcLocation),
 		errSaveUpdateTSEvent:         tsoCounter.WithLabelValues("err_save_update_ts", groupID, dcLocation),
+		hlcDriftLimitEvent:           tsoCounter.WithLabelValues("hlc_drift_limit", groupID, dcLocation),
 		notLeaderAnymoreEvent:        tsoCounter.WithLabelValues("not_leader_anymore", groupID, dcLocation),
 		logicalOverflowEvent:         tsoCounter.WithLabelValues("logical_overflow", groupID, dcLocation),
 		exceededMaxRetryEvent:        tsoCounter.WithLabelValues("exceeded_max_retry", groupID, dcLocation),
@@ -32,6 +32,9 @@ type TestServiceConfig struct {
 	TSOUpdatePhysicalInterval time.Duration       // Interval to update TSO in physical storage.
 	TSOSaveInterval           time.Duration       // Interval to save TSO to physical storage.
 	MaxResetTSGap             time.Duration       // Maximum gap to reset TSO.
+	TSOAuditLogDir            string              // Directory of the TSO audit log.
+	TSOMode                   string              // Mode of the TSO allocation.
+	TSOMaxClockDrift          time.Duration       // Maximum clock drift of the TSO in the HLC mode.
 	TLSConfig                 *grpcutil.TLSConfig // TLS configuration.
 }
 
@@ -75,6 +78,21 @@ func (c *TestServiceConfig) GetMaxResetTSGap() time.Duration {
 	return c.MaxResetTSGap
 }
 
+// GetTSOAuditLogDir returns the TSOAuditLogDir field of TestServiceConfig.
+func (c *TestServiceConfig) GetTSOAuditLogDir() string {
+	return c.TSOAuditLogDir
+}
+
+// GetTSOMode returns the TSOMode field of TestServiceConfig.
+func (c *TestServiceConfig) GetTSOMode(uint32) string {
+	return c.TSOMode
+}
+
+// GetTSOMaxClockDrift returns the TSOMaxClockDrift field of TestServiceConfig.
+func (c *TestServiceConfig) GetTSOMaxClockDrift() time.Duration {
+	return c.TSOMaxClockDrift
+}
+
 // GetTLSConfig returns the TLSConfig field of TestServiceConfig.
 func (c *TestServiceConfig) GetTLSConfig() *grpcutil.TLSConfig {
 	return c.TLSConfig
@@ -65,17 +65,42 @@ type timestampOracle struct {
 	saveInterval           time.Duration
 	updatePhysicalInterval time.Duration
 	maxResetTSGap          func() time.Duration
+	// mode is the TSO mode, which is `tsoutil.WindowMode` or `tsoutil.HLCMode`.
+	mode string
+	// maxClockDrift is the max drift of the physical time from the system time in the HLC mode.
+	maxClockDrift time.Duration
 	// tso info stored in the memory
 	tsoMux *tsoObject
-	// last timestamp window stored in etcd
+	// last timestamp window stored in etcd, it's the term fence in the HLC mode.
 	lastSavedTime atomic.Value // stored as time.Time
+	// auditLog records the saved windows and the resets, it is nil if the audit is disabled.
+	auditLog *AuditLog
 
 	// pre-initialized metrics
 	metrics *tsoMetrics
 }
 
 func (t *timestampOracle) saveTimestamp(ts time.Time) error {
-	return t.storage.SaveTimestamp(t.keyspaceGroupID, ts, t.member.GetLeadership())
+	if err := t.storage.SaveTimestamp(t.keyspaceGroupID, ts, t.member.GetLeadership()); err != nil {
+		return err
+	}
+	t.audit(&AuditRecord{Event: AuditEventSave, Window: ts})
+	return nil
+}
+
+// saveTimestampMode saves the mode of the current leader term if it's different from the previous one.
+func (t *timestampOracle) saveTimestampMode(prev *endpoint.TimestampMode) error {
+	mode := &endpoint.TimestampMode{Mode: t.mode}
+	if t.isHLCMode() {
+		mode.MaxClockDrift = t.maxClockDrift
+	}
+	if prev == nil && !t.isHLCMode() {
+		return nil
+	}
+	if prev != nil && *prev == *mode {
+		return nil
+	}
+	return t.storage.SaveTimestampMode(t.keyspaceGroupID, mode, t.member.GetLeadership())
 }
 
 func (t *timestampOracle) setTSOPhysical(next time.Time, force bool) {
@@ -123,6 +148,32 @@ func (t *timestampOracle) getLastSavedTime() time.Time {
 	return last.(time.Time)
 }
 
+// isHLCMode returns whether the TSO is allocated from a hybrid logical clock. In the HLC mode, the
+// saved timestamp is a fence instead of a window: it's the start physical time of the leader term
+// or the physical time reset by the user, and the physical time never exceeds the larger one of the
+// fence and the system time plus the max clock drift. So the monotonicity is kept without extending
+// the window in etcd:
+//  1. The previous leader stops allocating once its lease expires, which is before the new leader
+//     is elected.
+//  2. The new leader starts after the larger one of the fence and its system time plus the max clock
+//     drift, plus the max clock drift again. As long as the clock skew between the servers is less
+//     than the max clock drift, it's larger than any TSO allocated by the previous leader.
+//
+// The mode and the max clock drift of the leader term are saved along with the fence, so the next
+// leader term in the window mode also starts after any TSO allocated by the HLC term.
+func (t *timestampOracle) isHLCMode() bool {
+	return t.mode == tsoutil.HLCMode
+}
+
+// getHLCPhysicalLimit returns the max physical time that can be allocated in the HLC mode.
+func (t *timestampOracle) getHLCPhysicalLimit(now time.Time) time.Time {
+	limit := now
+	if fence := t.getLastSavedTime(); typeutil.SubRealTimeByWallClock(fence, limit) > 0 {
+		limit = fence
+	}
+	return limit.Add(t.maxClockDrift)
+}
+
 func (t *timestampOracle) syncTimestamp() error {
 	log.Info("start to sync timestamp", logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0))
 	t.metrics.syncEvent.Inc()
@@ -154,6 +205,16 @@ func (t *timestampOracle) syncTimestamp() error {
 		return nil
 	}
 
+	prevMode, err := t.storage.LoadTimestampMode(t.keyspaceGroupID)
+	if err != nil {
+		return err
+	}
+	// prevDrift is the max clock drift of the previous leader term if it allocated from the HLC.
+	var prevDrift time.Duration
+	if prevMode != nil && prevMode.Mode == tsoutil.HLCMode {
+		prevDrift = prevMode.MaxClockDrift
+	}
+
 	next := time.Now()
 	failpoint.Inject("fallBackSync", func() {
 		next = next.Add(time.Hour)
@@ -161,9 +222,31 @@ func (t *timestampOracle) syncTimestamp() error {
 	failpoint.Inject("systemTimeSlow", func() {
 		next = next.Add(-time.Hour)
 	})
-	// If the current system time minus the saved etcd timestamp is less than `UpdateTimestampGuard`,
-	// the timestamp allocation will start from the saved etcd timestamp temporarily.
-	if typeutil.SubRealTimeByWallClock(next, last) < updateTimestampGuard {
+	var save time.Time
+	if t.isHLCMode() {
+		// Start after any TSO allocated by the previous leader term, see `isHLCMode` for details.
+		drift := max(t.maxClockDrift, prevDrift)
+		if typeutil.SubRealTimeByWallClock(next.Add(drift), last) > 0 {
+			next = next.Add(drift)
+		} else {
+			next = last
+		}
+		next = next.Add(drift + updateTimestampGuard)
+		save = next
+	} else if prevDrift > 0 {
+		// The previous leader term allocated from the HLC, so its TSO may exceed the saved fence,
+		// start after it in the same way as the HLC mode.
+		fence := last
+		if typeutil.SubRealTimeByWallClock(next.Add(prevDrift), fence) > 0 {
+			fence = next.Add(prevDrift)
+		}
+		fence = fence.Add(prevDrift)
+		if typeutil.SubRealTimeByWallClock(next, fence) < updateTimestampGuard {
+			next = fence.Add(updateTimestampGuard)
+		}
+	} else if typeutil.SubRealTimeByWallClock(next, last) < updateTimestampGuard {
+		// If the current system time minus the saved etcd timestamp is less than `UpdateTimestampGuard`,
+		// the timestamp allocation will start from the saved etcd timestamp temporarily.
 		log.Warn("system time may be incorrect",
 			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
 			zap.Time("last", last), zap.Time("last-saved", lastSavedTime),
@@ -174,7 +257,9 @@ func (t *timestampOracle) syncTimestamp() error {
 	failpoint.Inject("failedToSaveTimestamp", func() {
 		failpoint.Return(errs.ErrEtcdTxnInternal)
 	})
-	save := next.Add(t.saveInterval)
+	if !t.isHLCMode() {
+		save = next.Add(t.saveInterval)
+	}
 	start := time.Now()
 	if err = t.saveTimestamp(save); err != nil {
 		t.metrics.errSaveSyncTSEvent.Inc()
@@ -182,6 +267,12 @@ func (t *timestampOracle) syncTimestamp() error {
 	}
 	t.lastSavedTime.Store(save)
 	t.metrics.syncSaveDuration.Observe(time.Since(start).Seconds())
+	// Save the mode after the timestamp, it's safe since nothing is allocated in this term yet.
+	if err = t.saveTimestampMode(prevMode); err != nil {
+		t.metrics.errSaveSyncTSEvent.Inc()
+		return err
+	}
+	t.audit(&AuditRecord{Event: AuditEventSync, Physical: next, Window: save})
 
 	t.metrics.syncOKEvent.Inc()
 	log.Info("sync and save timestamp",
@@ -243,8 +334,19 @@ func (t *timestampOracle) resetUserTimestamp(tso uint64, ignoreSmaller, skipUppe
 		t.metrics.errResetLargeTSEvent.Inc()
 		return errs.ErrResetUserTimestamp.FastGenByArgs("the specified ts is too larger than now")
 	}
-	// save into etcd only if nextPhysical is close to lastSavedTime
-	if typeutil.SubRealTimeByWallClock(t.getLastSavedTime(), nextPhysical) <= updateTimestampGuard {
+	// save into etcd only if nextPhysical is close to lastSavedTime, or it exceeds the physical
+	// limit in the HLC mode, in which case nextPhysical is saved as the new fence.
+	if t.isHLCMode() {
+		if typeutil.SubRealTimeByWallClock(nextPhysical, t.getHLCPhysicalLimit(time.Now())) > 0 {
+			start := time.Now()
+			if err := t.saveTimestamp(nextPhysical); err != nil {
+				t.metrics.errSaveResetTSEvent.Inc()
+				return err
+			}
+			t.lastSavedTime.Store(nextPhysical)
+			t.metrics.resetSaveDuration.Observe(time.Since(start).Seconds())
+		}
+	} else if typeutil.SubRealTimeByWallClock(t.getLastSavedTime(), nextPhysical) <= updateTimestampGuard {
 		save := nextPhysical.Add(t.saveInterval)
 		start := time.Now()
 		if err := t.saveTimestamp(save); err != nil {
@@ -258,6 +360,7 @@ func (t *timestampOracle) resetUserTimestamp(tso uint64, ignoreSmaller, skipUppe
 	t.tsoMux.physical = nextPhysical
 	t.tsoMux.logical = int64(nextLogical)
 	t.metrics.resetTSOOKEvent.Inc()
+	t.audit(&AuditRecord{Event: AuditEventReset, Physical: nextPhysical, Logical: int64(nextLogical)})
 	return nil
 }
 
@@ -273,6 +376,9 @@ func (t *timestampOracle) resetUserTimestamp(tso uint64, ignoreSmaller, skipUppe
 // 2. The physical time is monotonically increasing.
 // 3. The physical time is always less than the saved timestamp.
 //
+// In the HLC mode, the time window is not saved, and the third constraint is replaced by that the
+// physical time is always less than the limit returned by `getHLCPhysicalLimit`.
+//
 // NOTICE: this function should be called after the TSO in memory has been initialized
 // and should not be called when the TSO in memory has been reset anymore.
 func (t *timestampOracle) updateTimestamp() error {
@@ -325,9 +431,20 @@ func (t *timestampOracle) updateTimestamp() error {
 		return nil
 	}
 
-	// It is not safe to increase the physical time to `next`.
-	// The time window needs to be updated and saved to etcd.
-	if typeutil.SubRealTimeByWallClock(t.getLastSavedTime(), next) <= updateTimestampGuard {
+	if t.isHLCMode() {
+		// In the HLC mode, the physical time is bounded by the system time instead of the saved window,
+		// so it never waits for etcd. If the logical time is used up faster than the system time goes,
+		// the allocation is refused until the system time catches up.
+		if limit := t.getHLCPhysicalLimit(now); typeutil.SubRealTimeByWallClock(next, limit) > 0 {
+			log.Warn("the physical time reaches the max clock drift",
+				logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+				zap.Time("next", next), zap.Time("limit", limit))
+			t.metrics.hlcDriftLimitEvent.Inc()
+			return nil
+		}
+	} else if typeutil.SubRealTimeByWallClock(t.getLastSavedTime(), next) <= updateTimestampGuard {
+		// It is not safe to increase the physical time to `next`.
+		// The time window needs to be updated and saved to etcd.
 		save := next.Add(t.saveInterval)
 		start := time.Now()
 		if err := t.saveTimestamp(save); err != nil {
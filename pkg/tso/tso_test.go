@@ -0,0 +1,225 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/election"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+type mockElectionMember struct {
+	ElectionMember
+}
+
+func (*mockElectionMember) IsLeader() bool { return true }
+
+func (*mockElectionMember) GetLeadership() *election.Leadership { return nil }
+
+type mockTSOStorage struct {
+	sync.Mutex
+	ts        time.Time
+	mode      *endpoint.TimestampMode
+	saveCount int
+	saveErr   error
+}
+
+func (s *mockTSOStorage) LoadTimestamp(uint32) (time.Time, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.ts, nil
+}
+
+func (s *mockTSOStorage) SaveTimestamp(_ uint32, ts time.Time, _ *election.Leadership) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	if typeutil.SubRealTimeByWallClock(ts, s.ts) <= 0 {
+		return errors.New("saving timestamp is less than or equal to the previous one")
+	}
+	s.ts = ts
+	s.saveCount++
+	return nil
+}
+
+func (s *mockTSOStorage) DeleteTimestamp(uint32) error {
+	s.Lock()
+	defer s.Unlock()
+	s.ts = typeutil.ZeroTime
+	s.mode = nil
+	return nil
+}
+
+func (s *mockTSOStorage) LoadTimestampMode(uint32) (*endpoint.TimestampMode, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.mode, nil
+}
+
+func (s *mockTSOStorage) SaveTimestampMode(_ uint32, mode *endpoint.TimestampMode, _ *election.Leadership) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.mode = mode
+	return nil
+}
+
+func newTestTimestampOracle(storage *mockTSOStorage, mode string) *timestampOracle {
+	return &timestampOracle{
+		member:                 &mockElectionMember{},
+		storage:                storage,
+		saveInterval:           3 * time.Second,
+		updatePhysicalInterval: 50 * time.Millisecond,
+		maxResetTSGap:          func() time.Duration { return 24 * time.Hour },
+		mode:                   mode,
+		maxClockDrift:          100 * time.Millisecond,
+		tsoMux:                 &tsoObject{},
+		metrics:                newTSOMetrics("0", GlobalDCLocation),
+	}
+}
+
+func mustGetTS(re *require.Assertions, t *timestampOracle) uint64 {
+	ts, err := t.getTS(context.Background(), 1)
+	re.NoError(err)
+	return tsoutil.GenerateTS(&ts)
+}
+
+func TestTimestampOracle(t *testing.T) {
+	for _, mode := range []string{tsoutil.WindowMode, tsoutil.HLCMode} {
+		t.Run(mode, func(t *testing.T) {
+			re := require.New(t)
+			storage := &mockTSOStorage{}
+			oracle := newTestTimestampOracle(storage, mode)
+			_, err := oracle.getTS(context.Background(), 0)
+			re.Error(err)
+			re.NoError(oracle.syncTimestamp())
+			re.True(oracle.isInitialized())
+
+			last := mustGetTS(re, oracle)
+			for range 10 {
+				time.Sleep(5 * time.Millisecond)
+				re.NoError(oracle.updateTimestamp())
+				ts := mustGetTS(re, oracle)
+				re.Greater(ts, last)
+				last = ts
+			}
+
+			// Reset to a larger timestamp.
+			physical, _ := tsoutil.ParseTS(last)
+			re.NoError(oracle.resetUserTimestamp(tsoutil.ComposeTS(physical.Add(time.Second).UnixMilli(), 0), false, false))
+			ts := mustGetTS(re, oracle)
+			re.Greater(ts, last)
+			last = ts
+			re.Error(oracle.resetUserTimestamp(tsoutil.ComposeTS(physical.UnixMilli(), 0), false, false))
+			re.NoError(oracle.resetUserTimestamp(tsoutil.ComposeTS(physical.UnixMilli(), 0), true, false))
+
+			// The new leader allocates the larger timestamp.
+			newOracle := newTestTimestampOracle(storage, mode)
+			re.NoError(newOracle.syncTimestamp())
+			re.Greater(mustGetTS(re, newOracle), last)
+		})
+	}
+}
+
+func TestHLCTimestampOracle(t *testing.T) {
+	re := require.New(t)
+	storage := &mockTSOStorage{}
+	oracle := newTestTimestampOracle(storage, tsoutil.HLCMode)
+	re.NoError(oracle.syncTimestamp())
+	re.Equal(1, storage.saveCount)
+	// The term starts after the max clock drift twice.
+	physical, _ := oracle.getTSO()
+	re.GreaterOrEqual(typeutil.SubRealTimeByWallClock(physical, time.Now()), oracle.maxClockDrift)
+
+	// Updating the timestamp does not depend on etcd.
+	storage.saveErr = errors.New("etcd is unavailable")
+	for range 10 {
+		time.Sleep(5 * time.Millisecond)
+		re.NoError(oracle.updateTimestamp())
+		mustGetTS(re, oracle)
+	}
+	re.Equal(1, storage.saveCount)
+
+	// The physical time does not exceed the limit even if the logical time is used up.
+	_, err := oracle.getTS(context.Background(), uint32(maxLogical/2+1))
+	re.NoError(err)
+	limit := oracle.getHLCPhysicalLimit(time.Now())
+	for range 200 {
+		re.NoError(oracle.updateTimestamp())
+		oracle.tsoMux.Lock()
+		oracle.tsoMux.logical = maxLogical/2 + 1
+		oracle.tsoMux.Unlock()
+	}
+	physical, _ = oracle.getTSO()
+	re.LessOrEqual(typeutil.SubRealTimeByWallClock(physical, limit), time.Duration(0))
+
+	// Resetting to a timestamp beyond the limit needs to save the fence.
+	next := limit.Add(time.Second)
+	re.Error(oracle.resetUserTimestamp(tsoutil.ComposeTS(next.UnixMilli(), 0), false, false))
+	storage.saveErr = nil
+	re.NoError(oracle.resetUserTimestamp(tsoutil.ComposeTS(next.UnixMilli(), 0), false, false))
+	re.Equal(2, storage.saveCount)
+
+	// The window mode allocation is blocked by etcd.
+	storage.saveErr = errors.New("etcd is unavailable")
+	windowOracle := newTestTimestampOracle(&mockTSOStorage{saveErr: storage.saveErr}, tsoutil.WindowMode)
+	re.Error(windowOracle.syncTimestamp())
+}
+
+func TestSwitchTimestampMode(t *testing.T) {
+	re := require.New(t)
+	storage := &mockTSOStorage{}
+	windowOracle := newTestTimestampOracle(storage, tsoutil.WindowMode)
+	re.NoError(windowOracle.syncTimestamp())
+	// The window mode is not saved if it's never switched.
+	re.Nil(storage.mode)
+
+	hlcOracle := newTestTimestampOracle(storage, tsoutil.HLCMode)
+	re.NoError(hlcOracle.syncTimestamp())
+	re.Equal(&endpoint.TimestampMode{Mode: tsoutil.HLCMode, MaxClockDrift: hlcOracle.maxClockDrift}, storage.mode)
+	// The HLC term may allocate beyond the fence.
+	limit := hlcOracle.getHLCPhysicalLimit(time.Now())
+	re.Greater(typeutil.SubRealTimeByWallClock(limit, storage.ts), time.Duration(0))
+
+	// The window term starts after any TSO allocated by the HLC term.
+	windowOracle = newTestTimestampOracle(storage, tsoutil.WindowMode)
+	re.NoError(windowOracle.syncTimestamp())
+	physical, _ := windowOracle.getTSO()
+	re.Greater(typeutil.SubRealTimeByWallClock(physical, hlcOracle.getHLCPhysicalLimit(time.Now())), time.Duration(0))
+	re.Equal(&endpoint.TimestampMode{Mode: tsoutil.WindowMode}, storage.mode)
+
+	// The HLC term with a smaller max clock drift starts after the previous HLC term.
+	hlcOracle = newTestTimestampOracle(storage, tsoutil.HLCMode)
+	hlcOracle.maxClockDrift = time.Second
+	re.NoError(hlcOracle.syncTimestamp())
+	limit = hlcOracle.getHLCPhysicalLimit(time.Now())
+	smallDriftOracle := newTestTimestampOracle(storage, tsoutil.HLCMode)
+	re.NoError(smallDriftOracle.syncTimestamp())
+	physical, _ = smallDriftOracle.getTSO()
+	re.Greater(typeutil.SubRealTimeByWallClock(physical, limit), time.Duration(0))
+}
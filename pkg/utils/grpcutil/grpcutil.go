@@ -20,6 +20,7 @@ import (
 	"crypto/x509"
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,6 +45,8 @@ const (
 	ForwardMetadataKey = "pd-forwarded-host"
 	// FollowerHandleMetadataKey is used to mark the permit of follower handle.
 	FollowerHandleMetadataKey = "pd-allow-follower-handle"
+	// KeyspaceIDMetadataKey is used to record the keyspace ID of the client.
+	KeyspaceIDMetadataKey = "pd-keyspace-id"
 )
 
 // TLSConfig is the configuration for supporting tls.
@@ -179,6 +182,20 @@ func GetForwardedHost(ctx context.Context) string {
 	return ""
 }
 
+// GetKeyspaceID returns the keyspace ID of the client in metadata, it returns false if the
+// keyspace ID is not set or invalid.
+func GetKeyspaceID(ctx context.Context) (uint32, bool) {
+	s := metadata.ValueFromIncomingContext(ctx, KeyspaceIDMetadataKey)
+	if len(s) == 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(s[0], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(id), true
+}
+
 // IsFollowerHandleEnabled returns the follower host in metadata.
 func IsFollowerHandleEnabled(ctx context.Context) bool {
 	md, ok := metadata.FromIncomingContext(ctx)
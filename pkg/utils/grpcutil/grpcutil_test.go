@@ -95,3 +95,16 @@ func BenchmarkGetForwardedHost(b *testing.B) {
 		GetForwardedHost(ctx)
 	}
 }
+
+func TestGetKeyspaceID(t *testing.T) {
+	re := require.New(t)
+	_, ok := GetKeyspaceID(context.Background())
+	re.False(ok)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(KeyspaceIDMetadataKey, "invalid"))
+	_, ok = GetKeyspaceID(ctx)
+	re.False(ok)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(KeyspaceIDMetadataKey, "42"))
+	id, ok := GetKeyspaceID(ctx)
+	re.True(ok)
+	re.Equal(uint32(42), id)
+}
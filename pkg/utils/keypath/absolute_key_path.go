@@ -41,6 +41,9 @@ const (
 	schedulerConfigPathFormat   = "/pd/%d/scheduler_config/%s"                // "/pd/{cluster_id}/scheduler_config/{scheduler_name}"
 	storeLeaderWeightPathFormat = "/pd/%d/schedule/store_weight/%020d/leader" // "/pd/{cluster_id}/schedule/store_weight/{store_id}/leader"
 	storeRegionWeightPathFormat = "/pd/%d/schedule/store_weight/%020d/region" // "/pd/{cluster_id}/schedule/store_weight/{store_id}/region"
+	maintenanceWindowPathFormat = "/pd/%d/schedule/maintenance_window"        // "/pd/{cluster_id}/schedule/maintenance_window"
+	storeDrainJobPrefixFormat   = "/pd/%d/schedule/store_drain/"              // "/pd/{cluster_id}/schedule/store_drain/"
+	storeDrainJobPathFormat     = "/pd/%d/schedule/store_drain/%020d"         // "/pd/{cluster_id}/schedule/store_drain/{store_id}"
 
 	serviceMiddlewarePathFormat = "/pd/%d/service_middleware"                  // "/pd/{cluster_id}/service_middleware"
 	replicationModePathFormat   = "/pd/%d/replication_mode/%s"                 // "/pd/{cluster_id}/replication_mode/{mode}"
@@ -84,16 +87,26 @@ const (
 	minResolvedTSPathFormat        = "/pd/%d/raft/min_resolved_ts"            // "/pd/{cluster_id}/raft/min_resolved_ts"
 	externalTimestampPathFormat    = "/pd/%d/raft/external_timestamp"         // "/pd/{cluster_id}/raft/external_timestamp"
 
-	keyspaceMetaPrefixFormat    = "/pd/%d/keyspaces/meta/"                     // "/pd/{cluster_id}/keyspaces/meta/"
-	keyspaceMetaPathFormat      = "/pd/%d/keyspaces/meta/%08d"                 // "/pd/{cluster_id}/keyspaces/meta/{keyspace_id}"
-	keyspaceIDPathFormat        = "/pd/%d/keyspaces/id/%s"                     // "/pd/{cluster_id}/keyspaces/id/{keyspace_name}"
-	keyspaceGroupIDPrefixFormat = "/pd/%d/tso/keyspace_groups/membership/"     // "/pd/{cluster_id}/tso/keyspace_groups/membership/"
-	keyspaceGroupIDPathFormat   = "/pd/%d/tso/keyspace_groups/membership/%05d" // "/pd/{cluster_id}/tso/keyspace_groups/membership/{group_id}"
-	keyspaceGroupIDPattern      = `tso/keyspace_groups/membership/(\d{5})$`
+	keyspaceMetaPrefixFormat         = "/pd/%d/keyspaces/meta/"                     // "/pd/{cluster_id}/keyspaces/meta/"
+	keyspaceMetaPathFormat           = "/pd/%d/keyspaces/meta/%08d"                 // "/pd/{cluster_id}/keyspaces/meta/{keyspace_id}"
+	keyspaceIDPathFormat             = "/pd/%d/keyspaces/id/%s"                     // "/pd/{cluster_id}/keyspaces/id/{keyspace_name}"
+	keyspaceLifecyclePathFormat      = "/pd/%d/keyspaces/lifecycle/%08d"            // "/pd/{cluster_id}/keyspaces/lifecycle/{keyspace_id}"
+	keyspaceDestroyRangePrefixFormat = "/pd/%d/keyspaces/destroy_range/"            // "/pd/{cluster_id}/keyspaces/destroy_range/"
+	keyspaceDestroyRangePathFormat   = "/pd/%d/keyspaces/destroy_range/%08d"        // "/pd/{cluster_id}/keyspaces/destroy_range/{keyspace_id}"
+	keyspaceQuotaPrefixFormat        = "/pd/%d/keyspaces/quota/"                    // "/pd/{cluster_id}/keyspaces/quota/"
+	keyspaceQuotaPathFormat          = "/pd/%d/keyspaces/quota/%08d"                // "/pd/{cluster_id}/keyspaces/quota/{keyspace_id}"
+	keyspaceTemplatePrefixFormat     = "/pd/%d/keyspaces/template/"                 // "/pd/{cluster_id}/keyspaces/template/"
+	keyspaceTemplatePathFormat       = "/pd/%d/keyspaces/template/%s"               // "/pd/{cluster_id}/keyspaces/template/{template_name}"
+	keyspaceGroupIDPrefixFormat      = "/pd/%d/tso/keyspace_groups/membership/"     // "/pd/{cluster_id}/tso/keyspace_groups/membership/"
+	keyspaceGroupIDPathFormat        = "/pd/%d/tso/keyspace_groups/membership/%05d" // "/pd/{cluster_id}/tso/keyspace_groups/membership/{group_id}"
+	keyspaceGroupIDPattern           = `tso/keyspace_groups/membership/(\d{5})$`
 
 	servicePathFormat  = "/ms/%d/%s/registry/"   // "/ms/{cluster_id}/{service_name}/registry/"
 	registryPathFormat = "/ms/%d/%s/registry/%s" // "/ms/{cluster_id}/{service_name}/registry/{service_addr}"
 
+	tsoNodeLoadPrefixFormat = "/ms/%d/tso/load/"   // "/ms/{cluster_id}/tso/load/"
+	tsoNodeLoadPathFormat   = "/ms/%d/tso/load/%s" // "/ms/{cluster_id}/tso/load/{service_addr}"
+
 	msLeaderPathFormat           = "/ms/%d/%s/primary"                                // "/ms/{cluster_id}/{service_name}/primary"
 	msTsoDefaultLeaderPathFormat = "/ms/%d/tso/00000/primary"                         // "/ms/{cluster_id}/tso/00000/primary"
 	msTsoKespaceLeaderPathFormat = "/ms/%d/tso/keyspace_groups/election/%05d/primary" // "/ms/{cluster_id}/tso/keyspace_groups/election/{group_id}/primary"
@@ -107,12 +120,21 @@ const (
 	msTsoKespaceExpectedLeaderPathFormat = "/ms/%d/tso/keyspace_groups/election/%05d/primary/expected_primary" // "/ms/{cluster_id}/tso/keyspace_groups/election/{group_id}/primary"
 
 	// resource group path
-	resourceGroupSettingsPathFormat = "resource_group/settings/%s" // "resource_group/settings/{group_name}"
-	resourceGroupStatesPathFormat   = "resource_group/states/%s"   // "resource_group/states/{group_name}"
-	controllerConfigPath            = "resource_group/controller"  // "resource_group/controller"
+	resourceGroupSettingsPathFormat = "resource_group/settings/%s"          // "resource_group/settings/{group_name}"
+	resourceGroupStatesPathFormat   = "resource_group/states/%s"            // "resource_group/states/{group_name}"
+	resourceGroupParentsPathFormat  = "resource_group/parents/%s"           // "resource_group/parents/{parent_name}"
+	resourceGroupUsagePathFormat    = "resource_group/usage/%020d/%010d/%s" // "resource_group/usage/{hour}/{keyspace_id}/{group_name}"
+	resourceGroupUsagePrefix        = "resource_group/usage/"               // "resource_group/usage/"
+	controllerConfigPath            = "resource_group/controller"           // "resource_group/controller"
 
 	timestampPathFormat   = "/pd/%d/timestamp"              // "/pd/{cluster_id}/timestamp"
 	msTimestampPathFormat = "/ms/%d/tso/%05d/gta/timestamp" // "/ms/{cluster_id}/tso/{group_id}/gta/timestamp"
+
+	timestampModePathFormat   = "/pd/%d/timestamp_mode"              // "/pd/{cluster_id}/timestamp_mode"
+	msTimestampModePathFormat = "/ms/%d/tso/%05d/gta/timestamp_mode" // "/ms/{cluster_id}/tso/{group_id}/gta/timestamp_mode"
+
+	timestampAuditTermPrefixFormat   = "/pd/%d/timestamp_audit/"              // "/pd/{cluster_id}/timestamp_audit/"
+	msTimestampAuditTermPrefixFormat = "/ms/%d/tso/%05d/gta/timestamp_audit/" // "/ms/{cluster_id}/tso/{group_id}/gta/timestamp_audit/"
 )
 
 // MsParam is the parameter of microservice.
@@ -202,6 +224,41 @@ func KeyspaceIDPath(name string) string {
 	return fmt.Sprintf(keyspaceIDPathFormat, ClusterID(), name)
 }
 
+// KeyspaceLifecyclePath returns the path to the lifecycle records of the given keyspace.
+func KeyspaceLifecyclePath(spaceID uint32) string {
+	return fmt.Sprintf(keyspaceLifecyclePathFormat, ClusterID(), spaceID)
+}
+
+// KeyspaceDestroyRangePrefix returns the prefix of the pending destroy range tasks of keyspaces.
+func KeyspaceDestroyRangePrefix() string {
+	return fmt.Sprintf(keyspaceDestroyRangePrefixFormat, ClusterID())
+}
+
+// KeyspaceDestroyRangePath returns the path to the pending destroy range task of the given keyspace.
+func KeyspaceDestroyRangePath(spaceID uint32) string {
+	return fmt.Sprintf(keyspaceDestroyRangePathFormat, ClusterID(), spaceID)
+}
+
+// KeyspaceQuotaPrefix returns the prefix of keyspaces' quotas.
+func KeyspaceQuotaPrefix() string {
+	return fmt.Sprintf(keyspaceQuotaPrefixFormat, ClusterID())
+}
+
+// KeyspaceQuotaPath returns the path to the quota of the given keyspace.
+func KeyspaceQuotaPath(spaceID uint32) string {
+	return fmt.Sprintf(keyspaceQuotaPathFormat, ClusterID(), spaceID)
+}
+
+// KeyspaceTemplatePrefix returns the prefix of keyspace templates.
+func KeyspaceTemplatePrefix() string {
+	return fmt.Sprintf(keyspaceTemplatePrefixFormat, ClusterID())
+}
+
+// KeyspaceTemplatePath returns the path to the keyspace template with the given name.
+func KeyspaceTemplatePath(name string) string {
+	return fmt.Sprintf(keyspaceTemplatePathFormat, ClusterID(), name)
+}
+
 // KeyspaceGroupIDPrefix returns the prefix of keyspace group id.
 func KeyspaceGroupIDPrefix() string {
 	return fmt.Sprintf(keyspaceGroupIDPrefixFormat, ClusterID())
@@ -217,6 +274,16 @@ func GetCompiledKeyspaceGroupIDRegexp() *regexp.Regexp {
 	return regexp.MustCompile(keyspaceGroupIDPattern)
 }
 
+// TSONodeLoadPrefix returns the prefix of the TSO request loads reported by the TSO nodes.
+func TSONodeLoadPrefix() string {
+	return fmt.Sprintf(tsoNodeLoadPrefixFormat, ClusterID())
+}
+
+// TSONodeLoadPath returns the path to the TSO request load reported by the given TSO node.
+func TSONodeLoadPath(serviceAddr string) string {
+	return fmt.Sprintf(tsoNodeLoadPathFormat, ClusterID(), serviceAddr)
+}
+
 // ServiceMiddlewarePath is the path to save the service middleware config.
 func ServiceMiddlewarePath() string {
 	return fmt.Sprintf(serviceMiddlewarePathFormat, ClusterID())
@@ -245,6 +312,27 @@ func TimestampPath(groupID uint32) string {
 	return fmt.Sprintf(msTimestampPathFormat, ClusterID(), groupID)
 }
 
+// TimestampModePath returns the path of the TSO mode of the last leader term for the given group id.
+func TimestampModePath(groupID uint32) string {
+	if groupID == constant.DefaultKeyspaceGroupID {
+		return fmt.Sprintf(timestampModePathFormat, ClusterID())
+	}
+	return fmt.Sprintf(msTimestampModePathFormat, ClusterID(), groupID)
+}
+
+// TimestampAuditTermPrefix returns the prefix of the TSO audit records of the leader terms for the given group id.
+func TimestampAuditTermPrefix(groupID uint32) string {
+	if groupID == constant.DefaultKeyspaceGroupID {
+		return fmt.Sprintf(timestampAuditTermPrefixFormat, ClusterID())
+	}
+	return fmt.Sprintf(msTimestampAuditTermPrefixFormat, ClusterID(), groupID)
+}
+
+// TimestampAuditTermPath returns the path of the TSO audit record of the leader term which starts at the given time.
+func TimestampAuditTermPath(groupID uint32, startNano int64) string {
+	return fmt.Sprintf("%s%020d", TimestampAuditTermPrefix(groupID), startNano)
+}
+
 // RegionPath returns the region meta info key path with the given region ID.
 func RegionPath(regionID uint64) string {
 	// we use uint64 to represent ID, the max length of uint64 is 20.
@@ -30,3 +30,18 @@ func SchedulerConfigPathPrefix() string {
 func SchedulerConfigPath(schedulerName string) string {
 	return fmt.Sprintf(schedulerConfigPathFormat, ClusterID(), schedulerName)
 }
+
+// MaintenanceWindowPath returns the path to save the maintenance windows.
+func MaintenanceWindowPath() string {
+	return fmt.Sprintf(maintenanceWindowPathFormat, ClusterID())
+}
+
+// StoreDrainJobPrefix returns the path prefix to save the store drain jobs.
+func StoreDrainJobPrefix() string {
+	return fmt.Sprintf(storeDrainJobPrefixFormat, ClusterID())
+}
+
+// StoreDrainJobPath returns the path to save the drain job of the store.
+func StoreDrainJobPath(storeID uint64) string {
+	return fmt.Sprintf(storeDrainJobPathFormat, ClusterID(), storeID)
+}
@@ -40,3 +40,29 @@ func ResourceGroupSettingPrefix() string {
 func ResourceGroupStatePrefix() string {
 	return ResourceGroupStatePath("")
 }
+
+// ResourceGroupParentPath returns the path to save the parent resource group settings.
+func ResourceGroupParentPath(parentName string) string {
+	return fmt.Sprintf(resourceGroupParentsPathFormat, parentName)
+}
+
+// ResourceGroupParentPrefix returns the prefix of the parent resource group settings.
+func ResourceGroupParentPrefix() string {
+	return ResourceGroupParentPath("")
+}
+
+// ResourceGroupUsagePath returns the path to save the resource usage of a resource group
+// in a keyspace within the hour, which starts at the given unix seconds.
+func ResourceGroupUsagePath(hour int64, keyspaceID uint32, groupName string) string {
+	return fmt.Sprintf(resourceGroupUsagePathFormat, hour, keyspaceID, groupName)
+}
+
+// ResourceGroupUsageHourPrefix returns the prefix of the resource usage within the hour.
+func ResourceGroupUsageHourPrefix(hour int64) string {
+	return fmt.Sprintf("%s%020d/", resourceGroupUsagePrefix, hour)
+}
+
+// ResourceGroupUsagePrefix returns the prefix of the resource usage.
+func ResourceGroupUsagePrefix() string {
+	return resourceGroupUsagePrefix
+}
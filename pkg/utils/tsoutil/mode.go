@@ -0,0 +1,61 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"strconv"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	// WindowMode allocates the TSO within a time window persisted in etcd, the window
+	// is extended synchronously before the physical time reaches it.
+	WindowMode = "window"
+	// HLCMode allocates the TSO from a hybrid logical clock, whose drift from the system
+	// time is bounded. Etcd is only written when the leader term starts or the TSO is reset.
+	HLCMode = "hlc"
+)
+
+// IsValidMode checks whether the TSO mode is valid.
+func IsValidMode(mode string) bool {
+	return mode == WindowMode || mode == HLCMode
+}
+
+// ValidateModes checks the default TSO mode and the TSO modes of the keyspace groups,
+// whose keys are the keyspace group IDs.
+func ValidateModes(mode string, keyspaceGroupModes map[string]string) error {
+	if !IsValidMode(mode) {
+		return errors.Errorf("invalid tso mode %q", mode)
+	}
+	for id, groupMode := range keyspaceGroupModes {
+		if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+			return errors.Errorf("invalid keyspace group id %q of tso mode", id)
+		}
+		if !IsValidMode(groupMode) {
+			return errors.Errorf("invalid tso mode %q of keyspace group %s", groupMode, id)
+		}
+	}
+	return nil
+}
+
+// GetKeyspaceGroupMode returns the TSO mode of the keyspace group, the default mode is
+// used if the keyspace group does not specify one.
+func GetKeyspaceGroupMode(mode string, keyspaceGroupModes map[string]string, keyspaceGroupID uint32) string {
+	if groupMode, ok := keyspaceGroupModes[strconv.FormatUint(uint64(keyspaceGroupID), 10)]; ok {
+		return groupMode
+	}
+	return mode
+}
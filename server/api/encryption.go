@@ -0,0 +1,160 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/unrolled/render"
+
+	"github.com/pingcap/failpoint"
+
+	"github.com/tikv/pd/pkg/encryption"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+)
+
+type encryptionHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newEncryptionHandler(svr *server.Server, rd *render.Render) *encryptionHandler {
+	return &encryptionHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// GetMasterKeyStatus returns the master key in use and the progress of the online master key rotation.
+// @Tags     encryption
+// @Summary  Get the master key version loaded by each PD member and the last online rotation.
+// @Produce  json
+// @Success  200  {object}  encryption.MasterKeyRotationStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /encryption/master-key [get]
+func (h *encryptionHandler) GetMasterKeyStatus(w http.ResponseWriter, _ *http.Request) {
+	status, err := h.getMasterKeyStatus()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, status)
+}
+
+func (h *encryptionHandler) getMasterKeyStatus() (*encryption.MasterKeyRotationStatus, error) {
+	members, err := h.svr.GetMembers()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		names = append(names, member.GetName())
+	}
+	return h.svr.GetEncryptionKeyManager().GetMasterKeyRotationStatus(names)
+}
+
+// RotateMasterKey re-encrypts the encryption keys with a new master key online. The new master key
+// is only verified by the PD leader, so the file master key and the local KMS master key are refused
+// since the other members may not have the same file, and the rotation is refused until all members
+// have loaded the current master key.
+// @Tags     encryption
+// @Summary  Rotate the master key without restarting PD.
+// @Accept   json
+// @Param    body  body  encryption.MasterKeyConfig  true  "The new master key"
+// @Produce  json
+// @Success  200  {string}  string  "Rotate the master key successfully."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /encryption/master-key/rotate [post]
+func (h *encryptionHandler) RotateMasterKey(w http.ResponseWriter, r *http.Request) {
+	cfg := &encryption.Config{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &cfg.MasterKey); err != nil {
+		return
+	}
+	meta, err := cfg.GetMasterKeyMeta()
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if meta.GetFile() != nil {
+		h.rd.JSON(w, http.StatusBadRequest, "the file master key can't be rotated online, use the KMS master key instead")
+		return
+	}
+	allowLocalKMS := false
+	failpoint.Inject("allowLocalKMSMasterKeyRotation", func() {
+		allowLocalKMS = true
+	})
+	if encryption.IsLocalKMSMasterKey(meta) && !allowLocalKMS {
+		h.rd.JSON(w, http.StatusBadRequest, "the local KMS master key can't be rotated online, use the KMS master key of another vendor instead")
+		return
+	}
+	if !h.checkMasterKeyLoaded(w) {
+		return
+	}
+	if err := h.svr.GetEncryptionKeyManager().RotateMasterKey(meta); err != nil {
+		h.respondRotationError(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Rotate the master key successfully.")
+}
+
+// RollbackMasterKey re-encrypts the encryption keys with the master key before the last rotation.
+// Like the rotation, the rollback is refused until all members have loaded the current master key.
+// @Tags     encryption
+// @Summary  Roll back the last online master key rotation.
+// @Produce  json
+// @Success  200  {string}  string  "Roll back the master key successfully."
+// @Failure  400  {string}  string  "There is no rotation to roll back."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /encryption/master-key/rollback [post]
+func (h *encryptionHandler) RollbackMasterKey(w http.ResponseWriter, _ *http.Request) {
+	if !h.checkMasterKeyLoaded(w) {
+		return
+	}
+	if err := h.svr.GetEncryptionKeyManager().RollbackMasterKey(); err != nil {
+		h.respondRotationError(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Roll back the master key successfully.")
+}
+
+// checkMasterKeyLoaded responds with an error and returns false if some members have not loaded
+// the current master key.
+func (h *encryptionHandler) checkMasterKeyLoaded(w http.ResponseWriter) bool {
+	status, err := h.getMasterKeyStatus()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	// The manager refuses to re-encrypt if there is no encryption key at all.
+	if len(status.Version) > 0 && !status.Completed {
+		h.rd.JSON(w, http.StatusBadRequest, fmt.Sprintf(
+			"only %d of %d members have loaded the master key %s, retry after all members load it",
+			status.Loaded, len(status.Members), status.Version))
+		return false
+	}
+	return true
+}
+
+func (h *encryptionHandler) respondRotationError(w http.ResponseWriter, err error) {
+	if errs.ErrEncryptionRotateMasterKey.Equal(err) {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+}
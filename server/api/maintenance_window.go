@@ -0,0 +1,99 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+
+	"github.com/tikv/pd/pkg/errs"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+)
+
+type maintenanceWindowHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newMaintenanceWindowHandler(s *server.Server, rd *render.Render) *maintenanceWindowHandler {
+	return &maintenanceWindowHandler{
+		svr: s,
+		rd:  rd,
+	}
+}
+
+// GetMaintenanceWindows returns the maintenance windows and the active one.
+// @Tags     config
+// @Summary  List all maintenance windows and the active one.
+// @Produce  json
+// @Success  200  {object}  sc.MaintenanceWindowConfig
+// @Router   /config/maintenance-window [get]
+func (h *maintenanceWindowHandler) GetMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+	cluster := getCluster(r)
+	h.rd.JSON(w, http.StatusOK, cluster.GetMaintenanceWindows())
+}
+
+// SetMaintenanceWindow adds or updates a maintenance window.
+// @Tags     config
+// @Summary  Add or update a maintenance window.
+// @Accept   json
+// @Param    body  body  sc.MaintenanceWindow  true  "The maintenance window"
+// @Produce  json
+// @Success  200  {string}  string  "Update maintenance window successfully."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/maintenance-window [post]
+func (h *maintenanceWindowHandler) SetMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	cluster := getCluster(r)
+	var window sc.MaintenanceWindow
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &window); err != nil {
+		return
+	}
+	if err := cluster.SetMaintenanceWindow(&window); err != nil {
+		if errs.ErrMaintenanceWindowConfig.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Update maintenance window successfully.")
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window.
+// @Tags     config
+// @Summary  Delete a maintenance window, its changes are reverted if it is active.
+// @Param    name  path  string  true  "The name of the maintenance window"
+// @Produce  json
+// @Success  200  {string}  string  "Delete maintenance window successfully."
+// @Failure  404  {string}  string  "The maintenance window does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/maintenance-window/{name} [delete]
+func (h *maintenanceWindowHandler) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	cluster := getCluster(r)
+	if err := cluster.DeleteMaintenanceWindow(mux.Vars(r)["name"]); err != nil {
+		if errs.ErrMaintenanceWindowNotFound.Equal(err) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Delete maintenance window successfully.")
+}
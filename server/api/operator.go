@@ -22,6 +22,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
 
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/handler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/server"
@@ -203,3 +205,183 @@ func (h *operatorHandler) GetOperatorRecords(w http.ResponseWriter, r *http.Requ
 	}
 	h.r.JSON(w, http.StatusOK, records)
 }
+
+// GetOperatorHistory lists the persisted finished operators.
+// @Tags     operator
+// @Summary  List the persisted finished operators in the given time range.
+// @Param    start      query  integer  false  "Start Unix timestamp"
+// @Param    end        query  integer  false  "End Unix timestamp, default is now"
+// @Param    region_id  query  integer  false  "Only return the operators of the region"
+// @Param    store_id   query  integer  false  "Only return the operators related to the store"
+// @Produce  json
+// @Success  200  {object}  storage.HistoryOperators
+// @Failure  400  {string}  string  "The request is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/history [get]
+func (h *operatorHandler) GetOperatorHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, err := apiutil.ParseTime(query.Get("start"))
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	end := time.Now()
+	if endStr := query.Get("end"); len(endStr) > 0 {
+		end, err = apiutil.ParseTime(endStr)
+		if err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	request := &server.OperatorHistoryRequest{
+		StartTime: start.UnixMilli(),
+		EndTime:   end.UnixMilli(),
+	}
+	if request.RegionIDs, err = parseUint64Query(query["region_id"]); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if request.StoreIDs, err = parseUint64Query(query["store_id"]); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	history, err := h.Handler.GetOperatorHistory(request)
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, history)
+}
+
+func parseUint64Query(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetOperatorGroups lists all operator groups.
+// @Tags     operator
+// @Summary  List all operator groups and their progress.
+// @Produce  json
+// @Success  200  {array}   operator.OperatorGroupStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups [get]
+func (h *operatorHandler) GetOperatorGroups(w http.ResponseWriter, _ *http.Request) {
+	groups, err := h.Handler.GetOperatorGroups()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, groups)
+}
+
+// CreateOperatorGroup creates an operator group.
+// @Tags     operator
+// @Summary  Create a group of operators with dependencies and a shared concurrency.
+// @Accept   json
+// @Param    body  body  handler.OperatorGroupInput  true  "The operators of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is created."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups [post]
+func (h *operatorHandler) CreateOperatorGroup(w http.ResponseWriter, r *http.Request) {
+	var input handler.OperatorGroupInput
+	if err := apiutil.ReadJSONRespondError(h.r, w, r.Body, &input); err != nil {
+		return
+	}
+	if statusCode, err := h.AddOperatorGroup(&input); err != nil {
+		h.r.JSON(w, statusCode, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "The operator group is created.")
+}
+
+// GetOperatorGroup gets the operator group.
+// @Tags     operator
+// @Summary  Get the operator group and its progress.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {object}  operator.OperatorGroupStatus
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id} [get]
+func (h *operatorHandler) GetOperatorGroup(w http.ResponseWriter, r *http.Request) {
+	group, err := h.Handler.GetOperatorGroup(mux.Vars(r)["id"])
+	if err != nil {
+		h.r.JSON(w, operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, group)
+}
+
+// PauseOperatorGroup pauses the operator group.
+// @Tags     operator
+// @Summary  Stop dispatching the operators of the group, the running operators are not affected.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is paused."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id}/pause [post]
+func (h *operatorHandler) PauseOperatorGroup(w http.ResponseWriter, r *http.Request) {
+	if err := h.Handler.PauseOperatorGroup(mux.Vars(r)["id"]); err != nil {
+		h.r.JSON(w, operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "The operator group is paused.")
+}
+
+// ResumeOperatorGroup resumes the operator group.
+// @Tags     operator
+// @Summary  Continue dispatching the operators of the group.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is resumed."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id}/resume [post]
+func (h *operatorHandler) ResumeOperatorGroup(w http.ResponseWriter, r *http.Request) {
+	if err := h.Handler.ResumeOperatorGroup(mux.Vars(r)["id"]); err != nil {
+		h.r.JSON(w, operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "The operator group is resumed.")
+}
+
+// CancelOperatorGroup cancels the operator group.
+// @Tags     operator
+// @Summary  Cancel the running and pending operators of the group.
+// @Param    id  path  string  true  "The id of the group"
+// @Produce  json
+// @Success  200  {string}  string  "The operator group is canceled."
+// @Failure  400  {string}  string  "The operator group is ended."
+// @Failure  404  {string}  string  "The operator group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/groups/{id} [delete]
+func (h *operatorHandler) CancelOperatorGroup(w http.ResponseWriter, r *http.Request) {
+	if err := h.Handler.CancelOperatorGroup(mux.Vars(r)["id"]); err != nil {
+		h.r.JSON(w, operatorGroupErrorStatus(err), err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "The operator group is canceled.")
+}
+
+func operatorGroupErrorStatus(err error) int {
+	switch {
+	case errs.ErrOperatorGroupNotFound.Equal(err):
+		return http.StatusNotFound
+	case errs.ErrOperatorGroupInvalid.Equal(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -137,6 +137,13 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/operators", operatorHandler.CreateOperator, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/operators", operatorHandler.DeleteOperators, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/operators/records", operatorHandler.GetOperatorRecords, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/operators/history", operatorHandler.GetOperatorHistory, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/operators/groups", operatorHandler.GetOperatorGroups, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/operators/groups", operatorHandler.CreateOperatorGroup, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/operators/groups/{id}", operatorHandler.GetOperatorGroup, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/operators/groups/{id}", operatorHandler.CancelOperatorGroup, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/operators/groups/{id}/pause", operatorHandler.PauseOperatorGroup, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/operators/groups/{id}/resume", operatorHandler.ResumeOperatorGroup, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/operators/{region_id}", operatorHandler.GetOperatorsByRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/operators/{region_id}", operatorHandler.DeleteOperatorByRegion, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 
@@ -149,6 +156,7 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/schedulers", schedulerHandler.CreateScheduler, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/schedulers/{name}", schedulerHandler.DeleteScheduler, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/schedulers/{name}", schedulerHandler.PauseOrResumeScheduler, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/schedulers/dry-run/{name}", schedulerHandler.DryRunScheduler, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
 	diagnosticHandler := newDiagnosticHandler(svr, rd)
 	registerFunc(clusterRouter, "/schedulers/diagnostic/{name}", diagnosticHandler.getDiagnosticResult, setMethods(http.MethodGet), setAuditBackend(prometheus))
@@ -183,6 +191,7 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(ruleRouter, "/config/rules", rulesHandler.GetAllRules, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules", rulesHandler.SetAllRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(ruleRouter, "/config/rules/batch", rulesHandler.BatchRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(ruleRouter, "/config/rules/simulate", rulesHandler.SimulatePlacementRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(ruleRouter, "/config/rules/group/{group}", rulesHandler.GetRuleByGroup, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules/region/{region}", rulesHandler.GetRulesByRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules/region/{region}/detail", rulesHandler.CheckRegionPlacementRule, setMethods(http.MethodGet), setAuditBackend(prometheus))
@@ -215,6 +224,11 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(clusterRouter, "/region/id/{id}/label/{key}", regionLabelHandler.GetRegionLabelByKey, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter, "/region/id/{id}/labels", regionLabelHandler.GetRegionLabels, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
+	maintenanceWindowHandler := newMaintenanceWindowHandler(svr, rd)
+	registerFunc(clusterRouter, "/config/maintenance-window", maintenanceWindowHandler.GetMaintenanceWindows, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/config/maintenance-window", maintenanceWindowHandler.SetMaintenanceWindow, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/config/maintenance-window/{name}", maintenanceWindowHandler.DeleteMaintenanceWindow, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+
 	storeHandler := newStoreHandler(handler, rd)
 	registerFunc(clusterRouter, "/store/{id}", storeHandler.GetStore, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter, "/store/{id}", storeHandler.DeleteStore, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
@@ -224,6 +238,14 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(clusterRouter, "/store/{id}/weight", storeHandler.SetStoreWeight, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/store/{id}/limit", storeHandler.SetStoreLimit, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 
+	storeDrainHandler := newStoreDrainHandler(svr, rd)
+	registerFunc(clusterRouter, "/store/{id}/drain", storeDrainHandler.GetStoreDrainStatus, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/store/{id}/drain", storeDrainHandler.DrainStore, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/store/{id}/drain", storeDrainHandler.CancelStoreDrain, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/store/{id}/drain/pause", storeDrainHandler.PauseStoreDrain, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/store/{id}/drain/resume", storeDrainHandler.ResumeStoreDrain, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/stores/drain", storeDrainHandler.GetAllStoreDrainStatuses, setMethods(http.MethodGet), setAuditBackend(prometheus))
+
 	storesHandler := newStoresHandler(handler, rd)
 	registerFunc(clusterRouter, "/stores", storesHandler.GetAllStores, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter, "/stores/remove-tombstone", storesHandler.RemoveTombStone, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
@@ -305,6 +327,11 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	trendHandler := newTrendHandler(svr, rd)
 	registerFunc(apiRouter, "/trend", trendHandler.GetTrend, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
+	encryptionHandler := newEncryptionHandler(svr, rd)
+	registerFunc(apiRouter, "/encryption/master-key", encryptionHandler.GetMasterKeyStatus, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/encryption/master-key/rotate", encryptionHandler.RotateMasterKey, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/encryption/master-key/rollback", encryptionHandler.RollbackMasterKey, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+
 	adminHandler := newAdminHandler(svr, rd)
 	registerFunc(clusterRouter, "/admin/cache/region/{id}", adminHandler.DeleteRegionCache, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/admin/storage/region/{id}", adminHandler.DeleteRegionStorage, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
@@ -374,6 +401,7 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	tsoAdminHandler := tso.NewAdminHandler(svr.GetHandler(), rd)
 	// br ebs restore phase 1 will reset ts, but at that time the cluster hasn't bootstrapped, so cannot use clusterRouter
 	registerFunc(apiRouter, "/admin/reset-ts", tsoAdminHandler.ResetTS, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/admin/tso/audit", tsoAdminHandler.VerifyTSOAudit, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
 	// API to set or unset failpoints
 	if enableFailPointAPI {
@@ -476,6 +476,61 @@ func (h *ruleHandler) SetPlacementRules(w http.ResponseWriter, r *http.Request)
 	h.rd.JSON(w, http.StatusOK, "Update rules and groups successfully.")
 }
 
+type simulatePlacementRulesInput struct {
+	Bundles  []placement.GroupBundle `json:"bundles"`
+	Partial  bool                    `json:"partial"`
+	StartKey string                  `json:"start-key"`
+	EndKey   string                  `json:"end-key"`
+}
+
+// SimulatePlacementRules checks the regions in the key range with the rules and groups which are not saved.
+// @Tags     rule
+// @Summary  Simulate the rules and groups configuration on the regions in the key range.
+// @Param    body   body   object   true   "The candidate bundles and the hex-encoded key range"
+// @Param    limit  query  integer  false  "Limit count"  default(16)
+// @Produce  json
+// @Success  200  {array}   handler.RegionPlacementSimulation
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/simulate [post]
+func (h *ruleHandler) SimulatePlacementRules(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	var input simulatePlacementRulesInput
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	startKey, err := hex.DecodeString(input.StartKey)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, errs.ErrHexDecodingString.FastGenByArgs(input.StartKey).Error())
+		return
+	}
+	endKey, err := hex.DecodeString(input.EndKey)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, errs.ErrHexDecodingString.FastGenByArgs(input.EndKey).Error())
+		return
+	}
+	limit, err := h.AdjustLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	manager.SetKeyType(h.svr.GetConfig().PDServerCfg.KeyType)
+	results, err := h.Handler.SimulatePlacementRules(input.Bundles, input.Partial, startKey, endKey, limit)
+	if err != nil {
+		switch {
+		case err == errs.ErrPlacementDisabled:
+			h.rd.JSON(w, http.StatusPreconditionFailed, err.Error())
+		case errs.ErrRuleContent.Equal(err) || errs.ErrHexDecodingString.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, results)
+}
+
 // GetPlacementRuleByGroup returns group config and all rules belong to the group.
 // @Tags     rule
 // @Summary  Get group config and all rules belong to the group.
@@ -153,6 +153,37 @@ func (h *schedulerHandler) CreateScheduler(w http.ResponseWriter, r *http.Reques
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case types.RemotePluginScheduler:
+		exist, _ := h.IsSchedulerExisted(name)
+		if exist {
+			handler, err := h.GetSchedulerConfigHandler()
+			if err == nil && handler != nil {
+				r.URL.Path = path.Join(server.SchedulerConfigHandlerPath, string(types.RemotePluginScheduler), "config")
+				data, err := json.Marshal(input)
+				if err != nil {
+					h.r.JSON(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+
+				r.Body = io.NopCloser(bytes.NewBuffer(data))
+				handler.ServeHTTP(w, r)
+				return
+			}
+			h.r.JSON(w, http.StatusNotAcceptable, err.Error())
+			return
+		}
+		if err := apiutil.CollectStringOption("plugin_name", input, collector); err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := apiutil.CollectStringOption("address", input, collector); err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := apiutil.CollectStringOption("timeout", input, collector); err != nil && !errors.ErrorEqual(err, errs.ErrOptionNotExist) {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case types.ScatterRangeScheduler:
 		if err := apiutil.CollectEscapeStringOption("start_key", input, collector); err != nil {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
@@ -330,6 +361,25 @@ func (h *schedulerHandler) PauseOrResumeScheduler(w http.ResponseWriter, r *http
 	h.r.JSON(w, http.StatusOK, "Pause or resume the scheduler successfully.")
 }
 
+// DryRunScheduler runs a scheduler once without executing the operators.
+// @Tags     scheduler
+// @Summary  Show the operators a scheduler would create now without executing them.
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Produce  json
+// @Success  200  {object}  schedulers.DryRunResult
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/dry-run/{name} [get]
+func (h *schedulerHandler) DryRunScheduler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	result, err := h.Handler.DryRunScheduler(name)
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, result)
+}
+
 func (h *schedulerHandler) isSchedulerExist(scheduler types.CheckerSchedulerType) (bool, error) {
 	rc, err := h.GetRaftCluster()
 	if err != nil {
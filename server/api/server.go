@@ -48,13 +48,21 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	//	"/operators", http.MethodGet
 	//	"/operators", http.MethodPost
 	//	"/operators/records",http.MethodGet
+	//	"/operators/history", http.MethodGet, which requires the data dir of the scheduling service to be set
 	//	"/operators/{region_id}", http.MethodGet
 	//	"/operators/{region_id}", http.MethodDelete
+	//	"/operators/groups", http.MethodGet
+	//	"/operators/groups", http.MethodPost
+	//	"/operators/groups/{id}", http.MethodGet
+	//	"/operators/groups/{id}", http.MethodDelete
+	//	"/operators/groups/{id}/pause", http.MethodPost
+	//	"/operators/groups/{id}/resume", http.MethodPost
 	//	"/checker/{name}", http.MethodPost
 	//	"/checker/{name}", http.MethodGet
 	//	"/schedulers", http.MethodGet
 	//	"/schedulers/{name}", http.MethodPost, which is to be used to pause or resume the scheduler rather than create a new scheduler
 	//	"/schedulers/diagnostic/{name}", http.MethodGet
+	//	"/schedulers/dry-run/{name}", http.MethodGet
 	//	"/scheduler-config", http.MethodGet
 	//	"/hotspot/regions/read", http.MethodGet
 	//	"/hotspot/regions/write", http.MethodGet
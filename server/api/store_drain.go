@@ -0,0 +1,180 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+
+	"github.com/pingcap/errcode"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+)
+
+type storeDrainHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newStoreDrainHandler(s *server.Server, rd *render.Render) *storeDrainHandler {
+	return &storeDrainHandler{
+		svr: s,
+		rd:  rd,
+	}
+}
+
+// GetAllStoreDrainStatuses returns the status of all the store drain jobs.
+// @Tags     store
+// @Summary  List the status of all the store drain jobs.
+// @Produce  json
+// @Success  200  {array}  cluster.StoreDrainStatus
+// @Router   /stores/drain [get]
+func (h *storeDrainHandler) GetAllStoreDrainStatuses(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	h.rd.JSON(w, http.StatusOK, rc.GetStoreDrainStatuses())
+}
+
+// GetStoreDrainStatus returns the status of the store drain job, including the progress and ETA.
+// @Tags     store
+// @Summary  Get the status of the store drain job.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {object}  cluster.StoreDrainStatus
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The drain job does not exist."
+// @Router   /store/{id}/drain [get]
+func (h *storeDrainHandler) GetStoreDrainStatus(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	status, err := rc.GetStoreDrainStatus(storeID)
+	if err != nil {
+		h.responseStoreDrainErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, status)
+}
+
+// DrainStore creates a job to drain the store.
+// @Tags     store
+// @Summary  Evict the leaders and move all the peers out of the store.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {object}  cluster.StoreDrainJob
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The store does not exist."
+// @Failure  410  {string}  string  "The store has already been removed."
+// @Router   /store/{id}/drain [post]
+func (h *storeDrainHandler) DrainStore(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	job, err := rc.DrainStore(storeID)
+	if err != nil {
+		h.responseStoreDrainErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, job)
+}
+
+// PauseStoreDrain pauses the store drain job.
+// @Tags     store
+// @Summary  Pause the store drain job.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {string}  string  "The drain job is paused."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The drain job does not exist."
+// @Router   /store/{id}/drain/pause [post]
+func (h *storeDrainHandler) PauseStoreDrain(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	if err := rc.PauseStoreDrain(storeID); err != nil {
+		h.responseStoreDrainErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The drain job is paused.")
+}
+
+// ResumeStoreDrain resumes the paused store drain job.
+// @Tags     store
+// @Summary  Resume the store drain job.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {string}  string  "The drain job is resumed."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The drain job does not exist."
+// @Router   /store/{id}/drain/resume [post]
+func (h *storeDrainHandler) ResumeStoreDrain(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	if err := rc.ResumeStoreDrain(storeID); err != nil {
+		h.responseStoreDrainErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The drain job is resumed.")
+}
+
+// CancelStoreDrain cancels the store drain job and sets the store up again.
+// @Tags     store
+// @Summary  Cancel the store drain job.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {string}  string  "The drain job is cancelled."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The drain job does not exist."
+// @Router   /store/{id}/drain [delete]
+func (h *storeDrainHandler) CancelStoreDrain(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	if err := rc.CancelStoreDrain(storeID); err != nil {
+		h.responseStoreDrainErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The drain job is cancelled.")
+}
+
+func (h *storeDrainHandler) responseStoreDrainErr(w http.ResponseWriter, err error) {
+	switch {
+	case errs.ErrStoreNotFound.Equal(err), errs.ErrStoreDrainJobNotFound.Equal(err):
+		h.rd.JSON(w, http.StatusNotFound, err.Error())
+	case errs.ErrStoreRemoved.Equal(err):
+		h.rd.JSON(w, http.StatusGone, err.Error())
+	default:
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/server"
 	"github.com/tikv/pd/server/apiv2/middlewares"
 )
@@ -45,6 +46,22 @@ func RegisterKeyspace(r *gin.RouterGroup) {
 	router.PATCH("/:name/config", UpdateKeyspaceConfig)
 	router.PUT("/:name/state", UpdateKeyspaceState)
 	router.GET("/id/:id", LoadKeyspaceByID)
+	router.GET("/:name/lifecycle", GetKeyspaceLifecycleRecords)
+	router.GET("/:name/quota", GetKeyspaceQuota)
+	router.PUT("/:name/quota", SetKeyspaceQuota)
+	router.POST("/:name/clone", CloneKeyspace)
+
+	// Use separate prefixes, otherwise the keyspaces with the same names can't be loaded.
+	templateRouter := r.Group("keyspace-templates")
+	templateRouter.Use(middlewares.BootstrapChecker())
+	templateRouter.GET("", LoadAllKeyspaceTemplates)
+	templateRouter.POST("", SaveKeyspaceTemplate)
+	templateRouter.GET("/:name", LoadKeyspaceTemplate)
+	templateRouter.DELETE("/:name", DeleteKeyspaceTemplate)
+	destroyRangeRouter := r.Group("keyspace-destroy-ranges")
+	destroyRangeRouter.Use(middlewares.BootstrapChecker())
+	destroyRangeRouter.GET("", GetKeyspaceDestroyRanges)
+	destroyRangeRouter.DELETE("/:id", FinishKeyspaceDestroyRange)
 }
 
 // CreateKeyspaceParams represents parameters needed when creating a new keyspace.
@@ -52,6 +69,8 @@ func RegisterKeyspace(r *gin.RouterGroup) {
 type CreateKeyspaceParams struct {
 	Name   string            `json:"name"`
 	Config map[string]string `json:"config"`
+	// Template is the name of the keyspace template to create the keyspace from, it's optional.
+	Template string `json:"template,omitempty"`
 }
 
 // CreateKeyspace creates keyspace according to given input.
@@ -82,8 +101,17 @@ func CreateKeyspace(c *gin.Context) {
 		Config:     createParams.Config,
 		CreateTime: time.Now().Unix(),
 	}
-	meta, err := manager.CreateKeyspace(req)
+	var meta *keyspacepb.KeyspaceMeta
+	if createParams.Template != "" {
+		meta, err = manager.CreateKeyspaceFromTemplate(req, createParams.Template)
+	} else {
+		meta, err = manager.CreateKeyspace(req)
+	}
 	if err != nil {
+		if errs.ErrKeyspaceTemplateNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -421,6 +449,318 @@ func UpdateKeyspaceState(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, &KeyspaceMeta{meta})
 }
 
+// GetKeyspaceLifecycleRecords returns the lifecycle steps applied to the target keyspace.
+//
+// @Tags     keyspaces
+// @Summary  Get the lifecycle steps applied to the keyspace.
+// @Param    name  path  string  true  "Keyspace Name"
+// @Produce  json
+// @Success  200  {array}   endpoint.KeyspaceLifecycleRecord
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspaces/{name}/lifecycle [get]
+func GetKeyspaceLifecycleRecords(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	records, err := manager.GetLifecycleRecords(c.Param("name"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, records)
+}
+
+// GetKeyspaceDestroyRanges returns the pending tasks to destroy the data of the archived keyspaces.
+// PD does not destroy the data by itself, the GC worker or the operator should do it and then finish
+// the task by FinishKeyspaceDestroyRange.
+//
+// @Tags     keyspaces
+// @Summary  Get the pending tasks to destroy the data of the archived keyspaces.
+// @Produce  json
+// @Success  200  {array}   endpoint.KeyspaceDestroyRange
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-destroy-ranges [get]
+func GetKeyspaceDestroyRanges(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	tasks, err := manager.GetDestroyRanges()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, tasks)
+}
+
+// FinishKeyspaceDestroyRange marks the data of the archived keyspace as destroyed, which should be
+// called after the key ranges of the task are destroyed by UnsafeDestroyRange of TiKV.
+//
+// @Tags     keyspaces
+// @Summary  Finish the task to destroy the data of the archived keyspace.
+// @Param    id  path  string  true  "Keyspace id"
+// @Produce  json
+// @Success  200  {string}  string  "The destroy range task is finished."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The destroy range task does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-destroy-ranges/{id} [delete]
+func FinishKeyspaceDestroyRange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid keyspace id")
+		return
+	}
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	if err := manager.FinishDestroyRange(uint32(id), time.Now()); err != nil {
+		if errs.ErrKeyspaceDestroyRangeNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, "The destroy range task is finished.")
+}
+
+// KeyspaceQuotaParams represents the limits of the keyspace quota.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type KeyspaceQuotaParams struct {
+	RegionCount endpoint.KeyspaceQuotaLimit `json:"region-count"`
+	// StorageSize is the limit of the approximate size of the keyspace in MiB.
+	StorageSize        endpoint.KeyspaceQuotaLimit `json:"storage-size"`
+	ResourceGroupCount endpoint.KeyspaceQuotaLimit `json:"resource-group-count"`
+}
+
+// GetKeyspaceQuota returns the quota of the target keyspace along with its resource usage.
+//
+// @Tags     keyspaces
+// @Summary  Get the quota and the resource usage of the keyspace.
+// @Param    name  path  string  true  "Keyspace Name"
+// @Produce  json
+// @Success  200  {object}  keyspace.QuotaStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspaces/{name}/quota [get]
+func GetKeyspaceQuota(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	status, err := manager.GetKeyspaceQuota(c.Param("name"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// SetKeyspaceQuota replaces the quota of the target keyspace, the limits not given are removed.
+//
+// @Tags     keyspaces
+// @Summary  Set the quota of the keyspace.
+// @Param    name  path  string               true  "Keyspace Name"
+// @Param    body  body  KeyspaceQuotaParams  true  "The limits of the keyspace quota, 0 means no limit"
+// @Produce  json
+// @Success  200  {object}  keyspace.QuotaStatus
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspaces/{name}/quota [put]
+func SetKeyspaceQuota(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	params := &KeyspaceQuotaParams{}
+	if err := c.BindJSON(params); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errs.ErrBindJSON.Wrap(err).GenWithStackByCause())
+		return
+	}
+	status, err := manager.SetKeyspaceQuota(c.Param("name"), &endpoint.KeyspaceQuota{
+		RegionCount:        params.RegionCount,
+		StorageSize:        params.StorageSize,
+		ResourceGroupCount: params.ResourceGroupCount,
+	})
+	if err != nil {
+		if errs.ErrInvalidKeyspaceQuota.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// CloneKeyspaceParams represents parameters needed when cloning a keyspace.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type CloneKeyspaceParams struct {
+	// Name is the name of the new keyspace.
+	Name string `json:"name"`
+}
+
+// CloneKeyspace creates a new keyspace with the config, placement rules, region label rules
+// and quota of the target keyspace. The data of the target keyspace is not copied. Only the rules
+// applied from a keyspace template are copied, and they are applied after the keyspace meta is saved
+// and removed in a best-effort way if the cloning fails, see `keyspace.Manager.CloneKeyspace`.
+//
+// @Tags         keyspaces
+// @Summary      Clone the metadata of the keyspace to a new keyspace.
+// @Description  Only the placement rules and region label rules applied from a keyspace template are cloned.
+// The cloning is not atomic, the rules left by a failed cloning need to be removed manually.
+// @Param        name  path  string               true  "Keyspace Name"
+// @Param        body  body  CloneKeyspaceParams  true  "Clone keyspace parameters"
+// @Produce      json
+// @Success      200  {object}  KeyspaceMeta
+// @Failure      400  {string}  string  "The input is invalid."
+// @Failure      500  {string}  string  "PD server failed to proceed the request."
+// @Router       /keyspaces/{name}/clone [post]
+func CloneKeyspace(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	params := &CloneKeyspaceParams{}
+	if err := c.BindJSON(params); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errs.ErrBindJSON.Wrap(err).GenWithStackByCause())
+		return
+	}
+	meta, err := manager.CloneKeyspace(c.Param("name"), params.Name, time.Now().Unix())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, &KeyspaceMeta{meta})
+}
+
+// LoadAllKeyspaceTemplates returns all keyspace templates.
+//
+// @Tags     keyspaces
+// @Summary  List all keyspace templates.
+// @Produce  json
+// @Success  200  {array}   keyspace.Template
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-templates [get]
+func LoadAllKeyspaceTemplates(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	templates, err := manager.LoadTemplates()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, templates)
+}
+
+// SaveKeyspaceTemplate creates or replaces a keyspace template.
+//
+// @Tags     keyspaces
+// @Summary  Save the keyspace template.
+// @Param    body  body  keyspace.Template  true  "The keyspace template"
+// @Produce  json
+// @Success  200  {object}  keyspace.Template
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-templates [post]
+func SaveKeyspaceTemplate(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	template := &keyspace.Template{}
+	if err := c.BindJSON(template); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errs.ErrBindJSON.Wrap(err).GenWithStackByCause())
+		return
+	}
+	if err := manager.SaveTemplate(template); err != nil {
+		if errs.ErrInvalidKeyspaceTemplate.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, template)
+}
+
+// LoadKeyspaceTemplate returns the target keyspace template.
+//
+// @Tags     keyspaces
+// @Summary  Get the keyspace template.
+// @Param    name  path  string  true  "Template Name"
+// @Produce  json
+// @Success  200  {object}  keyspace.Template
+// @Failure  404  {string}  string  "The template does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-templates/{name} [get]
+func LoadKeyspaceTemplate(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	template, err := manager.LoadTemplate(c.Param("name"))
+	if err != nil {
+		if errs.ErrKeyspaceTemplateNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, template)
+}
+
+// DeleteKeyspaceTemplate deletes the target keyspace template.
+//
+// @Tags     keyspaces
+// @Summary  Delete the keyspace template.
+// @Param    name  path  string  true  "Template Name"
+// @Produce  json
+// @Success  200  {string}  string  "The template is deleted."
+// @Failure  404  {string}  string  "The template does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /keyspace-templates/{name} [delete]
+func DeleteKeyspaceTemplate(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	if err := manager.DeleteTemplate(c.Param("name")); err != nil {
+		if errs.ErrKeyspaceTemplateNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, "The template is deleted.")
+}
+
 // KeyspaceMeta wraps keyspacepb.KeyspaceMeta to provide custom JSON marshal.
 type KeyspaceMeta struct {
 	*keyspacepb.KeyspaceMeta
@@ -41,6 +41,8 @@ func RegisterTSOKeyspaceGroup(r *gin.RouterGroup) {
 	router.Use(middlewares.BootstrapChecker())
 	router.POST("", CreateKeyspaceGroups)
 	router.GET("", GetKeyspaceGroups)
+	router.GET("/rebalance", GetKeyspaceGroupRebalancePlan)
+	router.POST("/rebalance", RebalanceKeyspaceGroups)
 	router.GET("/:id", GetKeyspaceGroupByID)
 	router.DELETE("/:id", DeleteKeyspaceGroupByID)
 	router.PATCH("/:id", SetNodesForKeyspaceGroup)          // only to support set nodes
@@ -378,6 +380,38 @@ func FinishMergeKeyspaceByID(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
 
+// GetKeyspaceGroupRebalancePlan returns the plan to rebalance the keyspace groups without executing it.
+func GetKeyspaceGroupRebalancePlan(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, GroupManagerUninitializedErr)
+		return
+	}
+	plan, err := manager.GetRebalancePlan()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// RebalanceKeyspaceGroups rebalances the keyspace groups across the TSO nodes and returns the executed plan.
+func RebalanceKeyspaceGroups(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, GroupManagerUninitializedErr)
+		return
+	}
+	plan, err := manager.RebalanceKeyspaceGroups()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
 // AllocNodesForKeyspaceGroupParams defines the params for allocating nodes for keyspace groups.
 type AllocNodesForKeyspaceGroupParams struct {
 	Replica int `json:"replica"`
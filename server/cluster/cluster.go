@@ -54,10 +54,12 @@ import (
 	"github.com/tikv/pd/pkg/progress"
 	"github.com/tikv/pd/pkg/ratelimit"
 	"github.com/tikv/pd/pkg/replication"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/statistics"
@@ -133,6 +135,7 @@ type Server interface {
 	GetBasicCluster() *core.BasicCluster
 	GetMembers() ([]*pdpb.Member, error)
 	ReplicateFileToMember(ctx context.Context, member *pdpb.Member, name string, data []byte) error
+	GetKeyspaceManager() *keyspace.Manager
 	GetKeyspaceGroupManager() *keyspace.GroupManager
 	IsKeyspaceGroupEnabled() bool
 	GetSafePointV2Manager() *gc.SafePointV2Manager
@@ -185,6 +188,17 @@ type RaftCluster struct {
 	independentServices      sync.Map
 	hbstreams                *hbstream.HeartbeatStreams
 	tsoAllocator             *tso.Allocator
+	opHistoryRecorder        operator.HistoryRecorder
+	splitGuard               checker.SplitGuard
+
+	maintenanceWindow struct {
+		syncutil.Mutex
+		cfg *sc.MaintenanceWindowConfig
+	}
+	storeDrain struct {
+		syncutil.Mutex
+		jobs map[uint64]*StoreDrainJob
+	}
 
 	// heartbeatRunner is used to process the subtree update task asynchronously.
 	heartbeatRunner ratelimit.Runner
@@ -328,9 +342,23 @@ func (c *RaftCluster) InitCluster(
 		}
 	}
 	c.schedulingController = newSchedulingController(c.ctx, c.BasicCluster, c.opt, c.ruleManager)
+	c.schedulingController.opHistoryRecorder = c.opHistoryRecorder
+	c.schedulingController.splitGuard = c.splitGuard
 	return nil
 }
 
+// SetOperatorHistoryRecorder sets the recorder to persist the finished operators.
+// It should be called before the cluster is started.
+func (c *RaftCluster) SetOperatorHistoryRecorder(recorder operator.HistoryRecorder) {
+	c.opHistoryRecorder = recorder
+}
+
+// SetSplitGuard sets the guard to deny the split of some regions by the split checker or asked by TiKV.
+// It should be called before the cluster is started.
+func (c *RaftCluster) SetSplitGuard(guard checker.SplitGuard) {
+	c.splitGuard = guard
+}
+
 // Start starts a cluster.
 func (c *RaftCluster) Start(s Server, bootstrap bool) (err error) {
 	c.Lock()
@@ -390,17 +418,22 @@ func (c *RaftCluster) Start(s Server, bootstrap bool) (err error) {
 	}
 	c.loadExternalTS()
 	c.loadMinResolvedTS()
+	c.loadMaintenanceWindows()
+	c.loadStoreDrainJobs()
 
 	if c.isKeyspaceGroupEnabled {
 		// bootstrap keyspace group manager after starting other parts successfully.
 		// This order avoids a stuck goroutine in keyspaceGroupManager when it fails to create raftcluster.
+		c.keyspaceGroupManager.SetRebalanceConfig(func() keyspace.RebalanceConfig {
+			return c.opt.GetKeyspaceConfig()
+		})
 		err = c.keyspaceGroupManager.Bootstrap(c.ctx)
 		if err != nil {
 			return err
 		}
 	}
 	c.checkSchedulingService()
-	c.wg.Add(9)
+	c.wg.Add(13)
 	go c.runServiceCheckJob()
 	go c.runMetricsCollectionJob()
 	go c.runNodeStateCheckJob()
@@ -409,6 +442,10 @@ func (c *RaftCluster) Start(s Server, bootstrap bool) (err error) {
 	go c.runMinResolvedTSJob()
 	go c.runStoreConfigSync()
 	go c.runUpdateStoreStats()
+	go c.runMaintenanceWindowJob()
+	go c.runStoreDrainJob()
+	go c.runKeyspaceLifecycleJob(s.GetKeyspaceManager())
+	go c.runKeyspaceQuotaJob(s.GetKeyspaceManager())
 	go c.startGCTuner()
 
 	c.running = true
@@ -2431,6 +2468,24 @@ func (c *RaftCluster) runMinResolvedTSJob() {
 	}
 }
 
+// runKeyspaceLifecycleJob archives and tombstones the keyspaces according to the keyspace lifecycle policies.
+func (c *RaftCluster) runKeyspaceLifecycleJob(manager *keyspace.Manager) {
+	defer c.wg.Done()
+	if manager == nil {
+		return
+	}
+	manager.RunLifecycleLoop(c.ctx)
+}
+
+// runKeyspaceQuotaJob collects the resource usages of the keyspaces with quota and enforces the limits.
+func (c *RaftCluster) runKeyspaceQuotaJob(manager *keyspace.Manager) {
+	defer c.wg.Done()
+	if manager == nil {
+		return
+	}
+	manager.RunQuotaLoop(c.ctx)
+}
+
 func (c *RaftCluster) loadMinResolvedTS() {
 	// Use `c.GetStorage()` here to prevent from the data race in test.
 	minResolvedTS, err := c.GetStorage().LoadMinResolvedTS()
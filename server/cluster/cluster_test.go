@@ -3828,6 +3828,36 @@ func TestInterval(t *testing.T) {
 	}
 }
 
+func TestDryRunScheduler(t *testing.T) {
+	re := require.New(t)
+
+	tc, co, cleanup := prepare(nil, nil, nil, re)
+	defer cleanup()
+	oc := co.GetOperatorController()
+
+	re.NoError(tc.addLeaderStore(1, 10))
+	re.NoError(tc.addLeaderStore(2, 0))
+	re.NoError(tc.addLeaderStore(3, 0))
+	for i := uint64(1); i <= 10; i++ {
+		re.NoError(tc.addLeaderRegion(i, 1, 2, 3))
+	}
+	lb, err := schedulers.CreateScheduler(types.BalanceLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), schedulers.ConfigSliceDecoder(types.BalanceLeaderScheduler, []string{"", ""}))
+	re.NoError(err)
+	sc := schedulers.NewScheduleController(tc.ctx, co.GetCluster(), oc, lb)
+
+	result := sc.DryRun()
+	re.Equal(lb.GetName(), result.Name)
+	re.True(result.Allowed)
+	re.NotEmpty(result.Operators)
+	for _, op := range result.Operators {
+		re.NotEmpty(op.Steps)
+		re.NotEmpty(op.Influence)
+		// the proposed operators should never be added.
+		re.Nil(oc.GetOperator(op.RegionID))
+	}
+	re.Zero(oc.OperatorCount(operator.OpLeader))
+}
+
 func TestConcurrentStoreStats(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
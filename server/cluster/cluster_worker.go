@@ -90,6 +90,9 @@ func (c *RaftCluster) HandleAskSplit(request *pdpb.AskSplitRequest) (*pdpb.AskSp
 	if repMode := c.GetReplicationMode(); repMode != nil && repMode.IsRegionSplitPaused() {
 		return nil, errors.New("region split is paused by replication mode")
 	}
+	if c.splitGuard != nil && !c.splitGuard.AllowSplit(core.NewRegionInfo(reqRegion, nil)) {
+		return nil, errors.New("region split is denied by the keyspace quota")
+	}
 
 	newRegionID, _, err := c.id.Alloc(1)
 	if err != nil {
@@ -135,6 +138,9 @@ func (c *RaftCluster) HandleAskBatchSplit(request *pdpb.AskBatchSplitRequest) (*
 	if repMode := c.GetReplicationMode(); repMode != nil && repMode.IsRegionSplitPaused() {
 		return nil, errors.New("region split is paused by replication mode")
 	}
+	if c.splitGuard != nil && !c.splitGuard.AllowSplit(core.NewRegionInfo(reqRegion, nil)) {
+		return nil, errors.New("region split is denied by the keyspace quota")
+	}
 	splitIDs := make([]*pdpb.SplitID, 0, splitCount)
 	recordRegions := make([]uint64, 0, splitCount+1)
 
@@ -0,0 +1,300 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+const maintenanceWindowCheckInterval = 10 * time.Second
+
+func (c *RaftCluster) loadMaintenanceWindows() {
+	cfg := &sc.MaintenanceWindowConfig{}
+	// Use `c.GetStorage()` here to prevent from the data race in test.
+	if _, err := c.GetStorage().LoadMaintenanceWindows(cfg); err != nil {
+		log.Error("load maintenance windows meet error", errs.ZapError(err))
+	}
+	c.maintenanceWindow.Lock()
+	defer c.maintenanceWindow.Unlock()
+	c.maintenanceWindow.cfg = cfg
+}
+
+func (c *RaftCluster) runMaintenanceWindowJob() {
+	defer logutil.LogPanic()
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(maintenanceWindowCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("maintenance window job has been stopped")
+			return
+		case <-ticker.C:
+			c.checkMaintenanceWindows(time.Now())
+		}
+	}
+}
+
+// GetMaintenanceWindows returns the maintenance windows and the active one.
+func (c *RaftCluster) GetMaintenanceWindows() *sc.MaintenanceWindowConfig {
+	c.maintenanceWindow.Lock()
+	defer c.maintenanceWindow.Unlock()
+	return c.getMaintenanceWindowsLocked().Clone()
+}
+
+// SetMaintenanceWindow adds a maintenance window or replaces the one with the same name.
+func (c *RaftCluster) SetMaintenanceWindow(window *sc.MaintenanceWindow) error {
+	if err := window.Validate(); err != nil {
+		return err
+	}
+	if _, _, _, err := sc.ApplyScheduleOverlay(c.opt.GetScheduleConfig(), window.ScheduleOverlay); err != nil {
+		return errs.ErrMaintenanceWindowConfig.FastGenByArgs(err.Error())
+	}
+	c.maintenanceWindow.Lock()
+	defer c.maintenanceWindow.Unlock()
+	newCfg := c.getMaintenanceWindowsLocked().Clone()
+	replaced := false
+	for i, w := range newCfg.Windows {
+		if w.Name == window.Name {
+			newCfg.Windows[i] = window
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		newCfg.Windows = append(newCfg.Windows, window)
+	}
+	if err := c.storage.SaveMaintenanceWindows(newCfg); err != nil {
+		return err
+	}
+	c.maintenanceWindow.cfg = newCfg
+	// The active window should take effect again with the new definition, it is
+	// deactivated after being replaced so that the new definition is used.
+	if newCfg.Active != nil && newCfg.Active.Name == window.Name {
+		if err := c.deactivateMaintenanceWindowLocked(); err != nil {
+			return err
+		}
+	}
+	log.Info("maintenance window is updated", zap.Reflect("window", window))
+	c.checkMaintenanceWindowsLocked(time.Now())
+	return nil
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window, its changes will be reverted if it is active.
+func (c *RaftCluster) DeleteMaintenanceWindow(name string) error {
+	c.maintenanceWindow.Lock()
+	defer c.maintenanceWindow.Unlock()
+	cfg := c.getMaintenanceWindowsLocked()
+	if cfg.GetWindow(name) == nil {
+		return errs.ErrMaintenanceWindowNotFound.FastGenByArgs(name)
+	}
+	newCfg := cfg.Clone()
+	windows := newCfg.Windows[:0]
+	for _, w := range newCfg.Windows {
+		if w.Name != name {
+			windows = append(windows, w)
+		}
+	}
+	newCfg.Windows = windows
+	if err := c.storage.SaveMaintenanceWindows(newCfg); err != nil {
+		return err
+	}
+	c.maintenanceWindow.cfg = newCfg
+	// Deactivate the window after it is removed, so that the schedulers enabled
+	// by it are not paused until a start which never comes.
+	if newCfg.Active != nil && newCfg.Active.Name == name {
+		if err := c.deactivateMaintenanceWindowLocked(); err != nil {
+			return err
+		}
+	}
+	log.Info("maintenance window is deleted", zap.String("name", name))
+	c.checkMaintenanceWindowsLocked(time.Now())
+	return nil
+}
+
+func (c *RaftCluster) getMaintenanceWindowsLocked() *sc.MaintenanceWindowConfig {
+	if c.maintenanceWindow.cfg == nil {
+		c.maintenanceWindow.cfg = &sc.MaintenanceWindowConfig{}
+	}
+	return c.maintenanceWindow.cfg
+}
+
+// checkMaintenanceWindows activates the first window which covers now, and
+// deactivates the previous one if it is not active anymore.
+func (c *RaftCluster) checkMaintenanceWindows(now time.Time) {
+	c.maintenanceWindow.Lock()
+	defer c.maintenanceWindow.Unlock()
+	c.checkMaintenanceWindowsLocked(now)
+}
+
+func (c *RaftCluster) checkMaintenanceWindowsLocked(now time.Time) {
+	cfg := c.getMaintenanceWindowsLocked()
+	var (
+		window *sc.MaintenanceWindow
+		start  time.Time
+	)
+	for _, w := range cfg.Windows {
+		if s, ok := w.ActiveAt(now); ok {
+			window, start = w, s
+			break
+		}
+	}
+	if active := cfg.Active; active != nil {
+		if window != nil && window.Name == active.Name && start.Equal(active.StartTime) {
+			return
+		}
+		if err := c.deactivateMaintenanceWindowLocked(); err != nil {
+			log.Error("deactivate maintenance window meet error", zap.String("name", active.Name), errs.ZapError(err))
+			return
+		}
+	}
+	if window == nil {
+		return
+	}
+	if err := c.activateMaintenanceWindowLocked(window, start, now); err != nil {
+		log.Error("activate maintenance window meet error", zap.String("name", window.Name), errs.ZapError(err))
+	}
+}
+
+func (c *RaftCluster) activateMaintenanceWindowLocked(window *sc.MaintenanceWindow, start, now time.Time) error {
+	old := c.opt.GetScheduleConfig()
+	newScheduleCfg, applied, original, err := sc.ApplyScheduleOverlay(old, window.ScheduleOverlay)
+	if err != nil {
+		return err
+	}
+	active := &sc.ActiveMaintenanceWindow{
+		Name:      window.Name,
+		StartTime: start,
+		EndTime:   start.Add(window.Duration.Duration),
+		Applied:   applied,
+		Original:  original,
+	}
+	if c.canControlSchedulers() {
+		// Pause a little longer than the window to avoid the gap before the window is deactivated.
+		pauseSeconds := int64(active.EndTime.Sub(now).Seconds()) + int64(maintenanceWindowCheckInterval.Seconds())
+		schedulers := c.GetCoordinator().GetSchedulersController()
+		for _, name := range window.DisableSchedulers {
+			// Only record the schedulers paused by the window itself, the ones
+			// paused before the window should not be resumed when it ends.
+			if paused, err := schedulers.IsSchedulerPaused(name); err == nil && paused {
+				continue
+			}
+			if err := c.PauseOrResumeScheduler(name, pauseSeconds); err != nil {
+				log.Warn("failed to pause scheduler in maintenance window", zap.String("window", window.Name), zap.String("scheduler", name), errs.ZapError(err))
+				continue
+			}
+			active.PausedSchedulers = append(active.PausedSchedulers, name)
+		}
+		for _, name := range window.EnableSchedulers {
+			if err := c.PauseOrResumeScheduler(name, 0); err != nil {
+				log.Warn("failed to resume scheduler in maintenance window", zap.String("window", window.Name), zap.String("scheduler", name), errs.ZapError(err))
+				continue
+			}
+			active.ResumedSchedulers = append(active.ResumedSchedulers, name)
+		}
+	}
+	// Persist the state before changing the schedule config, so that the changes
+	// can always be reverted after they are made.
+	newCfg := c.getMaintenanceWindowsLocked().Clone()
+	newCfg.Active = active
+	if err := c.storage.SaveMaintenanceWindows(newCfg); err != nil {
+		return err
+	}
+	c.maintenanceWindow.cfg = newCfg
+	if len(applied) > 0 {
+		c.opt.SetScheduleConfig(newScheduleCfg)
+		if err := c.opt.Persist(c.storage); err != nil {
+			c.opt.SetScheduleConfig(old)
+			return err
+		}
+	}
+	log.Info("maintenance window is activated",
+		zap.String("name", window.Name),
+		zap.Time("start-time", active.StartTime),
+		zap.Time("end-time", active.EndTime),
+		zap.Reflect("applied", stringifyRawMessages(applied)))
+	return nil
+}
+
+func (c *RaftCluster) deactivateMaintenanceWindowLocked() error {
+	cfg := c.getMaintenanceWindowsLocked()
+	active := cfg.Active
+	if active == nil {
+		return nil
+	}
+	if len(active.Applied) > 0 {
+		old := c.opt.GetScheduleConfig()
+		reverted, err := sc.RevertScheduleOverlay(old, active.Applied, active.Original)
+		if err != nil {
+			return err
+		}
+		c.opt.SetScheduleConfig(reverted)
+		if err := c.opt.Persist(c.storage); err != nil {
+			c.opt.SetScheduleConfig(old)
+			return err
+		}
+	}
+	if c.canControlSchedulers() {
+		for _, name := range active.PausedSchedulers {
+			if err := c.PauseOrResumeScheduler(name, 0); err != nil {
+				log.Warn("failed to resume scheduler after maintenance window", zap.String("window", active.Name), zap.String("scheduler", name), errs.ZapError(err))
+			}
+		}
+		if window := cfg.GetWindow(active.Name); window != nil {
+			// Keep the enabled schedulers paused until the window starts again.
+			if next := window.NextStart(time.Now()); !next.IsZero() {
+				for _, name := range active.ResumedSchedulers {
+					if err := c.PauseOrResumeScheduler(name, int64(time.Until(next).Seconds())); err != nil {
+						log.Warn("failed to pause scheduler after maintenance window", zap.String("window", active.Name), zap.String("scheduler", name), errs.ZapError(err))
+					}
+				}
+			}
+		}
+	}
+	newCfg := cfg.Clone()
+	newCfg.Active = nil
+	if err := c.storage.SaveMaintenanceWindows(newCfg); err != nil {
+		return err
+	}
+	c.maintenanceWindow.cfg = newCfg
+	log.Info("maintenance window is deactivated", zap.String("name", active.Name))
+	return nil
+}
+
+// canControlSchedulers returns whether the schedulers are running in this PD,
+// they cannot be paused or resumed here if the scheduling service is independent.
+func (c *RaftCluster) canControlSchedulers() bool {
+	return !c.IsServiceIndependent(constant.SchedulingServiceName) && c.GetCoordinator() != nil
+}
+
+func stringifyRawMessages(m map[string]json.RawMessage) map[string]string {
+	res := make(map[string]string, len(m))
+	for k, v := range m {
+		res[k] = string(v)
+	}
+	return res
+}
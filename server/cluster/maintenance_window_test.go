@@ -0,0 +1,94 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, err := newTestScheduleConfig()
+	re.NoError(err)
+	s := storage.NewStorageWithMemoryBackend()
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, s)
+	regionScheduleLimit := opt.GetRegionScheduleLimit()
+	leaderScheduleLimit := opt.GetLeaderScheduleLimit()
+
+	// Only starts at 22:00 on January 1st, so it is not active when it is set.
+	window := &sc.MaintenanceWindow{
+		Name:            "new-year",
+		Cron:            "0 22 1 1 *",
+		Duration:        typeutil.NewDuration(8 * time.Hour),
+		TimeZone:        "UTC",
+		ScheduleOverlay: map[string]any{"region-schedule-limit": 4096, "leader-schedule-limit": 64},
+	}
+	re.NoError(cluster.SetMaintenanceWindow(window))
+	re.Len(cluster.GetMaintenanceWindows().Windows, 1)
+	re.Nil(cluster.GetMaintenanceWindows().Active)
+	re.Error(cluster.SetMaintenanceWindow(&sc.MaintenanceWindow{Name: "invalid", Cron: "* *"}))
+
+	start := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+	cluster.checkMaintenanceWindows(start.Add(time.Hour))
+	active := cluster.GetMaintenanceWindows().Active
+	re.NotNil(active)
+	re.Equal("new-year", active.Name)
+	re.True(start.Equal(active.StartTime))
+	re.Equal(uint64(4096), opt.GetRegionScheduleLimit())
+	re.Equal(uint64(64), opt.GetLeaderScheduleLimit())
+
+	// The state of the active window is persisted.
+	persisted := &sc.MaintenanceWindowConfig{}
+	ok, err := s.LoadMaintenanceWindows(persisted)
+	re.NoError(err)
+	re.True(ok)
+	re.NotNil(persisted.Active)
+	re.Equal("new-year", persisted.Active.Name)
+
+	// The items changed during the window are not reverted.
+	cfg := opt.GetScheduleConfig().Clone()
+	cfg.LeaderScheduleLimit = 32
+	opt.SetScheduleConfig(cfg)
+	cluster.checkMaintenanceWindows(start.Add(8 * time.Hour))
+	re.Nil(cluster.GetMaintenanceWindows().Active)
+	re.Equal(regionScheduleLimit, opt.GetRegionScheduleLimit())
+	re.Equal(uint64(32), opt.GetLeaderScheduleLimit())
+	cfg = opt.GetScheduleConfig().Clone()
+	cfg.LeaderScheduleLimit = leaderScheduleLimit
+	opt.SetScheduleConfig(cfg)
+
+	// The active window is reverted after it is deleted.
+	cluster.checkMaintenanceWindows(start.AddDate(1, 0, 0))
+	re.NotNil(cluster.GetMaintenanceWindows().Active)
+	re.Equal(uint64(4096), opt.GetRegionScheduleLimit())
+	re.NoError(cluster.DeleteMaintenanceWindow("new-year"))
+	re.Empty(cluster.GetMaintenanceWindows().Windows)
+	re.Nil(cluster.GetMaintenanceWindows().Active)
+	re.Equal(regionScheduleLimit, opt.GetRegionScheduleLimit())
+	re.True(errs.ErrMaintenanceWindowNotFound.Equal(cluster.DeleteMaintenanceWindow("new-year")))
+}
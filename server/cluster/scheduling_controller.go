@@ -57,6 +57,10 @@ type schedulingController struct {
 	hotStat     *statistics.HotStat
 	slowStat    *statistics.SlowStat
 	running     bool
+	// opHistoryRecorder is used to persist the finished operators.
+	opHistoryRecorder operator.HistoryRecorder
+	// splitGuard is used to deny the split of some regions by the split checker.
+	splitGuard checker.SplitGuard
 }
 
 // newSchedulingController creates a new scheduling controller.
@@ -114,6 +118,12 @@ func (sc *schedulingController) initCoordinator(ctx context.Context, cluster sch
 func (sc *schedulingController) initCoordinatorLocked(ctx context.Context, cluster sche.ClusterInformer, hbstreams *hbstream.HeartbeatStreams) {
 	sc.ctx, sc.cancel = context.WithCancel(ctx)
 	sc.coordinator = schedule.NewCoordinator(sc.ctx, cluster, hbstreams)
+	if sc.opHistoryRecorder != nil {
+		sc.coordinator.GetOperatorController().SetHistoryRecorder(sc.opHistoryRecorder)
+	}
+	if sc.splitGuard != nil {
+		sc.coordinator.GetCheckerController().SetSplitGuard(sc.splitGuard)
+	}
 }
 
 // runCoordinator runs the main scheduling loop.
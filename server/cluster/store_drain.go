@@ -0,0 +1,517 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	coreconstant "github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/utils/keyutil"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+const (
+	storeDrainCheckInterval = 10 * time.Second
+	// drainLeaderEvictionTimeout is the max duration of the leader eviction phase. The peers
+	// are moved after it even if some leaders are left, they are transferred with the peers.
+	drainLeaderEvictionTimeout = 10 * time.Minute
+	drainLeaderBatchSize       = 16
+	drainRegionBatchSize       = 64
+	// storeDrainJobRetention is how long the finished and cancelled jobs are kept.
+	storeDrainJobRetention = 7 * 24 * time.Hour
+	// drainPausedRemovePeerLimit is the remove-peer limit of the store when the drain job is
+	// paused. Zero means no limit, so use a tiny rate to block the peers from being removed.
+	drainPausedRemovePeerLimit = 0.001
+	evictingLeaderAction       = "evicting-leader"
+	drainOperatorDesc          = "drain-store"
+)
+
+// StoreDrainState is the state of a store drain job.
+type StoreDrainState string
+
+const (
+	// StoreDrainRunning means the job is draining the store.
+	StoreDrainRunning StoreDrainState = "running"
+	// StoreDrainPaused means the job is paused by the user.
+	StoreDrainPaused StoreDrainState = "paused"
+	// StoreDrainCancelled means the job is cancelled and the store is up again.
+	StoreDrainCancelled StoreDrainState = "cancelled"
+	// StoreDrainFinished means the store has been drained and removed.
+	StoreDrainFinished StoreDrainState = "finished"
+)
+
+// StoreDrainPhase is the phase of a store drain job.
+type StoreDrainPhase string
+
+const (
+	// StoreDrainEvictLeader transfers the leaders out of the store while it is still up.
+	StoreDrainEvictLeader StoreDrainPhase = "evict-leader"
+	// StoreDrainMovePeer marks the store offline and moves all the peers out of it.
+	StoreDrainMovePeer StoreDrainPhase = "move-peer"
+)
+
+// StoreDrainJob is the persisted state of draining a store.
+type StoreDrainJob struct {
+	StoreID        uint64          `json:"store_id"`
+	State          StoreDrainState `json:"state"`
+	Phase          StoreDrainPhase `json:"phase"`
+	CreateTime     time.Time       `json:"create_time"`
+	PhaseStartTime time.Time       `json:"phase_start_time"`
+	UpdateTime     time.Time       `json:"update_time"`
+	// OriginalRemovePeerLimit is the remove-peer limit of the store before draining, it is
+	// restored when the job is cancelled.
+	OriginalRemovePeerLimit float64 `json:"original_remove_peer_limit"`
+	InitialLeaderCount      int     `json:"initial_leader_count"`
+	InitialRegionSize       int64   `json:"initial_region_size"`
+
+	// pausedLeaderTransferIn is true if the job paused the leader transfer into the store.
+	// The pause state of the store is not persisted, so it is not persisted either.
+	pausedLeaderTransferIn bool
+}
+
+func (job *StoreDrainJob) isActive() bool {
+	return job.State == StoreDrainRunning || job.State == StoreDrainPaused
+}
+
+// StoreDrainStatus is the status of a store drain job.
+type StoreDrainStatus struct {
+	*StoreDrainJob
+	LeaderCount int   `json:"leader_count"`
+	RegionCount int   `json:"region_count"`
+	RegionSize  int64 `json:"region_size"`
+	// Progress is the progress of the current phase, in [0, 1].
+	Progress     float64 `json:"progress"`
+	CurrentSpeed float64 `json:"current_speed"`
+	LeftSeconds  float64 `json:"left_seconds"`
+}
+
+func (c *RaftCluster) loadStoreDrainJobs() {
+	jobs := make(map[uint64]*StoreDrainJob)
+	// Use `c.GetStorage()` here to prevent from the data race in test.
+	err := c.GetStorage().LoadStoreDrainJobs(func(_, v string) {
+		job := &StoreDrainJob{}
+		if err := json.Unmarshal([]byte(v), job); err != nil {
+			log.Error("failed to unmarshal store drain job", zap.String("job", v), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			return
+		}
+		jobs[job.StoreID] = job
+	})
+	if err != nil {
+		log.Error("load store drain jobs meet error", errs.ZapError(err))
+	}
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	c.storeDrain.jobs = jobs
+}
+
+func (c *RaftCluster) runStoreDrainJob() {
+	defer logutil.LogPanic()
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(storeDrainCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("store drain job has been stopped")
+			return
+		case <-ticker.C:
+			c.checkStoreDrainJobs()
+		}
+	}
+}
+
+// DrainStore creates a job to drain the store. The leaders are evicted first, then the
+// store is marked offline and all the peers are moved out of it.
+func (c *RaftCluster) DrainStore(storeID uint64) (*StoreDrainJob, error) {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	if job, ok := c.storeDrain.jobs[storeID]; ok && job.isActive() {
+		return nil, errs.ErrStoreDrainJobState.FastGenByArgs(storeID, job.State)
+	}
+	store := c.GetStore(storeID)
+	if store == nil {
+		return nil, errs.ErrStoreNotFound.FastGenByArgs(storeID)
+	}
+	if store.IsRemoved() {
+		return nil, errs.ErrStoreRemoved.FastGenByArgs(storeID)
+	}
+	if store.IsPhysicallyDestroyed() {
+		return nil, errs.ErrStoreDestroyed.FastGenByArgs(storeID)
+	}
+	now := time.Now()
+	job := &StoreDrainJob{
+		StoreID:                 storeID,
+		State:                   StoreDrainRunning,
+		Phase:                   StoreDrainEvictLeader,
+		CreateTime:              now,
+		PhaseStartTime:          now,
+		UpdateTime:              now,
+		OriginalRemovePeerLimit: c.GetStoreLimitByType(storeID, storelimit.RemovePeer),
+		InitialLeaderCount:      c.GetStoreLeaderCount(storeID),
+		InitialRegionSize:       c.GetStoreRegionSize(storeID),
+	}
+	if store.IsRemoving() {
+		// The store is already offline, only the peers need to be moved.
+		job.Phase = StoreDrainMovePeer
+		if limits, ok := c.prevStoreLimit[storeID]; ok {
+			job.OriginalRemovePeerLimit = limits[storelimit.RemovePeer]
+		}
+	} else if err := c.checkReplicaBeforeOfflineStore(storeID); err != nil {
+		return nil, err
+	}
+	if err := c.storage.SaveStoreDrainJob(storeID, job); err != nil {
+		return nil, err
+	}
+	c.storeDrain.jobs[storeID] = job
+	log.Info("store drain job is created", zap.Uint64("store-id", storeID), zap.String("phase", string(job.Phase)))
+	c.checkStoreDrainJobLocked(job)
+	return job.clone(), nil
+}
+
+// PauseStoreDrain pauses the drain job of the store.
+func (c *RaftCluster) PauseStoreDrain(storeID uint64) error {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	job, err := c.getActiveStoreDrainJobLocked(storeID)
+	if err != nil {
+		return err
+	}
+	if job.State == StoreDrainPaused {
+		return nil
+	}
+	if job.Phase == StoreDrainMovePeer {
+		if err := c.SetStoreLimit(storeID, storelimit.RemovePeer, drainPausedRemovePeerLimit); err != nil {
+			return err
+		}
+	}
+	if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) { job.State = StoreDrainPaused }); err != nil {
+		return err
+	}
+	log.Info("store drain job is paused", zap.Uint64("store-id", storeID))
+	return nil
+}
+
+// ResumeStoreDrain resumes the paused drain job of the store.
+func (c *RaftCluster) ResumeStoreDrain(storeID uint64) error {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	job, err := c.getActiveStoreDrainJobLocked(storeID)
+	if err != nil {
+		return err
+	}
+	if job.State == StoreDrainRunning {
+		return nil
+	}
+	if job.Phase == StoreDrainMovePeer {
+		if err := c.SetStoreLimit(storeID, storelimit.RemovePeer, storelimit.Unlimited); err != nil {
+			return err
+		}
+	}
+	if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) {
+		job.State = StoreDrainRunning
+		// The leader eviction should not time out because of the pause.
+		job.PhaseStartTime = time.Now()
+	}); err != nil {
+		return err
+	}
+	log.Info("store drain job is resumed", zap.Uint64("store-id", storeID))
+	c.checkStoreDrainJobLocked(job)
+	return nil
+}
+
+// CancelStoreDrain cancels the drain job of the store and sets the store up again.
+func (c *RaftCluster) CancelStoreDrain(storeID uint64) error {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	job, err := c.getActiveStoreDrainJobLocked(storeID)
+	if err != nil {
+		return err
+	}
+	if store := c.GetStore(storeID); store != nil && store.IsRemoving() {
+		if err := c.UpStore(storeID); err != nil {
+			return err
+		}
+		// The previous store limit is only recorded in memory, restore it explicitly
+		// in case the leader has changed.
+		if err := c.SetStoreLimit(storeID, storelimit.RemovePeer, job.OriginalRemovePeerLimit); err != nil {
+			log.Warn("failed to restore the store limit of the drained store", zap.Uint64("store-id", storeID), errs.ZapError(err))
+		}
+	}
+	if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) { job.State = StoreDrainCancelled }); err != nil {
+		return err
+	}
+	c.cleanStoreDrainJobLocked(job)
+	log.Info("store drain job is cancelled", zap.Uint64("store-id", storeID))
+	return nil
+}
+
+// GetStoreDrainStatus returns the status of the drain job of the store.
+func (c *RaftCluster) GetStoreDrainStatus(storeID uint64) (*StoreDrainStatus, error) {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	job, ok := c.storeDrain.jobs[storeID]
+	if !ok {
+		return nil, errs.ErrStoreDrainJobNotFound.FastGenByArgs(storeID)
+	}
+	return c.getStoreDrainStatusLocked(job), nil
+}
+
+// GetStoreDrainStatuses returns the status of all the store drain jobs.
+func (c *RaftCluster) GetStoreDrainStatuses() []*StoreDrainStatus {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	statuses := make([]*StoreDrainStatus, 0, len(c.storeDrain.jobs))
+	for _, job := range c.storeDrain.jobs {
+		statuses = append(statuses, c.getStoreDrainStatusLocked(job))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].StoreID < statuses[j].StoreID })
+	return statuses
+}
+
+func (c *RaftCluster) getStoreDrainStatusLocked(job *StoreDrainJob) *StoreDrainStatus {
+	status := &StoreDrainStatus{
+		StoreDrainJob: job.clone(),
+		LeaderCount:   c.GetStoreLeaderCount(job.StoreID),
+		RegionCount:   c.GetStoreRegionCount(job.StoreID),
+		RegionSize:    c.GetStoreRegionSize(job.StoreID),
+	}
+	switch {
+	case job.State == StoreDrainFinished:
+		status.Progress = 1
+	case !job.isActive():
+	case job.Phase == StoreDrainEvictLeader:
+		status.Progress, status.LeftSeconds, status.CurrentSpeed, _ = c.progressManager.Status(encodeEvictingLeaderProgressKey(job.StoreID))
+	default:
+		status.Progress, status.LeftSeconds, status.CurrentSpeed, _ = c.progressManager.Status(encodeRemovingProgressKey(job.StoreID))
+	}
+	return status
+}
+
+func (c *RaftCluster) getActiveStoreDrainJobLocked(storeID uint64) (*StoreDrainJob, error) {
+	job, ok := c.storeDrain.jobs[storeID]
+	if !ok {
+		return nil, errs.ErrStoreDrainJobNotFound.FastGenByArgs(storeID)
+	}
+	if !job.isActive() {
+		return nil, errs.ErrStoreDrainJobState.FastGenByArgs(storeID, job.State)
+	}
+	return job, nil
+}
+
+// updateStoreDrainJobLocked persists the job updated by f, the job is not changed if it fails.
+func (c *RaftCluster) updateStoreDrainJobLocked(job *StoreDrainJob, f func(*StoreDrainJob)) error {
+	newJob := job.clone()
+	f(newJob)
+	newJob.UpdateTime = time.Now()
+	if err := c.storage.SaveStoreDrainJob(job.StoreID, newJob); err != nil {
+		return err
+	}
+	f(job)
+	job.UpdateTime = newJob.UpdateTime
+	return nil
+}
+
+func (c *RaftCluster) checkStoreDrainJobs() {
+	c.storeDrain.Lock()
+	defer c.storeDrain.Unlock()
+	for storeID, job := range c.storeDrain.jobs {
+		if job.isActive() {
+			c.checkStoreDrainJobLocked(job)
+			continue
+		}
+		if time.Since(job.UpdateTime) < storeDrainJobRetention {
+			continue
+		}
+		if err := c.storage.DeleteStoreDrainJob(storeID); err != nil {
+			log.Error("failed to delete the expired store drain job", zap.Uint64("store-id", storeID), errs.ZapError(err))
+			continue
+		}
+		delete(c.storeDrain.jobs, storeID)
+		log.Info("expired store drain job is deleted", zap.Uint64("store-id", storeID), zap.String("state", string(job.State)))
+	}
+}
+
+func (c *RaftCluster) checkStoreDrainJobLocked(job *StoreDrainJob) {
+	store := c.GetStore(job.StoreID)
+	if store == nil || store.IsRemoved() {
+		if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) { job.State = StoreDrainFinished }); err != nil {
+			log.Error("failed to finish store drain job", zap.Uint64("store-id", job.StoreID), errs.ZapError(err))
+			return
+		}
+		c.cleanStoreDrainJobLocked(job)
+		log.Info("store drain job is finished", zap.Uint64("store-id", job.StoreID))
+		return
+	}
+	if job.Phase == StoreDrainMovePeer && store.IsUp() {
+		// The store is set up by others, e.g. `pd-ctl store cancel-delete`.
+		if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) { job.State = StoreDrainCancelled }); err != nil {
+			log.Error("failed to cancel store drain job", zap.Uint64("store-id", job.StoreID), errs.ZapError(err))
+			return
+		}
+		c.cleanStoreDrainJobLocked(job)
+		log.Info("store drain job is cancelled since the store is up", zap.Uint64("store-id", job.StoreID))
+		return
+	}
+	// Keep the leaders out of the store until the job ends.
+	if store.AllowLeaderTransferIn() {
+		if err := c.PauseLeaderTransfer(job.StoreID, coreconstant.In); err == nil {
+			job.pausedLeaderTransferIn = true
+		}
+	}
+	if job.State == StoreDrainPaused {
+		return
+	}
+	switch job.Phase {
+	case StoreDrainEvictLeader:
+		c.evictDrainingStoreLeaders(job, store)
+	case StoreDrainMovePeer:
+		c.moveDrainingStorePeers(job)
+	}
+}
+
+// evictDrainingStoreLeaders transfers the leaders out of the store, the job moves to the
+// next phase when there is no leader left or the eviction times out.
+func (c *RaftCluster) evictDrainingStoreLeaders(job *StoreDrainJob, store *core.StoreInfo) {
+	storeID := job.StoreID
+	leaderCount := c.GetStoreLeaderCount(storeID)
+	progressName := encodeEvictingLeaderProgressKey(storeID)
+	if !c.progressManager.AddProgress(progressName, float64(leaderCount), float64(job.InitialLeaderCount), storeDrainCheckInterval) {
+		c.progressManager.UpdateProgress(progressName, float64(leaderCount), float64(leaderCount), false)
+	}
+	// The operators can only be created here if the schedulers are running in this PD.
+	if leaderCount > 0 && time.Since(job.PhaseStartTime) < drainLeaderEvictionTimeout && c.canControlSchedulers() {
+		c.transferDrainingStoreLeaders(store)
+		return
+	}
+
+	if err := c.RemoveStore(storeID, false); err != nil {
+		log.Warn("failed to set the drained store offline", zap.Uint64("store-id", storeID), errs.ZapError(err))
+		return
+	}
+	if err := c.updateStoreDrainJobLocked(job, func(job *StoreDrainJob) {
+		job.Phase = StoreDrainMovePeer
+		job.PhaseStartTime = time.Now()
+	}); err != nil {
+		log.Error("failed to update store drain job", zap.Uint64("store-id", storeID), errs.ZapError(err))
+		return
+	}
+	c.progressManager.RemoveProgress(progressName)
+	log.Info("store drain job starts to move peers", zap.Uint64("store-id", storeID), zap.Int("left-leader-count", leaderCount))
+}
+
+func (c *RaftCluster) transferDrainingStoreLeaders(store *core.StoreInfo) {
+	oc := c.GetOperatorController()
+	conf := c.GetSharedConfig()
+	regions := c.RandLeaderRegions(store.GetID(), []keyutil.KeyRange{keyutil.NewKeyRange("", "")})
+	stateFilter := &filter.StoreStateFilter{ActionScope: drainOperatorDesc, TransferLeader: true, OperatorLevel: coreconstant.High}
+	count := 0
+	for _, region := range regions {
+		if count >= drainLeaderBatchSize {
+			return
+		}
+		if oc.GetOperator(region.GetID()) != nil {
+			continue
+		}
+		leaderFilter := filter.NewPlacementLeaderSafeguard(drainOperatorDesc, conf, c.GetBasicCluster(), c.GetRuleManager(), region, store, false)
+		var target *core.StoreInfo
+		for _, peer := range region.GetFollowers() {
+			candidate := c.GetStore(peer.GetStoreId())
+			if candidate == nil || !filter.Target(conf, candidate, []filter.Filter{stateFilter, leaderFilter}) {
+				continue
+			}
+			if target == nil || candidate.GetLeaderCount() < target.GetLeaderCount() {
+				target = candidate
+			}
+		}
+		if target == nil {
+			continue
+		}
+		op, err := operator.CreateTransferLeaderOperator(drainOperatorDesc, c, region, target.GetID(), []uint64{}, operator.OpLeader)
+		if err != nil {
+			log.Debug("fail to create transfer leader operator for draining store", zap.Uint64("region-id", region.GetID()), errs.ZapError(err))
+			continue
+		}
+		op.SetPriorityLevel(coreconstant.High)
+		if oc.AddWaitingOperator(op) > 0 {
+			count++
+		}
+	}
+}
+
+// moveDrainingStorePeers makes the replica checker move the peers out of the store in
+// priority order: the regions with unhealthy peers are the most likely to lose their
+// majority, so they are moved first, then the small regions which are quick to move.
+func (c *RaftCluster) moveDrainingStorePeers(job *StoreDrainJob) {
+	if !c.canControlSchedulers() {
+		return
+	}
+	oc := c.GetOperatorController()
+	regions := c.GetStoreRegions(job.StoreID)
+	candidates := regions[:0:0]
+	for _, region := range regions {
+		if oc.GetOperator(region.GetID()) == nil {
+			candidates = append(candidates, region)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ui := len(candidates[i].GetDownPeers()) + len(candidates[i].GetPendingPeers())
+		uj := len(candidates[j].GetDownPeers()) + len(candidates[j].GetPendingPeers())
+		if ui != uj {
+			return ui > uj
+		}
+		return candidates[i].GetApproximateSize() < candidates[j].GetApproximateSize()
+	})
+	if len(candidates) > drainRegionBatchSize {
+		candidates = candidates[:drainRegionBatchSize]
+	}
+	ids := make([]uint64, 0, len(candidates))
+	for _, region := range candidates {
+		ids = append(ids, region.GetID())
+	}
+	if len(ids) > 0 {
+		c.AddPendingProcessedRegions(false, ids...)
+	}
+}
+
+func (c *RaftCluster) cleanStoreDrainJobLocked(job *StoreDrainJob) {
+	if job.pausedLeaderTransferIn {
+		c.ResumeLeaderTransfer(job.StoreID, coreconstant.In)
+		job.pausedLeaderTransferIn = false
+	}
+	c.progressManager.RemoveProgress(encodeEvictingLeaderProgressKey(job.StoreID))
+}
+
+func (job *StoreDrainJob) clone() *StoreDrainJob {
+	j := *job
+	return &j
+}
+
+func encodeEvictingLeaderProgressKey(storeID uint64) string {
+	return fmt.Sprintf("%s-%d", evictingLeaderAction, storeID)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/progress"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestStoreDrain(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, err := newTestScheduleConfig()
+	re.NoError(err)
+	s := storage.NewStorageWithMemoryBackend()
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, s)
+	cluster.coordinator = schedule.NewCoordinator(ctx, cluster, nil)
+	cluster.progressManager = progress.NewManager()
+	cluster.SetPrepared()
+	cluster.loadStoreDrainJobs()
+
+	for _, store := range newTestStores(5, "5.0.0") {
+		re.NoError(cluster.PutMetaStore(store.GetMeta()))
+	}
+	for _, region := range newTestRegions(100, 5, 3) {
+		re.NoError(cluster.putRegion(region))
+	}
+	leaderCount := cluster.GetStoreLeaderCount(1)
+	re.Positive(leaderCount)
+
+	_, err = cluster.GetStoreDrainStatus(1)
+	re.True(errs.ErrStoreDrainJobNotFound.Equal(err))
+	_, err = cluster.DrainStore(10)
+	re.Error(err)
+
+	// The leaders are evicted first, the store stays up.
+	job, err := cluster.DrainStore(1)
+	re.NoError(err)
+	re.Equal(StoreDrainRunning, job.State)
+	re.Equal(StoreDrainEvictLeader, job.Phase)
+	re.Equal(leaderCount, job.InitialLeaderCount)
+	re.True(cluster.GetStore(1).IsUp())
+	re.False(cluster.GetStore(1).AllowLeaderTransferIn())
+	_, err = cluster.DrainStore(1)
+	re.True(errs.ErrStoreDrainJobState.Equal(err))
+
+	re.NoError(cluster.PauseStoreDrain(1))
+	status, err := cluster.GetStoreDrainStatus(1)
+	re.NoError(err)
+	re.Equal(StoreDrainPaused, status.State)
+	re.Equal(leaderCount, status.LeaderCount)
+	re.NoError(cluster.ResumeStoreDrain(1))
+
+	// The peers are moved after the leader eviction times out.
+	cluster.storeDrain.jobs[1].PhaseStartTime = time.Now().Add(-drainLeaderEvictionTimeout)
+	cluster.checkStoreDrainJobs()
+	status, err = cluster.GetStoreDrainStatus(1)
+	re.NoError(err)
+	re.Equal(StoreDrainMovePeer, status.Phase)
+	re.True(cluster.GetStore(1).IsRemoving())
+	re.Equal(storelimit.Unlimited, cluster.GetStoreLimitByType(1, storelimit.RemovePeer))
+
+	// Pausing the job blocks the peers from being removed.
+	re.NoError(cluster.PauseStoreDrain(1))
+	re.Equal(drainPausedRemovePeerLimit, cluster.GetStoreLimitByType(1, storelimit.RemovePeer))
+	re.NoError(cluster.ResumeStoreDrain(1))
+	re.Equal(storelimit.Unlimited, cluster.GetStoreLimitByType(1, storelimit.RemovePeer))
+
+	// The job is loaded by the new leader.
+	newCluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, s)
+	newCluster.loadStoreDrainJobs()
+	statuses := newCluster.GetStoreDrainStatuses()
+	re.Len(statuses, 1)
+	re.Equal(uint64(1), statuses[0].StoreID)
+	re.Equal(StoreDrainRunning, statuses[0].State)
+	re.Equal(StoreDrainMovePeer, statuses[0].Phase)
+
+	// Cancelling the job sets the store up again.
+	re.NoError(cluster.CancelStoreDrain(1))
+	re.True(cluster.GetStore(1).IsUp())
+	re.True(cluster.GetStore(1).AllowLeaderTransferIn())
+	status, err = cluster.GetStoreDrainStatus(1)
+	re.NoError(err)
+	re.Equal(StoreDrainCancelled, status.State)
+	re.True(errs.ErrStoreDrainJobState.Equal(cluster.PauseStoreDrain(1)))
+
+	// The ended job is deleted after the retention.
+	cluster.checkStoreDrainJobs()
+	_, err = cluster.GetStoreDrainStatus(1)
+	re.NoError(err)
+	cluster.storeDrain.jobs[1].UpdateTime = time.Now().Add(-storeDrainJobRetention)
+	cluster.checkStoreDrainJobs()
+	_, err = cluster.GetStoreDrainStatus(1)
+	re.True(errs.ErrStoreDrainJobNotFound.Equal(err))
+	newCluster.loadStoreDrainJobs()
+	re.Empty(newCluster.GetStoreDrainStatuses())
+
+	// A finished or cancelled job can be restarted.
+	_, err = cluster.DrainStore(1)
+	re.NoError(err)
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/configutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 )
@@ -97,6 +98,24 @@ type Config struct {
 	// be automatically clamped to the range.
 	TSOUpdatePhysicalInterval typeutil.Duration `toml:"tso-update-physical-interval" json:"tso-update-physical-interval"`
 
+	// TSOAuditLogDir is the directory to record the persisted timestamp windows, the leader
+	// terms and the reset requests, which are used to verify the monotonicity of the TSO.
+	// The audit log is disabled if it is empty.
+	TSOAuditLogDir string `toml:"tso-audit-log-dir" json:"tso-audit-log-dir"`
+
+	// TSOMode is the mode of the TSO allocation, which can be "window" or "hlc". In the "window" mode,
+	// the TSO is allocated within a time window which is extended in etcd synchronously, so the
+	// allocation may be stalled by a slow etcd. In the "hlc" mode, the TSO is allocated from a hybrid
+	// logical clock whose drift from the system time is bounded by `TSOMaxClockDrift`, and etcd is
+	// only written to fence the TSO of the previous leader term.
+	TSOMode string `toml:"tso-mode" json:"tso-mode"`
+	// TSOKeyspaceGroupModes specifies the TSO mode of the keyspace groups, whose keys are the
+	// keyspace group IDs. The keyspace groups not specified here use `TSOMode`.
+	TSOKeyspaceGroupModes map[string]string `toml:"tso-keyspace-group-modes" json:"tso-keyspace-group-modes"`
+	// TSOMaxClockDrift is the max drift of the TSO physical time from the system time in the "hlc"
+	// mode. It should be larger than the clock skew between the servers.
+	TSOMaxClockDrift typeutil.Duration `toml:"tso-max-clock-drift" json:"tso-max-clock-drift"`
+
 	// Deprecated
 	EnableLocalTSO bool `toml:"enable-local-tso" json:"enable-local-tso"`
 
@@ -229,6 +248,8 @@ const (
 	// MaxTSOUpdatePhysicalInterval is the max value of the config `TSOUpdatePhysicalInterval`.
 	MaxTSOUpdatePhysicalInterval = 10 * time.Second
 	minTSOUpdatePhysicalInterval = 1 * time.Millisecond
+	// defaultTSOMaxClockDrift is the default value of the config `TSOMaxClockDrift`.
+	defaultTSOMaxClockDrift = 500 * time.Millisecond
 
 	defaultLogFormat = "text"
 	defaultLogLevel  = "info"
@@ -249,6 +270,15 @@ const (
 	minCheckRegionSplitInterval     = 1 * time.Millisecond
 	maxCheckRegionSplitInterval     = 100 * time.Millisecond
 
+	defaultTSOKeyspaceGroupRebalanceInterval     = 5 * time.Minute
+	minTSOKeyspaceGroupRebalanceInterval         = time.Minute
+	defaultTSOKeyspaceGroupSplitLoad             = 50000
+	defaultTSOKeyspaceGroupMergeLoad             = 500
+	defaultTSOKeyspaceGroupRebalanceMaxOperators = 1
+
+	defaultKeyspaceArchiveAfter   = 7 * 24 * time.Hour
+	defaultKeyspaceTombstoneAfter = 30 * 24 * time.Hour
+
 	defaultEnableSchedulingFallback  = true
 	defaultEnableTSODynamicSwitching = false
 )
@@ -411,6 +441,12 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 			zap.Duration("update-physical-interval", c.TSOUpdatePhysicalInterval.Duration))
 	}
 
+	configutil.AdjustString(&c.TSOMode, tsoutil.WindowMode)
+	configutil.AdjustDuration(&c.TSOMaxClockDrift, defaultTSOMaxClockDrift)
+	if err := tsoutil.ValidateModes(c.TSOMode, c.TSOKeyspaceGroupModes); err != nil {
+		return err
+	}
+
 	if c.Labels == nil {
 		c.Labels = make(map[string]string)
 	}
@@ -672,6 +708,21 @@ func (c *Config) GetTSOSaveInterval() time.Duration {
 	return c.TSOSaveInterval.Duration
 }
 
+// GetTSOAuditLogDir returns the directory of the TSO audit log.
+func (c *Config) GetTSOAuditLogDir() string {
+	return c.TSOAuditLogDir
+}
+
+// GetTSOMode returns the TSO mode of the keyspace group.
+func (c *Config) GetTSOMode(keyspaceGroupID uint32) string {
+	return tsoutil.GetKeyspaceGroupMode(c.TSOMode, c.TSOKeyspaceGroupModes, keyspaceGroupID)
+}
+
+// GetTSOMaxClockDrift returns the max clock drift of the TSO in the "hlc" mode.
+func (c *Config) GetTSOMaxClockDrift() time.Duration {
+	return c.TSOMaxClockDrift.Duration
+}
+
 // GetTLSConfig returns the TLS config.
 func (c *Config) GetTLSConfig() *grpcutil.TLSConfig {
 	return &c.Security.TLSConfig
@@ -863,6 +914,25 @@ type KeyspaceConfig struct {
 	WaitRegionSplitTimeout typeutil.Duration `toml:"wait-region-split-timeout" json:"wait-region-split-timeout"`
 	// CheckRegionSplitInterval indicates the interval to check whether the region split is complete
 	CheckRegionSplitInterval typeutil.Duration `toml:"check-region-split-interval" json:"check-region-split-interval"`
+	// EnableTSOKeyspaceGroupRebalance indicates whether to rebalance the keyspace groups across the TSO nodes
+	// automatically according to the TSO request load.
+	EnableTSOKeyspaceGroupRebalance bool `toml:"enable-tso-keyspace-group-rebalance" json:"enable-tso-keyspace-group-rebalance"`
+	// TSOKeyspaceGroupRebalanceInterval is the min interval between two keyspace group rebalances.
+	TSOKeyspaceGroupRebalanceInterval typeutil.Duration `toml:"tso-keyspace-group-rebalance-interval" json:"tso-keyspace-group-rebalance-interval"`
+	// TSOKeyspaceGroupSplitLoad is the TSO request rate above which a keyspace group is split.
+	TSOKeyspaceGroupSplitLoad float64 `toml:"tso-keyspace-group-split-load" json:"tso-keyspace-group-split-load"`
+	// TSOKeyspaceGroupMergeLoad is the TSO request rate below which the keyspace groups are merged.
+	TSOKeyspaceGroupMergeLoad float64 `toml:"tso-keyspace-group-merge-load" json:"tso-keyspace-group-merge-load"`
+	// TSOKeyspaceGroupRebalanceMaxOperators is the max count of the split, merge and member move
+	// operators executed in one keyspace group rebalance.
+	TSOKeyspaceGroupRebalanceMaxOperators int `toml:"tso-keyspace-group-rebalance-max-operators" json:"tso-keyspace-group-rebalance-max-operators"`
+	// EnableLifecycle indicates whether to archive and tombstone the keyspaces automatically, and clean up
+	// the resources of the archived keyspaces.
+	EnableLifecycle bool `toml:"enable-lifecycle" json:"enable-lifecycle"`
+	// ArchiveAfter is how long a keyspace stays disabled before it's archived, 0 means never.
+	ArchiveAfter typeutil.Duration `toml:"archive-after" json:"archive-after"`
+	// TombstoneAfter is how long a keyspace stays archived before it's tombstoned, 0 means never.
+	TombstoneAfter typeutil.Duration `toml:"tombstone-after" json:"tombstone-after"`
 }
 
 // Validate checks if keyspace config falls within acceptable range.
@@ -874,6 +944,19 @@ func (c *KeyspaceConfig) Validate() error {
 	if c.CheckRegionSplitInterval.Duration >= c.WaitRegionSplitTimeout.Duration {
 		return errors.New("[keyspace] check-region-split-interval should be less than wait-region-split-timeout")
 	}
+	if c.TSOKeyspaceGroupRebalanceInterval.Duration < minTSOKeyspaceGroupRebalanceInterval {
+		return errors.New(fmt.Sprintf("[keyspace] tso-keyspace-group-rebalance-interval should be at least %v",
+			minTSOKeyspaceGroupRebalanceInterval))
+	}
+	if c.TSOKeyspaceGroupMergeLoad < 0 || c.TSOKeyspaceGroupMergeLoad >= c.TSOKeyspaceGroupSplitLoad {
+		return errors.New("[keyspace] tso-keyspace-group-merge-load should be non-negative and less than tso-keyspace-group-split-load")
+	}
+	if c.TSOKeyspaceGroupRebalanceMaxOperators <= 0 {
+		return errors.New("[keyspace] tso-keyspace-group-rebalance-max-operators should be positive")
+	}
+	if c.ArchiveAfter.Duration < 0 || c.TombstoneAfter.Duration < 0 {
+		return errors.New("[keyspace] archive-after and tombstone-after should be non-negative")
+	}
 	return nil
 }
 
@@ -887,6 +970,24 @@ func (c *KeyspaceConfig) adjust(meta *configutil.ConfigMetaData) {
 	if !meta.IsDefined("check-region-split-interval") {
 		c.CheckRegionSplitInterval = typeutil.NewDuration(defaultCheckRegionSplitInterval)
 	}
+	if !meta.IsDefined("tso-keyspace-group-rebalance-interval") {
+		c.TSOKeyspaceGroupRebalanceInterval = typeutil.NewDuration(defaultTSOKeyspaceGroupRebalanceInterval)
+	}
+	if !meta.IsDefined("tso-keyspace-group-split-load") {
+		c.TSOKeyspaceGroupSplitLoad = defaultTSOKeyspaceGroupSplitLoad
+	}
+	if !meta.IsDefined("tso-keyspace-group-merge-load") {
+		c.TSOKeyspaceGroupMergeLoad = defaultTSOKeyspaceGroupMergeLoad
+	}
+	if !meta.IsDefined("tso-keyspace-group-rebalance-max-operators") {
+		c.TSOKeyspaceGroupRebalanceMaxOperators = defaultTSOKeyspaceGroupRebalanceMaxOperators
+	}
+	if !meta.IsDefined("archive-after") {
+		c.ArchiveAfter = typeutil.NewDuration(defaultKeyspaceArchiveAfter)
+	}
+	if !meta.IsDefined("tombstone-after") {
+		c.TombstoneAfter = typeutil.NewDuration(defaultKeyspaceTombstoneAfter)
+	}
 }
 
 // Clone makes a deep copy of the keyspace config.
@@ -916,3 +1017,43 @@ func (c *KeyspaceConfig) GetWaitRegionSplitTimeout() time.Duration {
 func (c *KeyspaceConfig) GetCheckRegionSplitInterval() time.Duration {
 	return c.CheckRegionSplitInterval.Duration
 }
+
+// IsTSOKeyspaceGroupRebalanceEnabled returns whether to rebalance the keyspace groups automatically.
+func (c *KeyspaceConfig) IsTSOKeyspaceGroupRebalanceEnabled() bool {
+	return c.EnableTSOKeyspaceGroupRebalance
+}
+
+// GetTSOKeyspaceGroupRebalanceInterval returns the min interval between two keyspace group rebalances.
+func (c *KeyspaceConfig) GetTSOKeyspaceGroupRebalanceInterval() time.Duration {
+	return c.TSOKeyspaceGroupRebalanceInterval.Duration
+}
+
+// GetTSOKeyspaceGroupSplitLoad returns the TSO request rate above which a keyspace group is split.
+func (c *KeyspaceConfig) GetTSOKeyspaceGroupSplitLoad() float64 {
+	return c.TSOKeyspaceGroupSplitLoad
+}
+
+// GetTSOKeyspaceGroupMergeLoad returns the TSO request rate below which the keyspace groups are merged.
+func (c *KeyspaceConfig) GetTSOKeyspaceGroupMergeLoad() float64 {
+	return c.TSOKeyspaceGroupMergeLoad
+}
+
+// GetTSOKeyspaceGroupRebalanceMaxOperators returns the max count of the operators in one keyspace group rebalance.
+func (c *KeyspaceConfig) GetTSOKeyspaceGroupRebalanceMaxOperators() int {
+	return c.TSOKeyspaceGroupRebalanceMaxOperators
+}
+
+// IsLifecycleEnabled returns whether to archive and tombstone the keyspaces automatically.
+func (c *KeyspaceConfig) IsLifecycleEnabled() bool {
+	return c.EnableLifecycle
+}
+
+// GetArchiveAfter returns how long a keyspace stays disabled before it's archived.
+func (c *KeyspaceConfig) GetArchiveAfter() time.Duration {
+	return c.ArchiveAfter.Duration
+}
+
+// GetTombstoneAfter returns how long a keyspace stays archived before it's tombstoned.
+func (c *KeyspaceConfig) GetTombstoneAfter() time.Duration {
+	return c.TombstoneAfter.Duration
+}
@@ -700,6 +700,11 @@ func (o *PersistOptions) GetHotRegionsReservedDays() uint64 {
 	return o.GetScheduleConfig().HotRegionsReservedDays
 }
 
+// GetOperatorHistoryReservedDays gets days the finished operators are kept.
+func (o *PersistOptions) GetOperatorHistoryReservedDays() uint64 {
+	return o.GetScheduleConfig().OperatorHistoryReservedDays
+}
+
 // AddSchedulerCfg adds the scheduler configurations.
 func (o *PersistOptions) AddSchedulerCfg(tp types.CheckerSchedulerType, args []string) {
 	oldType := types.SchedulerTypeCompatibleMap[tp]
@@ -16,6 +16,7 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
@@ -41,6 +42,7 @@ import (
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/tso"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/server/cluster"
@@ -123,6 +125,11 @@ func (h *Handler) GetHotRegionsReservedDays() uint64 {
 	return h.opt.GetHotRegionsReservedDays()
 }
 
+// GetOperatorHistoryReservedDays gets days the finished operators are kept.
+func (h *Handler) GetOperatorHistoryReservedDays() uint64 {
+	return h.opt.GetOperatorHistoryReservedDays()
+}
+
 // HistoryHotRegionsRequest wrap request condition from tidb.
 // it is request from tidb
 type HistoryHotRegionsRequest struct {
@@ -187,6 +194,20 @@ func (h *Handler) GetAllRequestHistoryHotRegion(request *HistoryHotRegionsReques
 	}, err
 }
 
+// OperatorHistoryRequest wraps the conditions to query the persisted operators.
+type OperatorHistoryRequest struct {
+	// StartTime and EndTime are in ms.
+	StartTime int64    `json:"start_time,omitempty"`
+	EndTime   int64    `json:"end_time,omitempty"`
+	RegionIDs []uint64 `json:"region_ids,omitempty"`
+	StoreIDs  []uint64 `json:"store_ids,omitempty"`
+}
+
+// GetOperatorHistory gets the persisted operators which match the request.
+func (h *Handler) GetOperatorHistory(request *OperatorHistoryRequest) (*storage.HistoryOperators, error) {
+	return h.s.operatorHistoryStorage.LoadOperatorHistory(request.StartTime, request.EndTime, request.RegionIDs, request.StoreIDs)
+}
+
 // AddScheduler adds a scheduler.
 func (h *Handler) AddScheduler(tp types.CheckerSchedulerType, args ...string) error {
 	c, err := h.GetRaftCluster()
@@ -331,6 +352,20 @@ func (h *Handler) ResetTS(ts uint64, ignoreSmaller, skipUpperBoundCheck bool, _
 	return tsoAllocator.SetTSO(ts, ignoreSmaller, skipUpperBoundCheck)
 }
 
+// VerifyTSOAudit verifies the TSO audit log of the leader. PD only serves the default keyspace group,
+// the other groups should be verified on the TSO microservice.
+func (h *Handler) VerifyTSOAudit(keyspaceGroupID uint32) (*tso.AuditReport, error) {
+	if keyspaceGroupID != constant.DefaultKeyspaceGroupID {
+		return nil, errs.ErrKeyspaceGroupIDInvalid.FastGenByArgs(
+			fmt.Sprintf("keyspace group %d is not served by PD", keyspaceGroupID))
+	}
+	tsoAllocator := h.s.GetTSOAllocator()
+	if tsoAllocator == nil {
+		return nil, errs.ErrServerNotStarted
+	}
+	return tsoAllocator.VerifyAudit(h.s.Context())
+}
+
 // GetProgressByID returns the progress details for a given store ID.
 func (h *Handler) GetProgressByID(storeID string) (action string, p, ls, cs float64, err error) {
 	return h.s.GetRaftCluster().GetProgressByID(storeID)
@@ -196,6 +196,8 @@ type Server struct {
 
 	// hot region history info storage
 	hotRegionStorage *storage.HotRegionStorage
+	// operatorHistoryStorage is used to persist the finished operators.
+	operatorHistoryStorage *storage.OperatorHistoryStorage
 	// Store as map[string]*grpc.ClientConn
 	clientConns sync.Map
 
@@ -456,6 +458,7 @@ func (s *Server) startServer(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	s.encryptionKeyManager.SetMemberName(s.Name())
 	// Initialize an etcd storage as the default storage.
 	defaultStorage := storage.NewStorageWithEtcdBackend(s.client)
 	// Initialize a specialized LevelDB storage to store the region-related meta info independently.
@@ -485,6 +488,7 @@ func (s *Server) startServer(ctx context.Context) error {
 	}
 	s.keyspaceManager = keyspace.NewKeyspaceManager(s.ctx, s.storage, s.cluster, keyspaceIDAllocator, &s.cfg.Keyspace, s.keyspaceGroupManager)
 	s.gcStateManager = gc.NewGCStateManager(s.storage.GetGCStateProvider(), s.cfg.PDServerCfg, s.keyspaceManager)
+	s.keyspaceManager.SetGCStatesCleaner(s.gcStateManager.DeleteKeyspaceGCStates)
 	s.safePointV2Manager = gc.NewSafePointManagerV2(s.ctx, s.storage, s.storage, s.storage)
 	s.hbStreams = hbstream.NewHeartbeatStreams(ctx, "", s.cluster)
 	// initial hot_region_storage in here.
@@ -494,6 +498,13 @@ func (s *Server) startServer(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	s.operatorHistoryStorage, err = storage.NewOperatorHistoryStorage(
+		ctx, filepath.Join(s.cfg.DataDir, "operator-history"), s.handler)
+	if err != nil {
+		return err
+	}
+	s.cluster.SetOperatorHistoryRecorder(s.operatorHistoryStorage)
+	s.cluster.SetSplitGuard(s.keyspaceManager)
 
 	// Run callbacks
 	log.Info("triggering the start callback functions")
@@ -572,6 +583,12 @@ func (s *Server) Close() {
 		}
 	}
 
+	if s.operatorHistoryStorage != nil {
+		if err := s.operatorHistoryStorage.Close(); err != nil {
+			log.Error("close operator history storage meet error", errs.ZapError(err))
+		}
+	}
+
 	s.grpcServiceRateLimiter.Close()
 	s.serviceRateLimiter.Close()
 	// Run callbacks
@@ -851,6 +868,11 @@ func (s *Server) GetStorage() storage.Storage {
 	return s.storage
 }
 
+// GetEncryptionKeyManager returns the encryption key manager of the server.
+func (s *Server) GetEncryptionKeyManager() *encryption.Manager {
+	return s.encryptionKeyManager
+}
+
 // GetGCStateManager returns the GC state manager of the server.
 func (s *Server) GetGCStateManager() *gc.GCStateManager {
 	return s.gcStateManager
@@ -861,6 +883,11 @@ func (s *Server) GetHistoryHotRegionStorage() *storage.HotRegionStorage {
 	return s.hotRegionStorage
 }
 
+// GetOperatorHistoryStorage returns the backend storage of the finished operators.
+func (s *Server) GetOperatorHistoryStorage() *storage.OperatorHistoryStorage {
+	return s.operatorHistoryStorage
+}
+
 // SetStorage changes the storage only for test purpose.
 // When we use it, we should prevent calling GetStorage, otherwise, it may cause a data race problem.
 func (s *Server) SetStorage(storage storage.Storage) {
@@ -2097,6 +2124,21 @@ func (s *Server) GetMaxResetTSGap() time.Duration {
 	return s.persistOptions.GetMaxResetTSGap()
 }
 
+// GetTSOAuditLogDir returns the directory of the TSO audit log.
+func (s *Server) GetTSOAuditLogDir() string {
+	return s.cfg.GetTSOAuditLogDir()
+}
+
+// GetTSOMode returns the TSO mode of the keyspace group.
+func (s *Server) GetTSOMode(keyspaceGroupID uint32) string {
+	return s.cfg.GetTSOMode(keyspaceGroupID)
+}
+
+// GetTSOMaxClockDrift returns the max clock drift of the TSO in the HLC mode.
+func (s *Server) GetTSOMaxClockDrift() time.Duration {
+	return s.cfg.GetTSOMaxClockDrift()
+}
+
 // SetClient sets the etcd client.
 // Notes: it is only used for test.
 func (s *Server) SetClient(client *clientv3.Client) {
@@ -990,3 +990,90 @@ func (suite *httpClientTestSuite) TestGetGCSafePoint() {
 	re.NoError(err)
 	re.Equal("Delete service GC safepoint successfully.", msg)
 }
+
+func (suite *httpClientTestSuite) TestOperators() {
+	re := suite.Require()
+	client := suite.client
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	ops, err := client.GetOperators(ctx)
+	re.NoError(err)
+	re.Empty(ops)
+	err = client.CreateOperator(ctx, map[string]any{
+		"name":      "add-peer",
+		"region_id": 10,
+		"store_id":  2,
+	})
+	re.NoError(err)
+	ops, err = client.GetOperators(ctx)
+	re.NoError(err)
+	re.Len(ops, 1)
+	re.Equal(uint64(10), ops[0].RegionID)
+	ops, err = client.GetOperators(ctx, "leader")
+	re.NoError(err)
+	re.Empty(ops)
+	op, err := client.GetOperatorByRegionID(ctx, 10)
+	re.NoError(err)
+	re.Contains(op, "admin-add-peer")
+	re.NoError(client.DeleteOperatorByRegionID(ctx, 10))
+	ops, err = client.GetOperators(ctx)
+	re.NoError(err)
+	re.Empty(ops)
+}
+
+func (suite *httpClientTestSuite) TestCheckers() {
+	re := suite.Require()
+	client := suite.client
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	status, err := client.GetCheckerStatus(ctx, "merge")
+	re.NoError(err)
+	re.False(status.Paused)
+	re.NoError(client.PauseChecker(ctx, "merge", 100))
+	status, err = client.GetCheckerStatus(ctx, "merge")
+	re.NoError(err)
+	re.True(status.Paused)
+	re.NoError(client.ResumeChecker(ctx, "merge"))
+	status, err = client.GetCheckerStatus(ctx, "merge")
+	re.NoError(err)
+	re.False(status.Paused)
+	_, err = client.GetCheckerStatus(ctx, "unknown")
+	re.Error(err)
+}
+
+func (suite *httpClientTestSuite) TestStoreLimit() {
+	re := suite.Require()
+	client := suite.client
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	re.NoError(client.SetAllStoresLimit(ctx, &pd.StoreLimitInput{Rate: 20}))
+	limits, err := client.GetStoresLimit(ctx)
+	re.NoError(err)
+	re.NotEmpty(limits)
+	for _, limit := range limits {
+		re.Equal(20.0, limit.AddPeer)
+		re.Equal(20.0, limit.RemovePeer)
+	}
+	re.NoError(client.SetStoreLimit(ctx, 2, &pd.StoreLimitInput{Rate: 30, Type: pd.StoreLimitAddPeer}))
+	limits, err = client.GetStoresLimit(ctx)
+	re.NoError(err)
+	re.Equal(30.0, limits[2].AddPeer)
+	re.Equal(20.0, limits[2].RemovePeer)
+	re.Error(client.SetStoreLimit(ctx, 2, &pd.StoreLimitInput{Rate: -1}))
+}
+
+func (suite *httpClientTestSuite) TestReplicationModeAndUnsafeRecovery() {
+	re := suite.Require()
+	client := suite.client
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	status, err := client.GetReplicationModeStatus(ctx)
+	re.NoError(err)
+	re.Equal("majority", status.Mode)
+	outputs, err := client.GetFailedStoresRemovalStatus(ctx)
+	re.NoError(err)
+	re.Len(outputs, 1)
+	re.Equal("No on-going recovery.", outputs[0].Info)
+	// The store ID is required if the failed stores are not detected automatically.
+	re.Error(client.RemoveFailedStores(ctx, &pd.RemoveFailedStoresInput{}))
+}
@@ -33,12 +33,15 @@ import (
 	"github.com/tikv/pd/pkg/utils/tempurl"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
 )
 
 type tsoConsistencyTestSuite struct {
 	suite.Suite
 	legacy bool
+	// mode is the TSO mode, the window mode is used if it's empty.
+	mode string
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -68,13 +71,31 @@ func TestMicroserviceTSOConsistencySuite(t *testing.T) {
 	})
 }
 
+func TestLegacyTSOConsistencySuiteWithHLC(t *testing.T) {
+	suite.Run(t, &tsoConsistencyTestSuite{
+		legacy: true,
+		mode:   tsoutil.HLCMode,
+	})
+}
+
+func TestMicroserviceTSOConsistencySuiteWithHLC(t *testing.T) {
+	suite.Run(t, &tsoConsistencyTestSuite{
+		legacy: false,
+		mode:   tsoutil.HLCMode,
+	})
+}
+
 func (suite *tsoConsistencyTestSuite) SetupSuite() {
 	re := suite.Require()
 
 	var err error
 	suite.ctx, suite.cancel = context.WithCancel(context.Background())
 	if suite.legacy {
-		suite.cluster, err = tests.NewTestCluster(suite.ctx, serverCount)
+		suite.cluster, err = tests.NewTestCluster(suite.ctx, serverCount, func(conf *config.Config, _ string) {
+			if len(suite.mode) > 0 {
+				conf.TSOMode = suite.mode
+			}
+		})
 	} else {
 		suite.cluster, err = tests.NewTestClusterWithKeyspaceGroup(suite.ctx, serverCount)
 	}
@@ -89,7 +110,11 @@ func (suite *tsoConsistencyTestSuite) SetupSuite() {
 	if suite.legacy {
 		suite.pdClient = tu.MustNewGrpcClient(re, backendEndpoints)
 	} else {
-		suite.tsoServer, suite.tsoServerCleanup = tests.StartSingleTSOTestServer(suite.ctx, re, backendEndpoints, tempurl.Alloc())
+		suite.tsoServer, suite.tsoServerCleanup = tests.StartSingleTSOTestServer(suite.ctx, re, backendEndpoints, tempurl.Alloc(), func(cfg *tso.Config) {
+			if len(suite.mode) > 0 {
+				cfg.TSOMode = suite.mode
+			}
+		})
 		suite.tsoClientConn, suite.tsoClient = tso.MustNewGrpcClient(re, suite.tsoServer.GetAddr())
 	}
 }
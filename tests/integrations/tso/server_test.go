@@ -33,12 +33,16 @@ import (
 	"github.com/tikv/pd/pkg/utils/keypath"
 	"github.com/tikv/pd/pkg/utils/tempurl"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
 )
 
 type tsoServerTestSuite struct {
 	suite.Suite
 	legacy bool
+	// mode is the TSO mode, the window mode is used if it's empty.
+	mode string
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -68,13 +72,31 @@ func TestMicroserviceTSOServer(t *testing.T) {
 	})
 }
 
+func TestLegacyTSOServerWithHLC(t *testing.T) {
+	suite.Run(t, &tsoServerTestSuite{
+		legacy: true,
+		mode:   tsoutil.HLCMode,
+	})
+}
+
+func TestMicroserviceTSOServerWithHLC(t *testing.T) {
+	suite.Run(t, &tsoServerTestSuite{
+		legacy: false,
+		mode:   tsoutil.HLCMode,
+	})
+}
+
 func (suite *tsoServerTestSuite) SetupSuite() {
 	re := suite.Require()
 
 	var err error
 	suite.ctx, suite.cancel = context.WithCancel(context.Background())
 	if suite.legacy {
-		suite.cluster, err = tests.NewTestCluster(suite.ctx, serverCount)
+		suite.cluster, err = tests.NewTestCluster(suite.ctx, serverCount, func(conf *config.Config, _ string) {
+			if len(suite.mode) > 0 {
+				conf.TSOMode = suite.mode
+			}
+		})
 	} else {
 		suite.cluster, err = tests.NewTestClusterWithKeyspaceGroup(suite.ctx, serverCount)
 	}
@@ -89,7 +111,11 @@ func (suite *tsoServerTestSuite) SetupSuite() {
 	if suite.legacy {
 		suite.pdClient = tu.MustNewGrpcClient(re, backendEndpoints)
 	} else {
-		suite.tsoServer, suite.tsoServerCleanup = tests.StartSingleTSOTestServer(suite.ctx, re, backendEndpoints, tempurl.Alloc())
+		suite.tsoServer, suite.tsoServerCleanup = tests.StartSingleTSOTestServer(suite.ctx, re, backendEndpoints, tempurl.Alloc(), func(cfg *tso.Config) {
+			if len(suite.mode) > 0 {
+				cfg.TSOMode = suite.mode
+			}
+		})
 		suite.tsoClientConn, suite.tsoClient = tso.MustNewGrpcClient(re, suite.tsoServer.GetAddr())
 	}
 	// Ensure the TSO is ready to serve before running the tests.
@@ -0,0 +1,129 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/pingcap/failpoint"
+
+	"github.com/tikv/pd/pkg/encryption"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server/config"
+	"github.com/tikv/pd/tests"
+)
+
+type encryptionTestSuite struct {
+	suite.Suite
+	env *tests.SchedulingTestEnvironment
+	dir string
+}
+
+func TestEncryptionTestSuite(t *testing.T) {
+	suite.Run(t, new(encryptionTestSuite))
+}
+
+func (suite *encryptionTestSuite) SetupSuite() {
+	suite.dir = suite.T().TempDir()
+	suite.env = tests.NewSchedulingTestEnvironment(suite.T(), func(conf *config.Config, _ string) {
+		conf.Security.Encryption.DataEncryptionMethod = "aes128-ctr"
+		conf.Security.Encryption.MasterKey = encryption.MasterKeyConfig{
+			Type: "kms",
+			MasterKeyKMSConfig: encryption.MasterKeyKMSConfig{
+				KmsVendor: "local",
+				KmsKeyID:  filepath.Join(suite.dir, "kms.json"),
+			},
+		}
+	})
+	suite.env.PDCount = 3
+}
+
+func (suite *encryptionTestSuite) TearDownSuite() {
+	suite.env.Cleanup()
+}
+
+func (suite *encryptionTestSuite) TestMasterKeyRotation() {
+	suite.env.RunTestInNonMicroserviceEnv(suite.checkMasterKeyRotation)
+}
+
+func (suite *encryptionTestSuite) checkMasterKeyRotation(cluster *tests.TestCluster) {
+	re := suite.Require()
+	urlPrefix := cluster.GetLeaderServer().GetAddr() + "/pd/api/v1/encryption/master-key"
+
+	status := waitMasterKeyLoaded(re, urlPrefix)
+	re.Len(status.Members, 3)
+	re.Nil(status.Rotation)
+	version := status.Version
+
+	// The file master key can't be rotated online.
+	data, err := json.Marshal(&encryption.MasterKeyConfig{
+		Type: "file",
+		MasterKeyFileConfig: encryption.MasterKeyFileConfig{
+			FilePath: filepath.Join(suite.dir, "master.key"),
+		},
+	})
+	re.NoError(err)
+	re.NoError(tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rotate", data,
+		tu.StatusNotOK(re), tu.StringContain(re, "file master key")))
+
+	// The local KMS master key can't be rotated online either, unless it's allowed for the test.
+	data, err = json.Marshal(&encryption.MasterKeyConfig{
+		Type: "kms",
+		MasterKeyKMSConfig: encryption.MasterKeyKMSConfig{
+			KmsVendor: "local",
+			KmsKeyID:  filepath.Join(suite.dir, "kms2.json"),
+		},
+	})
+	re.NoError(err)
+	re.NoError(tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rotate", data,
+		tu.StatusNotOK(re), tu.StringContain(re, "local KMS master key")))
+	re.NoError(failpoint.Enable("github.com/tikv/pd/server/api/allowLocalKMSMasterKeyRotation", "return(true)"))
+	defer func() {
+		re.NoError(failpoint.Disable("github.com/tikv/pd/server/api/allowLocalKMSMasterKeyRotation"))
+	}()
+
+	// Rotate to a new KMS key.
+	re.NoError(tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rotate", data, tu.StatusOK(re)))
+	status = waitMasterKeyLoaded(re, urlPrefix)
+	re.NotNil(status.Rotation)
+	re.Equal(encryption.MasterKeyRotated, status.Rotation.State)
+	re.Equal(status.Version, status.Rotation.Version)
+	re.Equal(version, status.Rotation.PreviousVersion)
+	re.NotEqual(version, status.Version)
+	// The same master key can't be rotated to again.
+	re.NoError(tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rotate", data, tu.StatusNotOK(re)))
+
+	// Roll back to the master key before the rotation.
+	re.NoError(tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rollback", nil, tu.StatusOK(re)))
+	status = waitMasterKeyLoaded(re, urlPrefix)
+	re.Equal(encryption.MasterKeyRolledBack, status.Rotation.State)
+	re.Equal(version, status.Version)
+	re.Equal(uint64(2), status.Rotation.ID)
+}
+
+// waitMasterKeyLoaded waits until all the members have loaded the master key in use.
+func waitMasterKeyLoaded(re *require.Assertions, urlPrefix string) *encryption.MasterKeyRotationStatus {
+	status := &encryption.MasterKeyRotationStatus{}
+	tu.Eventually(re, func() bool {
+		re.NoError(tu.ReadGetJSON(re, tests.TestDialClient, urlPrefix, status))
+		return len(status.Version) > 0 && status.Completed
+	})
+	return status
+}
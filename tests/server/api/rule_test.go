@@ -32,6 +32,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/handler"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
@@ -992,6 +993,93 @@ func (suite *ruleTestSuite) checkLeaderAndVoter(cluster *tests.TestCluster) {
 	}
 }
 
+func (suite *ruleTestSuite) TestSimulate() {
+	// The simulation is not forwarded to the scheduling service.
+	suite.env.RunTestInNonMicroserviceEnv(suite.checkSimulate)
+}
+
+func (suite *ruleTestSuite) checkSimulate(cluster *tests.TestCluster) {
+	re := suite.Require()
+	leaderServer := cluster.GetLeaderServer()
+	pdAddr := leaderServer.GetAddr()
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/config", pdAddr)
+
+	for id := uint64(1); id <= 3; id++ {
+		tests.MustPutStore(re, cluster, &metapb.Store{
+			Id:        id,
+			State:     metapb.StoreState_Up,
+			NodeState: metapb.NodeState_Serving,
+		})
+	}
+	peers := []*metapb.Peer{{Id: 11, StoreId: 1}, {Id: 12, StoreId: 2}, {Id: 13, StoreId: 3}}
+	region := core.NewRegionInfo(&metapb.Region{
+		Id:          10,
+		StartKey:    []byte{0x11},
+		EndKey:      []byte{0x22},
+		Peers:       peers,
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+	}, peers[0])
+	tests.MustPutRegionInfo(re, cluster, region)
+
+	// The region has one more peer than the candidate rule requires.
+	input := map[string]any{
+		"bundles": []placement.GroupBundle{{
+			ID: "pd",
+			Rules: []*placement.Rule{
+				{GroupID: "pd", ID: "default", Role: placement.Voter, Count: 2},
+			},
+		}},
+		"start-key": "11",
+		"end-key":   "22",
+	}
+	data, err := json.Marshal(input)
+	re.NoError(err)
+	var results []*handler.RegionPlacementSimulation
+	err = tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rules/simulate", data,
+		tu.StatusOK(re), tu.ExtractJSON(re, &results))
+	re.NoError(err)
+	re.Len(results, 1)
+	result := results[0]
+	re.Equal(uint64(10), result.RegionID)
+	re.True(result.CurrentFit.IsSatisfied())
+	re.Len(result.CandidateFit.RuleFits, 1)
+	re.Len(result.CandidateFit.RuleFits[0].Peers, 2)
+	re.Len(result.CandidateFit.OrphanPeers, 1)
+	orphanStore := result.CandidateFit.OrphanPeers[0].GetStoreId()
+	re.Equal("remove-orphan-peer", result.OperatorDesc)
+	re.Equal([]string{fmt.Sprintf("remove peer on store %d", orphanStore)}, result.OperatorSteps)
+
+	// The candidate rules are not saved.
+	var rule placement.Rule
+	err = tu.ReadGetJSON(re, tests.TestDialClient, urlPrefix+"/rule/pd/default", &rule)
+	re.NoError(err)
+	re.Equal(3, rule.Count)
+
+	// No operator is needed if the candidate rules are satisfied.
+	input["bundles"] = []placement.GroupBundle{{
+		ID: "pd",
+		Rules: []*placement.Rule{
+			{GroupID: "pd", ID: "default", Role: placement.Voter, Count: 3},
+		},
+	}}
+	data, err = json.Marshal(input)
+	re.NoError(err)
+	err = tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rules/simulate", data,
+		tu.StatusOK(re), tu.ExtractJSON(re, &results))
+	re.NoError(err)
+	re.Len(results, 1)
+	re.True(results[0].CandidateFit.IsSatisfied())
+	re.Empty(results[0].OperatorDesc)
+	re.Empty(results[0].OperatorSteps)
+
+	// The key range should be hex encoded.
+	input["start-key"] = "xyz"
+	data, err = json.Marshal(input)
+	re.NoError(err)
+	err = tu.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rules/simulate", data, tu.StatusNotOK(re))
+	re.NoError(err)
+}
+
 func (suite *ruleTestSuite) TestDeleteAndUpdate() {
 	suite.env.RunTest(suite.checkDeleteAndUpdate)
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/metapb"
 
 	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/schedulers"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/utils/apiutil"
@@ -262,6 +263,8 @@ func (suite *scheduleTestSuite) checkAPI(cluster *tests.TestCluster) {
 					"src-tolerance-ratio":        1.05,
 					"dst-tolerance-ratio":        1.05,
 					"split-thresholds":           0.2,
+					"enable-forecast":            "false",
+					"forecast-confidence":        0.8,
 					"rank-formula-version":       "v2",
 					"read-priorities":            []any{"byte", "key"},
 					"write-leader-priorities":    []any{"key", "byte"},
@@ -807,6 +810,42 @@ func (suite *scheduleTestSuite) checkEmptySchedulers(cluster *tests.TestCluster)
 	}
 }
 
+func (suite *scheduleTestSuite) TestDryRunAPI() {
+	suite.te.RunTest(suite.checkDryRunAPI)
+}
+
+func (suite *scheduleTestSuite) checkDryRunAPI(cluster *tests.TestCluster) {
+	re := suite.Require()
+	leaderAddr := cluster.GetLeaderServer().GetAddr()
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/schedulers", leaderAddr)
+	for i := 1; i <= 4; i++ {
+		store := &metapb.Store{
+			Id:            uint64(i),
+			State:         metapb.StoreState_Up,
+			NodeState:     metapb.NodeState_Serving,
+			LastHeartbeat: time.Now().UnixNano(),
+		}
+		tests.MustPutStore(re, cluster, store)
+	}
+	tests.MustPutRegion(re, cluster, 1, 1, []byte("a"), []byte("b"))
+	suite.assertSchedulerExists(urlPrefix, types.BalanceLeaderScheduler.String())
+
+	dryRunURL := fmt.Sprintf("%s/dry-run/%s", urlPrefix, types.BalanceLeaderScheduler.String())
+	re.NoError(tu.CheckGetUntilStatusCode(re, tests.TestDialClient, dryRunURL, http.StatusOK))
+	var result schedulers.DryRunResult
+	re.NoError(tu.ReadGetJSON(re, tests.TestDialClient, dryRunURL, &result, tu.StatusOK(re)))
+	re.Equal(types.BalanceLeaderScheduler.String(), result.Name)
+	re.NotZero(result.Timestamp)
+	re.NotNil(result.Operators)
+	// The dry run never adds operators.
+	var ops []any
+	re.NoError(tu.ReadGetJSON(re, tests.TestDialClient, fmt.Sprintf("%s/pd/api/v1/operators", leaderAddr), &ops, tu.StatusOK(re)))
+	re.Empty(ops)
+
+	re.NoError(tu.CheckGetJSON(tests.TestDialClient, fmt.Sprintf("%s/dry-run/not-exist-scheduler", urlPrefix), nil,
+		tu.Status(re, http.StatusNotFound)))
+}
+
 func (suite *scheduleTestSuite) assertSchedulerExists(urlPrefix string, scheduler string) {
 	var schedulers []string
 	re := suite.Require()
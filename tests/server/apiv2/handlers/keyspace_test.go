@@ -16,7 +16,9 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,7 +28,9 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 
+	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server/apiv2/handlers"
 	"github.com/tikv/pd/tests"
@@ -157,6 +161,54 @@ func (suite *keyspaceTestSuite) TestLoadRangeKeyspace() {
 	re.Equal(keyspacepb.KeyspaceState_ENABLED, loadResponse.Keyspaces[0].State)
 }
 
+func (suite *keyspaceTestSuite) TestKeyspaceQuota() {
+	re := suite.Require()
+	created := mustMakeTestKeyspaces(re, suite.server, 1)[0]
+	// No quota is set.
+	code, status := sendGetKeyspaceQuotaRequest(re, suite.server, created.Name)
+	re.Equal(http.StatusOK, code)
+	re.True(status.Quota.IsEmpty())
+	re.Equal(created.Id, status.Quota.KeyspaceID)
+	code, _ = sendGetKeyspaceQuotaRequest(re, suite.server, "not_exist")
+	re.NotEqual(http.StatusOK, code)
+
+	// The soft limit should not be larger than the hard limit.
+	code, _ = sendSetKeyspaceQuotaRequest(re, suite.server, created.Name, &handlers.KeyspaceQuotaParams{
+		RegionCount: endpoint.KeyspaceQuotaLimit{Soft: 3, Hard: 2},
+	})
+	re.Equal(http.StatusBadRequest, code)
+
+	request := &handlers.KeyspaceQuotaParams{
+		RegionCount:        endpoint.KeyspaceQuotaLimit{Soft: 10, Hard: 20},
+		ResourceGroupCount: endpoint.KeyspaceQuotaLimit{Hard: 1},
+	}
+	code, status = sendSetKeyspaceQuotaRequest(re, suite.server, created.Name, request)
+	re.Equal(http.StatusOK, code)
+	re.Equal(request.RegionCount, status.Quota.RegionCount)
+	re.Equal(request.ResourceGroupCount, status.Quota.ResourceGroupCount)
+	re.Empty(status.HardLimitReached)
+	code, status = sendGetKeyspaceQuotaRequest(re, suite.server, created.Name)
+	re.Equal(http.StatusOK, code)
+	re.Equal(request.RegionCount, status.Quota.RegionCount)
+
+	// Adding resource groups beyond the hard limit is rejected.
+	resourceGroups := "rg1,rg2"
+	data, err := json.Marshal(&handlers.UpdateConfigParams{
+		Config: map[string]*string{keyspace.ResourceGroupsKey: &resourceGroups},
+	})
+	re.NoError(err)
+	re.NoError(testutil.CheckPatchJSON(tests.TestDialClient,
+		suite.server.GetAddr()+keyspacesPrefix+"/"+created.Name+"/config", data, testutil.StatusNotOK(re)))
+
+	// Remove the quota.
+	code, status = sendSetKeyspaceQuotaRequest(re, suite.server, created.Name, &handlers.KeyspaceQuotaParams{})
+	re.Equal(http.StatusOK, code)
+	re.True(status.Quota.IsEmpty())
+	mustUpdateKeyspaceConfig(re, suite.server, created.Name, &handlers.UpdateConfigParams{
+		Config: map[string]*string{keyspace.ResourceGroupsKey: &resourceGroups},
+	})
+}
+
 func mustMakeTestKeyspaces(re *require.Assertions, server *tests.TestServer, count int) []*keyspacepb.KeyspaceMeta {
 	testConfig := map[string]string{
 		"config1": "100",
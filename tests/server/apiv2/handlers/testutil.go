@@ -25,6 +25,7 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 
+	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server/apiv2/handlers"
@@ -154,6 +155,38 @@ func mustLoadKeyspaces(re *require.Assertions, server *tests.TestServer, name st
 	return meta.KeyspaceMeta
 }
 
+func sendSetKeyspaceQuotaRequest(re *require.Assertions, server *tests.TestServer, name string, request *handlers.KeyspaceQuotaParams) (int, *keyspace.QuotaStatus) {
+	data, err := json.Marshal(request)
+	re.NoError(err)
+	httpReq, err := http.NewRequest(http.MethodPut, server.GetAddr()+keyspacesPrefix+"/"+name+"/quota", bytes.NewBuffer(data))
+	re.NoError(err)
+	resp, err := tests.TestDialClient.Do(httpReq)
+	re.NoError(err)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	data, err = io.ReadAll(resp.Body)
+	re.NoError(err)
+	status := &keyspace.QuotaStatus{}
+	re.NoError(json.Unmarshal(data, status))
+	return resp.StatusCode, status
+}
+
+func sendGetKeyspaceQuotaRequest(re *require.Assertions, server *tests.TestServer, name string) (int, *keyspace.QuotaStatus) {
+	resp, err := tests.TestDialClient.Get(server.GetAddr() + keyspacesPrefix + "/" + name + "/quota")
+	re.NoError(err)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	re.NoError(err)
+	status := &keyspace.QuotaStatus{}
+	re.NoError(json.Unmarshal(data, status))
+	return resp.StatusCode, status
+}
+
 // MustLoadKeyspaceGroups loads all keyspace groups from the server.
 func MustLoadKeyspaceGroups(re *require.Assertions, server *tests.TestServer, token, limit string) []*endpoint.KeyspaceGroup {
 	// Construct load range request.
@@ -27,7 +27,10 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
 )
 
@@ -132,6 +135,73 @@ func TestAskSplit(t *testing.T) {
 	re.NoError(err)
 }
 
+func TestAskBatchSplitWithKeyspaceQuota(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1, func(conf *config.Config, _ string) {
+		conf.Keyspace.WaitRegionSplit = false
+	})
+	defer cluster.Destroy()
+	re.NoError(err)
+
+	err = cluster.RunInitialServers()
+	re.NoError(err)
+
+	re.NotEmpty(cluster.WaitLeader())
+	leaderServer := cluster.GetLeaderServer()
+	grpcPDClient := testutil.MustNewGrpcClient(re, leaderServer.GetAddr())
+	clusterID := leaderServer.GetClusterID()
+	bootstrapCluster(re, clusterID, grpcPDClient)
+	rc := leaderServer.GetRaftCluster()
+
+	manager := leaderServer.GetKeyspaceManager()
+	meta, err := manager.CreateKeyspace(&keyspace.CreateKeyspaceRequest{
+		Name:       "ks1",
+		CreateTime: time.Now().Unix(),
+	})
+	re.NoError(err)
+	// Put a region inside the txn key range of the keyspace.
+	bound := keyspace.MakeRegionBound(meta.GetId())
+	regionID, _, err := rc.AllocID(1)
+	re.NoError(err)
+	peerID, _, err := rc.AllocID(1)
+	re.NoError(err)
+	peer := &metapb.Peer{Id: peerID, StoreId: 1}
+	region := &metapb.Region{
+		Id:          regionID,
+		StartKey:    bound.TxnLeftBound,
+		EndKey:      bound.TxnRightBound,
+		Peers:       []*metapb.Peer{peer},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 10, Version: 10},
+	}
+	re.NoError(rc.HandleRegionHeartbeat(core.NewRegionInfo(region, peer)))
+
+	req := &pdpb.AskBatchSplitRequest{
+		Header: &pdpb.RequestHeader{
+			ClusterId: clusterID,
+		},
+		Region:     region,
+		SplitCount: 2,
+	}
+	_, err = rc.HandleAskBatchSplit(req)
+	re.NoError(err)
+
+	// The keyspace already has one region, so the hard limit is reached.
+	_, err = manager.SetKeyspaceQuota(meta.GetName(), &endpoint.KeyspaceQuota{
+		RegionCount: endpoint.KeyspaceQuotaLimit{Hard: 1},
+	})
+	re.NoError(err)
+	_, err = rc.HandleAskBatchSplit(req)
+	re.Error(err)
+
+	// Remove the quota.
+	_, err = manager.SetKeyspaceQuota(meta.GetName(), &endpoint.KeyspaceQuota{})
+	re.NoError(err)
+	_, err = rc.HandleAskBatchSplit(req)
+	re.NoError(err)
+}
+
 func TestPendingProcessedRegions(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -28,6 +28,7 @@ import (
 	"github.com/tikv/pd/pkg/tso"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
@@ -38,10 +39,20 @@ func TestMain(m *testing.M) {
 }
 
 func TestRequestFollower(t *testing.T) {
+	for _, mode := range []string{tsoutil.WindowMode, tsoutil.HLCMode} {
+		t.Run(mode, func(t *testing.T) {
+			testRequestFollower(t, mode)
+		})
+	}
+}
+
+func testRequestFollower(t *testing.T, mode string) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	cluster, err := tests.NewTestCluster(ctx, 2)
+	cluster, err := tests.NewTestCluster(ctx, 2, func(conf *config.Config, _ string) {
+		conf.TSOMode = mode
+	})
 	re.NoError(err)
 	defer cluster.Destroy()
 
@@ -82,10 +93,20 @@ func TestRequestFollower(t *testing.T) {
 // In some cases, when a TSO request arrives, the SyncTimestamp may not finish yet.
 // This test is used to simulate this situation and verify that the retry mechanism.
 func TestDelaySyncTimestamp(t *testing.T) {
+	for _, mode := range []string{tsoutil.WindowMode, tsoutil.HLCMode} {
+		t.Run(mode, func(t *testing.T) {
+			testDelaySyncTimestamp(t, mode)
+		})
+	}
+}
+
+func testDelaySyncTimestamp(t *testing.T, mode string) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	cluster, err := tests.NewTestCluster(ctx, 2)
+	cluster, err := tests.NewTestCluster(ctx, 2, func(conf *config.Config, _ string) {
+		conf.TSOMode = mode
+	})
 	re.NoError(err)
 	defer cluster.Destroy()
 	re.NoError(cluster.RunInitialServers())
@@ -136,6 +157,14 @@ func checkAndReturnTimestampResponse(re *require.Assertions, req *pdpb.TsoReques
 }
 
 func TestLogicalOverflow(t *testing.T) {
+	for _, mode := range []string{tsoutil.WindowMode, tsoutil.HLCMode} {
+		t.Run(mode, func(t *testing.T) {
+			testLogicalOverflow(t, mode)
+		})
+	}
+}
+
+func testLogicalOverflow(t *testing.T, mode string) {
 	re := require.New(t)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -144,6 +173,7 @@ func TestLogicalOverflow(t *testing.T) {
 	updateInterval := config.MaxTSOUpdatePhysicalInterval
 	cluster, err := tests.NewTestCluster(ctx, 1, func(conf *config.Config, _ string) {
 		conf.TSOUpdatePhysicalInterval = typeutil.Duration{Duration: updateInterval}
+		conf.TSOMode = mode
 	})
 	defer cluster.Destroy()
 	re.NoError(err)
@@ -121,13 +121,16 @@ func InitLogger(logConfig log.Config, logger *zap.Logger, logProps *log.ZapPrope
 }
 
 // StartSingleTSOTestServerWithoutCheck creates and starts a tso server with default config for testing.
-func StartSingleTSOTestServerWithoutCheck(ctx context.Context, re *require.Assertions, backendEndpoints, listenAddrs string) (*tso.Server, func(), error) {
+func StartSingleTSOTestServerWithoutCheck(ctx context.Context, re *require.Assertions, backendEndpoints, listenAddrs string, opts ...func(*tso.Config)) (*tso.Server, func(), error) {
 	cfg := tso.NewConfig()
 	cfg.BackendEndpoints = backendEndpoints
 	cfg.ListenAddr = listenAddrs
 	cfg.Name = cfg.ListenAddr
 	cfg, err := tso.GenerateConfig(cfg)
 	re.NoError(err)
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	// Setup the logger.
 	err = InitLogger(cfg.Log, cfg.Logger, cfg.LogProps, cfg.Security.RedactInfoLog)
 	re.NoError(err)
@@ -135,8 +138,8 @@ func StartSingleTSOTestServerWithoutCheck(ctx context.Context, re *require.Asser
 }
 
 // StartSingleTSOTestServer creates and starts a tso server with default config for testing.
-func StartSingleTSOTestServer(ctx context.Context, re *require.Assertions, backendEndpoints, listenAddrs string) (*tso.Server, func()) {
-	s, cleanup, err := StartSingleTSOTestServerWithoutCheck(ctx, re, backendEndpoints, listenAddrs)
+func StartSingleTSOTestServer(ctx context.Context, re *require.Assertions, backendEndpoints, listenAddrs string, opts ...func(*tso.Config)) (*tso.Server, func()) {
+	s, cleanup, err := StartSingleTSOTestServerWithoutCheck(ctx, re, backendEndpoints, listenAddrs, opts...)
 	re.NoError(err)
 	testutil.Eventually(re, func() bool {
 		return !s.IsClosed()
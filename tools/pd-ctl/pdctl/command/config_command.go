@@ -48,6 +48,7 @@ const (
 	replicationModePrefix         = "pd/api/v1/config/replication-mode"
 	ruleBundlePrefix              = "pd/api/v1/config/placement-rule"
 	pdServerPrefix                = "pd/api/v1/config/pd-server"
+	maintenanceWindowPrefix       = "pd/api/v1/config/maintenance-window"
 	serviceMiddlewareConfigPrefix = "pd/api/v1/service-middleware/config"
 	// flagFromPD is useful for us to debug.
 	flagFromPD = "from_pd"
@@ -81,6 +82,7 @@ func NewShowConfigCommand() *cobra.Command {
 	sc.AddCommand(newShowReplicationModeCommand())
 	sc.AddCommand(NewShowServerConfigCommand())
 	sc.AddCommand(NewShowServiceMiddlewareConfigCommand())
+	sc.AddCommand(newShowMaintenanceWindowCommand())
 	sc.Flags().Bool(flagFromPD, false, "read data from PD rather than microservice")
 	return sc
 }
@@ -176,6 +178,7 @@ func NewSetConfigCommand() *cobra.Command {
 	sc.AddCommand(NewSetClusterVersionCommand())
 	sc.AddCommand(newSetReplicationModeCommand())
 	sc.AddCommand(newSetServiceMiddlewareCommand())
+	sc.AddCommand(newSetMaintenanceWindowCommand())
 	return sc
 }
 
@@ -218,10 +221,11 @@ func newSetServiceMiddlewareCommand() *cobra.Command {
 // NewDeleteConfigCommand a set subcommand of cfgCmd
 func NewDeleteConfigCommand() *cobra.Command {
 	sc := &cobra.Command{
-		Use:   "delete label-property",
+		Use:   "delete label-property|maintenance-window",
 		Short: "delete the config option",
 	}
 	sc.AddCommand(NewDeleteLabelPropertyConfigCommand())
+	sc.AddCommand(newDeleteMaintenanceWindowCommand())
 	return sc
 }
 
@@ -235,6 +239,32 @@ func NewDeleteLabelPropertyConfigCommand() *cobra.Command {
 	return sc
 }
 
+func newShowMaintenanceWindowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "maintenance-window",
+		Short: "show the maintenance windows and the active one",
+		Run:   showMaintenanceWindowCommandFunc,
+	}
+}
+
+func newSetMaintenanceWindowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "maintenance-window",
+		Short: "add or update a maintenance window from file",
+		Run:   setMaintenanceWindowCommandFunc,
+	}
+	c.Flags().String("in", "window.json", "the file contains one maintenance window")
+	return c
+}
+
+func newDeleteMaintenanceWindowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "maintenance-window <name>",
+		Short: "delete a maintenance window, its changes are reverted if it is active",
+		Run:   deleteMaintenanceWindowCommandFunc,
+	}
+}
+
 func showConfigCommandFunc(cmd *cobra.Command, _ []string) {
 	header := buildHeader(cmd)
 	allR, err := doRequest(cmd, configPrefix, http.MethodGet, header)
@@ -347,6 +377,43 @@ func showReplicationModeCommandFunc(cmd *cobra.Command, _ []string) {
 	cmd.Println(r)
 }
 
+func showMaintenanceWindowCommandFunc(cmd *cobra.Command, _ []string) {
+	r, err := doRequest(cmd, maintenanceWindowPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get maintenance windows: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setMaintenanceWindowCommandFunc(cmd *cobra.Command, _ []string) {
+	file := cmd.Flag("in").Value.String()
+	content, err := os.ReadFile(file)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	input := make(map[string]any)
+	if err := json.Unmarshal(content, &input); err != nil {
+		cmd.Println(err)
+		return
+	}
+	postJSON(cmd, maintenanceWindowPrefix, input)
+}
+
+func deleteMaintenanceWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, path.Join(maintenanceWindowPrefix, url.PathEscape(args[0])), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to delete maintenance window: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
 func showServerCommandFunc(cmd *cobra.Command, _ []string) {
 	r, err := doRequest(cmd, pdServerPrefix, http.MethodGet, http.Header{})
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/server/apiv2/handlers"
 )
 
@@ -35,6 +36,13 @@ const (
 	nmRemove              = "remove"
 	nmUpdate              = "update"
 	nmForceRefreshGroupID = "force_refresh_group_id"
+
+	nmRegionCountSoft        = "region-count-soft"
+	nmRegionCountHard        = "region-count-hard"
+	nmStorageSizeSoft        = "storage-size-soft"
+	nmStorageSizeHard        = "storage-size-hard"
+	nmResourceGroupCountSoft = "resource-group-count-soft"
+	nmResourceGroupCountHard = "resource-group-count-hard"
 )
 
 // NewKeyspaceCommand returns a keyspace subcommand of rootCmd.
@@ -48,6 +56,7 @@ func NewKeyspaceCommand() *cobra.Command {
 	cmd.AddCommand(newUpdateKeyspaceConfigCommand())
 	cmd.AddCommand(newUpdateKeyspaceStateCommand())
 	cmd.AddCommand(newListKeyspaceCommand())
+	cmd.AddCommand(newKeyspaceQuotaCommand())
 	return cmd
 }
 
@@ -308,3 +317,80 @@ func listKeyspaceCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println(resp)
 }
+
+func newKeyspaceQuotaCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "quota <command> [flags]",
+		Short: "keyspace quota commands",
+	}
+	show := &cobra.Command{
+		Use:   "show <keyspace-name>",
+		Short: "show the quota and the resource usage of the keyspace",
+		Run:   showKeyspaceQuotaCommandFunc,
+	}
+	set := &cobra.Command{
+		Use:   "set <keyspace-name> [flags]",
+		Short: "set the quota of the keyspace, the limits not specified are removed",
+		Run:   setKeyspaceQuotaCommandFunc,
+	}
+	set.Flags().Uint64(nmRegionCountSoft, 0, "the soft limit of the region count, 0 means no limit")
+	set.Flags().Uint64(nmRegionCountHard, 0, "the hard limit of the region count, 0 means no limit")
+	set.Flags().Uint64(nmStorageSizeSoft, 0, "the soft limit of the storage size in MiB, 0 means no limit")
+	set.Flags().Uint64(nmStorageSizeHard, 0, "the hard limit of the storage size in MiB, 0 means no limit")
+	set.Flags().Uint64(nmResourceGroupCountSoft, 0, "the soft limit of the resource group count, 0 means no limit")
+	set.Flags().Uint64(nmResourceGroupCountHard, 0, "the hard limit of the resource group count, 0 means no limit")
+	r.AddCommand(show)
+	r.AddCommand(set)
+	return r
+}
+
+func showKeyspaceQuotaCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	url := fmt.Sprintf("%s/%s/quota", keyspacePrefix, args[0])
+	resp, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.PrintErrln("Failed to get the keyspace quota: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
+
+func setKeyspaceQuotaCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	limits := make(map[string]uint64)
+	for _, name := range []string{
+		nmRegionCountSoft, nmRegionCountHard,
+		nmStorageSizeSoft, nmStorageSizeHard,
+		nmResourceGroupCountSoft, nmResourceGroupCountHard,
+	} {
+		limit, err := cmd.Flags().GetUint64(name)
+		if err != nil {
+			cmd.PrintErrln("Failed to parse flag: ", err)
+			return
+		}
+		limits[name] = limit
+	}
+	params := handlers.KeyspaceQuotaParams{
+		RegionCount:        endpoint.KeyspaceQuotaLimit{Soft: limits[nmRegionCountSoft], Hard: limits[nmRegionCountHard]},
+		StorageSize:        endpoint.KeyspaceQuotaLimit{Soft: limits[nmStorageSizeSoft], Hard: limits[nmStorageSizeHard]},
+		ResourceGroupCount: endpoint.KeyspaceQuotaLimit{Soft: limits[nmResourceGroupCountSoft], Hard: limits[nmResourceGroupCountHard]},
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		cmd.PrintErrln("Failed to encode the request body: ", err)
+		return
+	}
+	url := fmt.Sprintf("%s/%s/quota", keyspacePrefix, args[0])
+	resp, err := doRequest(cmd, url, http.MethodPut, http.Header{}, WithBody(bytes.NewBuffer(data)))
+	if err != nil {
+		cmd.PrintErrln("Failed to set the keyspace quota: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
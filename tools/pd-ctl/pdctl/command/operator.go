@@ -15,8 +15,12 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"path"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -25,8 +29,9 @@ import (
 )
 
 var (
-	operatorsPrefix = "pd/api/v1/operators"
-	peerRoles       = map[string]struct{}{
+	operatorsPrefix      = "pd/api/v1/operators"
+	operatorGroupsPrefix = "pd/api/v1/operators/groups"
+	peerRoles            = map[string]struct{}{
 		"leader":   {},
 		"voter":    {},
 		"follower": {},
@@ -58,6 +63,7 @@ func NewOperatorCommand() *cobra.Command {
 	c.AddCommand(NewAddOperatorCommand())
 	c.AddCommand(NewRemoveOperatorCommand())
 	c.AddCommand(NewHistoryOperatorCommand())
+	c.AddCommand(NewOperatorGroupCommand())
 	return c
 }
 
@@ -449,11 +455,15 @@ func removeOperatorCommandFunc(cmd *cobra.Command, args []string) {
 // NewHistoryOperatorCommand returns a command to history finished operators.
 func NewHistoryOperatorCommand() *cobra.Command {
 	c := &cobra.Command{
-		Use:     "history <start>",
-		Short:   "list all finished operators since start, start is a timestamp",
+		Use:     "history [<start>] [--start=<start>] [--end=<end>] [--region=<region_id>] [--store=<store_id>]",
+		Short:   "list all finished operators since start, start is a timestamp. The persisted history will be queried if any flag is set",
 		Run:     historyOperatorCommandFunc,
 		Example: HistoryExample,
 	}
+	c.Flags().Int64("start", 0, "the start timestamp of the persisted history")
+	c.Flags().Int64("end", 0, "the end timestamp of the persisted history, default is now")
+	c.Flags().Uint64("region", 0, "only show the persisted operators of the region")
+	c.Flags().Uint64("store", 0, "only show the persisted operators related to the store")
 	return c
 }
 
@@ -462,6 +472,15 @@ func historyOperatorCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) == 1 {
 		path += "?from=" + args[0]
 	}
+	query := url.Values{}
+	for flag, param := range map[string]string{"start": "start", "end": "end", "region": "region_id", "store": "store_id"} {
+		if cmd.Flags().Changed(flag) {
+			query.Set(param, cmd.Flag(flag).Value.String())
+		}
+	}
+	if len(query) > 0 {
+		path = operatorsPrefix + "/" + "history?" + query.Encode()
+	}
 	records, err := doRequest(cmd, path, http.MethodGet, http.Header{})
 	if err != nil {
 		cmd.Println(err)
@@ -470,6 +489,95 @@ func historyOperatorCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(records)
 }
 
+// NewOperatorGroupCommand returns a command to manage operator groups.
+func NewOperatorGroupCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "group",
+		Short: "operator group commands, the operators of a group are dispatched with dependencies",
+	}
+	show := &cobra.Command{
+		Use:   "show [<id>]",
+		Short: "show all operator groups or the specified one",
+		Run:   showOperatorGroupCommandFunc,
+	}
+	add := &cobra.Command{
+		Use:   "add [--in=<file>]",
+		Short: "add an operator group from the file",
+		Run:   addOperatorGroupCommandFunc,
+	}
+	add.Flags().String("in", "group.json", "the file contains the id, the concurrency and the operators of the group")
+	pause := &cobra.Command{
+		Use:   "pause <id>",
+		Short: "stop dispatching new operators of the group",
+		Run:   operatorGroupActionCommandFunc("pause"),
+	}
+	resume := &cobra.Command{
+		Use:   "resume <id>",
+		Short: "continue dispatching the operators of the group",
+		Run:   operatorGroupActionCommandFunc("resume"),
+	}
+	cancel := &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "cancel all running and pending operators of the group",
+		Run:   cancelOperatorGroupCommandFunc,
+	}
+	c.AddCommand(show, add, pause, resume, cancel)
+	return c
+}
+
+func showOperatorGroupCommandFunc(cmd *cobra.Command, args []string) {
+	p := operatorGroupsPrefix
+	if len(args) == 1 {
+		p = path.Join(operatorGroupsPrefix, url.PathEscape(args[0]))
+	} else if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, p, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get operator groups: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func addOperatorGroupCommandFunc(cmd *cobra.Command, _ []string) {
+	content, err := os.ReadFile(cmd.Flag("in").Value.String())
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	input := make(map[string]any)
+	if err := json.Unmarshal(content, &input); err != nil {
+		cmd.Println(err)
+		return
+	}
+	postJSON(cmd, operatorGroupsPrefix, input)
+}
+
+func operatorGroupActionCommandFunc(action string) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			cmd.Println(cmd.UsageString())
+			return
+		}
+		postJSON(cmd, path.Join(operatorGroupsPrefix, url.PathEscape(args[0]), action), nil)
+	}
+}
+
+func cancelOperatorGroupCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, path.Join(operatorGroupsPrefix, url.PathEscape(args[0])), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to cancel operator group: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
 func parseUint64s(args []string) ([]uint64, error) {
 	results := make([]uint64, 0, len(args))
 	for _, arg := range args {
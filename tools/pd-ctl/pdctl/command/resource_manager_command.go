@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -28,6 +29,8 @@ const (
 	resourceManagerPrefix = "resource-manager/api/v1"
 	// flags
 	rmConfigController = "config/controller"
+	rmUsage            = "usage"
+	rmUsageExport      = "usage/export"
 )
 
 // NewResourceManagerCommand return a resource manager subcommand of rootCmd
@@ -37,6 +40,7 @@ func NewResourceManagerCommand() *cobra.Command {
 		Short: "resource-manager commands",
 	}
 	cmd.AddCommand(newResourceManagerConfigCommand())
+	cmd.AddCommand(newResourceManagerUsageCommand())
 	return cmd
 }
 
@@ -110,3 +114,55 @@ func newConfigControllerShowCommand() *cobra.Command {
 	}
 	return r
 }
+
+func newResourceManagerUsageCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "usage [flags]",
+		Short: "show the hourly resource usage of the resource groups",
+		Run:   showResourceManagerUsageCommandFunc,
+	}
+	r.Flags().String("start", "", "the start time in unix seconds or RFC3339, 24 hours before the end by default")
+	r.Flags().String("end", "", "the end time in unix seconds or RFC3339, now by default")
+	r.Flags().String("keyspace-id", "", "only show the usage of the keyspace")
+	r.Flags().String("group", "", "only show the usage of the resource group")
+	r.Flags().String("format", "json", "the output format, json or csv")
+	return r
+}
+
+func showResourceManagerUsageCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	query := url.Values{}
+	for flag, param := range map[string]string{
+		"start":       "start",
+		"end":         "end",
+		"keyspace-id": "keyspace_id",
+		"group":       "group",
+	} {
+		if v, _ := cmd.Flags().GetString(flag); len(v) > 0 {
+			query.Set(param, v)
+		}
+	}
+	prefix := rmUsage
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case "json":
+	case "csv":
+		prefix = rmUsageExport
+		query.Set("format", format)
+	default:
+		cmd.Printf("unsupported format %s\n", format)
+		return
+	}
+	if len(query) > 0 {
+		prefix = fmt.Sprintf("%s?%s", prefix, query.Encode())
+	}
+	resp, err := doRequest(cmd, fmt.Sprintf("%s/%s", resourceManagerPrefix, prefix), http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.PrintErrln("Failed to get the resource usage: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
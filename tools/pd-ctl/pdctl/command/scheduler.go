@@ -33,6 +33,7 @@ var (
 	schedulersPrefix          = "pd/api/v1/schedulers"
 	schedulerConfigPrefix     = "pd/api/v1/scheduler-config"
 	schedulerDiagnosticPrefix = "pd/api/v1/schedulers/diagnostic"
+	schedulerDryRunPrefix     = "pd/api/v1/schedulers/dry-run"
 	evictLeaderSchedulerName  = "evict-leader-scheduler"
 	grantLeaderSchedulerName  = "grant-leader-scheduler"
 )
@@ -50,6 +51,7 @@ func NewSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewResumeSchedulerCommand())
 	c.AddCommand(NewConfigSchedulerCommand())
 	c.AddCommand(NewDescribeSchedulerCommand())
+	c.AddCommand(NewDryRunSchedulerCommand())
 	return c
 }
 
@@ -85,6 +87,30 @@ func getEscapedSchedulerName(schedulerName string) string {
 	return url.PathEscape(schedulerName)
 }
 
+// NewDryRunSchedulerCommand returns a command to show the operators a scheduler would create.
+func NewDryRunSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "dry-run <scheduler>",
+		Short: "show the operators a scheduler would create now without executing them",
+		Run:   dryRunSchedulerCommandFunc,
+	}
+	return c
+}
+
+func dryRunSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	url := schedulerDryRunPrefix + "/" + getEscapedSchedulerName(args[0])
+	r, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
 // NewResumeSchedulerCommand returns a command to resume a scheduler.
 func NewResumeSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -163,6 +189,9 @@ func NewAddSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewBalanceWitnessSchedulerCommand())
 	c.AddCommand(NewTransferWitnessLeaderSchedulerCommand())
 	c.AddCommand(NewBalanceRangeSchedulerCommand())
+	c.AddCommand(NewBalanceCostSchedulerCommand())
+	c.AddCommand(NewZoneLeaderSchedulerCommand())
+	c.AddCommand(NewRemotePluginSchedulerCommand())
 	return c
 }
 
@@ -387,6 +416,51 @@ func NewBalanceRangeSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewBalanceCostSchedulerCommand returns a command to add a balance-cost-scheduler.
+func NewBalanceCostSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cost-scheduler",
+		Short: "add a scheduler to balance the weighted cost of disk, CPU, IO and QPS between stores",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewZoneLeaderSchedulerCommand returns a command to add a zone-leader-scheduler.
+func NewZoneLeaderSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "zone-leader-scheduler",
+		Short: "add a scheduler to distribute the leaders of the labeled key ranges across zones by weights",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewRemotePluginSchedulerCommand returns a command to add a remote-plugin-scheduler.
+func NewRemotePluginSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "remote-plugin-scheduler <plugin_name> <address> [timeout]",
+		Short: "add a scheduler to call the scheduler plugin running out of process",
+		Run:   addSchedulerForRemotePluginCommandFunc,
+	}
+	return c
+}
+
+func addSchedulerForRemotePluginCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 && len(args) != 3 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]any)
+	input["name"] = cmd.Name()
+	input["plugin_name"] = args[0]
+	input["address"] = args[1]
+	if len(args) == 3 {
+		input["timeout"] = args[2]
+	}
+	postJSON(cmd, schedulersPrefix, input)
+}
+
 // NewTransferWitnessLeaderSchedulerCommand returns a command to add a transfer-witness-leader-shceudler.
 func NewTransferWitnessLeaderSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -564,6 +638,9 @@ func NewConfigSchedulerCommand() *cobra.Command {
 		newConfigShuffleHotRegionSchedulerCommand(),
 		newConfigEvictSlowTrendCommand(),
 		newConfigBalanceRangeCommand(),
+		newConfigBalanceCostCommand(),
+		newConfigZoneLeaderCommand(),
+		newConfigRemotePluginCommand(),
 	)
 	return c
 }
@@ -608,6 +685,92 @@ func newConfigBalanceRangeCommand() *cobra.Command {
 	return c
 }
 
+func newConfigBalanceCostCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cost-scheduler",
+		Short: "balance-cost-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
+func newConfigZoneLeaderCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "zone-leader-scheduler",
+		Short: "zone-leader-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
+func newConfigRemotePluginCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "remote-plugin-scheduler",
+		Short: "remote-plugin-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "add-plugin <plugin_name> <address> [timeout]",
+		Short: "add a plugin or update the address and timeout of the plugin",
+		Run:   func(cmd *cobra.Command, args []string) { addPluginToSchedulerConfig(cmd, c.Name(), args) },
+	}, &cobra.Command{
+		Use:   "delete-plugin <plugin_name>",
+		Short: "delete a plugin, the scheduler is removed if it is the last one",
+		Run:   func(cmd *cobra.Command, args []string) { deleteStoreFromSchedulerConfig(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
+func addPluginToSchedulerConfig(cmd *cobra.Command, schedulerName string, args []string) {
+	if len(args) != 2 && len(args) != 3 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	exist, err := checkSchedulerExist(cmd, schedulerName)
+	if err != nil {
+		return
+	}
+	if !exist {
+		cmd.Printf("Unable to update config: scheduler %s does not exist.\n", schedulerName)
+		return
+	}
+	input := make(map[string]any)
+	input["plugin_name"] = args[0]
+	input["address"] = args[1]
+	if len(args) == 3 {
+		input["timeout"] = args[2]
+	}
+	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
+}
+
 func newSplitBucketCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "split-bucket-scheduler",
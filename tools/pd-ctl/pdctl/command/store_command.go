@@ -37,6 +37,8 @@ var (
 	storesLimitPrefix  = "pd/api/v1/stores/limit"
 	storePrefix        = "pd/api/v1/store/%v"
 	storeUpStatePrefix = "pd/api/v1/store/%v/state?state=Up"
+	storeDrainPrefix   = "pd/api/v1/store/%v/drain"
+	storesDrainPrefix  = "pd/api/v1/stores/drain"
 	maxStoreLimit      = float64(200)
 )
 
@@ -54,6 +56,7 @@ func NewStoreCommand() *cobra.Command {
 	s.AddCommand(NewStoreLimitCommand())
 	s.AddCommand(NewRemoveTombStoneCommand())
 	s.AddCommand(NewStoreCheckCommand())
+	s.AddCommand(NewDrainStoreCommand())
 	s.Flags().String("jq", "", "jq query")
 	s.Flags().StringSlice("state", nil, "state filter")
 	return s
@@ -151,6 +154,27 @@ func NewStoreCheckCommand() *cobra.Command {
 	return d
 }
 
+// NewDrainStoreCommand returns a drain subcommand of storeCmd.
+func NewDrainStoreCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use: "drain [<store_id>] [--pause|--resume|--status|--cancel]",
+		Example: `  # Evict the leaders and move all the peers out of the store
+	drain <store_id>
+  # Show the progress and ETA of the drain job, or all the jobs if the store_id is omitted
+	drain [<store_id>] --status
+  # Pause, resume or cancel the drain job
+	drain <store_id> --pause|--resume|--cancel`,
+		Short: "drain the store before taking it down",
+		Run:   drainStoreCommandFunc,
+	}
+	d.Flags().Bool("pause", false, "pause the drain job")
+	d.Flags().Bool("resume", false, "resume the paused drain job")
+	d.Flags().Bool("status", false, "show the status of the drain job")
+	d.Flags().Bool("cancel", false, "cancel the drain job and set the store up")
+	d.MarkFlagsMutuallyExclusive("pause", "resume", "status", "cancel")
+	return d
+}
+
 // NewStoresCommand returns a store subcommand of rootCmd
 func NewStoresCommand() *cobra.Command {
 	s := &cobra.Command{
@@ -627,6 +651,53 @@ func storeCheckCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(r)
 }
 
+func drainStoreCommandFunc(cmd *cobra.Command, args []string) {
+	flags := cmd.Flags()
+	status, _ := flags.GetBool("status")
+	pause, _ := flags.GetBool("pause")
+	resume, _ := flags.GetBool("resume")
+	cancel, _ := flags.GetBool("cancel")
+	if status && len(args) == 0 {
+		r, err := doRequest(cmd, storesDrainPrefix, http.MethodGet, http.Header{})
+		if err != nil {
+			cmd.Printf("Failed to get the drain jobs: %s\n", err)
+			return
+		}
+		cmd.Println(r)
+		return
+	}
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	if _, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+		cmd.Println("store_id should be a number")
+		return
+	}
+	prefix := fmt.Sprintf(storeDrainPrefix, args[0])
+	method := http.MethodPost
+	switch {
+	case status:
+		method = http.MethodGet
+	case pause:
+		prefix = path.Join(prefix, "pause")
+	case resume:
+		prefix = path.Join(prefix, "resume")
+	case cancel:
+		method = http.MethodDelete
+	}
+	r, err := doRequest(cmd, prefix, method, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to drain store %s: %s\n", args[0], err)
+		return
+	}
+	if method == http.MethodGet {
+		cmd.Println(r)
+		return
+	}
+	cmd.Println("Success!")
+}
+
 func showStoresCommandFunc(cmd *cobra.Command, _ []string) {
 	prefix := storesPrefix
 	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
@@ -15,6 +15,8 @@
 package command
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -22,6 +24,8 @@ import (
 	"github.com/tikv/pd/pkg/utils/tsoutil"
 )
 
+const tsoAuditPrefix = "pd/api/v1/admin/tso/audit"
+
 // NewTSOCommand return a TSO subcommand of rootCmd
 func NewTSOCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -29,6 +33,18 @@ func NewTSOCommand() *cobra.Command {
 		Short: "parse TSO to the system and logic time",
 		Run:   showTSOCommandFunc,
 	}
+	cmd.AddCommand(NewTSOAuditCommand())
+	return cmd
+}
+
+// NewTSOAuditCommand return a audit subcommand of tsoCmd
+func NewTSOAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [--keyspace-group-id=<id>]",
+		Short: "verify that the TSO never regressed with the audit log and the etcd history",
+		Run:   tsoAuditCommandFunc,
+	}
+	cmd.Flags().Uint32("keyspace-group-id", 0, "the keyspace group ID")
 	return cmd
 }
 
@@ -47,3 +63,25 @@ func showTSOCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println("system: ", physicalTime)
 	cmd.Println("logic:  ", logical)
 }
+
+func tsoAuditCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Usage()
+		return
+	}
+	prefix := tsoAuditPrefix
+	if cmd.Flags().Changed("keyspace-group-id") {
+		id, err := cmd.Flags().GetUint32("keyspace-group-id")
+		if err != nil {
+			cmd.Printf("Failed to parse the keyspace group id: %s\n", err)
+			return
+		}
+		prefix = fmt.Sprintf("%s?keyspace-group-id=%d", prefix, id)
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to verify the tso audit log: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	api "github.com/tikv/pd/server/apiv2/handlers"
 	"github.com/tikv/pd/server/config"
@@ -282,6 +283,59 @@ func (suite *keyspaceTestSuite) TestUpdateKeyspaceState() {
 	re.Contains(string(output), "Fail")
 }
 
+func (suite *keyspaceTestSuite) TestKeyspaceQuota() {
+	re := suite.Require()
+	param := api.CreateKeyspaceParams{
+		Name: "test_keyspace",
+	}
+	meta := mustCreateKeyspace(suite, param)
+	var status keyspace.QuotaStatus
+	// No quota is set.
+	args := []string{"-u", suite.pdAddr, "keyspace", "quota", "show", param.Name}
+	output, err := tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.NoError(json.Unmarshal(output, &status))
+	re.Equal(meta.GetId(), status.Quota.KeyspaceID)
+	re.True(status.Quota.IsEmpty())
+
+	// Set the quota.
+	args = []string{"-u", suite.pdAddr, "keyspace", "quota", "set", param.Name,
+		"--region-count-soft", "10", "--region-count-hard", "20", "--storage-size-hard", "1024"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	status = keyspace.QuotaStatus{}
+	re.NoError(json.Unmarshal(output, &status))
+	re.Equal(endpoint.KeyspaceQuotaLimit{Soft: 10, Hard: 20}, status.Quota.RegionCount)
+	re.Equal(endpoint.KeyspaceQuotaLimit{Hard: 1024}, status.Quota.StorageSize)
+	re.True(status.Quota.ResourceGroupCount.IsEmpty())
+	args = []string{"-u", suite.pdAddr, "keyspace", "quota", "show", param.Name}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	status = keyspace.QuotaStatus{}
+	re.NoError(json.Unmarshal(output, &status))
+	re.Equal(endpoint.KeyspaceQuotaLimit{Soft: 10, Hard: 20}, status.Quota.RegionCount)
+
+	// The soft limit should not be larger than the hard limit.
+	args = []string{"-u", suite.pdAddr, "keyspace", "quota", "set", param.Name,
+		"--region-count-soft", "20", "--region-count-hard", "10"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "Fail")
+	// The keyspace should exist.
+	args = []string{"-u", suite.pdAddr, "keyspace", "quota", "show", "not_exist"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "Fail")
+
+	// Remove the quota.
+	args = []string{"-u", suite.pdAddr, "keyspace", "quota", "set", param.Name}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	status = keyspace.QuotaStatus{}
+	re.NoError(json.Unmarshal(output, &status))
+	re.True(status.Quota.IsEmpty())
+}
+
 func (suite *keyspaceTestSuite) TestListKeyspace() {
 	re := suite.Require()
 	var param api.CreateKeyspaceParams
@@ -17,6 +17,7 @@ package resourcemanager_test
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,3 +99,22 @@ func (s *testResourceManagerSuite) TestConfigController() {
 	expectCfg.Controller.RequestUnit.WriteBaseCost = 2
 	checkShow()
 }
+
+func (s *testResourceManagerSuite) TestUsage() {
+	re := s.Require()
+	args := []string{"-u", s.pdAddr, "resource-manager", "usage", "--group", "default"}
+	output, err := tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	var records []*server.UsageRecord
+	re.NoError(json.Unmarshal(output, &records), string(output))
+
+	args = []string{"-u", s.pdAddr, "resource-manager", "usage", "--format", "csv"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.True(strings.HasPrefix(string(output), "hour,keyspace_id,resource_group,"), string(output))
+
+	args = []string{"-u", s.pdAddr, "resource-manager", "usage", "--start", "2025-01-02T00:00:00Z", "--end", "2025-01-01T00:00:00Z"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "the start time should be before the end time")
+}
@@ -783,6 +783,8 @@ func (suite *schedulerTestSuite) checkHotRegionSchedulerConfig(cluster *pdTests.
 		"strict-picking-store":    "true",
 		"rank-formula-version":    "v2",
 		"split-thresholds":        0.2,
+		"enable-forecast":         "false",
+		"forecast-confidence":     0.8,
 		"history-sample-duration": "5m0s",
 		"history-sample-interval": "30s",
 	}
@@ -958,6 +960,39 @@ func (suite *schedulerTestSuite) checkSchedulerDiagnostic(cluster *pdTests.TestC
 	checkSchedulerDescribeCommand("balance-leader-scheduler", "normal", "")
 }
 
+func (suite *schedulerTestSuite) TestSchedulerDryRun() {
+	suite.env.RunTest(suite.checkSchedulerDryRun)
+}
+
+func (suite *schedulerTestSuite) checkSchedulerDryRun(cluster *pdTests.TestCluster) {
+	re := suite.Require()
+	pdAddr := cluster.GetConfig().GetClientURL()
+	cmd := ctl.GetRootCmd()
+
+	for i := uint64(1); i <= 4; i++ {
+		pdTests.MustPutStore(re, cluster, &metapb.Store{
+			Id:            i,
+			State:         metapb.StoreState_Up,
+			LastHeartbeat: time.Now().UnixNano(),
+		})
+	}
+	pdTests.MustPutRegion(re, cluster, 1, 1, []byte("a"), []byte("b"))
+	suite.checkDefaultSchedulers(re, cmd, pdAddr)
+
+	result := make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "dry-run", "balance-leader-scheduler"}, &result)
+		return result["name"] == "balance-leader-scheduler"
+	})
+	re.Contains(result, "operators")
+	re.Contains(result, "allowed")
+
+	echo := mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "dry-run", "not-exist-scheduler"}, nil)
+	re.Contains(echo, "404")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "dry-run"}, nil)
+	re.Contains(echo, "Usage")
+}
+
 func (suite *schedulerTestSuite) TestEvictLeaderScheduler() {
 	suite.env.RunTest(suite.checkEvictLeaderScheduler)
 }